@@ -5,11 +5,14 @@ package admin
 
 import (
 	"context"
+	"time"
 
 	"github.com/MetalBlockchain/metalgo/api"
 	"github.com/MetalBlockchain/metalgo/database/rpcdb"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman"
 	"github.com/MetalBlockchain/metalgo/utils/formatting"
+	"github.com/MetalBlockchain/metalgo/utils/json"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/rpc"
 )
@@ -25,6 +28,13 @@ type Client interface {
 	Alias(ctx context.Context, endpoint string, alias string, options ...rpc.Option) error
 	AliasChain(ctx context.Context, chainID string, alias string, options ...rpc.Option) error
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
+	GetConsensusState(ctx context.Context, chain string, options ...rpc.Option) (*snowman.ConsensusState, error)
+	ExportBlocks(ctx context.Context, chain string, startHeight, endHeight uint64, options ...rpc.Option) (ids.ID, [][]byte, error)
+	PauseChain(ctx context.Context, chain string, options ...rpc.Option) error
+	ResumeChain(ctx context.Context, chain string, options ...rpc.Option) error
+	RebootstrapChain(ctx context.Context, chain string, stateSync bool, options ...rpc.Option) error
+	BenchNode(ctx context.Context, chain string, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) error
+	UnbenchNode(ctx context.Context, chain string, nodeID ids.NodeID, options ...rpc.Option) error
 	Stacktrace(context.Context, ...rpc.Option) error
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
@@ -83,6 +93,69 @@ func (c *client) GetChainAliases(ctx context.Context, chain string, options ...r
 	return res.Aliases, err
 }
 
+func (c *client) GetConsensusState(ctx context.Context, chain string, options ...rpc.Option) (*snowman.ConsensusState, error) {
+	res := &snowman.ConsensusState{}
+	err := c.requester.SendRequest(ctx, "admin.getConsensusState", &GetConsensusStateArgs{
+		Chain: chain,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) ExportBlocks(ctx context.Context, chain string, startHeight, endHeight uint64, options ...rpc.Option) (ids.ID, [][]byte, error) {
+	res := &ExportBlocksReply{}
+	err := c.requester.SendRequest(ctx, "admin.exportBlocks", &ExportBlocksArgs{
+		Chain:       chain,
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+	}, res, options...)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+
+	blks := make([][]byte, len(res.Blocks))
+	for i, blkStr := range res.Blocks {
+		blks[i], err = formatting.Decode(formatting.HexNC, blkStr)
+		if err != nil {
+			return ids.Empty, nil, err
+		}
+	}
+	return res.ChainID, blks, nil
+}
+
+func (c *client) PauseChain(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.pauseChain", &ChainArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) ResumeChain(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.resumeChain", &ChainArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) RebootstrapChain(ctx context.Context, chain string, stateSync bool, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.rebootstrapChain", &RebootstrapChainArgs{
+		Chain:     chain,
+		StateSync: stateSync,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) BenchNode(ctx context.Context, chain string, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.benchNode", &BenchNodeArgs{
+		Chain:    chain,
+		NodeID:   nodeID,
+		Duration: json.Uint64(duration / time.Second),
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UnbenchNode(ctx context.Context, chain string, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.unbenchNode", &UnbenchNodeArgs{
+		Chain:  chain,
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
 func (c *client) Stacktrace(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "admin.stacktrace", struct{}{}, &api.EmptyReply{}, options...)
 }
@@ -7,11 +7,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/api"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/rpc"
 )
@@ -59,6 +61,12 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *GetChainAliasesReply:
 		response := mc.response.(*GetChainAliasesReply)
 		*p = *response
+	case *snowman.ConsensusState:
+		response := mc.response.(*snowman.ConsensusState)
+		*p = *response
+	case *ExportBlocksReply:
+		response := mc.response.(*ExportBlocksReply)
+		*p = *response
 	case *LoadVMsReply:
 		response := mc.response.(*LoadVMsReply)
 		*p = *response
@@ -155,6 +163,100 @@ func TestGetChainAliases(t *testing.T) {
 	})
 }
 
+func TestGetConsensusState(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedReply := &snowman.ConsensusState{}
+		expectedReply.Preference = ids.GenerateTestID()
+		expectedReply.NumPolls = 5
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.GetConsensusState(context.Background(), "chain")
+		require.NoError(err)
+		require.Equal(expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&snowman.ConsensusState{}, errTest)}
+		_, err := mockClient.GetConsensusState(context.Background(), "chain")
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestExportBlocks(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		chainID := ids.GenerateTestID()
+		mockClient := client{requester: NewMockClient(&ExportBlocksReply{
+			ChainID: chainID,
+			Blocks:  []string{"0x01", "0x0203"},
+		}, nil)}
+
+		replyChainID, blks, err := mockClient.ExportBlocks(context.Background(), "chain", 1, 2)
+		require.NoError(err)
+		require.Equal(chainID, replyChainID)
+		require.Equal([][]byte{{1}, {2, 3}}, blks)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ExportBlocksReply{}, errTest)}
+		_, _, err := mockClient.ExportBlocks(context.Background(), "chain", 1, 2)
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestPauseChain(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.PauseChain(context.Background(), "chain")
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestResumeChain(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.ResumeChain(context.Background(), "chain")
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestRebootstrapChain(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.RebootstrapChain(context.Background(), "chain", true)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestBenchNode(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.BenchNode(context.Background(), "chain", ids.GenerateTestNodeID(), time.Minute)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestUnbenchNode(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.UnbenchNode(context.Background(), "chain", ids.GenerateTestNodeID())
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
 func TestStacktrace(t *testing.T) {
 	for _, test := range SuccessResponseTests {
 		t.Run(test.name, func(t *testing.T) {
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"path"
 	"sync"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
@@ -18,6 +19,8 @@ import (
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/database/rpcdb"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman"
+	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/formatting"
@@ -41,6 +44,8 @@ const (
 var (
 	errAliasTooLong = errors.New("alias length is too long")
 	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+
+	errInvalidHeightRange = errors.New("end height must be >= start height")
 )
 
 type Config struct {
@@ -50,6 +55,7 @@ type Config struct {
 	NodeConfig   interface{}
 	DB           database.Database
 	ChainManager chains.Manager
+	Benchlist    benchlist.Manager
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
@@ -214,6 +220,187 @@ func (a *Admin) GetChainAliases(_ *http.Request, args *GetChainAliasesArgs, repl
 	return err
 }
 
+// GetConsensusStateArgs are the arguments for calling GetConsensusState
+type GetConsensusStateArgs struct {
+	Chain string `json:"chain"`
+}
+
+// GetConsensusState returns a snapshot of the consensus state of the chain,
+// including its processing blocks and outstanding polls
+func (a *Admin) GetConsensusState(_ *http.Request, args *GetConsensusStateArgs, reply *snowman.ConsensusState) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getConsensusState"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	*reply, err = a.ChainManager.ConsensusState(chainID)
+	return err
+}
+
+// ExportBlocksArgs are the arguments for calling ExportBlocks
+type ExportBlocksArgs struct {
+	Chain       string      `json:"chain"`
+	StartHeight json.Uint64 `json:"startHeight"`
+	EndHeight   json.Uint64 `json:"endHeight"`
+}
+
+// ExportBlocksReply is the response from calling ExportBlocks
+type ExportBlocksReply struct {
+	ChainID ids.ID `json:"chainID"`
+	// Blocks are the hex encoded bytes of the blocks, in increasing height
+	// order, starting at the requested start height
+	Blocks []string `json:"blocks"`
+}
+
+// ExportBlocks returns the accepted blocks of the chain in the requested height
+// range. Fewer blocks than requested are returned if the response would be too
+// large.
+func (a *Admin) ExportBlocks(_ *http.Request, args *ExportBlocksArgs, reply *ExportBlocksReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "exportBlocks"),
+		logging.UserString("chain", args.Chain),
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+		zap.Uint64("endHeight", uint64(args.EndHeight)),
+	)
+
+	if args.EndHeight < args.StartHeight {
+		return errInvalidHeightRange
+	}
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	blks, err := a.ChainManager.ExportBlocks(chainID, uint64(args.StartHeight), uint64(args.EndHeight))
+	if err != nil {
+		return err
+	}
+
+	reply.ChainID = chainID
+	reply.Blocks = make([]string, len(blks))
+	for i, blk := range blks {
+		reply.Blocks[i], err = formatting.Encode(formatting.HexNC, blk)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChainArgs are the arguments for calling methods that control a chain
+type ChainArgs struct {
+	Chain string `json:"chain"`
+}
+
+// PauseChain stops the chain from handling messages until it is resumed
+func (a *Admin) PauseChain(_ *http.Request, args *ChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "pauseChain"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.ChainManager.PauseChain(chainID)
+}
+
+// ResumeChain resumes a paused chain
+func (a *Admin) ResumeChain(_ *http.Request, args *ChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "resumeChain"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.ChainManager.ResumeChain(chainID)
+}
+
+// RebootstrapChainArgs are the arguments for calling RebootstrapChain
+type RebootstrapChainArgs struct {
+	Chain     string `json:"chain"`
+	StateSync bool   `json:"stateSync"`
+}
+
+// RebootstrapChain moves the chain back into state sync or bootstrapping
+func (a *Admin) RebootstrapChain(_ *http.Request, args *RebootstrapChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "rebootstrapChain"),
+		logging.UserString("chain", args.Chain),
+		zap.Bool("stateSync", args.StateSync),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.ChainManager.RebootstrapChain(chainID, args.StateSync)
+}
+
+// BenchNodeArgs are the arguments for calling BenchNode
+type BenchNodeArgs struct {
+	Chain  string     `json:"chain"`
+	NodeID ids.NodeID `json:"nodeID"`
+	// Number of seconds to bench the node for. If 0, the configured benchlist
+	// duration is used.
+	Duration json.Uint64 `json:"duration"`
+}
+
+// BenchNode benches a node on a chain so that queries to it fail immediately
+func (a *Admin) BenchNode(_ *http.Request, args *BenchNodeArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "benchNode"),
+		logging.UserString("chain", args.Chain),
+		zap.Stringer("nodeID", args.NodeID),
+		zap.Uint64("duration", uint64(args.Duration)),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	duration := time.Duration(args.Duration) * time.Second
+	return a.Benchlist.Bench(chainID, args.NodeID, duration)
+}
+
+// UnbenchNodeArgs are the arguments for calling UnbenchNode
+type UnbenchNodeArgs struct {
+	Chain  string     `json:"chain"`
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// UnbenchNode immediately unbenches a node on a chain
+func (a *Admin) UnbenchNode(_ *http.Request, args *UnbenchNodeArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "unbenchNode"),
+		logging.UserString("chain", args.Chain),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.Benchlist.Unbench(chainID, args.NodeID)
+}
+
 // Stacktrace returns the current global stacktrace
 func (a *Admin) Stacktrace(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
 	a.Log.Debug("API called",
@@ -5,6 +5,7 @@ package api
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/utils/formatting"
@@ -158,3 +159,42 @@ type GetUTXOsReply struct {
 	// Encoding specifies the encoding format the UTXOs are returned in
 	Encoding formatting.Encoding `json:"encoding"`
 }
+
+// GetUptimeHistoryArgs are the arguments for fetching how long [NodeID] was
+// online over [StartTime, EndTime). Times are Unix timestamps in seconds. If
+// [EndTime] is 0, the current time is used.
+type GetUptimeHistoryArgs struct {
+	NodeID    ids.NodeID     `json:"nodeID"`
+	StartTime avajson.Uint64 `json:"startTime"`
+	EndTime   avajson.Uint64 `json:"endTime"`
+}
+
+// UptimeBucket reports how many seconds a node was online during
+// [StartTime, EndTime).
+type UptimeBucket struct {
+	StartTime  avajson.Uint64 `json:"startTime"`
+	EndTime    avajson.Uint64 `json:"endTime"`
+	UpDuration avajson.Uint64 `json:"upDuration"`
+}
+
+// GetUptimeHistoryReply is the response from fetching the uptime history of a
+// node. Buckets are ordered from oldest to newest.
+type GetUptimeHistoryReply struct {
+	Buckets []UptimeBucket `json:"buckets"`
+}
+
+// NewUptimeBuckets converts the up durations of consecutive buckets of width
+// [bucketDuration], the first of which starts at [startTime], into their API
+// representation.
+func NewUptimeBuckets(startTime time.Time, bucketDuration time.Duration, upDurations []time.Duration) []UptimeBucket {
+	buckets := make([]UptimeBucket, len(upDurations))
+	for i, upDuration := range upDurations {
+		bucketStart := startTime.Add(time.Duration(i) * bucketDuration)
+		buckets[i] = UptimeBucket{
+			StartTime:  avajson.Uint64(bucketStart.Unix()),
+			EndTime:    avajson.Uint64(bucketStart.Add(bucketDuration).Unix()),
+			UpDuration: avajson.Uint64(upDuration / time.Second),
+		}
+	}
+	return buckets
+}
@@ -8,8 +8,11 @@ import (
 	"net/netip"
 	"time"
 
+	"github.com/MetalBlockchain/metalgo/api"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/peer"
 	"github.com/MetalBlockchain/metalgo/upgrade"
+	"github.com/MetalBlockchain/metalgo/utils/json"
 	"github.com/MetalBlockchain/metalgo/utils/rpc"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/signer"
 )
@@ -26,10 +29,13 @@ type Client interface {
 	GetNetworkName(context.Context, ...rpc.Option) (string, error)
 	GetBlockchainID(context.Context, string, ...rpc.Option) (ids.ID, error)
 	Peers(context.Context, ...rpc.Option) ([]Peer, error)
+	PeerStats(context.Context, []ids.NodeID, ...rpc.Option) ([]peer.Stats, error)
+	Benchlist(context.Context, ...rpc.Option) (map[ids.ID][]BenchedNode, error)
 	IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error)
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Upgrades(context.Context, ...rpc.Option) (*upgrade.Config, error)
 	Uptime(context.Context, ...rpc.Option) (*UptimeResponse, error)
+	UptimeHistory(context.Context, ids.NodeID, time.Time, time.Time, ...rpc.Option) ([]api.UptimeBucket, error)
 	GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error)
 }
 
@@ -89,6 +95,20 @@ func (c *client) Peers(ctx context.Context, options ...rpc.Option) ([]Peer, erro
 	return res.Peers, err
 }
 
+func (c *client) PeerStats(ctx context.Context, nodeIDs []ids.NodeID, options ...rpc.Option) ([]peer.Stats, error) {
+	res := &PeerStatsReply{}
+	err := c.requester.SendRequest(ctx, "info.peerStats", &PeerStatsArgs{
+		NodeIDs: nodeIDs,
+	}, res, options...)
+	return res.Peers, err
+}
+
+func (c *client) Benchlist(ctx context.Context, options ...rpc.Option) (map[ids.ID][]BenchedNode, error) {
+	res := &BenchlistReply{}
+	err := c.requester.SendRequest(ctx, "info.benchlist", struct{}{}, res, options...)
+	return res.Chains, err
+}
+
 func (c *client) IsBootstrapped(ctx context.Context, chainID string, options ...rpc.Option) (bool, error) {
 	res := &IsBootstrappedResponse{}
 	err := c.requester.SendRequest(ctx, "info.isBootstrapped", &IsBootstrappedArgs{
@@ -115,6 +135,19 @@ func (c *client) Uptime(ctx context.Context, options ...rpc.Option) (*UptimeResp
 	return res, err
 }
 
+func (c *client) UptimeHistory(ctx context.Context, nodeID ids.NodeID, startTime, endTime time.Time, options ...rpc.Option) ([]api.UptimeBucket, error) {
+	args := &api.GetUptimeHistoryArgs{
+		NodeID:    nodeID,
+		StartTime: json.Uint64(startTime.Unix()),
+	}
+	if !endTime.IsZero() {
+		args.EndTime = json.Uint64(endTime.Unix())
+	}
+	res := &api.GetUptimeHistoryReply{}
+	err := c.requester.SendRequest(ctx, "info.uptimeHistory", args, res, options...)
+	return res.Buckets, err
+}
+
 func (c *client) GetVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID][]string, error) {
 	res := &GetVMsReply{}
 	err := c.requester.SendRequest(ctx, "info.getVMs", struct{}{}, res, options...)
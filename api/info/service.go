@@ -8,16 +8,19 @@ import (
 	"fmt"
 	"net/http"
 	"net/netip"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
 
+	"github.com/MetalBlockchain/metalgo/api"
 	"github.com/MetalBlockchain/metalgo/chains"
 	"github.com/MetalBlockchain/metalgo/genesis"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/network"
 	"github.com/MetalBlockchain/metalgo/network/peer"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+	"github.com/MetalBlockchain/metalgo/snow/uptime"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/upgrade"
 	"github.com/MetalBlockchain/metalgo/utils"
@@ -45,6 +48,7 @@ type Info struct {
 	chainManager chains.Manager
 	vmManager    vms.Manager
 	benchlist    benchlist.Manager
+	uptimes      uptime.Calculator
 }
 
 type Parameters struct {
@@ -66,6 +70,7 @@ func NewService(
 	myIP *utils.Atomic[netip.AddrPort],
 	network network.Network,
 	benchlist benchlist.Manager,
+	uptimes uptime.Calculator,
 ) (http.Handler, error) {
 	server := rpc.NewServer()
 	codec := json.NewCodec()
@@ -81,6 +86,7 @@ func NewService(
 			myIP:         myIP,
 			networking:   network,
 			benchlist:    benchlist,
+			uptimes:      uptimes,
 		},
 		"info",
 	)
@@ -252,6 +258,74 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 	return nil
 }
 
+// BenchedNode describes a node that is benched on a chain
+type BenchedNode struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Number of consecutive failed queries that caused the node to be benched
+	FailureStreak json.Uint64 `json:"failureStreak"`
+	BenchedAt     time.Time   `json:"benchedAt"`
+	BenchedUntil  time.Time   `json:"benchedUntil"`
+	// True if the node was benched through the admin API
+	Manual bool `json:"manual"`
+}
+
+// BenchlistReply are the results from calling Benchlist
+type BenchlistReply struct {
+	// Chain ID --> nodes currently benched on that chain
+	Chains map[ids.ID][]BenchedNode `json:"chains"`
+}
+
+// Benchlist returns the nodes that are currently benched on each chain
+func (i *Info) Benchlist(_ *http.Request, _ *struct{}, reply *BenchlistReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "benchlist"),
+	)
+
+	benched := i.benchlist.Benched()
+	reply.Chains = make(map[ids.ID][]BenchedNode, len(benched))
+	for chainID, nodes := range benched {
+		benchedNodes := make([]BenchedNode, len(nodes))
+		for idx, node := range nodes {
+			benchedNodes[idx] = BenchedNode{
+				NodeID:        node.NodeID,
+				FailureStreak: json.Uint64(node.FailureStreak),
+				BenchedAt:     node.BenchedAt.UTC(),
+				BenchedUntil:  node.BenchedUntil.UTC(),
+				Manual:        node.Manual,
+			}
+		}
+		reply.Chains[chainID] = benchedNodes
+	}
+	return nil
+}
+
+// PeerStatsArgs are the arguments for calling PeerStats
+type PeerStatsArgs struct {
+	NodeIDs []ids.NodeID `json:"nodeIDs"`
+}
+
+// PeerStatsReply are the results from calling PeerStats
+type PeerStatsReply struct {
+	// Number of elements in [Peers]
+	NumPeers json.Uint64 `json:"numPeers"`
+	// Each element is the traffic exchanged with a peer
+	Peers []peer.Stats `json:"peers"`
+}
+
+// PeerStats returns the number of messages and bytes, per op, sent to and
+// received from the requested peers
+func (i *Info) PeerStats(_ *http.Request, args *PeerStatsArgs, reply *PeerStatsReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "peerStats"),
+	)
+
+	reply.Peers = i.networking.PeerStats(args.NodeIDs)
+	reply.NumPeers = json.Uint64(len(reply.Peers))
+	return nil
+}
+
 // IsBootstrappedArgs are the arguments for calling IsBootstrapped
 type IsBootstrappedArgs struct {
 	// Alias of the chain
@@ -263,6 +337,8 @@ type IsBootstrappedArgs struct {
 type IsBootstrappedResponse struct {
 	// True iff the chain exists and is done bootstrapping
 	IsBootstrapped bool `json:"isBootstrapped"`
+	// True iff the chain exists and was paused with the admin API
+	IsPaused bool `json:"isPaused"`
 }
 
 // IsBootstrapped returns nil and sets [reply.IsBootstrapped] == true iff [args.Chain] exists and is done bootstrapping
@@ -282,6 +358,7 @@ func (i *Info) IsBootstrapped(_ *http.Request, args *IsBootstrappedArgs, reply *
 		return fmt.Errorf("there is no chain with alias/ID '%s'", args.Chain)
 	}
 	reply.IsBootstrapped = i.chainManager.IsBootstrapped(chainID)
+	reply.IsPaused = i.chainManager.IsPaused(chainID)
 	return nil
 }
 
@@ -328,6 +405,31 @@ func (i *Info) Uptime(_ *http.Request, _ *struct{}, reply *UptimeResponse) error
 	return nil
 }
 
+// UptimeHistory returns how long a primary network validator was online in
+// each uptime bucket over the requested time range, as observed by this node.
+func (i *Info) UptimeHistory(_ *http.Request, args *api.GetUptimeHistoryArgs, reply *api.GetUptimeHistoryReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "uptimeHistory"),
+		zap.Stringer("nodeID", args.NodeID),
+		zap.Uint64("startTime", uint64(args.StartTime)),
+		zap.Uint64("endTime", uint64(args.EndTime)),
+	)
+
+	startTime := time.Unix(int64(args.StartTime), 0).Truncate(uptime.BucketDuration)
+	endTime := time.Now()
+	if args.EndTime != 0 {
+		endTime = time.Unix(int64(args.EndTime), 0)
+	}
+
+	upDurations, err := i.uptimes.CalculateUptimeHistory(args.NodeID, startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("couldn't get uptime history: %w", err)
+	}
+	reply.Buckets = api.NewUptimeBuckets(startTime, uptime.BucketDuration, upDurations)
+	return nil
+}
+
 type ACP struct {
 	SupportWeight json.Uint64         `json:"supportWeight"`
 	Supporters    set.Set[ids.NodeID] `json:"supporters"`
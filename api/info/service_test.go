@@ -6,11 +6,17 @@ package info
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/MetalBlockchain/metalgo/api"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+	"github.com/MetalBlockchain/metalgo/snow/uptime"
+	"github.com/MetalBlockchain/metalgo/snow/uptime/uptimemock"
+	"github.com/MetalBlockchain/metalgo/utils/json"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/vms/vmsmock"
 )
@@ -94,3 +100,112 @@ func TestGetVMsGetAliasesFails(t *testing.T) {
 	err := resources.info.GetVMs(nil, nil, &reply)
 	require.ErrorIs(t, err, errTest)
 }
+
+func TestUptimeHistory(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	uptimes := uptimemock.NewCalculator(ctrl)
+	info := &Info{
+		log:     logging.NoLog{},
+		uptimes: uptimes,
+	}
+
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		startTime = time.Unix(1_700_000_000, 0)
+		endTime   = startTime.Add(uptime.BucketDuration)
+		bucket0   = startTime.Truncate(uptime.BucketDuration)
+		bucket1   = bucket0.Add(uptime.BucketDuration)
+	)
+	uptimes.EXPECT().CalculateUptimeHistory(nodeID, bucket0, endTime).Return(
+		[]time.Duration{
+			uptime.BucketDuration,
+			time.Minute,
+		},
+		nil,
+	)
+
+	reply := api.GetUptimeHistoryReply{}
+	require.NoError(info.UptimeHistory(nil, &api.GetUptimeHistoryArgs{
+		NodeID:    nodeID,
+		StartTime: json.Uint64(startTime.Unix()),
+		EndTime:   json.Uint64(endTime.Unix()),
+	}, &reply))
+	require.Equal(
+		[]api.UptimeBucket{
+			{
+				StartTime:  json.Uint64(bucket0.Unix()),
+				EndTime:    json.Uint64(bucket1.Unix()),
+				UpDuration: json.Uint64(uptime.BucketDuration / time.Second),
+			},
+			{
+				StartTime:  json.Uint64(bucket1.Unix()),
+				EndTime:    json.Uint64(bucket1.Add(uptime.BucketDuration).Unix()),
+				UpDuration: 60,
+			},
+		},
+		reply.Buckets,
+	)
+
+	uptimes.EXPECT().CalculateUptimeHistory(nodeID, bucket0, endTime).Return(nil, errTest)
+	err := info.UptimeHistory(nil, &api.GetUptimeHistoryArgs{
+		NodeID:    nodeID,
+		StartTime: json.Uint64(startTime.Unix()),
+		EndTime:   json.Uint64(endTime.Unix()),
+	}, &reply)
+	require.ErrorIs(err, errTest)
+}
+
+type testBenchlist struct {
+	benchlist.Manager
+
+	benched map[ids.ID][]benchlist.BenchedNode
+}
+
+func (b *testBenchlist) Benched() map[ids.ID][]benchlist.BenchedNode {
+	return b.benched
+}
+
+func TestBenchlist(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		chainID      = ids.GenerateTestID()
+		nodeID       = ids.GenerateTestNodeID()
+		benchedAt    = time.Unix(1_700_000_000, 0)
+		benchedUntil = benchedAt.Add(time.Minute)
+	)
+	info := &Info{
+		log: logging.NoLog{},
+		benchlist: &testBenchlist{
+			Manager: benchlist.NewNoBenchlist(),
+			benched: map[ids.ID][]benchlist.BenchedNode{
+				chainID: {
+					{
+						NodeID:        nodeID,
+						FailureStreak: 5,
+						BenchedAt:     benchedAt,
+						BenchedUntil:  benchedUntil,
+					},
+				},
+			},
+		},
+	}
+
+	reply := BenchlistReply{}
+	require.NoError(info.Benchlist(nil, nil, &reply))
+	require.Equal(
+		map[ids.ID][]BenchedNode{
+			chainID: {
+				{
+					NodeID:        nodeID,
+					FailureStreak: 5,
+					BenchedAt:     benchedAt.UTC(),
+					BenchedUntil:  benchedUntil.UTC(),
+				},
+			},
+		},
+		reply.Chains,
+	)
+}
@@ -34,6 +34,8 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/bootstrap/archive"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/journal"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/syncer"
 	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
@@ -104,6 +106,8 @@ var (
 	errUnknownVMType           = errors.New("the vm should have type avalanche.DAGVM or snowman.ChainVM")
 	errCreatePlatformVM        = errors.New("attempted to create a chain running the PlatformVM")
 	errNotBootstrapped         = errors.New("subnets not bootstrapped")
+	errUnknownChain            = errors.New("unknown chain")
+	errNotRunningSnowman       = errors.New("chain isn't running snowman consensus")
 	errPartialSyncAsAValidator = errors.New("partial sync should not be configured for a validator")
 
 	fxs = map[ids.ID]fx.Factory{
@@ -143,6 +147,31 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// ConsensusState returns a snapshot of the consensus state of the chain
+	// with the given ID. Returns an error if the chain isn't running the
+	// snowman consensus engine.
+	ConsensusState(ids.ID) (smeng.ConsensusState, error)
+
+	// ExportBlocks returns the bytes of the accepted blocks of the chain with
+	// the given ID, starting at the given start height and ending at, at most,
+	// the given end height. Returns an error if the chain isn't running the
+	// snowman consensus engine.
+	ExportBlocks(id ids.ID, startHeight uint64, endHeight uint64) ([][]byte, error)
+
+	// PauseChain stops the chain with the given ID from handling messages
+	// until ResumeChain is called. Other chains are unaffected.
+	PauseChain(ids.ID) error
+
+	// ResumeChain resumes the chain with the given ID after it was paused.
+	ResumeChain(ids.ID) error
+
+	// Returns true iff the chain with the given ID exists and is paused
+	IsPaused(ids.ID) bool
+
+	// RebootstrapChain moves the chain with the given ID back into state sync,
+	// if stateSync is true, or into bootstrapping.
+	RebootstrapChain(id ids.ID, stateSync bool) error
+
 	// Starts the chain creator with the initial platform chain parameters, must
 	// be called once.
 	StartChainCreator(platformChain ChainParameters) error
@@ -171,6 +200,9 @@ type chain struct {
 	Context *snow.ConsensusContext
 	VM      common.VM
 	Handler handler.Handler
+	// Engine is the snowman consensus engine of the chain, without any
+	// tracing wrappers.
+	Engine *smeng.Engine
 }
 
 // ChainConfig is configuration settings for the current execution.
@@ -232,6 +264,9 @@ type ManagerConfig struct {
 	// This node will only consider the first [AncestorsMaxContainersReceived]
 	// containers in an ancestors message it receives.
 	BootstrapAncestorsMaxContainersReceived int
+	// Max number of GetAncestors requests that can be outstanding at once
+	// while bootstrapping.
+	BootstrapMaxOutstandingRequests int
 
 	Upgrades upgrade.Config
 
@@ -242,6 +277,20 @@ type ManagerConfig struct {
 
 	ChainDataDir string
 
+	// ConsensusJournalChains are the IDs or aliases of the chains whose
+	// consensus events are recorded to a journal.
+	ConsensusJournalChains set.Set[string]
+	ConsensusJournalConfig journal.Config
+
+	// ConsensusSamplingLatencyBias is the probability that a validator sampled
+	// for a poll is replaced by the lower latency of two validators of equal
+	// stake. If 0, validators are sampled by stake alone.
+	ConsensusSamplingLatencyBias float64
+
+	// BootstrapBlockArchives are the paths of the block archives that chains
+	// read blocks from during bootstrapping, before fetching them from peers.
+	BootstrapBlockArchives []string
+
 	Subnets *Subnets
 }
 
@@ -267,6 +316,13 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]handler.Handler
+	// Key: Chain's ID
+	// Value: The chain's snowman consensus engine
+	engines map[ids.ID]*smeng.Engine
+
+	// blockArchives that were opened to bootstrap chains. Only accessed by
+	// the chain creator.
+	blockArchives []*archive.Reader
 
 	// snowman++ related interface to allow validators retrieval
 	validatorState validators.State
@@ -328,6 +384,7 @@ func New(config *ManagerConfig) (Manager, error) {
 		Aliaser:                ids.NewAliaser(),
 		ManagerConfig:          *config,
 		chains:                 make(map[ids.ID]handler.Handler),
+		engines:                make(map[ids.ID]*smeng.Engine),
 		chainsQueue:            buffer.NewUnboundedBlockingDeque[ChainParameters](initialQueueSize),
 		unblockChainCreatorCh:  make(chan struct{}),
 		chainCreatorShutdownCh: make(chan struct{}),
@@ -434,6 +491,7 @@ func (m *manager) createChain(chainParams ChainParameters) {
 
 	m.chainsLock.Lock()
 	m.chains[chainParams.ID] = chain.Handler
+	m.engines[chainParams.ID] = chain.Engine
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -924,6 +982,16 @@ func (m *manager) createAvalancheChain(
 		return nil, fmt.Errorf("couldn't initialize snow base message handler: %w", err)
 	}
 
+	consensusJournal, err := m.getConsensusJournal(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating consensus journal: %w", err)
+	}
+
+	blockArchive, err := m.getBlockArchive(ctx.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("error opening block archive: %w", err)
+	}
+
 	var snowmanConsensus smcon.Consensus = &smcon.Topological{}
 	if m.TracingEnabled {
 		snowmanConsensus = smcon.Trace(snowmanConsensus, m.Tracer)
@@ -940,13 +1008,15 @@ func (m *manager) createAvalancheChain(
 		ConnectedValidators: connectedValidators,
 		Params:              consensusParams,
 		Consensus:           snowmanConsensus,
+		Journal:             consensusJournal,
+		Sampler:             m.getValidatorSampler(vdrs),
 	}
-	var snowmanEngine common.Engine
-	snowmanEngine, err = smeng.New(snowmanEngineConfig)
+	consensusEngine, err := smeng.New(snowmanEngineConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
 	}
 
+	var snowmanEngine common.Engine = consensusEngine
 	if m.TracingEnabled {
 		snowmanEngine = common.TraceEngine(snowmanEngine, m.Tracer)
 	}
@@ -965,8 +1035,10 @@ func (m *manager) createAvalancheChain(
 		Timer:                          h,
 		PeerTracker:                    peerTracker,
 		AncestorsMaxContainersReceived: m.BootstrapAncestorsMaxContainersReceived,
+		MaxOutstandingRequests:         m.BootstrapMaxOutstandingRequests,
 		DB:                             blockBootstrappingDB,
 		VM:                             vmWrappingProposerVM,
+		Archive:                        blockArchive,
 	}
 	var snowmanBootstrapper common.BootstrapableEngine
 	snowmanBootstrapper, err = smbootstrap.New(
@@ -1053,6 +1125,7 @@ func (m *manager) createAvalancheChain(
 		Context: ctx,
 		VM:      dagVM,
 		Handler: h,
+		Engine:  consensusEngine,
 	}, nil
 }
 
@@ -1318,6 +1391,16 @@ func (m *manager) createSnowmanChain(
 		return nil, fmt.Errorf("couldn't initialize snow base message handler: %w", err)
 	}
 
+	consensusJournal, err := m.getConsensusJournal(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating consensus journal: %w", err)
+	}
+
+	blockArchive, err := m.getBlockArchive(ctx.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("error opening block archive: %w", err)
+	}
+
 	var consensus smcon.Consensus = &smcon.Topological{}
 	if m.TracingEnabled {
 		consensus = smcon.Trace(consensus, m.Tracer)
@@ -1335,13 +1418,15 @@ func (m *manager) createSnowmanChain(
 		Params:              consensusParams,
 		Consensus:           consensus,
 		PartialSync:         m.PartialSyncPrimaryNetwork && ctx.ChainID == constants.PlatformChainID,
+		Journal:             consensusJournal,
+		Sampler:             m.getValidatorSampler(vdrs),
 	}
-	var engine common.Engine
-	engine, err = smeng.New(engineConfig)
+	consensusEngine, err := smeng.New(engineConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing snowman engine: %w", err)
 	}
 
+	var engine common.Engine = consensusEngine
 	if m.TracingEnabled {
 		engine = common.TraceEngine(engine, m.Tracer)
 	}
@@ -1360,8 +1445,10 @@ func (m *manager) createSnowmanChain(
 		Timer:                          h,
 		PeerTracker:                    peerTracker,
 		AncestorsMaxContainersReceived: m.BootstrapAncestorsMaxContainersReceived,
+		MaxOutstandingRequests:         m.BootstrapMaxOutstandingRequests,
 		DB:                             bootstrappingDB,
 		VM:                             vm,
+		Archive:                        blockArchive,
 		Bootstrapped:                   bootstrapFunc,
 	}
 	var bootstrapper common.BootstrapableEngine
@@ -1420,6 +1507,7 @@ func (m *manager) createSnowmanChain(
 		Context: ctx,
 		VM:      vm,
 		Handler: h,
+		Engine:  consensusEngine,
 	}, nil
 }
 
@@ -1434,6 +1522,92 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Context().State.Get().State == snow.NormalOp
 }
 
+func (m *manager) ConsensusState(id ids.ID) (smeng.ConsensusState, error) {
+	engine, err := m.getSnowmanEngine(id)
+	if err != nil {
+		return smeng.ConsensusState{}, err
+	}
+	return engine.ConsensusState(), nil
+}
+
+func (m *manager) ExportBlocks(id ids.ID, startHeight uint64, endHeight uint64) ([][]byte, error) {
+	engine, err := m.getSnowmanEngine(id)
+	if err != nil {
+		return nil, err
+	}
+
+	engine.Ctx.Lock.Lock()
+	defer engine.Ctx.Lock.Unlock()
+
+	return archive.ReadBlocks(
+		context.TODO(),
+		engine.VM,
+		startHeight,
+		endHeight,
+		constants.DefaultMaxMessageSize,
+	)
+}
+
+func (m *manager) PauseChain(id ids.ID) error {
+	chain, err := m.getChain(id)
+	if err != nil {
+		return err
+	}
+	return chain.Pause(context.TODO())
+}
+
+func (m *manager) ResumeChain(id ids.ID) error {
+	chain, err := m.getChain(id)
+	if err != nil {
+		return err
+	}
+	return chain.Resume(context.TODO())
+}
+
+func (m *manager) IsPaused(id ids.ID) bool {
+	chain, err := m.getChain(id)
+	return err == nil && chain.Paused()
+}
+
+func (m *manager) RebootstrapChain(id ids.ID, stateSync bool) error {
+	chain, err := m.getChain(id)
+	if err != nil {
+		return err
+	}
+	return chain.Rebootstrap(context.TODO(), stateSync)
+}
+
+// getChain returns the handler of the running chain with the given ID.
+func (m *manager) getChain(id ids.ID) (handler.Handler, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[id]
+	m.chainsLock.Unlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", errUnknownChain, id)
+	}
+	return chain, nil
+}
+
+// getSnowmanEngine returns the snowman consensus engine of the chain with the
+// given ID, if the chain is running it.
+func (m *manager) getSnowmanEngine(id ids.ID) (*smeng.Engine, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[id]
+	engine := m.engines[id]
+	m.chainsLock.Unlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", errUnknownChain, id)
+	}
+
+	// The snowman engine is only initialized once the chain has finished
+	// bootstrapping with it.
+	state := chain.Context().State.Get()
+	if state.Type != p2ppb.EngineType_ENGINE_TYPE_SNOWMAN || state.State != snow.NormalOp {
+		return nil, fmt.Errorf("%w: %s", errNotRunningSnowman, id)
+	}
+	return engine, nil
+}
+
 func (m *manager) registerBootstrappedHealthChecks() error {
 	bootstrappedCheck := health.CheckerFunc(func(context.Context) (interface{}, error) {
 		if subnetIDs := m.Subnets.Bootstrapping(); len(subnetIDs) != 0 {
@@ -1524,6 +1698,14 @@ func (m *manager) Shutdown() {
 	close(m.chainCreatorShutdownCh)
 	m.chainCreatorExited.Wait()
 	m.ManagerConfig.Router.Shutdown(context.TODO())
+
+	for _, blockArchive := range m.blockArchives {
+		if err := blockArchive.Close(); err != nil {
+			m.Log.Warn("failed to close block archive",
+				zap.Error(err),
+			)
+		}
+	}
 }
 
 // LookupVM returns the ID of the VM associated with an alias
@@ -1558,6 +1740,51 @@ func (m *manager) getChainConfig(id ids.ID) (ChainConfig, error) {
 	return ChainConfig{}, nil
 }
 
+// getConsensusJournal returns the journal that the consensus events of the
+// chain should be recorded to. A chain's events are only recorded if its ID or
+// one of its aliases was opted in.
+func (m *manager) getConsensusJournal(ctx *snow.ConsensusContext) (journal.Journal, error) {
+	if m.ConsensusJournalChains.Contains(ctx.ChainID.String()) {
+		return journal.New(ctx.Log, m.ConsensusJournalConfig, ctx.ChainID), nil
+	}
+	aliases, err := m.Aliases(ctx.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	for _, alias := range aliases {
+		if m.ConsensusJournalChains.Contains(alias) {
+			return journal.New(ctx.Log, m.ConsensusJournalConfig, ctx.ChainID), nil
+		}
+	}
+	return journal.NoJournal, nil
+}
+
+// getBlockArchive returns the block archive that the chain should read blocks
+// from during bootstrapping, if any.
+func (m *manager) getBlockArchive(chainID ids.ID) (smbootstrap.Archive, error) {
+	blockArchive, ok, err := archive.Find(m.BootstrapBlockArchives, chainID)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	m.Log.Info("bootstrapping from block archive",
+		zap.Stringer("chainID", chainID),
+		zap.Uint64("startHeight", blockArchive.StartHeight()),
+		zap.Uint64("numBlocks", blockArchive.NumBlocks()),
+	)
+	m.blockArchives = append(m.blockArchives, blockArchive)
+	return blockArchive, nil
+}
+
+// getValidatorSampler returns the sampler that selects the validators queried
+// by consensus. If nil is returned, validators are sampled by stake.
+func (m *manager) getValidatorSampler(vdrs validators.Manager) validators.Sampler {
+	if m.ConsensusSamplingLatencyBias == 0 {
+		return nil
+	}
+	return validators.NewLatencyAwareSampler(vdrs, m.TimeoutManager, m.ConsensusSamplingLatencyBias)
+}
+
 func (m *manager) getOrMakeVMRegisterer(vmID ids.ID, chainAlias string) (metrics.MultiGatherer, error) {
 	vmGatherer, ok := m.vmGatherer[vmID]
 	if !ok {
@@ -3,7 +3,10 @@
 
 package chains
 
-import "github.com/MetalBlockchain/metalgo/ids"
+import (
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman"
+)
 
 // TestManager implements Manager but does nothing. Always returns nil error.
 // To be used only in tests
@@ -49,6 +52,30 @@ func (testManager) IsBootstrapped(ids.ID) bool {
 	return false
 }
 
+func (testManager) ConsensusState(ids.ID) (snowman.ConsensusState, error) {
+	return snowman.ConsensusState{}, nil
+}
+
+func (testManager) ExportBlocks(ids.ID, uint64, uint64) ([][]byte, error) {
+	return nil, nil
+}
+
+func (testManager) PauseChain(ids.ID) error {
+	return nil
+}
+
+func (testManager) ResumeChain(ids.ID) error {
+	return nil
+}
+
+func (testManager) IsPaused(ids.ID) bool {
+	return false
+}
+
+func (testManager) RebootstrapChain(ids.ID, bool) error {
+	return nil
+}
+
 func (testManager) Lookup(s string) (ids.ID, error) {
 	return ids.FromString(s)
 }
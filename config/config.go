@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io/fs"
 	"math"
+	"net/netip"
 	"os"
 	"path/filepath"
 	"strings"
@@ -24,9 +25,11 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/network"
 	"github.com/MetalBlockchain/metalgo/network/dialer"
+	"github.com/MetalBlockchain/metalgo/network/peer"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/node"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/journal"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
@@ -41,6 +44,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/perms"
 	"github.com/MetalBlockchain/metalgo/utils/profiler"
+	"github.com/MetalBlockchain/metalgo/utils/resource"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 	"github.com/MetalBlockchain/metalgo/utils/storage"
 	"github.com/MetalBlockchain/metalgo/utils/timer"
@@ -89,6 +93,9 @@ var (
 	errCannotReadDirectory                    = errors.New("cannot read directory")
 	errUnmarshalling                          = errors.New("unmarshalling failed")
 	errFileDoesNotExist                       = errors.New("file does not exist")
+	errMissingZstdDictionaries                = fmt.Errorf("%s must be set when %s is %s", NetworkCompressionZstdDictionariesKey, NetworkCompressionTypeKey, compression.TypeZstdDict)
+	errTooManyAdditionalAddresses             = fmt.Errorf("%s can contain at most %d addresses", StakingAdditionalAddressesKey, peer.MaxAdditionalIPs)
+	errUnroutableAdditionalAddress            = fmt.Errorf("%s must contain specific IPs and ports", StakingAdditionalAddressesKey)
 )
 
 func getConsensusConfig(v *viper.Viper) snowball.Parameters {
@@ -244,6 +251,23 @@ func getAdaptiveTimeoutConfig(v *viper.Viper) (timer.AdaptiveTimeoutConfig, erro
 	return config, nil
 }
 
+func getZstdDicts(v *viper.Viper) ([]*compression.ZstdDict, error) {
+	dictPaths := v.GetStringSlice(NetworkCompressionZstdDictionariesKey)
+	dicts := make([]*compression.ZstdDict, len(dictPaths))
+	for i, dictPath := range dictPaths {
+		dictPath = GetExpandedString(v, dictPath)
+		dictBytes, err := os.ReadFile(filepath.Clean(dictPath))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read zstd dictionary %q: %w", dictPath, err)
+		}
+		dicts[i], err = compression.ParseZstdDict(dictBytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse zstd dictionary %q: %w", dictPath, err)
+		}
+	}
+	return dicts, nil
+}
+
 func getNetworkConfig(
 	v *viper.Viper,
 	networkID uint32,
@@ -262,11 +286,33 @@ func getNetworkConfig(
 		return network.Config{}, err
 	}
 
+	zstdDicts, err := getZstdDicts(v)
+	if err != nil {
+		return network.Config{}, err
+	}
+	if compressionType == compression.TypeZstdDict && len(zstdDicts) == 0 {
+		return network.Config{}, errMissingZstdDictionaries
+	}
+
+	proxyURLs := v.GetStringSlice(NetworkOutboundProxiesKey)
+	proxies := make([]dialer.ProxyConfig, len(proxyURLs))
+	for i, proxyURL := range proxyURLs {
+		proxies[i], err = dialer.ParseProxyURL(proxyURL)
+		if err != nil {
+			return network.Config{}, fmt.Errorf("invalid %s: %w", NetworkOutboundProxiesKey, err)
+		}
+	}
+
 	allowPrivateIPs := !constants.ProductionNetworkIDs.Contains(networkID)
 	if v.IsSet(NetworkAllowPrivateIPsKey) {
 		allowPrivateIPs = v.GetBool(NetworkAllowPrivateIPsKey)
 	}
 
+	dialIPPreference, err := ips.PreferenceFromString(v.GetString(NetworkDialIPPreferenceKey))
+	if err != nil {
+		return network.Config{}, fmt.Errorf("invalid %s: %w", NetworkDialIPPreferenceKey, err)
+	}
+
 	var supportedACPs set.Set[uint32]
 	for _, acp := range v.GetIntSlice(ACPSupportKey) {
 		if acp < 0 || acp > math.MaxInt32 {
@@ -323,6 +369,9 @@ func getNetworkConfig(
 				DiskThrottlerConfig: throttling.SystemThrottlerConfig{
 					MaxRecheckDelay: v.GetDuration(InboundThrottlerDiskMaxRecheckDelayKey),
 				},
+				MemoryThrottlerConfig: throttling.SystemThrottlerConfig{
+					MaxRecheckDelay: v.GetDuration(InboundThrottlerMemoryMaxRecheckDelayKey),
+				},
 			},
 
 			OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
@@ -332,6 +381,22 @@ func getNetworkConfig(
 			},
 		},
 
+		MessageQueueConfig: peer.MessageQueueConfig{
+			LanesEnabled: v.GetBool(NetworkOutboundLanesEnabledKey),
+			ConsensusLane: peer.LaneConfig{
+				Weight:   v.GetUint64(NetworkOutboundConsensusLaneWeightKey),
+				MaxBytes: v.GetUint64(NetworkOutboundConsensusLaneMaxBytesKey),
+			},
+			BootstrapLane: peer.LaneConfig{
+				Weight:   v.GetUint64(NetworkOutboundBootstrapLaneWeightKey),
+				MaxBytes: v.GetUint64(NetworkOutboundBootstrapLaneMaxBytesKey),
+			},
+			AppLane: peer.LaneConfig{
+				Weight:   v.GetUint64(NetworkOutboundAppLaneWeightKey),
+				MaxBytes: v.GetUint64(NetworkOutboundAppLaneMaxBytesKey),
+			},
+		},
+
 		HealthConfig: network.HealthConfig{
 			Enabled:                      sybilProtectionEnabled,
 			MaxTimeSinceMsgSent:          v.GetDuration(NetworkHealthMaxTimeSinceMsgSentKey),
@@ -348,9 +413,11 @@ func getNetworkConfig(
 		DialerConfig: dialer.Config{
 			ThrottleRps:       v.GetUint32(NetworkOutboundConnectionThrottlingRpsKey),
 			ConnectionTimeout: v.GetDuration(NetworkOutboundConnectionTimeoutKey),
+			Proxies:           proxies,
 		},
 
 		TLSKeyLogFile: v.GetString(NetworkTLSKeyLogFileKey),
+		QUICEnabled:   v.GetBool(NetworkQUICExperimentalEnabledKey),
 
 		TimeoutConfig: network.TimeoutConfig{
 			PingPongTimeout:      v.GetDuration(NetworkPingTimeoutKey),
@@ -363,6 +430,12 @@ func getNetworkConfig(
 			PeerListBloomResetFreq:  v.GetDuration(NetworkPeerListBloomResetFreqKey),
 		},
 
+		PeerRotationConfig: network.PeerRotationConfig{
+			PeerRotationFrequency: v.GetDuration(NetworkPeerRotationFrequencyKey),
+			PeerRotationPortion:   v.GetFloat64(NetworkPeerRotationPortionKey),
+			PeerScoreHalflife:     v.GetDuration(NetworkPeerScoreHalflifeKey),
+		},
+
 		DelayConfig: network.DelayConfig{
 			MaxReconnectDelay:     v.GetDuration(NetworkMaxReconnectDelayKey),
 			InitialReconnectDelay: v.GetDuration(NetworkInitialReconnectDelayKey),
@@ -370,8 +443,11 @@ func getNetworkConfig(
 
 		MaxClockDifference:           v.GetDuration(NetworkMaxClockDifferenceKey),
 		CompressionType:              compressionType,
+		CompressionZstdDicts:         zstdDicts,
+		CompressionZstdSamplesDir:    GetExpandedArg(v, NetworkCompressionZstdSamplesDirKey),
 		PingFrequency:                v.GetDuration(NetworkPingFrequencyKey),
 		AllowPrivateIPs:              allowPrivateIPs,
+		DialIPPreference:             dialIPPreference,
 		UptimeMetricFreq:             v.GetDuration(UptimeMetricFreqKey),
 		MaximumInboundMessageTimeout: v.GetDuration(NetworkMaximumInboundTimeoutKey),
 
@@ -381,6 +457,7 @@ func getNetworkConfig(
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+		PeerStatsMetricsEnabled:   v.GetBool(NetworkPeerStatsMetricsEnabledKey),
 	}
 
 	switch {
@@ -392,16 +469,26 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be in [0,1]", NetworkHealthMaxSendFailRateKey)
 	case config.HealthConfig.MaxPortionSendQueueBytesFull < 0 || config.HealthConfig.MaxPortionSendQueueBytesFull > 1:
 		return network.Config{}, fmt.Errorf("%s must be in [0,1]", NetworkHealthMaxPortionSendQueueFillKey)
+	case config.QUICEnabled && config.ProxyEnabled:
+		return network.Config{}, fmt.Errorf("%s can't be used with %s", NetworkQUICExperimentalEnabledKey, NetworkTCPProxyEnabledKey)
 	case config.DialerConfig.ConnectionTimeout < 0:
 		return network.Config{}, fmt.Errorf("%q must be >= 0", NetworkOutboundConnectionTimeoutKey)
 	case config.PeerListPullGossipFreq < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerListPullGossipFreqKey)
 	case config.PeerListBloomResetFreq < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerListBloomResetFreqKey)
+	case config.PeerRotationFrequency < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerRotationFrequencyKey)
+	case config.PeerRotationPortion < 0 || config.PeerRotationPortion > 1:
+		return network.Config{}, fmt.Errorf("%s must be in [0,1]", NetworkPeerRotationPortionKey)
+	case config.PeerScoreHalflife <= 0:
+		return network.Config{}, fmt.Errorf("%s must be positive", NetworkPeerScoreHalflifeKey)
 	case config.ThrottlerConfig.InboundMsgThrottlerConfig.CPUThrottlerConfig.MaxRecheckDelay < constants.MinInboundThrottlerMaxRecheckDelay:
 		return network.Config{}, fmt.Errorf("%s must be >= %d", InboundThrottlerCPUMaxRecheckDelayKey, constants.MinInboundThrottlerMaxRecheckDelay)
 	case config.ThrottlerConfig.InboundMsgThrottlerConfig.DiskThrottlerConfig.MaxRecheckDelay < constants.MinInboundThrottlerMaxRecheckDelay:
 		return network.Config{}, fmt.Errorf("%s must be >= %d", InboundThrottlerDiskMaxRecheckDelayKey, constants.MinInboundThrottlerMaxRecheckDelay)
+	case config.ThrottlerConfig.InboundMsgThrottlerConfig.MemoryThrottlerConfig.MaxRecheckDelay < constants.MinInboundThrottlerMaxRecheckDelay:
+		return network.Config{}, fmt.Errorf("%s must be >= %d", InboundThrottlerMemoryMaxRecheckDelayKey, constants.MinInboundThrottlerMaxRecheckDelay)
 	case config.MaxReconnectDelay < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxReconnectDelayKey)
 	case config.InitialReconnectDelay < 0:
@@ -419,6 +506,9 @@ func getNetworkConfig(
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
 	}
+	if err := config.MessageQueueConfig.Verify(); err != nil {
+		return network.Config{}, fmt.Errorf("invalid outbound lanes config: %w", err)
+	}
 	return config, nil
 }
 
@@ -433,6 +523,7 @@ func getBenchlistConfig(v *viper.Viper, consensusParameters snowball.Parameters)
 		Duration:               v.GetDuration(BenchlistDurationKey),
 		MinimumFailingDuration: v.GetDuration(BenchlistMinFailingDurationKey),
 		MaxPortion:             (1.0 - (float64(alpha) / float64(k))) / 3.0,
+		Persist:                v.GetBool(BenchlistPersistKey),
 	}
 	switch {
 	case config.Duration < 0:
@@ -487,6 +578,13 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 		BootstrapMaxTimeGetAncestors:            v.GetDuration(BootstrapMaxTimeGetAncestorsKey),
 		BootstrapAncestorsMaxContainersSent:     int(v.GetUint(BootstrapAncestorsMaxContainersSentKey)),
 		BootstrapAncestorsMaxContainersReceived: int(v.GetUint(BootstrapAncestorsMaxContainersReceivedKey)),
+		BootstrapMaxOutstandingRequests:         int(v.GetUint(BootstrapMaxOutstandingRequestsKey)),
+	}
+	if config.BootstrapMaxOutstandingRequests <= 0 {
+		return node.BootstrapConfig{}, fmt.Errorf("%q must be positive", BootstrapMaxOutstandingRequestsKey)
+	}
+	for _, path := range v.GetStringSlice(BootstrapBlockArchivesKey) {
+		config.BootstrapBlockArchives = append(config.BootstrapBlockArchives, GetExpandedString(v, path))
 	}
 
 	// TODO: Add a "BootstrappersKey" flag to more clearly enforce ID and IP
@@ -545,23 +643,75 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 	return config, nil
 }
 
+// getCommaSeparatedStrings returns the strings in [key]. Unlike
+// [viper.Viper.GetStringSlice], a string value, as given by an environment
+// variable or config file, is split on commas rather than on whitespace.
+func getCommaSeparatedStrings(v *viper.Viper, key string) []string {
+	value, ok := v.Get(key).(string)
+	if !ok {
+		return v.GetStringSlice(key)
+	}
+
+	var strs []string
+	for _, str := range strings.Split(value, ",") {
+		if str = strings.TrimSpace(str); str != "" {
+			strs = append(strs, str)
+		}
+	}
+	return strs
+}
+
 func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 	ipConfig := node.IPConfig{
-		PublicIP:                  v.GetString(PublicIPKey),
-		PublicIPResolutionService: v.GetString(PublicIPResolutionServiceKey),
-		PublicIPResolutionFreq:    v.GetDuration(PublicIPResolutionFreqKey),
-		ListenHost:                v.GetString(StakingHostKey),
-		ListenPort:                uint16(v.GetUint(StakingPortKey)),
+		PublicIP:                   v.GetString(PublicIPKey),
+		PublicIPResolutionServices: getCommaSeparatedStrings(v, PublicIPResolutionServiceKey),
+		PublicIPResolutionFreq:     v.GetDuration(PublicIPResolutionFreqKey),
+		ListenHost:                 v.GetString(StakingHostKey),
+		ListenPort:                 uint16(v.GetUint(StakingPortKey)),
+		QUICListenPort:             uint16(v.GetUint(NetworkQUICPortKey)),
 	}
 	if ipConfig.PublicIPResolutionFreq <= 0 {
 		return node.IPConfig{}, fmt.Errorf("%q must be > 0", PublicIPResolutionFreqKey)
 	}
-	if ipConfig.PublicIP != "" && ipConfig.PublicIPResolutionService != "" {
+	if ipConfig.PublicIP != "" && len(ipConfig.PublicIPResolutionServices) != 0 {
 		return node.IPConfig{}, fmt.Errorf("only one of --%s and --%s can be given", PublicIPKey, PublicIPResolutionServiceKey)
 	}
+
+	additionalAddresses := v.GetStringSlice(StakingAdditionalAddressesKey)
+	if len(additionalAddresses) > peer.MaxAdditionalIPs {
+		return node.IPConfig{}, errTooManyAdditionalAddresses
+	}
+	ipConfig.AdditionalListenAddresses = make([]netip.AddrPort, len(additionalAddresses))
+	for i, additionalAddress := range additionalAddresses {
+		addrPort, err := ips.ParseAddrPort(additionalAddress)
+		if err != nil {
+			return node.IPConfig{}, fmt.Errorf("invalid %s %q: %w", StakingAdditionalAddressesKey, additionalAddress, err)
+		}
+		// Additional addresses are advertised to peers as is, so they must be
+		// dialable.
+		if addrPort.Addr().IsUnspecified() || addrPort.Port() == 0 {
+			return node.IPConfig{}, fmt.Errorf("%w: %q", errUnroutableAdditionalAddress, additionalAddress)
+		}
+		ipConfig.AdditionalListenAddresses[i] = addrPort
+	}
 	return ipConfig, nil
 }
 
+func getConsensusJournalConfig(v *viper.Viper) (journal.Config, error) {
+	config := journal.Config{
+		Directory: GetExpandedArg(v, ConsensusJournalDirKey),
+		MaxSize:   v.GetInt(ConsensusJournalMaxSizeKey),
+		MaxFiles:  v.GetInt(ConsensusJournalMaxFilesKey),
+	}
+	if config.MaxSize <= 0 {
+		return journal.Config{}, fmt.Errorf("%s must be > 0", ConsensusJournalMaxSizeKey)
+	}
+	if config.MaxFiles < 0 {
+		return journal.Config{}, fmt.Errorf("%s must be >= 0", ConsensusJournalMaxFilesKey)
+	}
+	return config, nil
+}
+
 func getProfilerConfig(v *viper.Viper) (profiler.Config, error) {
 	config := profiler.Config{
 		Dir:         GetExpandedArg(v, ProfileDirKey),
@@ -1203,6 +1353,39 @@ func getDiskTargeterConfig(v *viper.Viper) (tracker.TargeterConfig, error) {
 	}
 }
 
+func getMemoryTargeterConfig(v *viper.Viper) (tracker.TargeterConfig, error) {
+	// The defaults are relative to the memory available to the process.
+	var (
+		memoryLimit        = float64(resource.MemoryLimit())
+		vdrAlloc           = memoryLimit / 2
+		maxNonVdrUsage     = .6 * memoryLimit
+		maxNonVdrNodeUsage = memoryLimit / 64
+	)
+	if v.IsSet(MemoryVdrAllocKey) {
+		vdrAlloc = v.GetFloat64(MemoryVdrAllocKey)
+	}
+	if v.IsSet(MemoryMaxNonVdrUsageKey) {
+		maxNonVdrUsage = v.GetFloat64(MemoryMaxNonVdrUsageKey)
+	}
+	if v.IsSet(MemoryMaxNonVdrNodeUsageKey) {
+		maxNonVdrNodeUsage = v.GetFloat64(MemoryMaxNonVdrNodeUsageKey)
+	}
+	switch {
+	case vdrAlloc < 0:
+		return tracker.TargeterConfig{}, fmt.Errorf("%q (%f) < 0", MemoryVdrAllocKey, vdrAlloc)
+	case maxNonVdrUsage < 0:
+		return tracker.TargeterConfig{}, fmt.Errorf("%q (%f) < 0", MemoryMaxNonVdrUsageKey, maxNonVdrUsage)
+	case maxNonVdrNodeUsage < 0:
+		return tracker.TargeterConfig{}, fmt.Errorf("%q (%f) < 0", MemoryMaxNonVdrNodeUsageKey, maxNonVdrNodeUsage)
+	default:
+		return tracker.TargeterConfig{
+			VdrAlloc:           vdrAlloc,
+			MaxNonVdrUsage:     maxNonVdrUsage,
+			MaxNonVdrNodeUsage: maxNonVdrNodeUsage,
+		}, nil
+	}
+}
+
 func getTraceConfig(v *viper.Viper) (trace.Config, error) {
 	enabled := v.GetBool(TracingEnabledKey)
 	if !enabled {
@@ -1281,6 +1464,12 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, fmt.Errorf("%s must be >= 0", ConsensusFrontierPollFrequencyKey)
 	}
 
+	// Sampling
+	nodeConfig.ConsensusSamplingLatencyBias = v.GetFloat64(ConsensusSamplingLatencyBiasKey)
+	if nodeConfig.ConsensusSamplingLatencyBias < 0 || nodeConfig.ConsensusSamplingLatencyBias > 1 {
+		return node.Config{}, fmt.Errorf("%s must be in [0, 1]", ConsensusSamplingLatencyBiasKey)
+	}
+
 	// App handling
 	nodeConfig.ConsensusAppConcurrency = int(v.GetUint(ConsensusAppConcurrencyKey))
 	if nodeConfig.ConsensusAppConcurrency <= 0 {
@@ -1351,6 +1540,15 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	// Metrics
 	nodeConfig.MeterVMEnabled = v.GetBool(MeterVMsEnabledKey)
 
+	// Subnet inbound message throttling
+	nodeConfig.SubnetInboundMsgThrottlerConfig = throttling.SubnetInboundMsgThrottlerConfig{
+		AllocSize:                  v.GetUint64(InboundThrottlerSubnetAllocSizeKey),
+		PrimaryNetworkReservedSize: v.GetUint64(InboundThrottlerPrimaryNetworkReservedSizeKey),
+	}
+	if err := nodeConfig.SubnetInboundMsgThrottlerConfig.Verify(); err != nil {
+		return node.Config{}, fmt.Errorf("invalid %s: %w", InboundThrottlerPrimaryNetworkReservedSizeKey, err)
+	}
+
 	// Adaptive Timeout Config
 	nodeConfig.AdaptiveTimeoutConfig, err = getAdaptiveTimeoutConfig(v)
 	if err != nil {
@@ -1431,6 +1629,13 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	// Consensus Journal
+	nodeConfig.ConsensusJournalChains = set.Of(v.GetStringSlice(ConsensusJournalChainsKey)...)
+	nodeConfig.ConsensusJournalConfig, err = getConsensusJournalConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	// VM Aliases
 	nodeConfig.VMAliases, err = getVMAliases(v)
 	if err != nil {
@@ -1462,6 +1667,11 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	nodeConfig.MemoryTargeterConfig, err = getMemoryTargeterConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	nodeConfig.TraceConfig, err = getTraceConfig(v)
 	if err != nil {
 		return node.Config{}, err
@@ -575,3 +575,50 @@ func setupViper(configFilePath string) *viper.Viper {
 	}
 	return v
 }
+
+func TestGetPublicIPResolutionServices(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        string
+		configJSON string
+		expected   []string
+	}{
+		{
+			name:       "default",
+			configJSON: "{}",
+			expected:   []string{},
+		},
+		{
+			name:       "env",
+			env:        "opendns, stun:stun.example.com:3478",
+			configJSON: "{}",
+			expected:   []string{"opendns", "stun:stun.example.com:3478"},
+		},
+		{
+			name:       "config file string",
+			configJSON: fmt.Sprintf(`{%q: "opendns,stun"}`, PublicIPResolutionServiceKey),
+			expected:   []string{"opendns", "stun"},
+		},
+		{
+			name:       "config file array",
+			configJSON: fmt.Sprintf(`{%q: ["opendns", "stun"]}`, PublicIPResolutionServiceKey),
+			expected:   []string{"opendns", "stun"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			if test.env != "" {
+				t.Setenv(EnvVarName(EnvPrefix, PublicIPResolutionServiceKey), test.env)
+			}
+			configFilePath := setupConfigJSON(t, t.TempDir(), test.configJSON)
+			v, err := BuildViper(BuildFlagSet(), []string{"--" + ConfigFileKey, configFilePath})
+			require.NoError(err)
+
+			ipConfig, err := getIPConfig(v)
+			require.NoError(err)
+			require.Equal(test.expected, ipConfig.PublicIPResolutionServices)
+		})
+	}
+}
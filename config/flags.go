@@ -17,11 +17,13 @@ import (
 	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/database/pebbledb"
 	"github.com/MetalBlockchain/metalgo/genesis"
+	"github.com/MetalBlockchain/metalgo/network/peer"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
 	"github.com/MetalBlockchain/metalgo/trace"
 	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/dynamicip"
+	"github.com/MetalBlockchain/metalgo/utils/ips"
 	"github.com/MetalBlockchain/metalgo/utils/ulimit"
 	"github.com/MetalBlockchain/metalgo/utils/units"
 	"github.com/MetalBlockchain/metalgo/vms/components/gas"
@@ -55,6 +57,7 @@ var (
 	defaultSubnetConfigDir      = filepath.Join(defaultConfigDir, "subnets")
 	defaultPluginDir            = filepath.Join(defaultUnexpandedDataDir, "plugins")
 	defaultChainDataDir         = filepath.Join(defaultUnexpandedDataDir, "chainData")
+	defaultConsensusJournalDir  = filepath.Join(defaultUnexpandedDataDir, "journal")
 	defaultProcessContextPath   = filepath.Join(defaultUnexpandedDataDir, DefaultProcessContextFilename)
 )
 
@@ -154,10 +157,15 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkPeerListPullGossipFreqKey, constants.DefaultNetworkPeerListPullGossipFreq, "Frequency to request peers from other nodes")
 	fs.Duration(NetworkPeerListBloomResetFreqKey, constants.DefaultNetworkPeerListBloomResetFreq, "Frequency to recalculate the bloom filter used to request new peers from other nodes")
 
+	// Peer Rotation
+	fs.Duration(NetworkPeerRotationFrequencyKey, constants.DefaultNetworkPeerRotationFrequency, "Frequency to disconnect from the worst scoring non-validator peers. If 0, peers are never rotated")
+	fs.Float64(NetworkPeerRotationPortionKey, constants.DefaultNetworkPeerRotationPortion, "Portion of the connected non-validator peers to disconnect from in every rotation")
+	fs.Duration(NetworkPeerScoreHalflifeKey, constants.DefaultNetworkPeerScoreHalflife, "Halflife of the averagers used to calculate the failure rates of peers")
+
 	// Public IP Resolution
 	fs.String(PublicIPKey, "", "Public IP of this node for P2P communication")
 	fs.Duration(PublicIPResolutionFreqKey, 5*time.Minute, "Frequency at which this node resolves/updates its public IP and renew NAT mappings, if applicable")
-	fs.String(PublicIPResolutionServiceKey, "", fmt.Sprintf("Only acceptable values are %q, %q or %q. When provided, the node will use that service to periodically resolve/update its public IP", dynamicip.OpenDNSName, dynamicip.IFConfigCoName, dynamicip.IFConfigMeName))
+	fs.StringSlice(PublicIPResolutionServiceKey, nil, fmt.Sprintf("Only acceptable values are %q, %q, %q, %q or %q followed by the host:port of a STUN server. When provided, the node will use that service to periodically resolve/update its public IP. If multiple services are provided, an IP is only used if a majority of the services resolved it", dynamicip.OpenDNSName, dynamicip.IFConfigCoName, dynamicip.IFConfigMeName, dynamicip.STUNName, dynamicip.STUNPrefix))
 
 	// Inbound Connection Throttling
 	fs.Duration(NetworkInboundConnUpgradeThrottlerCooldownKey, constants.DefaultInboundConnUpgradeThrottlerCooldown, "Upgrade an inbound connection from a given IP at most once per this duration. If 0, don't rate-limit inbound connection upgrades")
@@ -165,6 +173,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Outbound Connection Throttling
 	fs.Uint(NetworkOutboundConnectionThrottlingRpsKey, constants.DefaultOutboundConnectionThrottlingRps, "Make at most this number of outgoing peer connection attempts per second")
 	fs.Duration(NetworkOutboundConnectionTimeoutKey, constants.DefaultOutboundConnectionTimeout, "Timeout when dialing a peer")
+	fs.StringSlice(NetworkOutboundProxiesKey, nil, "SOCKS5 proxies, of the form socks5://[user:password@]host:port, to dial peers through. If a proxy can't be connected to or authenticated with, the next one is tried. If empty, peers are dialed directly")
 	// Timeouts
 	fs.Duration(NetworkInitialTimeoutKey, constants.DefaultNetworkInitialTimeout, "Initial timeout value of the adaptive timeout manager")
 	fs.Duration(NetworkMinimumTimeoutKey, constants.DefaultNetworkMinimumTimeout, "Minimum timeout value of the adaptive timeout manager")
@@ -176,16 +185,20 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkPingTimeoutKey, constants.DefaultPingPongTimeout, "Timeout value for Ping-Pong with a peer")
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
 
-	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Compression type for outbound messages. Must be one of [%s, %s]", compression.TypeZstd, compression.TypeNone))
+	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Compression type for outbound messages. Must be one of [%s, %s, %s]", compression.TypeZstd, compression.TypeZstdDict, compression.TypeNone))
+	fs.StringSlice(NetworkCompressionZstdDictionariesKey, nil, fmt.Sprintf("Files of zstd dictionaries that messages can be compressed with, in order of preference. Outbound messages are compressed with the first dictionary if the compression type is %s", compression.TypeZstdDict))
+	fs.String(NetworkCompressionZstdSamplesDirKey, "", "Directory to record a sample of outbound messages to, before they are compressed. The samples can be used to train zstd dictionaries. If empty, messages aren't recorded")
 
 	fs.Duration(NetworkMaxClockDifferenceKey, constants.DefaultNetworkMaxClockDifference, "Max allowed clock difference value between this node and peers")
 	// Note: The default value is set to false here because the default
 	// networkID is mainnet. The real default value of NetworkAllowPrivateIPs is
 	// based on the networkID.
 	fs.Bool(NetworkAllowPrivateIPsKey, false, fmt.Sprintf("Allows the node to initiate outbound connection attempts to peers with private IPs. If the provided --%s is one of [%s, %s] the default is false. Oterhwise, the default is true", NetworkNameKey, constants.MainnetName, constants.TahoeName))
+	fs.String(NetworkDialIPPreferenceKey, ips.NoPreference.String(), fmt.Sprintf("IP version to dial first when a peer is reachable on multiple IPs. Must be one of [%s, %s, %s]. If %s, the peer's IPs are dialed in the order the peer advertised them", ips.NoPreference, ips.PreferIPv4, ips.PreferIPv6, ips.NoPreference))
 	fs.Bool(NetworkRequireValidatorToConnectKey, constants.DefaultNetworkRequireValidatorToConnect, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
 	fs.Uint(NetworkPeerReadBufferSizeKey, constants.DefaultNetworkPeerReadBufferSize, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, constants.DefaultNetworkPeerWriteBufferSize, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Bool(NetworkPeerStatsMetricsEnabledKey, false, "If true, message and byte counters will be reported per peer and per op. This produces high-cardinality metrics")
 
 	fs.Bool(NetworkTCPProxyEnabledKey, constants.DefaultNetworkTCPProxyEnabled, "Require all P2P connections to be initiated with a TCP proxy header")
 	// The PROXY protocol specification recommends setting this value to be at
@@ -196,32 +209,48 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkTCPProxyReadTimeoutKey, constants.DefaultNetworkTCPProxyReadTimeout, "Maximum duration to wait for a TCP proxy header")
 
 	fs.String(NetworkTLSKeyLogFileKey, "", "TLS key log file path. Should only be specified for debugging")
+	fs.Bool(NetworkQUICExperimentalEnabledKey, false, "Experimental. If true, the node accepts P2P connections over QUIC, and reconnects to peers that accept QUIC connections over QUIC before falling back to TCP. The first connection to a peer is always over TCP")
+	fs.Uint(NetworkQUICPortKey, DefaultStakingPort, fmt.Sprintf("UDP port to accept QUIC connections on if %s is true. If the port is 0 a port number is automatically chosen", NetworkQUICExperimentalEnabledKey))
 
 	// Benchlist
 	fs.Int(BenchlistFailThresholdKey, constants.DefaultBenchlistFailThreshold, "Number of consecutive failed queries before benchlisting a node")
 	fs.Duration(BenchlistDurationKey, constants.DefaultBenchlistDuration, "Max amount of time a peer is benchlisted after surpassing the threshold")
 	fs.Duration(BenchlistMinFailingDurationKey, constants.DefaultBenchlistMinFailingDuration, "Minimum amount of time messages to a peer must be failing before the peer is benched")
+	fs.Bool(BenchlistPersistKey, false, "If true, benched peers are persisted to the database so that they remain benched across restarts")
 
 	// Router
 	fs.Uint(ConsensusAppConcurrencyKey, constants.DefaultConsensusAppConcurrency, "Maximum number of goroutines to use when handling App messages on a chain")
 	fs.Duration(ConsensusShutdownTimeoutKey, constants.DefaultConsensusShutdownTimeout, "Timeout before killing an unresponsive chain")
 	fs.Duration(ConsensusFrontierPollFrequencyKey, constants.DefaultFrontierPollFrequency, "Frequency of polling for new consensus frontiers")
+	fs.Float64(ConsensusSamplingLatencyBiasKey, 0, "Probability, in [0, 1], that a validator sampled for a consensus poll is replaced by the lower latency of two validators of equal stake. If 0, validators are sampled by stake alone")
 
 	// Inbound Throttling
 	fs.Uint64(InboundThrottlerAtLargeAllocSizeKey, constants.DefaultInboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in inbound message throttler")
 	fs.Uint64(InboundThrottlerVdrAllocSizeKey, constants.DefaultInboundThrottlerVdrAllocSize, "Size, in bytes, of validator byte allocation in inbound message throttler")
 	fs.Uint64(InboundThrottlerNodeMaxAtLargeBytesKey, constants.DefaultInboundThrottlerNodeMaxAtLargeBytes, "Max number of bytes a node can take from the inbound message throttler's at-large allocation. Must be at least the max message size")
 	fs.Uint64(InboundThrottlerMaxProcessingMsgsPerNodeKey, constants.DefaultInboundThrottlerMaxProcessingMsgsPerNode, "Max number of messages currently processing from a given node")
+	fs.Uint64(InboundThrottlerSubnetAllocSizeKey, constants.DefaultInboundThrottlerSubnetAllocSize, "Max number of bytes of unrequested inbound messages that may be processing at once across all subnets, including the primary network")
+	fs.Uint64(InboundThrottlerPrimaryNetworkReservedSizeKey, constants.DefaultInboundThrottlerPrimaryNetworkReservedSize, fmt.Sprintf("Number of bytes of %s that can only be used by the primary network", InboundThrottlerSubnetAllocSizeKey))
 	fs.Uint64(InboundThrottlerBandwidthRefillRateKey, constants.DefaultInboundThrottlerBandwidthRefillRate, "Max average inbound bandwidth usage of a peer, in bytes per second. See BandwidthThrottler")
 	fs.Uint64(InboundThrottlerBandwidthMaxBurstSizeKey, constants.DefaultInboundThrottlerBandwidthMaxBurstSize, "Max inbound bandwidth a node can use at once. Must be at least the max message size. See BandwidthThrottler")
 	fs.Duration(InboundThrottlerCPUMaxRecheckDelayKey, constants.DefaultInboundThrottlerCPUMaxRecheckDelay, "In the CPU-based network throttler, check at least this often whether the node's CPU usage has fallen to an acceptable level")
 	fs.Duration(InboundThrottlerDiskMaxRecheckDelayKey, constants.DefaultInboundThrottlerDiskMaxRecheckDelay, "In the disk-based network throttler, check at least this often whether the node's disk usage has fallen to an acceptable level")
+	fs.Duration(InboundThrottlerMemoryMaxRecheckDelayKey, constants.DefaultInboundThrottlerMemoryMaxRecheckDelay, "In the memory-based network throttler, check at least this often whether the node's memory usage has fallen to an acceptable level")
 
 	// Outbound Throttling
 	fs.Uint64(OutboundThrottlerAtLargeAllocSizeKey, constants.DefaultOutboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in outbound message throttler")
 	fs.Uint64(OutboundThrottlerVdrAllocSizeKey, constants.DefaultOutboundThrottlerVdrAllocSize, "Size, in bytes, of validator byte allocation in outbound message throttler")
 	fs.Uint64(OutboundThrottlerNodeMaxAtLargeBytesKey, constants.DefaultOutboundThrottlerNodeMaxAtLargeBytes, "Max number of bytes a node can take from the outbound message throttler's at-large allocation. Must be at least the max message size")
 
+	// Outbound Lanes
+	fs.Bool(NetworkOutboundLanesEnabledKey, constants.DefaultNetworkOutboundLanesEnabled, "If true, outbound messages to each peer are split into consensus, bootstrap, and app lanes that are scheduled by weight")
+	fs.Uint64(NetworkOutboundConsensusLaneWeightKey, constants.DefaultNetworkOutboundConsensusLaneWeight, "Relative share of a peer's outbound bandwidth given to the consensus lane. Must be > 0")
+	fs.Uint64(NetworkOutboundBootstrapLaneWeightKey, constants.DefaultNetworkOutboundBootstrapLaneWeight, "Relative share of a peer's outbound bandwidth given to the bootstrap lane. Must be > 0")
+	fs.Uint64(NetworkOutboundAppLaneWeightKey, constants.DefaultNetworkOutboundAppLaneWeight, "Relative share of a peer's outbound bandwidth given to the app lane. Must be > 0")
+	fs.Uint64(NetworkOutboundConsensusLaneMaxBytesKey, constants.DefaultNetworkOutboundConsensusLaneMaxBytes, "Max number of bytes that can be pending in a peer's consensus lane. Must be at least the max message size")
+	fs.Uint64(NetworkOutboundBootstrapLaneMaxBytesKey, constants.DefaultNetworkOutboundBootstrapLaneMaxBytes, "Max number of bytes that can be pending in a peer's bootstrap lane. Must be at least the max message size")
+	fs.Uint64(NetworkOutboundAppLaneMaxBytesKey, constants.DefaultNetworkOutboundAppLaneMaxBytes, "Max number of bytes that can be pending in a peer's app lane. Must be at least the max message size")
+
 	// HTTP APIs
 	fs.String(HTTPHostKey, "127.0.0.1", "Address of the HTTP server. If the address is empty or a literal unspecified IP address, the server will bind on all available unicast and anycast IP addresses of the local system")
 	fs.Uint(HTTPPortKey, DefaultHTTPPort, "Port of the HTTP server. If the port is 0 a port number is automatically chosen")
@@ -263,6 +292,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Staking
 	fs.String(StakingHostKey, "", "Address of the consensus server. If the address is empty or a literal unspecified IP address, the server will bind on all available unicast and anycast IP addresses of the local system") // Bind to all interfaces by default.
 	fs.Uint(StakingPortKey, DefaultStakingPort, "Port of the consensus server. If the port is 0 a port number is automatically chosen")
+	fs.StringSlice(StakingAdditionalAddressesKey, nil, fmt.Sprintf("Additional IP:port addresses of the consensus server. The server listens on each address and advertises it to peers as is, so the addresses must be reachable by peers. At most %d addresses can be given", peer.MaxAdditionalIPs))
 	fs.Bool(StakingEphemeralCertEnabledKey, false, "If true, the node uses an ephemeral staking TLS key and certificate, and has an ephemeral node ID")
 	fs.String(StakingTLSKeyPathKey, defaultStakingTLSKeyPath, fmt.Sprintf("Path to the TLS private key for staking. Ignored if %s is specified", StakingTLSKeyContentKey))
 	fs.String(StakingTLSKeyContentKey, "", "Specifies base64 encoded TLS private key for staking")
@@ -307,6 +337,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(BootstrapMaxTimeGetAncestorsKey, 50*time.Millisecond, "Max Time to spend fetching a container and its ancestors when responding to a GetAncestors")
 	fs.Uint(BootstrapAncestorsMaxContainersSentKey, 2000, "Max number of containers in an Ancestors message sent by this node")
 	fs.Uint(BootstrapAncestorsMaxContainersReceivedKey, 2000, "This node reads at most this many containers from an incoming Ancestors message")
+	fs.Uint(BootstrapMaxOutstandingRequestsKey, 16, "Max number of GetAncestors requests that can be outstanding at once while bootstrapping. Block ranges left by an interrupted bootstrap are fetched in parallel with the accepted frontier, from different peers, up to this limit")
+	fs.StringSlice(BootstrapBlockArchivesKey, nil, "Paths to block archives, exported with \"metalgo chain export-blocks\", that chains read blocks from while bootstrapping before fetching them from peers")
 
 	// Consensus
 	fs.Int(SnowSampleSizeKey, snowball.DefaultParameters.K, "Number of nodes to query for each network poll")
@@ -347,6 +379,12 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(ProfileContinuousFreqKey, 15*time.Minute, "How frequently to rotate performance profiles")
 	fs.Int(ProfileContinuousMaxFilesKey, 5, "Maximum number of historical profiles to keep")
 
+	// Consensus Journal
+	fs.StringSlice(ConsensusJournalChainsKey, nil, "IDs or aliases of the chains whose consensus events are recorded to a journal")
+	fs.String(ConsensusJournalDirKey, defaultConsensusJournalDir, "Path to the directory that consensus journals are written to")
+	fs.Int(ConsensusJournalMaxSizeKey, 64, "Size, in megabytes, that a consensus journal file can grow to before it is rotated")
+	fs.Int(ConsensusJournalMaxFilesKey, 8, "Maximum number of rotated consensus journal files to keep per chain")
+
 	// Aliasing
 	fs.String(VMAliasesFileKey, defaultVMAliasFilePath, fmt.Sprintf("Specifies a JSON file that maps vmIDs with custom aliases. Ignored if %s is specified", VMAliasesContentKey))
 	fs.String(VMAliasesContentKey, "", "Specifies base64 encoded maps vmIDs with custom aliases")
@@ -375,6 +413,11 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Float64(DiskMaxNonVdrUsageKey, 1000*units.GiB, "Number of disk reads/writes per second that, if fully utilized, will rate limit all non-validators. Must be >= 0")
 	fs.Float64(DiskMaxNonVdrNodeUsageKey, 1000*units.GiB, "Maximum number of disk reads/writes per second that a non-validator can utilize. Must be >= 0")
 
+	// Memory management
+	fs.Float64(MemoryVdrAllocKey, 0, "Maximum number of bytes of memory to allocate for use by validators. If not provided, defaults to half of the memory available to the process. Must be >= 0")
+	fs.Float64(MemoryMaxNonVdrUsageKey, 0, "Number of bytes of memory that, if utilized by the process, will rate limit all non-validators. If not provided, defaults to 60% of the memory available to the process. Must be >= 0")
+	fs.Float64(MemoryMaxNonVdrNodeUsageKey, 0, "Maximum number of bytes of memory that a non-validator can utilize. If not provided, defaults to 1/64th of the memory available to the process. Must be >= 0")
+
 	// Opentelemetry tracing
 	fs.Bool(TracingEnabledKey, false, "If true, enable opentelemetry tracing")
 	fs.String(TracingExporterTypeKey, trace.GRPC.String(), fmt.Sprintf("Type of exporter to use for tracing. Options are [%s, %s]", trace.GRPC, trace.HTTP))
@@ -84,6 +84,7 @@ const (
 	BootstrapIDsKey                                    = "bootstrap-ids"
 	StakingHostKey                                     = "staking-host"
 	StakingPortKey                                     = "staking-port"
+	StakingAdditionalAddressesKey                      = "staking-additional-addresses"
 	StakingEphemeralCertEnabledKey                     = "staking-ephemeral-cert-enabled"
 	StakingTLSKeyPathKey                               = "staking-tls-key-file"
 	StakingTLSKeyContentKey                            = "staking-tls-key-file-content"
@@ -109,27 +110,45 @@ const (
 	NetworkPeerListNumValidatorIPsKey                  = "network-peer-list-num-validator-ips"
 	NetworkPeerListPullGossipFreqKey                   = "network-peer-list-pull-gossip-frequency"
 	NetworkPeerListBloomResetFreqKey                   = "network-peer-list-bloom-reset-frequency"
+	NetworkPeerRotationFrequencyKey                    = "network-peer-rotation-frequency"
+	NetworkPeerRotationPortionKey                      = "network-peer-rotation-portion"
+	NetworkPeerScoreHalflifeKey                        = "network-peer-score-halflife"
 	NetworkInitialReconnectDelayKey                    = "network-initial-reconnect-delay"
 	NetworkReadHandshakeTimeoutKey                     = "network-read-handshake-timeout"
 	NetworkPingTimeoutKey                              = "network-ping-timeout"
 	NetworkPingFrequencyKey                            = "network-ping-frequency"
 	NetworkMaxReconnectDelayKey                        = "network-max-reconnect-delay"
 	NetworkCompressionTypeKey                          = "network-compression-type"
+	NetworkCompressionZstdDictionariesKey              = "network-compression-zstd-dictionaries"
+	NetworkCompressionZstdSamplesDirKey                = "network-compression-zstd-samples-dir"
 	NetworkMaxClockDifferenceKey                       = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                          = "network-allow-private-ips"
+	NetworkDialIPPreferenceKey                         = "network-dial-ip-preference"
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkPeerStatsMetricsEnabledKey                  = "network-peer-stats-metrics-enabled"
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
+	NetworkQUICExperimentalEnabledKey                  = "network-quic-experimental-enabled"
+	NetworkQUICPortKey                                 = "network-quic-port"
 	NetworkInboundConnUpgradeThrottlerCooldownKey      = "network-inbound-connection-throttling-cooldown"
 	NetworkInboundThrottlerMaxConnsPerSecKey           = "network-inbound-connection-throttling-max-conns-per-sec"
 	NetworkOutboundConnectionThrottlingRpsKey          = "network-outbound-connection-throttling-rps"
 	NetworkOutboundConnectionTimeoutKey                = "network-outbound-connection-timeout"
+	NetworkOutboundProxiesKey                          = "network-outbound-proxies"
+	NetworkOutboundLanesEnabledKey                     = "network-outbound-lanes-enabled"
+	NetworkOutboundConsensusLaneWeightKey              = "network-outbound-consensus-lane-weight"
+	NetworkOutboundBootstrapLaneWeightKey              = "network-outbound-bootstrap-lane-weight"
+	NetworkOutboundAppLaneWeightKey                    = "network-outbound-app-lane-weight"
+	NetworkOutboundConsensusLaneMaxBytesKey            = "network-outbound-consensus-lane-max-bytes"
+	NetworkOutboundBootstrapLaneMaxBytesKey            = "network-outbound-bootstrap-lane-max-bytes"
+	NetworkOutboundAppLaneMaxBytesKey                  = "network-outbound-app-lane-max-bytes"
 	BenchlistFailThresholdKey                          = "benchlist-fail-threshold"
 	BenchlistDurationKey                               = "benchlist-duration"
 	BenchlistMinFailingDurationKey                     = "benchlist-min-failing-duration"
+	BenchlistPersistKey                                = "benchlist-persist"
 	LogsDirKey                                         = "log-dir"
 	LogLevelKey                                        = "log-level"
 	LogDisplayLevelKey                                 = "log-display-level"
@@ -159,6 +178,7 @@ const (
 	ConsensusAppConcurrencyKey                         = "consensus-app-concurrency"
 	ConsensusShutdownTimeoutKey                        = "consensus-shutdown-timeout"
 	ConsensusFrontierPollFrequencyKey                  = "consensus-frontier-poll-frequency"
+	ConsensusSamplingLatencyBiasKey                    = "consensus-sampling-latency-bias"
 	ProposerVMUseCurrentHeightKey                      = "proposervm-use-current-height"
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
@@ -172,6 +192,8 @@ const (
 	BootstrapMaxTimeGetAncestorsKey                    = "bootstrap-max-time-get-ancestors"
 	BootstrapAncestorsMaxContainersSentKey             = "bootstrap-ancestors-max-containers-sent"
 	BootstrapAncestorsMaxContainersReceivedKey         = "bootstrap-ancestors-max-containers-received"
+	BootstrapBlockArchivesKey                          = "bootstrap-block-archives"
+	BootstrapMaxOutstandingRequestsKey                 = "bootstrap-max-outstanding-requests"
 	ChainDataDirKey                                    = "chain-data-dir"
 	ChainConfigDirKey                                  = "chain-config-dir"
 	ChainConfigContentKey                              = "chain-config-content"
@@ -181,14 +203,21 @@ const (
 	ProfileContinuousEnabledKey                        = "profile-continuous-enabled"
 	ProfileContinuousFreqKey                           = "profile-continuous-freq"
 	ProfileContinuousMaxFilesKey                       = "profile-continuous-max-files"
+	ConsensusJournalChainsKey                          = "consensus-journal-chains"
+	ConsensusJournalDirKey                             = "consensus-journal-dir"
+	ConsensusJournalMaxSizeKey                         = "consensus-journal-max-size"
+	ConsensusJournalMaxFilesKey                        = "consensus-journal-max-files"
 	InboundThrottlerAtLargeAllocSizeKey                = "throttler-inbound-at-large-alloc-size"
 	InboundThrottlerVdrAllocSizeKey                    = "throttler-inbound-validator-alloc-size"
 	InboundThrottlerNodeMaxAtLargeBytesKey             = "throttler-inbound-node-max-at-large-bytes"
 	InboundThrottlerMaxProcessingMsgsPerNodeKey        = "throttler-inbound-node-max-processing-msgs"
+	InboundThrottlerSubnetAllocSizeKey                 = "throttler-inbound-subnet-alloc-size"
+	InboundThrottlerPrimaryNetworkReservedSizeKey      = "throttler-inbound-primary-network-reserved-size"
 	InboundThrottlerBandwidthRefillRateKey             = "throttler-inbound-bandwidth-refill-rate"
 	InboundThrottlerBandwidthMaxBurstSizeKey           = "throttler-inbound-bandwidth-max-burst-size"
 	InboundThrottlerCPUMaxRecheckDelayKey              = "throttler-inbound-cpu-max-recheck-delay"
 	InboundThrottlerDiskMaxRecheckDelayKey             = "throttler-inbound-disk-max-recheck-delay"
+	InboundThrottlerMemoryMaxRecheckDelayKey           = "throttler-inbound-memory-max-recheck-delay"
 	CPUVdrAllocKey                                     = "throttler-inbound-cpu-validator-alloc"
 	CPUMaxNonVdrUsageKey                               = "throttler-inbound-cpu-max-non-validator-usage"
 	CPUMaxNonVdrNodeUsageKey                           = "throttler-inbound-cpu-max-non-validator-node-usage"
@@ -201,6 +230,9 @@ const (
 	DiskVdrAllocKey                                    = "throttler-inbound-disk-validator-alloc"
 	DiskMaxNonVdrUsageKey                              = "throttler-inbound-disk-max-non-validator-usage"
 	DiskMaxNonVdrNodeUsageKey                          = "throttler-inbound-disk-max-non-validator-node-usage"
+	MemoryVdrAllocKey                                  = "throttler-inbound-memory-validator-alloc"
+	MemoryMaxNonVdrUsageKey                            = "throttler-inbound-memory-max-non-validator-usage"
+	MemoryMaxNonVdrNodeUsageKey                        = "throttler-inbound-memory-max-non-validator-node-usage"
 	OutboundThrottlerAtLargeAllocSizeKey               = "throttler-outbound-at-large-alloc-size"
 	OutboundThrottlerVdrAllocSizeKey                   = "throttler-outbound-validator-alloc-size"
 	OutboundThrottlerNodeMaxAtLargeBytesKey            = "throttler-outbound-node-max-at-large-bytes"
@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/MetalBlockchain/metalgo/api/admin"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/bootstrap/archive"
+)
+
+const (
+	chainCommand        = "chain"
+	exportBlocksCommand = "export-blocks"
+
+	chainUsage = `Usage: metalgo chain <command> [flags]
+
+Commands:
+  export-blocks  Export the accepted blocks of a chain from a running node to a
+                 block archive that can be passed to --bootstrap-block-archives`
+)
+
+var (
+	errUnknownCommand     = errors.New("unknown command")
+	errMissingChain       = errors.New("--chain must be provided")
+	errMissingOutput      = errors.New("--output must be provided")
+	errInvalidHeightRange = errors.New("--end-height must be >= --start-height")
+	errNoBlocksExported   = errors.New("no blocks were exported")
+)
+
+// runChainCommand runs the chain subcommand with [args] and returns the exit
+// code of the process.
+func runChainCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Println(chainUsage)
+		return 1
+	}
+
+	var err error
+	switch command := args[0]; command {
+	case exportBlocksCommand:
+		err = exportBlocks(args[1:])
+	default:
+		fmt.Println(chainUsage)
+		err = fmt.Errorf("%w: %q", errUnknownCommand, command)
+	}
+	if errors.Is(err, pflag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		fmt.Printf("couldn't run chain %s: %s\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+// exportBlocks writes the accepted blocks of a chain, in the requested height
+// range, to a block archive. The blocks are requested in chunks from the admin
+// API of a running node.
+func exportBlocks(args []string) error {
+	fs := pflag.NewFlagSet(exportBlocksCommand, pflag.ContinueOnError)
+	var (
+		uri         = fs.String("uri", "http://127.0.0.1:9650", "URI of the node to export the blocks from. The admin API must be enabled")
+		chain       = fs.String("chain", "", "ID or alias of the chain to export the blocks of")
+		startHeight = fs.Uint64("start-height", 1, "Height of the first block to export")
+		endHeight   = fs.Uint64("end-height", 0, "Height of the last block to export")
+		output      = fs.String("output", "", "Path to write the block archive to")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	switch {
+	case *chain == "":
+		return errMissingChain
+	case *output == "":
+		return errMissingOutput
+	case *endHeight < *startHeight:
+		return errInvalidHeightRange
+	}
+
+	var (
+		ctx    = context.Background()
+		client = admin.NewClient(*uri)
+		height = *startHeight
+	)
+	chainID, blks, err := getBlocks(ctx, client, *chain, height, *endHeight)
+	if err != nil {
+		return err
+	}
+
+	writer, err := archive.Create(*output, chainID, *startHeight)
+	if err != nil {
+		return fmt.Errorf("failed to create block archive: %w", err)
+	}
+	for {
+		for _, blk := range blks {
+			if err := writer.Add(blk); err != nil {
+				return errors.Join(err, writer.Close())
+			}
+		}
+
+		lastHeight := height + uint64(len(blks)) - 1
+		fmt.Printf("exported blocks up to height %d of %d\n", lastHeight, *endHeight)
+		if lastHeight >= *endHeight {
+			break
+		}
+
+		height = lastHeight + 1
+		_, blks, err = getBlocks(ctx, client, *chain, height, *endHeight)
+		if err != nil {
+			return errors.Join(err, writer.Close())
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to write block archive: %w", err)
+	}
+	fmt.Printf("wrote %d blocks to %s\n", writer.NumBlocks(), *output)
+	return nil
+}
+
+func getBlocks(
+	ctx context.Context,
+	client admin.Client,
+	chain string,
+	startHeight uint64,
+	endHeight uint64,
+) (ids.ID, [][]byte, error) {
+	chainID, blks, err := client.ExportBlocks(ctx, chain, startHeight, endHeight)
+	if err != nil {
+		return ids.Empty, nil, fmt.Errorf("failed to export blocks starting at height %d: %w", startHeight, err)
+	}
+	if len(blks) == 0 {
+		return ids.Empty, nil, fmt.Errorf("%w starting at height %d", errNoBlocksExported, startHeight)
+	}
+	return chainID, blks, nil
+}
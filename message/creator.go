@@ -28,16 +28,29 @@ func NewCreator(
 	log logging.Logger,
 	metrics prometheus.Registerer,
 	compressionType compression.Type,
+	zstdDicts []*compression.ZstdDict,
+	zstdSamplesDir string,
 	maxMessageTimeout time.Duration,
 ) (Creator, error) {
+	if compressionType == compression.TypeZstdDict && len(zstdDicts) == 0 {
+		return nil, errNoZstdDicts
+	}
+
 	builder, err := newMsgBuilder(
 		log,
 		metrics,
 		maxMessageTimeout,
+		zstdDicts,
 	)
 	if err != nil {
 		return nil, err
 	}
+	if zstdSamplesDir != "" {
+		builder.samples, err = newSampleRecorder(log, zstdSamplesDir)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return &creator{
 		OutboundMsgBuilder: newOutboundBuilder(compressionType, builder),
@@ -25,6 +25,7 @@ func Test_newMsgBuilder(t *testing.T) {
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 		10*time.Second,
+		nil,
 	)
 	require.NoError(err)
 	require.NotNil(mb)
@@ -394,6 +395,7 @@ func TestAppError(t *testing.T) {
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 		time.Second,
+		nil,
 	)
 	require.NoError(err)
 
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	message "github.com/MetalBlockchain/metalgo/message"
+	set "github.com/MetalBlockchain/metalgo/utils/set"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -67,6 +68,21 @@ func (mr *OutboundMessageMockRecorder) Bytes() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bytes", reflect.TypeOf((*OutboundMessage)(nil).Bytes))
 }
 
+// BytesFor mocks base method.
+func (m *OutboundMessage) BytesFor(arg0 set.Set[uint32]) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BytesFor", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BytesFor indicates an expected call of BytesFor.
+func (mr *OutboundMessageMockRecorder) BytesFor(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesFor", reflect.TypeOf((*OutboundMessage)(nil).BytesFor), arg0)
+}
+
 // BytesSavedCompression mocks base method.
 func (m *OutboundMessage) BytesSavedCompression() int {
 	m.ctrl.T.Helper()
@@ -6,9 +6,11 @@ package message
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/MetalBlockchain/metalgo/ids"
@@ -16,6 +18,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
 	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 )
 
@@ -35,6 +38,9 @@ var (
 	metricLabels = []string{typeLabel, opLabel, directionLabel}
 
 	errUnknownCompressionType = errors.New("message is compressed with an unknown compression type")
+	errNoZstdDicts            = errors.New("no zstd dictionaries provided")
+	errDuplicateZstdDict      = errors.New("duplicate zstd dictionary ID")
+	errUnknownZstdDict        = errors.New("message is compressed with an unknown zstd dictionary")
 )
 
 // InboundMessage represents a set of fields for an inbound message
@@ -107,6 +113,11 @@ type OutboundMessage interface {
 	Op() Op
 	// Bytes returns the bytes that will be sent
 	Bytes() []byte
+	// BytesFor returns the bytes to send to a peer that supports the zstd
+	// dictionaries in [zstdDictIDs]. If this message was compressed with a
+	// dictionary that the peer doesn't support, the message is compressed
+	// without a dictionary instead, or sent uncompressed if that fails.
+	BytesFor(zstdDictIDs set.Set[uint32]) ([]byte, error)
 	// BytesSavedCompression returns the number of bytes that this message saved
 	// due to being compressed
 	BytesSavedCompression() int
@@ -117,6 +128,17 @@ type outboundMessage struct {
 	op                    Op
 	bytes                 []byte
 	bytesSavedCompression int
+
+	// zstdDictID is the ID of the dictionary that [bytes] were compressed
+	// with. It is only set if [compressedWithDict] is true.
+	zstdDictID         uint32
+	compressedWithDict bool
+	// fallback compresses the message without a dictionary. It is only called
+	// once, as the message may be sent to multiple peers.
+	fallback      func() ([]byte, error)
+	fallbackOnce  sync.Once
+	fallbackBytes []byte
+	fallbackErr   error
 }
 
 func (m *outboundMessage) BypassThrottling() bool {
@@ -131,6 +153,17 @@ func (m *outboundMessage) Bytes() []byte {
 	return m.bytes
 }
 
+func (m *outboundMessage) BytesFor(zstdDictIDs set.Set[uint32]) ([]byte, error) {
+	if !m.compressedWithDict || zstdDictIDs.Contains(m.zstdDictID) {
+		return m.bytes, nil
+	}
+
+	m.fallbackOnce.Do(func() {
+		m.fallbackBytes, m.fallbackErr = m.fallback()
+	})
+	return m.fallbackBytes, m.fallbackErr
+}
+
 func (m *outboundMessage) BytesSavedCompression() int {
 	return m.bytesSavedCompression
 }
@@ -140,8 +173,17 @@ type msgBuilder struct {
 	log logging.Logger
 
 	zstdCompressor compression.Compressor
-	count          *prometheus.CounterVec // type + op + direction
-	duration       *prometheus.GaugeVec   // type + op + direction
+	// zstdDictCompressors are the compressors of each supported dictionary,
+	// indexed by the ID of the dictionary.
+	zstdDictCompressors map[uint32]compression.Compressor
+	// zstdDictIDs are the IDs of the supported dictionaries, in order of
+	// preference. Messages are compressed with the first dictionary.
+	zstdDictIDs []uint32
+	count       *prometheus.CounterVec // type + op + direction
+	duration    *prometheus.GaugeVec   // type + op + direction
+	// samples records outbound messages to train zstd dictionaries on, if
+	// non-nil.
+	samples *sampleRecorder
 
 	maxMessageTimeout time.Duration
 }
@@ -150,16 +192,36 @@ func newMsgBuilder(
 	log logging.Logger,
 	metrics prometheus.Registerer,
 	maxMessageTimeout time.Duration,
+	zstdDicts []*compression.ZstdDict,
 ) (*msgBuilder, error) {
 	zstdCompressor, err := compression.NewZstdCompressor(constants.DefaultMaxMessageSize)
 	if err != nil {
 		return nil, err
 	}
 
+	var (
+		zstdDictCompressors = make(map[uint32]compression.Compressor, len(zstdDicts))
+		zstdDictIDs         = make([]uint32, len(zstdDicts))
+	)
+	for i, dict := range zstdDicts {
+		if _, ok := zstdDictCompressors[dict.ID]; ok {
+			return nil, fmt.Errorf("%w: %d", errDuplicateZstdDict, dict.ID)
+		}
+
+		compressor, err := compression.NewZstdDictCompressor(constants.DefaultMaxMessageSize, dict)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't load zstd dictionary %d: %w", dict.ID, err)
+		}
+		zstdDictCompressors[dict.ID] = compressor
+		zstdDictIDs[i] = dict.ID
+	}
+
 	mb := &msgBuilder{
 		log: log,
 
-		zstdCompressor: zstdCompressor,
+		zstdCompressor:      zstdCompressor,
+		zstdDictCompressors: zstdDictCompressors,
+		zstdDictIDs:         zstdDictIDs,
 		count: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "codec_compressed_count",
@@ -183,20 +245,13 @@ func newMsgBuilder(
 	)
 }
 
-func (mb *msgBuilder) marshal(
-	uncompressedMsg *p2p.Message,
+// compress returns [uncompressedMsgBytes] compressed with [compressionType] and
+// the number of bytes saved by the compression.
+func (mb *msgBuilder) compress(
+	uncompressedMsgBytes []byte,
+	op Op,
 	compressionType compression.Type,
-) ([]byte, int, Op, error) {
-	uncompressedMsgBytes, err := proto.Marshal(uncompressedMsg)
-	if err != nil {
-		return nil, 0, 0, err
-	}
-
-	op, err := ToOp(uncompressedMsg)
-	if err != nil {
-		return nil, 0, 0, err
-	}
-
+) ([]byte, int, error) {
 	// If compression is enabled, we marshal twice:
 	// 1. the original message
 	// 2. the message with compressed bytes
@@ -209,24 +264,41 @@ func (mb *msgBuilder) marshal(
 	)
 	switch compressionType {
 	case compression.TypeNone:
-		return uncompressedMsgBytes, 0, op, nil
+		return uncompressedMsgBytes, 0, nil
 	case compression.TypeZstd:
 		compressedBytes, err := mb.zstdCompressor.Compress(uncompressedMsgBytes)
 		if err != nil {
-			return nil, 0, 0, err
+			return nil, 0, err
 		}
 		compressedMsg = p2p.Message{
 			Message: &p2p.Message_CompressedZstd{
 				CompressedZstd: compressedBytes,
 			},
 		}
+	case compression.TypeZstdDict:
+		if len(mb.zstdDictIDs) == 0 {
+			return nil, 0, errNoZstdDicts
+		}
+		dictID := mb.zstdDictIDs[0]
+		compressedBytes, err := mb.zstdDictCompressors[dictID].Compress(uncompressedMsgBytes)
+		if err != nil {
+			return nil, 0, err
+		}
+		compressedMsg = p2p.Message{
+			Message: &p2p.Message_CompressedZstdDict{
+				CompressedZstdDict: &p2p.CompressedZstdDict{
+					DictionaryId: dictID,
+					Message:      compressedBytes,
+				},
+			},
+		}
 	default:
-		return nil, 0, 0, errUnknownCompressionType
+		return nil, 0, errUnknownCompressionType
 	}
 
 	compressedMsgBytes, err := proto.Marshal(&compressedMsg)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 	compressTook := time.Since(startTime)
 
@@ -239,7 +311,7 @@ func (mb *msgBuilder) marshal(
 	mb.duration.With(labels).Add(float64(compressTook))
 
 	bytesSaved := len(uncompressedMsgBytes) - len(compressedMsgBytes)
-	return compressedMsgBytes, bytesSaved, op, nil
+	return compressedMsgBytes, bytesSaved, nil
 }
 
 func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
@@ -250,14 +322,26 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 
 	// Figure out what compression type, if any, was used to compress the message.
 	var (
-		compressor      compression.Compressor
-		compressedBytes []byte
-		zstdCompressed  = m.GetCompressedZstd()
+		compressionType    compression.Type
+		compressor         compression.Compressor
+		compressedBytes    []byte
+		zstdCompressed     = m.GetCompressedZstd()
+		zstdDictCompressed = m.GetCompressedZstdDict()
 	)
 	switch {
 	case len(zstdCompressed) > 0:
+		compressionType = compression.TypeZstd
 		compressor = mb.zstdCompressor
 		compressedBytes = zstdCompressed
+	case zstdDictCompressed != nil:
+		dictID := zstdDictCompressed.DictionaryId
+		dictCompressor, ok := mb.zstdDictCompressors[dictID]
+		if !ok {
+			return nil, 0, 0, fmt.Errorf("%w: %d", errUnknownZstdDict, dictID)
+		}
+		compressionType = compression.TypeZstdDict
+		compressor = dictCompressor
+		compressedBytes = zstdDictCompressed.Message
 	default:
 		// The message wasn't compressed
 		op, err := ToOp(m)
@@ -284,7 +368,7 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 	}
 
 	labels := prometheus.Labels{
-		typeLabel:      compression.TypeZstd.String(),
+		typeLabel:      compressionType.String(),
 		opLabel:        op.String(),
 		directionLabel: decompressionLabel,
 	}
@@ -295,17 +379,48 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 }
 
 func (mb *msgBuilder) createOutbound(m *p2p.Message, compressionType compression.Type, bypassThrottling bool) (*outboundMessage, error) {
-	b, saved, op, err := mb.marshal(m, compressionType)
+	uncompressedMsgBytes, err := proto.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := ToOp(m)
 	if err != nil {
 		return nil, err
 	}
 
-	return &outboundMessage{
+	if mb.samples != nil {
+		mb.samples.record(op, uncompressedMsgBytes)
+	}
+
+	b, saved, err := mb.compress(uncompressedMsgBytes, op, compressionType)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &outboundMessage{
 		bypassThrottling:      bypassThrottling,
 		op:                    op,
 		bytes:                 b,
 		bytesSavedCompression: saved,
-	}, nil
+	}
+	if compressionType == compression.TypeZstdDict {
+		msg.zstdDictID = mb.zstdDictIDs[0]
+		msg.compressedWithDict = true
+		msg.fallback = func() ([]byte, error) {
+			b, _, err := mb.compress(uncompressedMsgBytes, op, compression.TypeZstd)
+			if err != nil {
+				// Peers can always parse uncompressed messages.
+				mb.log.Warn("failed to compress message without a zstd dictionary",
+					zap.Stringer("op", op),
+					zap.Error(err),
+				)
+				return uncompressedMsgBytes, nil
+			}
+			return b, nil
+		}
+	}
+	return msg, nil
 }
 
 func (mb *msgBuilder) parseInbound(
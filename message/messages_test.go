@@ -18,6 +18,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/staking"
 	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
 )
 
 func TestMessage(t *testing.T) {
@@ -27,6 +28,7 @@ func TestMessage(t *testing.T) {
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 		5*time.Second,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -664,6 +666,7 @@ func TestInboundMessageToString(t *testing.T) {
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 		5*time.Second,
+		nil,
 	)
 	require.NoError(err)
 
@@ -694,6 +697,7 @@ func TestEmptyInboundMessage(t *testing.T) {
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 		5*time.Second,
+		nil,
 	)
 	require.NoError(err)
 
@@ -714,6 +718,7 @@ func TestNilInboundMessage(t *testing.T) {
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 		5*time.Second,
+		nil,
 	)
 	require.NoError(err)
 
@@ -732,3 +737,97 @@ func TestNilInboundMessage(t *testing.T) {
 	pingMsg := parsedMsg.message.(*p2p.Ping)
 	require.NotNil(pingMsg)
 }
+
+func TestZstdDictCompression(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+
+	var (
+		dict1 = &compression.ZstdDict{
+			ID:      1,
+			Content: []byte("chain request container height the dictionary used for testing"),
+		}
+		dict2 = &compression.ZstdDict{
+			ID:      2,
+			Content: []byte("another dictionary that is only supported by the receiver"),
+		}
+	)
+	sender, err := newMsgBuilder(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		5*time.Second,
+		[]*compression.ZstdDict{dict1},
+	)
+	require.NoError(err)
+	receiver, err := newMsgBuilder(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		5*time.Second,
+		[]*compression.ZstdDict{dict2, dict1},
+	)
+	require.NoError(err)
+	noDictReceiver, err := newMsgBuilder(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		5*time.Second,
+		nil,
+	)
+	require.NoError(err)
+
+	outMsg, err := sender.createOutbound(
+		&p2p.Message{
+			Message: &p2p.Message_AppGossip{
+				AppGossip: &p2p.AppGossip{
+					ChainId:  ids.Empty[:],
+					AppBytes: bytes.Repeat([]byte("chain request container height"), 10),
+				},
+			},
+		},
+		compression.TypeZstdDict,
+		false,
+	)
+	require.NoError(err)
+
+	// Peers that support the dictionary are sent the message compressed with
+	// the dictionary.
+	dictBytes, err := outMsg.BytesFor(set.Of[uint32](1, 2))
+	require.NoError(err)
+	require.Equal(outMsg.Bytes(), dictBytes)
+
+	inMsg, err := receiver.parseInbound(dictBytes, ids.EmptyNodeID, func() {})
+	require.NoError(err)
+	require.Equal(AppGossipOp, inMsg.Op())
+
+	// Peers that don't support the dictionary can't decompress the message...
+	_, err = noDictReceiver.parseInbound(dictBytes, ids.EmptyNodeID, func() {})
+	require.ErrorIs(err, errUnknownZstdDict)
+
+	// ... so they are sent the message compressed without a dictionary.
+	fallbackBytes, err := outMsg.BytesFor(set.Of[uint32](2))
+	require.NoError(err)
+	require.NotEqual(dictBytes, fallbackBytes)
+
+	inMsg, err = noDictReceiver.parseInbound(fallbackBytes, ids.EmptyNodeID, func() {})
+	require.NoError(err)
+	require.Equal(AppGossipOp, inMsg.Op())
+
+	_, err = newMsgBuilder(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		5*time.Second,
+		[]*compression.ZstdDict{dict1, dict1},
+	)
+	require.ErrorIs(err, errDuplicateZstdDict)
+
+	_, err = noDictReceiver.createOutbound(
+		&p2p.Message{
+			Message: &p2p.Message_Pong{
+				Pong: &p2p.Pong{},
+			},
+		},
+		compression.TypeZstdDict,
+		false,
+	)
+	require.ErrorIs(err, errNoZstdDicts)
+}
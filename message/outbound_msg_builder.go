@@ -30,6 +30,9 @@ type OutboundMsgBuilder interface {
 		ipSigningTime uint64,
 		ipNodeIDSig []byte,
 		ipBLSSig []byte,
+		additionalIPs []netip.AddrPort,
+		additionalIPNodeIDSigs [][]byte,
+		quicPort uint16,
 		trackedSubnets []ids.ID,
 		supportedACPs []uint32,
 		objectedACPs []uint32,
@@ -237,6 +240,9 @@ func (b *outMsgBuilder) Handshake(
 	ipSigningTime uint64,
 	ipNodeIDSig []byte,
 	ipBLSSig []byte,
+	additionalIPs []netip.AddrPort,
+	additionalIPNodeIDSigs [][]byte,
+	quicPort uint16,
 	trackedSubnets []ids.ID,
 	supportedACPs []uint32,
 	objectedACPs []uint32,
@@ -248,6 +254,15 @@ func (b *outMsgBuilder) Handshake(
 	encodeIDs(trackedSubnets, subnetIDBytes)
 	// TODO: Use .AsSlice() after v1.12.x activates.
 	addr := ip.Addr().As16()
+	additionalIPsPB := make([]*p2p.AdditionalIp, len(additionalIPs))
+	for i, additionalIP := range additionalIPs {
+		additionalAddr := additionalIP.Addr().As16()
+		additionalIPsPB[i] = &p2p.AdditionalIp{
+			IpAddr:      additionalAddr[:],
+			IpPort:      uint32(additionalIP.Port()),
+			IpNodeIdSig: additionalIPNodeIDSigs[i],
+		}
+	}
 	return b.builder.createOutbound(
 		&p2p.Message{
 			Message: &p2p.Message_Handshake{
@@ -271,8 +286,11 @@ func (b *outMsgBuilder) Handshake(
 						Filter: knownPeersFilter,
 						Salt:   knownPeersSalt,
 					},
-					IpBlsSig:   ipBLSSig,
-					AllSubnets: requestAllSubnetIPs,
+					IpBlsSig:                  ipBLSSig,
+					AllSubnets:                requestAllSubnetIPs,
+					SupportedZstdDictionaries: b.builder.zstdDictIDs,
+					AdditionalIps:             additionalIPsPB,
+					QuicPort:                  uint32(quicPort),
 				},
 			},
 		},
@@ -22,6 +22,7 @@ func Test_newOutboundBuilder(t *testing.T) {
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 		10*time.Second,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/perms"
+)
+
+const (
+	// defaultSampleProbability is the probability that an outbound message is
+	// recorded as a sample.
+	defaultSampleProbability = .01
+	// maxSamples is the maximum number of samples that are recorded. Training
+	// a dictionary on more samples than this rarely improves it.
+	maxSamples = 10_000
+)
+
+// sampleRecorder records a fraction of the outbound messages, before they are
+// compressed, to a directory. The recorded samples can be used to train zstd
+// dictionaries with message/traindict.
+type sampleRecorder struct {
+	log         logging.Logger
+	dir         string
+	probability float64
+	numSamples  atomic.Uint64
+}
+
+func newSampleRecorder(log logging.Logger, dir string) (*sampleRecorder, error) {
+	if err := os.MkdirAll(dir, perms.ReadWriteExecute); err != nil {
+		return nil, fmt.Errorf("couldn't create message samples directory: %w", err)
+	}
+	return &sampleRecorder{
+		log:         log,
+		dir:         dir,
+		probability: defaultSampleProbability,
+	}, nil
+}
+
+// record writes [msgBytes], the uncompressed bytes of a message with [op], to
+// the samples directory with probability [r.probability], until [maxSamples]
+// samples have been recorded.
+func (r *sampleRecorder) record(op Op, msgBytes []byte) {
+	if rand.Float64() >= r.probability { // #nosec G404
+		return
+	}
+	numSamples := r.numSamples.Add(1)
+	if numSamples > maxSamples {
+		return
+	}
+
+	path := filepath.Join(r.dir, fmt.Sprintf("%d-%s", numSamples, op))
+	if err := perms.WriteFile(path, msgBytes, perms.ReadWrite); err != nil {
+		r.log.Debug("failed to record message sample",
+			zap.String("path", path),
+			zap.Error(err),
+		)
+	}
+}
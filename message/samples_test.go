@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+func TestSampleRecorder(t *testing.T) {
+	require := require.New(t)
+
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		5*time.Second,
+		nil,
+	)
+	require.NoError(err)
+
+	dir := filepath.Join(t.TempDir(), "samples")
+	mb.samples, err = newSampleRecorder(logging.NoLog{}, dir)
+	require.NoError(err)
+	mb.samples.probability = 1
+
+	msg := &p2p.Message{
+		Message: &p2p.Message_Ping{
+			Ping: &p2p.Ping{},
+		},
+	}
+	uncompressedMsgBytes, err := proto.Marshal(msg)
+	require.NoError(err)
+
+	// Samples are recorded before they are compressed.
+	_, err = mb.createOutbound(msg, compression.TypeZstd, false)
+	require.NoError(err)
+
+	sample, err := os.ReadFile(filepath.Join(dir, "1-ping"))
+	require.NoError(err)
+	require.Equal(uncompressedMsgBytes, sample)
+
+	// No samples are recorded after [maxSamples] samples.
+	mb.samples.numSamples.Store(maxSamples)
+	_, err = mb.createOutbound(msg, compression.TypeZstd, false)
+	require.NoError(err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(err)
+	require.Len(entries, 1)
+}
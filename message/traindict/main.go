@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/perms"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+// This trains a zstd dictionary for p2p messages from recorded messages.
+//
+// Each file in the samples directory must contain a single message, as it is
+// sent over the wire. Messages compressed with zstd are decompressed before
+// training. Nodes record samples to --network-compression-zstd-samples-dir.
+// The written dictionary can be provided to nodes with
+// --network-compression-zstd-dictionaries.
+func main() {
+	var (
+		samplesDir = flag.String("samples", "", "directory of recorded messages, one message per file")
+		output     = flag.String("output", "zstd.dict", "file to write the dictionary to")
+		id         = flag.Uint("id", 1, "ID of the dictionary. Must not have been used by a different dictionary")
+		size       = flag.Int("size", 32*units.KiB, "maximum size of the dictionary in bytes")
+	)
+	flag.Parse()
+
+	if *samplesDir == "" {
+		log.Fatal("--samples must be provided")
+	}
+	if *id == 0 || *id > uint(^uint32(0)) {
+		log.Fatalf("--id must be in the range [1, %d]", ^uint32(0))
+	}
+
+	samples, err := readSamples(*samplesDir)
+	if err != nil {
+		log.Fatalf("failed to read samples: %v", err)
+	}
+
+	content, err := compression.TrainZstdDict(samples, *size)
+	if err != nil {
+		log.Fatalf("failed to train dictionary: %v", err)
+	}
+
+	dict := &compression.ZstdDict{
+		ID:      uint32(*id),
+		Content: content,
+	}
+	if err := perms.WriteFile(*output, dict.Bytes(), perms.ReadWrite); err != nil {
+		log.Fatalf("failed to write dictionary: %v", err)
+	}
+	log.Printf("wrote %d byte dictionary %d trained on %d samples to %s", len(content), dict.ID, len(samples), *output)
+}
+
+func readSamples(dir string) ([][]byte, error) {
+	zstdCompressor, err := compression.NewZstdCompressor(constants.DefaultMaxMessageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([][]byte, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		sample, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		// Dictionaries are used to compress uncompressed messages, so they
+		// must be trained on uncompressed messages.
+		var msg p2p.Message
+		if err := proto.Unmarshal(sample, &msg); err == nil {
+			if compressed := msg.GetCompressedZstd(); len(compressed) > 0 {
+				sample, err = zstdCompressor.Decompress(compressed)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
@@ -32,15 +32,34 @@ type Router interface {
 	ExternalIP() (netip.Addr, error)
 }
 
-// GetRouter returns a router on the current network.
-func GetRouter() Router {
-	if r := getUPnPRouter(); r != nil {
+// GetRouter returns a router on the current network. The protocols are probed
+// concurrently, with UPnP preferred over PCP and PCP preferred over NAT-PMP.
+// Probing may map [port], which is the port that is expected to be mapped.
+func GetRouter(port uint16) Router {
+	var (
+		upnp = make(chan *upnpRouter, 1)
+		pcp  = make(chan *pcpRouter, 1)
+		pmp  = make(chan *pmpRouter, 1)
+	)
+	go func() {
+		upnp <- getUPnPRouter()
+	}()
+	go func() {
+		pcp <- getPCPRouter(port)
+	}()
+	go func() {
+		pmp <- getPMPRouter()
+	}()
+
+	if r := <-upnp; r != nil {
 		return r
 	}
-	if r := getPMPRouter(); r != nil {
+	if r := <-pcp; r != nil {
+		return r
+	}
+	if r := <-pmp; r != nil {
 		return r
 	}
-
 	return NewNoRouter()
 }
 
@@ -0,0 +1,447 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackpal/gateway"
+)
+
+// See RFC 6887 for the specification of the Port Control Protocol.
+const (
+	pcpPort    = 5351
+	pcpVersion = 2
+
+	pcpOpMap      = 1
+	pcpOpResponse = 0x80
+
+	pcpProtocolTCP = 6
+
+	pcpNonceLen        = 12
+	pcpHeaderLen       = 24
+	pcpMapPayloadLen   = 36
+	pcpMapMessageLen   = pcpHeaderLen + pcpMapPayloadLen
+	pcpMaxResponseSize = 1100
+
+	// pcpRequestAttempts is the number of times a request is sent before
+	// giving up. The wait for a response doubles after each attempt.
+	pcpRequestAttempts = 3
+	pcpInitialTimeout  = 250 * time.Millisecond
+
+	// pcpProbeLifetime is the lifetime of the mapping requested to learn our
+	// external IP if we don't have a mapping yet. The mapping is renewed once
+	// the port is mapped.
+	pcpProbeLifetime = time.Minute
+
+	// ipv6RoutesPath lists the IPv6 routing table on Linux.
+	ipv6RoutesPath = "/proc/net/ipv6_route"
+)
+
+// pcpResultSuccess is the only result code that doesn't indicate a failure.
+const pcpResultSuccess = 0
+
+var (
+	_ Router = (*pcpRouter)(nil)
+
+	errPCPNoResponse          = errors.New("no response from PCP server")
+	errPCPUnexpectedPort      = errors.New("PCP server assigned an unexpected external port")
+	errPCPNoExternalIP        = errors.New("PCP server didn't assign an external IP")
+	errPCPUnsupportedClientIP = errors.New("unsupported PCP client IP")
+	errPCPUnknownPort         = errors.New("port to probe PCP with is unknown")
+
+	pcpResultCodes = map[byte]string{
+		1:  "UNSUPP_VERSION",
+		2:  "NOT_AUTHORIZED",
+		3:  "MALFORMED_REQUEST",
+		4:  "UNSUPP_OPCODE",
+		5:  "UNSUPP_OPTION",
+		6:  "MALFORMED_OPTION",
+		7:  "NETWORK_FAILURE",
+		8:  "NO_RESOURCES",
+		9:  "UNSUPP_PROTOCOL",
+		10: "USER_EX_QUOTA",
+		11: "CANNOT_PROVIDE_EXTERNAL",
+		12: "ADDRESS_MISMATCH",
+		13: "EXCESSIVE_REMOTE_PEERS",
+	}
+)
+
+// pcpResultError is returned when the PCP server rejects a request.
+type pcpResultError byte
+
+func (e pcpResultError) Error() string {
+	if name, ok := pcpResultCodes[byte(e)]; ok {
+		return fmt.Sprintf("PCP request failed with %s (%d)", name, byte(e))
+	}
+	return fmt.Sprintf("PCP request failed with result code %d", byte(e))
+}
+
+// pcpMapping is the mapping that the PCP server reported in a response to a
+// MAP request.
+type pcpMapping struct {
+	externalPort uint16
+	externalIP   netip.Addr
+}
+
+// pcpRouter maps ports using the Port Control Protocol. Mappings are made for
+// the address family of the PCP server, so an IPv6 server results in IPv6
+// mappings.
+type pcpRouter struct {
+	server netip.AddrPort
+	// port is the port that is mapped to learn our external IP if there
+	// aren't any mappings yet.
+	port uint16
+
+	// lock serializes requests to the server so that responses can't be
+	// confused with each other.
+	lock sync.Mutex
+	// nonces contains the nonce of each of our mappings, indexed by the
+	// internal port. PCP requires the same nonce to be used to renew or delete
+	// a mapping.
+	nonces map[uint16][pcpNonceLen]byte
+	// externalIP is the external IP reported in the latest response to a MAP
+	// request.
+	externalIP netip.Addr
+}
+
+func newPCPRouter(server netip.AddrPort, port uint16) *pcpRouter {
+	return &pcpRouter{
+		server: server,
+		port:   port,
+		nonces: make(map[uint16][pcpNonceLen]byte),
+	}
+}
+
+func (*pcpRouter) SupportsNAT() bool {
+	return true
+}
+
+func (r *pcpRouter) MapPort(
+	intPort uint16,
+	extPort uint16,
+	_ string,
+	duration time.Duration,
+) error {
+	lifetime := duration.Seconds()
+	if lifetime < 0 || lifetime > math.MaxUint32 {
+		return errInvalidLifetime
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// Reusing the nonce of an existing mapping renews it.
+	nonce, ok := r.nonces[intPort]
+	if !ok {
+		if _, err := rand.Read(nonce[:]); err != nil {
+			return err
+		}
+	}
+
+	mapping, err := r.requestMapping(nonce, intPort, extPort, uint32(lifetime))
+	if err != nil {
+		return err
+	}
+	r.nonces[intPort] = nonce
+
+	if mapping.externalPort != extPort {
+		// The assigned port can't be advertised, so the mapping is removed.
+		delete(r.nonces, intPort)
+		_, _ = r.requestMapping(nonce, intPort, 0, 0)
+		return fmt.Errorf("%w: requested %d but got %d",
+			errPCPUnexpectedPort,
+			extPort,
+			mapping.externalPort,
+		)
+	}
+	return nil
+}
+
+func (r *pcpRouter) UnmapPort(intPort uint16, _ uint16) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	nonce, ok := r.nonces[intPort]
+	if !ok {
+		return nil
+	}
+	delete(r.nonces, intPort)
+
+	_, err := r.requestMapping(nonce, intPort, 0, 0)
+	return err
+}
+
+// ExternalIP returns the external IP reported when our mappings were last
+// requested or renewed. If there aren't any mappings, a short-lived mapping of
+// [r.port] is requested to learn the external IP. That mapping is renewed,
+// rather than replaced, when [r.port] is mapped.
+func (r *pcpRouter) ExternalIP() (netip.Addr, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.nonces) != 0 && r.externalIP.IsValid() {
+		return r.externalIP, nil
+	}
+
+	// Port 0 would request a mapping of every port.
+	if r.port == 0 {
+		return netip.Addr{}, errPCPUnknownPort
+	}
+
+	nonce, ok := r.nonces[r.port]
+	if !ok {
+		if _, err := rand.Read(nonce[:]); err != nil {
+			return netip.Addr{}, err
+		}
+	}
+	mapping, err := r.requestMapping(nonce, r.port, r.port, uint32(pcpProbeLifetime.Seconds()))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	r.nonces[r.port] = nonce
+	return mapping.externalIP, nil
+}
+
+// requestMapping sends a MAP request to the server and waits for its response.
+// A [lifetime] of 0 deletes the mapping.
+//
+// Invariant: [r.lock] must be held.
+func (r *pcpRouter) requestMapping(
+	nonce [pcpNonceLen]byte,
+	intPort uint16,
+	extPort uint16,
+	lifetime uint32,
+) (pcpMapping, error) {
+	conn, err := net.DialUDP("udp", nil, net.UDPAddrFromAddrPort(r.server))
+	if err != nil {
+		return pcpMapping{}, err
+	}
+	defer conn.Close()
+
+	// The server rejects requests whose client IP doesn't match the source
+	// address of the packet.
+	localAddr, ok := conn.LocalAddr().(*net.UDPAddr)
+	if !ok {
+		return pcpMapping{}, errPCPUnsupportedClientIP
+	}
+	clientIP := localAddr.AddrPort().Addr().Unmap()
+	if !clientIP.IsValid() {
+		return pcpMapping{}, errPCPUnsupportedClientIP
+	}
+
+	request := marshalPCPMapRequest(clientIP, nonce, intPort, extPort, lifetime)
+	response := make([]byte, pcpMaxResponseSize)
+	timeout := pcpInitialTimeout
+	for i := 0; i < pcpRequestAttempts; i++ {
+		if _, err := conn.Write(request); err != nil {
+			return pcpMapping{}, err
+		}
+
+		if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return pcpMapping{}, err
+		}
+		timeout *= 2
+
+		for {
+			n, err := conn.Read(response)
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				break
+			}
+			if err != nil {
+				return pcpMapping{}, err
+			}
+
+			mapping, ok, err := parsePCPMapResponse(response[:n], nonce, intPort)
+			if !ok {
+				// Ignore responses to other requests.
+				continue
+			}
+			if err != nil {
+				return pcpMapping{}, err
+			}
+			if lifetime != 0 {
+				r.externalIP = mapping.externalIP
+			}
+			return mapping, nil
+		}
+	}
+	return pcpMapping{}, errPCPNoResponse
+}
+
+func marshalPCPMapRequest(
+	clientIP netip.Addr,
+	nonce [pcpNonceLen]byte,
+	intPort uint16,
+	extPort uint16,
+	lifetime uint32,
+) []byte {
+	request := make([]byte, pcpMapMessageLen)
+	request[0] = pcpVersion
+	request[1] = pcpOpMap
+	binary.BigEndian.PutUint32(request[4:], lifetime)
+	clientIPBytes := clientIP.As16()
+	copy(request[8:], clientIPBytes[:])
+
+	payload := request[pcpHeaderLen:]
+	copy(payload, nonce[:])
+	payload[12] = pcpProtocolTCP
+	binary.BigEndian.PutUint16(payload[16:], intPort)
+	binary.BigEndian.PutUint16(payload[18:], extPort)
+
+	// We don't have a preference for the external IP, which is denoted by the
+	// unspecified address of the client's address family.
+	suggestedIP := netip.IPv6Unspecified()
+	if clientIP.Is4() {
+		suggestedIP = netip.IPv4Unspecified()
+	}
+	suggestedIPBytes := suggestedIP.As16()
+	copy(payload[20:], suggestedIPBytes[:])
+	return request
+}
+
+// parsePCPMapResponse returns the mapping reported in [response]. ok is false
+// if [response] isn't a response to the MAP request with [nonce] for
+// [intPort].
+func parsePCPMapResponse(
+	response []byte,
+	nonce [pcpNonceLen]byte,
+	intPort uint16,
+) (pcpMapping, bool, error) {
+	if len(response) < 4 || response[1] != pcpOpResponse|pcpOpMap {
+		return pcpMapping{}, false, nil
+	}
+
+	// Servers may reject a request without echoing its payload, for example
+	// NAT-PMP servers reject PCP requests with a shorter NAT-PMP response. So,
+	// the payload is only required if the request succeeded.
+	result := response[3]
+	if result != pcpResultSuccess {
+		if len(response) >= pcpMapMessageLen && !isPCPMapResponseFor(response[pcpHeaderLen:], nonce, intPort) {
+			return pcpMapping{}, false, nil
+		}
+		return pcpMapping{}, true, pcpResultError(result)
+	}
+
+	if response[0] != pcpVersion || len(response) < pcpMapMessageLen {
+		return pcpMapping{}, false, nil
+	}
+	payload := response[pcpHeaderLen:]
+	if !isPCPMapResponseFor(payload, nonce, intPort) {
+		return pcpMapping{}, false, nil
+	}
+
+	externalIP := netip.AddrFrom16([16]byte(payload[20:36])).Unmap()
+	if binary.BigEndian.Uint32(response[4:]) != 0 && externalIP.IsUnspecified() {
+		return pcpMapping{}, true, errPCPNoExternalIP
+	}
+	return pcpMapping{
+		externalPort: binary.BigEndian.Uint16(payload[18:]),
+		externalIP:   externalIP,
+	}, true, nil
+}
+
+func isPCPMapResponseFor(payload []byte, nonce [pcpNonceLen]byte, intPort uint16) bool {
+	return [pcpNonceLen]byte(payload[:pcpNonceLen]) == nonce &&
+		payload[12] == pcpProtocolTCP &&
+		binary.BigEndian.Uint16(payload[16:]) == intPort
+}
+
+// getPCPRouter returns a router for the first of our gateways that supports
+// PCP. The gateways are probed concurrently by mapping [port]. IPv4 gateways
+// are preferred over IPv6 gateways.
+func getPCPRouter(port uint16) *pcpRouter {
+	gateways := discoverGateways()
+	routers := make([]chan *pcpRouter, len(gateways))
+	for i, gatewayAddr := range gateways {
+		routers[i] = make(chan *pcpRouter, 1)
+		go func(routers chan<- *pcpRouter, gatewayAddr netip.Addr) {
+			pcp := newPCPRouter(netip.AddrPortFrom(gatewayAddr, pcpPort), port)
+			if _, err := pcp.ExternalIP(); err != nil {
+				pcp = nil
+			}
+			routers <- pcp
+		}(routers[i], gatewayAddr)
+	}
+
+	for _, router := range routers {
+		if pcp := <-router; pcp != nil {
+			return pcp
+		}
+	}
+	return nil
+}
+
+// discoverGateways returns the default IPv4 gateway followed by the default
+// IPv6 gateways.
+func discoverGateways() []netip.Addr {
+	var gateways []netip.Addr
+	if gatewayIP, err := gateway.DiscoverGateway(); err == nil {
+		if gatewayAddr, ok := netip.AddrFromSlice(gatewayIP); ok {
+			gateways = append(gateways, gatewayAddr.Unmap())
+		}
+	}
+
+	// IPv6 gateway discovery is only supported on Linux.
+	routes, err := os.Open(ipv6RoutesPath)
+	if err != nil {
+		return gateways
+	}
+	defer routes.Close()
+
+	ipv6Gateways, _ := parseIPv6DefaultGateways(routes)
+	return append(gateways, ipv6Gateways...)
+}
+
+// parseIPv6DefaultGateways returns the next hops of the default routes in
+// [routes], which must be formatted as /proc/net/ipv6_route. Link-local
+// gateways are zoned to the interface of their route.
+func parseIPv6DefaultGateways(routes io.Reader) ([]netip.Addr, error) {
+	const (
+		destinationField       = 0
+		destinationLengthField = 1
+		nextHopField           = 4
+		deviceField            = 9
+		numFields              = 10
+	)
+
+	var gateways []netip.Addr
+	scanner := bufio.NewScanner(routes)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != numFields ||
+			fields[destinationLengthField] != "00" ||
+			strings.Trim(fields[destinationField], "0") != "" {
+			continue
+		}
+
+		nextHopBytes, err := hex.DecodeString(fields[nextHopField])
+		if err != nil || len(nextHopBytes) != net.IPv6len {
+			continue
+		}
+		nextHop := netip.AddrFrom16([16]byte(nextHopBytes))
+		if nextHop.IsUnspecified() {
+			continue
+		}
+		if nextHop.IsLinkLocalUnicast() {
+			nextHop = nextHop.WithZone(fields[deviceField])
+		}
+		gateways = append(gateways, nextHop)
+	}
+	return gateways, scanner.Err()
+}
@@ -0,0 +1,273 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"encoding/binary"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+const (
+	pcpResultNotAuthorized   = 2
+	pcpResultAddressMismatch = 12
+)
+
+type fakePCPMapping struct {
+	nonce        [pcpNonceLen]byte
+	externalPort uint16
+}
+
+// fakePCPServer is an in-process PCP server that supports the MAP opcode.
+type fakePCPServer struct {
+	conn *net.UDPConn
+
+	lock sync.Mutex
+	// result is sent as the result code of every response, if non-zero.
+	result byte
+	// portOffset is added to the suggested external port of every mapping.
+	portOffset  uint16
+	externalIP  netip.Addr
+	mappings    map[uint16]fakePCPMapping
+	numRequests map[uint16]int
+}
+
+func newFakePCPServer(t *testing.T, addr netip.Addr, externalIP netip.Addr) *fakePCPServer {
+	conn, err := net.ListenUDP("udp", net.UDPAddrFromAddrPort(netip.AddrPortFrom(addr, 0)))
+	if err != nil {
+		t.Skipf("couldn't listen on %s: %s", addr, err)
+	}
+
+	s := &fakePCPServer{
+		conn:        conn,
+		externalIP:  externalIP,
+		mappings:    make(map[uint16]fakePCPMapping),
+		numRequests: make(map[uint16]int),
+	}
+	go s.serve()
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return s
+}
+
+func (s *fakePCPServer) addr() netip.AddrPort {
+	return s.conn.LocalAddr().(*net.UDPAddr).AddrPort()
+}
+
+func (s *fakePCPServer) serve() {
+	request := make([]byte, pcpMaxResponseSize)
+	for {
+		n, from, err := s.conn.ReadFromUDPAddrPort(request)
+		if err != nil {
+			return
+		}
+		if n != pcpMapMessageLen || request[0] != pcpVersion || request[1] != pcpOpMap {
+			continue
+		}
+
+		response := s.handle(request[:n], from.Addr().Unmap())
+		_, _ = s.conn.WriteToUDPAddrPort(response, from)
+	}
+}
+
+func (s *fakePCPServer) handle(request []byte, from netip.Addr) []byte {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	payload := request[pcpHeaderLen:]
+	var (
+		lifetime = binary.BigEndian.Uint32(request[4:])
+		clientIP = netip.AddrFrom16([16]byte(request[8:24])).Unmap()
+		nonce    = [pcpNonceLen]byte(payload[:pcpNonceLen])
+		intPort  = binary.BigEndian.Uint16(payload[16:])
+		extPort  = binary.BigEndian.Uint16(payload[18:])
+	)
+	s.numRequests[intPort]++
+
+	result := s.result
+	mapping, exists := s.mappings[intPort]
+	switch {
+	case result != pcpResultSuccess:
+	case clientIP != from:
+		result = pcpResultAddressMismatch
+	case exists && mapping.nonce != nonce:
+		result = pcpResultNotAuthorized
+	case lifetime == 0:
+		delete(s.mappings, intPort)
+	case exists:
+		extPort = mapping.externalPort
+	default:
+		extPort += s.portOffset
+		s.mappings[intPort] = fakePCPMapping{
+			nonce:        nonce,
+			externalPort: extPort,
+		}
+	}
+
+	response := make([]byte, pcpMapMessageLen)
+	response[0] = pcpVersion
+	response[1] = pcpOpResponse | pcpOpMap
+	response[3] = result
+	binary.BigEndian.PutUint32(response[4:], lifetime)
+	copy(response[pcpHeaderLen:], payload)
+	binary.BigEndian.PutUint16(response[pcpHeaderLen+18:], extPort)
+	externalIP := s.externalIP.As16()
+	copy(response[pcpHeaderLen+20:], externalIP[:])
+	return response
+}
+
+func (s *fakePCPServer) setExternalIP(ip netip.Addr) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.externalIP = ip
+}
+
+func (s *fakePCPServer) numMappings() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return len(s.mappings)
+}
+
+func (s *fakePCPServer) requests(intPort uint16) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.numRequests[intPort]
+}
+
+func TestPCPRouter(t *testing.T) {
+	tests := []struct {
+		name       string
+		addr       netip.Addr
+		externalIP netip.Addr
+	}{
+		{
+			name:       "ipv4",
+			addr:       netip.MustParseAddr("127.0.0.1"),
+			externalIP: netip.MustParseAddr("203.0.113.1"),
+		},
+		{
+			name:       "ipv6",
+			addr:       netip.IPv6Loopback(),
+			externalIP: netip.MustParseAddr("2001:db8::1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			server := newFakePCPServer(t, tt.addr, tt.externalIP)
+			router := newPCPRouter(server.addr(), 9651)
+
+			// Learning the external IP without a mapping maps the port that
+			// is expected to be mapped.
+			externalIP, err := router.ExternalIP()
+			require.NoError(err)
+			require.Equal(tt.externalIP, externalIP)
+			require.Equal(1, server.numMappings())
+
+			// Mapping the probed port must renew the probe.
+			require.NoError(router.MapPort(9651, 9651, "", time.Minute))
+			require.Equal(1, server.numMappings())
+
+			// Renewing the mapping must reuse its nonce.
+			require.NoError(router.MapPort(9651, 9651, "", time.Minute))
+			require.Equal(1, server.numMappings())
+
+			externalIP, err = router.ExternalIP()
+			require.NoError(err)
+			require.Equal(tt.externalIP, externalIP)
+
+			require.NoError(router.UnmapPort(9651, 9651))
+			require.Zero(server.numMappings())
+		})
+	}
+}
+
+func TestPCPRouterErrors(t *testing.T) {
+	require := require.New(t)
+
+	server := newFakePCPServer(t, netip.MustParseAddr("127.0.0.1"), netip.MustParseAddr("203.0.113.1"))
+	router := newPCPRouter(server.addr(), 9651)
+
+	server.lock.Lock()
+	server.portOffset = 1
+	server.lock.Unlock()
+
+	err := router.MapPort(9651, 9651, "", time.Minute)
+	require.ErrorIs(err, errPCPUnexpectedPort)
+	require.Zero(server.numMappings())
+
+	server.lock.Lock()
+	server.result = pcpResultNotAuthorized
+	server.lock.Unlock()
+
+	err = router.MapPort(9651, 9651, "", time.Minute)
+	require.ErrorIs(err, pcpResultError(pcpResultNotAuthorized))
+
+	_, err = router.ExternalIP()
+	require.ErrorIs(err, pcpResultError(pcpResultNotAuthorized))
+
+	err = router.MapPort(9651, 9651, "", -time.Second)
+	require.ErrorIs(err, errInvalidLifetime)
+}
+
+func TestPCPMapperRenewal(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		oldIP = netip.MustParseAddr("203.0.113.1")
+		newIP = netip.MustParseAddr("203.0.113.2")
+	)
+	server := newFakePCPServer(t, netip.MustParseAddr("127.0.0.1"), oldIP)
+	mapper := NewPortMapper(logging.NoLog{}, newPCPRouter(server.addr(), 9651))
+
+	ip := utils.NewAtomic(netip.AddrPortFrom(oldIP, 9651))
+	mapper.Map(9651, 9651, "", ip, 10*time.Millisecond)
+	require.Equal(1, server.numMappings())
+
+	require.Eventually(func() bool {
+		return server.requests(9651) >= 3
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(1, server.numMappings())
+
+	server.setExternalIP(newIP)
+	require.Eventually(func() bool {
+		return ip.Get() == netip.AddrPortFrom(newIP, 9651)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mapper.UnmapAllPorts()
+	require.Zero(server.numMappings())
+}
+
+func TestParseIPv6DefaultGateways(t *testing.T) {
+	require := require.New(t)
+
+	routes := strings.NewReader(`20010db8000000000000000000000000 40 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000001 00000000 00000001     eth0
+00000000000000000000000000000000 00 00000000000000000000000000000000 00 fe800000000000000000000000000001 00000400 00000002 00000000 00000003     eth0
+00000000000000000000000000000000 00 00000000000000000000000000000000 00 20010db8000000000000000000000002 00000400 00000001 00000000 00000003     eth1
+00000000000000000000000000000000 00 00000000000000000000000000000000 00 00000000000000000000000000000000 ffffffff 00000001 00000000 00200200       lo
+`)
+	gateways, err := parseIPv6DefaultGateways(routes)
+	require.NoError(err)
+	require.Equal(
+		[]netip.Addr{
+			netip.MustParseAddr("fe80::1%eth0"),
+			netip.MustParseAddr("2001:db8::2"),
+		},
+		gateways,
+	)
+}
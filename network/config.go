@@ -11,6 +11,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/network/dialer"
+	"github.com/MetalBlockchain/metalgo/network/peer"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/uptime"
@@ -18,6 +19,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
+	"github.com/MetalBlockchain/metalgo/utils/ips"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 )
 
@@ -68,6 +70,21 @@ type PeerListGossipConfig struct {
 	PeerListBloomResetFreq time.Duration `json:"peerListBloomResetFreq"`
 }
 
+type PeerRotationConfig struct {
+	// PeerRotationFrequency is how frequently this node disconnects from its
+	// worst scoring non-validator peers and replaces them with peers from the
+	// IP tracker. If 0, peers are never rotated.
+	PeerRotationFrequency time.Duration `json:"peerRotationFrequency"`
+
+	// PeerRotationPortion is the portion of the connected non-validator peers
+	// that are disconnected from in every rotation. Should be in [0,1].
+	PeerRotationPortion float64 `json:"peerRotationPortion"`
+
+	// PeerScoreHalflife is the halflife of the averagers used to calculate
+	// the request and send failure rates of peers. Should be > 0.
+	PeerScoreHalflife time.Duration `json:"peerScoreHalflife"`
+}
+
 type TimeoutConfig struct {
 	// PingPongTimeout is the maximum amount of time to wait for a Pong response
 	// from a peer we sent a Ping to.
@@ -99,10 +116,13 @@ type ThrottlerConfig struct {
 type Config struct {
 	HealthConfig         `json:"healthConfig"`
 	PeerListGossipConfig `json:"peerListGossipConfig"`
+	PeerRotationConfig   `json:"peerRotationConfig"`
 	TimeoutConfig        `json:"timeoutConfigs"`
 	DelayConfig          `json:"delayConfig"`
 	ThrottlerConfig      ThrottlerConfig `json:"throttlerConfig"`
 
+	MessageQueueConfig peer.MessageQueueConfig `json:"messageQueueConfig"`
+
 	ProxyEnabled           bool          `json:"proxyEnabled"`
 	ProxyReadHeaderTimeout time.Duration `json:"proxyReadHeaderTimeout"`
 
@@ -118,6 +138,31 @@ type Config struct {
 	PingFrequency      time.Duration                 `json:"pingFrequency"`
 	AllowPrivateIPs    bool                          `json:"allowPrivateIPs"`
 
+	// MyAdditionalIPPorts are IPs, other than MyIPPort, that this node can be
+	// reached on. They are signed and advertised along with MyIPPort.
+	MyAdditionalIPPorts []netip.AddrPort `json:"myAdditionalIPs"`
+	// DialIPPreference determines which IP of a peer is dialed first when the
+	// peer can be reached on multiple IPs.
+	DialIPPreference ips.Preference `json:"dialIPPreference"`
+
+	// QUICEnabled causes this node to accept, and dial, QUIC connections in
+	// addition to TCP connections. QUIC support is experimental.
+	QUICEnabled bool `json:"quicEnabled"`
+	// MyQUICPort is the UDP port that this node accepts QUIC connections on.
+	// It is advertised to peers so that they can dial this node over QUIC. If
+	// 0, this node only accepts TCP connections.
+	MyQUICPort uint16 `json:"myQUICPort"`
+	// QUICDialer dials peers over QUIC. Peers that advertised a QUIC port are
+	// dialed with QUICDialer before falling back to TCP. If nil, peers are
+	// only dialed over TCP.
+	QUICDialer dialer.Dialer `json:"-"`
+
+	// PeerScorer scores the quality of the connections to peers. It is
+	// notified of the outcome of requests outside of the network, so it is
+	// provided by the caller. If nil, requests don't affect the scores of
+	// peers.
+	PeerScorer *PeerScorer `json:"-"`
+
 	SupportedACPs set.Set[uint32] `json:"supportedACPs"`
 	ObjectedACPs  set.Set[uint32] `json:"objectedACPs"`
 
@@ -125,6 +170,15 @@ type Config struct {
 	// Assumes all peers support this compression type.
 	CompressionType compression.Type `json:"compressionType"`
 
+	// The zstd dictionaries that messages can be compressed with, in order of
+	// preference. Peers that don't support the preferred dictionary are sent
+	// messages compressed without a dictionary.
+	CompressionZstdDicts []*compression.ZstdDict `json:"-"`
+
+	// The directory that a sample of outbound messages is recorded to, to
+	// train zstd dictionaries on. If empty, messages aren't recorded.
+	CompressionZstdSamplesDir string `json:"compressionZstdSamplesDir"`
+
 	// TLSKey is this node's TLS key that is used to sign IPs.
 	TLSKey crypto.Signer `json:"-"`
 	// BLSKey is this node's BLS key that is used to sign IPs.
@@ -167,7 +221,13 @@ type Config struct {
 	// (there is one buffer per peer)
 	PeerWriteBufferSize int `json:"peerWriteBufferSize"`
 
-	// Tracks the CPU/disk usage caused by processing messages of each peer.
+	// PeerStatsMetricsEnabled reports message and byte counters for each
+	// connected peer. This produces high-cardinality metrics and should only
+	// be enabled when investigating the traffic of individual peers.
+	PeerStatsMetricsEnabled bool `json:"peerStatsMetricsEnabled"`
+
+	// Tracks the CPU/disk/memory usage caused by processing messages of each
+	// peer.
 	ResourceTracker tracker.ResourceTracker `json:"-"`
 
 	// Specifies how much CPU usage each peer can cause before
@@ -177,4 +237,8 @@ type Config struct {
 	// Specifies how much disk usage each peer can cause before
 	// we rate-limit them.
 	DiskTargeter tracker.Targeter `json:"-"`
+
+	// Specifies how much memory usage each peer can cause before
+	// we rate-limit them.
+	MemoryTargeter tracker.Targeter `json:"-"`
 }
@@ -5,18 +5,33 @@ package dialer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/netip"
+	"net/url"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
+	"golang.org/x/net/proxy"
 
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 )
 
-var _ Dialer = (*dialer)(nil)
+const (
+	socks5Scheme  = "socks5"
+	socks5Version = 0x05
+)
+
+var (
+	_ Dialer = (*dialer)(nil)
+
+	errUnsupportedProxyScheme = errors.New("unsupported proxy scheme")
+	errMissingProxyHost       = errors.New("missing proxy host")
+	errTargetRejected         = errors.New("proxy failed to connect to target")
+)
 
 // Dialer attempts to create a connection with the provided IP/port pair
 type Dialer interface {
@@ -30,11 +45,60 @@ type dialer struct {
 	log       logging.Logger
 	network   string
 	throttler throttling.DialThrottler
+
+	timeout time.Duration
+	proxies []proxyDialer
+	// currentProxy is the index of the proxy that is tried first. It is
+	// updated to the last proxy that successfully dialed.
+	currentProxy atomic.Int64
+}
+
+type proxyDialer struct {
+	address string
+	auth    *proxy.Auth
 }
 
 type Config struct {
 	ThrottleRps       uint32        `json:"throttleRps"`
 	ConnectionTimeout time.Duration `json:"connectionTimeout"`
+	// Proxies are the SOCKS5 proxies to dial through. If a proxy can't be
+	// connected to or authenticated with, the next one is tried. If empty,
+	// peers are dialed directly.
+	Proxies []ProxyConfig `json:"proxies"`
+}
+
+type ProxyConfig struct {
+	Address  string `json:"address"`
+	Username string `json:"username"`
+	Password string `json:"-"`
+}
+
+// ParseProxyURL parses a proxy of the form socks5://[user:password@]host:port.
+func ParseProxyURL(rawURL string) (ProxyConfig, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// Drop the URL from the error so that credentials aren't leaked
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return ProxyConfig{}, err
+	}
+	if u.Scheme != socks5Scheme {
+		return ProxyConfig{}, fmt.Errorf("%w: %q", errUnsupportedProxyScheme, u.Scheme)
+	}
+	if u.Hostname() == "" || u.Port() == "" {
+		return ProxyConfig{}, fmt.Errorf("%w: %q", errMissingProxyHost, u.Redacted())
+	}
+
+	config := ProxyConfig{
+		Address: u.Host,
+	}
+	if u.User != nil {
+		config.Username = u.User.Username()
+		config.Password, _ = u.User.Password()
+	}
+	return config, nil
 }
 
 // NewDialer returns a new Dialer that calls net.Dial with the provided network.
@@ -42,6 +106,9 @@ type Config struct {
 // [dialerConfig.connectionTimeout] gives the timeout when dialing an IP.
 // [dialerConfig.throttleRps] gives the max number of outgoing connection attempts/second.
 // If [dialerConfig.throttleRps] == 0, outgoing connections aren't rate-limited.
+// If [dialerConfig.proxies] is non-empty, connections are made through the
+// proxies, which are tried in order until one succeeds. [dialerConfig.connectionTimeout]
+// applies to each attempt.
 func NewDialer(network string, dialerConfig Config, log logging.Logger) Dialer {
 	var throttler throttling.DialThrottler
 	if dialerConfig.ThrottleRps <= 0 {
@@ -53,13 +120,28 @@ func NewDialer(network string, dialerConfig Config, log logging.Logger) Dialer {
 		"creating dialer",
 		zap.Uint32("throttleRPS", dialerConfig.ThrottleRps),
 		zap.Duration("dialTimeout", dialerConfig.ConnectionTimeout),
+		zap.Int("numProxies", len(dialerConfig.Proxies)),
 	)
-	return &dialer{
+	d := &dialer{
 		dialer:    net.Dialer{Timeout: dialerConfig.ConnectionTimeout},
 		log:       log,
 		network:   network,
 		throttler: throttler,
+		timeout:   dialerConfig.ConnectionTimeout,
+		proxies:   make([]proxyDialer, len(dialerConfig.Proxies)),
+	}
+	for i, proxyConfig := range dialerConfig.Proxies {
+		d.proxies[i] = proxyDialer{
+			address: proxyConfig.Address,
+		}
+		if proxyConfig.Username != "" || proxyConfig.Password != "" {
+			d.proxies[i].auth = &proxy.Auth{
+				User:     proxyConfig.Username,
+				Password: proxyConfig.Password,
+			}
+		}
 	}
+	return d
 }
 
 func (d *dialer) Dial(ctx context.Context, ip netip.AddrPort) (net.Conn, error) {
@@ -69,9 +151,127 @@ func (d *dialer) Dial(ctx context.Context, ip netip.AddrPort) (net.Conn, error)
 	d.log.Verbo("dialing",
 		zap.Stringer("ip", ip),
 	)
+	if len(d.proxies) != 0 {
+		return d.dialProxies(ctx, ip)
+	}
+
 	conn, err := d.dialer.DialContext(ctx, d.network, ip.String())
 	if err != nil {
 		return nil, fmt.Errorf("error while dialing %s: %w", ip, err)
 	}
 	return conn, nil
 }
+
+// dialProxies dials [ip] through the first proxy that succeeds, starting with
+// the proxy that last succeeded. Only failures to connect to or authenticate
+// with a proxy fail over to the next proxy. If a proxy reports that it couldn't
+// connect to [ip], the error is returned immediately.
+func (d *dialer) dialProxies(ctx context.Context, ip netip.AddrPort) (net.Conn, error) {
+	var (
+		numProxies = int64(len(d.proxies))
+		start      = d.currentProxy.Load()
+		errs       = make([]error, 0, numProxies)
+	)
+	for i := int64(0); i < numProxies; i++ {
+		index := (start + i) % numProxies
+		p := d.proxies[index]
+
+		conn, err := d.dialProxy(ctx, p, ip)
+		if err == nil {
+			d.currentProxy.Store(index)
+			return conn, nil
+		}
+
+		d.log.Debug("failed to dial through proxy",
+			zap.Stringer("ip", ip),
+			zap.String("proxy", p.address),
+			zap.Error(err),
+		)
+		errs = append(errs, fmt.Errorf("proxy %s: %w", p.address, err))
+
+		// Don't fail over if the caller gave up or if the target couldn't be
+		// reached, as other proxies are unlikely to reach it either.
+		if ctx.Err() != nil || errors.Is(err, errTargetRejected) {
+			break
+		}
+	}
+	return nil, fmt.Errorf("error while dialing %s: %w", ip, errors.Join(errs...))
+}
+
+func (d *dialer) dialProxy(ctx context.Context, p proxyDialer, ip netip.AddrPort) (net.Conn, error) {
+	// The proxy dialer only applies the timeout to the connection to the proxy,
+	// so it's applied to the entire attempt here.
+	if d.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.timeout)
+		defer cancel()
+	}
+
+	forward := &handshakeDialer{dialer: &d.dialer}
+	// SOCKS5 never returns an error and the returned dialer always supports
+	// contexts.
+	proxyDialerIntf, _ := proxy.SOCKS5(d.network, p.address, p.auth, forward)
+	conn, err := proxyDialerIntf.(proxy.ContextDialer).DialContext(ctx, d.network, ip.String())
+	if err != nil && forward.conn != nil && forward.conn.reply != 0 {
+		return nil, fmt.Errorf("%w: %w", errTargetRejected, err)
+	}
+	return conn, err
+}
+
+// handshakeDialer dials the proxy and records the SOCKS5 handshake on the
+// resulting connection.
+type handshakeDialer struct {
+	dialer *net.Dialer
+	conn   *handshakeConn
+}
+
+func (h *handshakeDialer) Dial(network, address string) (net.Conn, error) {
+	return h.DialContext(context.Background(), network, address)
+}
+
+func (h *handshakeDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	conn, err := h.dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	h.conn = &handshakeConn{Conn: conn}
+	return h.conn, nil
+}
+
+// handshakeConn records the reply code of the proxy to the CONNECT request, so
+// that failures to reach the target can be told apart from failures of the
+// proxy itself.
+type handshakeConn struct {
+	net.Conn
+
+	numWrites int
+	// requestSent is true once the CONNECT request has been written. The
+	// request is the only message after the method negotiation that starts
+	// with the SOCKS version.
+	requestSent bool
+	// replyRead is the number of bytes of the reply read so far.
+	replyRead int
+	// reply is the second byte of the reply, which is 0 on success.
+	reply byte
+}
+
+func (c *handshakeConn) Write(b []byte) (int, error) {
+	if c.numWrites > 0 && len(b) > 0 && b[0] == socks5Version {
+		c.requestSent = true
+	}
+	c.numWrites++
+	return c.Conn.Write(b)
+}
+
+func (c *handshakeConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if c.requestSent {
+		for i := 0; i < n && c.replyRead < 2; i++ {
+			if c.replyRead == 1 {
+				c.reply = b[i]
+			}
+			c.replyRead++
+		}
+	}
+	return n, err
+}
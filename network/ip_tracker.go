@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/peer"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/bloom"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
@@ -34,8 +35,11 @@ const (
 	untrackedTimestamp = -2
 	olderTimestamp     = -1
 	sameTimestamp      = 0
-	newerTimestamp     = 1
-	newTimestamp       = 2
+	// newAddress is used when the IP has the same timestamp as the previously
+	// known IP, but is an additional address of the node.
+	newAddress     = 1
+	newerTimestamp = 2
+	newTimestamp   = 3
 )
 
 var _ validators.ManagerCallbackListener = (*ipTracker)(nil)
@@ -95,6 +99,9 @@ type trackedNode struct {
 	trackedSubnets set.Set[ids.ID]
 	// ip is the most recently known IP of this node.
 	ip *ips.ClaimedIPPort
+	// additionalIPs are the other IPs of this node that were claimed at the
+	// same timestamp as [ip].
+	additionalIPs []*ips.ClaimedIPPort
 }
 
 func (n *trackedNode) wantsConnection() bool {
@@ -105,6 +112,29 @@ func (n *trackedNode) canDelete() bool {
 	return !n.manuallyTracked && n.validatedSubnets.Len() == 0
 }
 
+// isNewAddress returns true if [ip] was claimed at the same timestamp as the
+// most recently known IP of this node, but is for an address that isn't known
+// yet.
+func (n *trackedNode) isNewAddress(ip *ips.ClaimedIPPort) bool {
+	if n.ip == nil ||
+		n.ip.Timestamp != ip.Timestamp ||
+		n.ip.AddrPort == ip.AddrPort ||
+		len(n.additionalIPs) >= peer.MaxAdditionalIPs {
+		return false
+	}
+	for _, additionalIP := range n.additionalIPs {
+		if additionalIP.AddrPort == ip.AddrPort {
+			return false
+		}
+	}
+	return true
+}
+
+// ips returns all of the most recently known IPs of this node.
+func (n *trackedNode) ips() []*ips.ClaimedIPPort {
+	return append([]*ips.ClaimedIPPort{n.ip}, n.additionalIPs...)
+}
+
 type connectedNode struct {
 	// trackedSubnets contains all the subnets that this node is syncing,
 	// including the primary network.
@@ -112,6 +142,8 @@ type connectedNode struct {
 	// ip this node claimed when connecting. The IP is not necessarily the same
 	// IP as in the tracked map.
 	ip *ips.ClaimedIPPort
+	// additionalIPs this node claimed when connecting.
+	additionalIPs []*ips.ClaimedIPPort
 }
 
 type gossipableSubnet struct {
@@ -169,11 +201,14 @@ func (s *gossipableSubnet) removeGossipableIP(nodeID ids.NodeID) {
 // [maxNumIPs] applies to the total number of IPs returned, including the IPs
 // initially provided in [ips].
 // [ips] and [nodeIDs] are extended and returned with the additional IPs added.
+// Each sampled IP is followed by the IPs returned by [additionalIPs] for it, for
+// as long as they fit in [maxNumIPs].
 func (s *gossipableSubnet) getGossipableIPs(
 	exceptNodeID ids.NodeID,
 	exceptIPs *bloom.ReadFilter,
 	salt []byte,
 	maxNumIPs int,
+	additionalIPs func(*ips.ClaimedIPPort) []*ips.ClaimedIPPort,
 	ips []*ips.ClaimedIPPort,
 	nodeIDs set.Set[ids.NodeID],
 ) ([]*ips.ClaimedIPPort, set.Set[ids.NodeID]) {
@@ -195,6 +230,10 @@ func (s *gossipableSubnet) getGossipableIPs(
 
 		ips = append(ips, ip)
 		nodeIDs.Add(ip.NodeID)
+
+		additionalIPs := additionalIPs(ip)
+		numAdditionalIPs := min(len(additionalIPs), maxNumIPs-len(ips))
+		ips = append(ips, additionalIPs[:numAdditionalIPs]...)
 	}
 	return ips, nodeIDs
 }
@@ -278,7 +317,8 @@ func (i *ipTracker) WantsConnection(nodeID ids.NodeID) bool {
 // ShouldVerifyIP is used as an optimization to avoid unnecessary IP
 // verification. It returns true if all of the following conditions are met:
 //  1. The provided IP is from a node whose connection is desired.
-//  2. This IP is newer than the most recent IP we know of for the node.
+//  2. This IP is newer than the most recent IP we know of for the node, or is
+//     an additional address claimed at the same time as the most recent IP.
 func (i *ipTracker) ShouldVerifyIP(
 	ip *ips.ClaimedIPPort,
 	trackAllSubnets bool,
@@ -296,7 +336,8 @@ func (i *ipTracker) ShouldVerifyIP(
 	}
 
 	return node.ip == nil || // This would be the first IP
-		node.ip.Timestamp < ip.Timestamp // This would be a newer IP
+		node.ip.Timestamp < ip.Timestamp || // This would be a newer IP
+		node.isNewAddress(ip) // This would be an additional IP
 }
 
 // AddIP attempts to update the node's IP to the provided IP. This function
@@ -304,7 +345,8 @@ func (i *ipTracker) ShouldVerifyIP(
 // following conditions are met:
 //  1. The provided IP is from a node whose connection is desired on a tracked
 //     subnet.
-//  2. This IP is newer than the most recent IP we know of for the node.
+//  2. This IP is newer than the most recent IP we know of for the node, or is
+//     an additional address claimed at the same time as the most recent IP.
 //
 // If this IP is replacing a gossipable IP, this IP will also be marked as
 // gossipable.
@@ -323,12 +365,13 @@ func (i *ipTracker) AddIP(ip *ips.ClaimedIPPort) bool {
 	return trackedNode.wantsConnection()
 }
 
-// GetIP returns the most recent IP of the provided nodeID. Returns true if all
-// of the following conditions are met:
+// GetIPs returns the most recent IPs of the provided nodeID. The first IP is
+// the node's primary IP. Returns true if all of the following conditions are
+// met:
 //  1. There is currently an IP for the provided nodeID.
 //  2. The provided IP is from a node whose connection is desired on a tracked
 //     subnet.
-func (i *ipTracker) GetIP(nodeID ids.NodeID) (*ips.ClaimedIPPort, bool) {
+func (i *ipTracker) GetIPs(nodeID ids.NodeID) ([]*ips.ClaimedIPPort, bool) {
 	i.lock.RLock()
 	defer i.lock.RUnlock()
 
@@ -336,24 +379,77 @@ func (i *ipTracker) GetIP(nodeID ids.NodeID) (*ips.ClaimedIPPort, bool) {
 	if !ok || node.ip == nil {
 		return nil, false
 	}
-	return node.ip, node.wantsConnection()
+	return node.ips(), node.wantsConnection()
+}
+
+// GetDialCandidates returns the most recent IPs of up to [maxNumNodes]
+// randomly sampled nodes, other than [exceptNodeID], that aren't connected.
+// The first IP of each node is the node's primary IP.
+func (i *ipTracker) GetDialCandidates(exceptNodeID ids.NodeID, maxNumNodes int) [][]*ips.ClaimedIPPort {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	var nodes []*trackedNode
+	for nodeID, node := range i.tracked {
+		if _, connected := i.connected[nodeID]; connected || nodeID == exceptNodeID || node.ip == nil {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+
+	uniform := sampler.NewUniform()
+	uniform.Initialize(uint64(len(nodes)))
+
+	candidates := make([][]*ips.ClaimedIPPort, 0, min(len(nodes), maxNumNodes))
+	for len(candidates) < maxNumNodes {
+		index, hasNext := uniform.Next()
+		if !hasNext {
+			break
+		}
+		candidates = append(candidates, nodes[index].ips())
+	}
+	return candidates
 }
 
 // Connected is called when a connection is established. The peer should have
-// provided [ip] during the handshake.
-func (i *ipTracker) Connected(ip *ips.ClaimedIPPort, trackedSubnets set.Set[ids.ID]) {
+// provided [ip] and [additionalIPs] during the handshake.
+func (i *ipTracker) Connected(
+	ip *ips.ClaimedIPPort,
+	additionalIPs []*ips.ClaimedIPPort,
+	trackedSubnets set.Set[ids.ID],
+) {
 	i.lock.Lock()
 	defer i.lock.Unlock()
 
+	// Additional IPs are only valid if they were claimed alongside [ip].
+	additionalIPs = claimedAlongside(ip, additionalIPs)
 	i.connected[ip.NodeID] = &connectedNode{
 		trackedSubnets: trackedSubnets,
 		ip:             ip,
+		additionalIPs:  additionalIPs,
 	}
 
 	timestampComparison, trackedNode := i.addIP(ip)
-	if timestampComparison != untrackedTimestamp {
-		i.setGossipableIP(trackedNode.ip, trackedSubnets)
+	if timestampComparison == untrackedTimestamp {
+		return
+	}
+
+	for _, additionalIP := range additionalIPs {
+		i.addIP(additionalIP)
+	}
+	i.setGossipableIP(trackedNode.ip, trackedSubnets)
+}
+
+// claimedAlongside returns the IPs in [additionalIPs] that were claimed by the
+// same node at the same timestamp as [ip].
+func claimedAlongside(ip *ips.ClaimedIPPort, additionalIPs []*ips.ClaimedIPPort) []*ips.ClaimedIPPort {
+	var claimed []*ips.ClaimedIPPort
+	for _, additionalIP := range additionalIPs {
+		if additionalIP.NodeID == ip.NodeID && additionalIP.Timestamp == ip.Timestamp {
+			claimed = append(claimed, additionalIP)
+		}
 	}
+	return claimed
 }
 
 func (i *ipTracker) addIP(ip *ips.ClaimedIPPort) (int, *trackedNode) {
@@ -373,6 +469,11 @@ func (i *ipTracker) addIP(ip *ips.ClaimedIPPort) (int, *trackedNode) {
 		return olderTimestamp, node // This IP is older than the previously known IP.
 	}
 	if node.ip.Timestamp == ip.Timestamp {
+		if node.isNewAddress(ip) {
+			// This IP is an additional address of the previously known IP.
+			node.additionalIPs = append(node.additionalIPs, ip)
+			return newAddress, node
+		}
 		return sameTimestamp, node // This IP is equal to the previously known IP.
 	}
 
@@ -445,6 +546,9 @@ func (i *ipTracker) addTrackableID(nodeID ids.NodeID, subnetID *ids.ID) {
 	// Because we previously weren't tracking this nodeID, the IP from the
 	// connection is guaranteed to be the most up-to-date IP that we know.
 	i.updateMostRecentTrackedIP(nodeTracker, node.ip)
+	for _, additionalIP := range node.additionalIPs {
+		i.addIP(additionalIP)
+	}
 }
 
 func (i *ipTracker) addGossipableID(nodeID ids.NodeID, subnetID ids.ID, manuallyGossiped bool) {
@@ -523,6 +627,7 @@ func (i *ipTracker) OnValidatorRemoved(subnetID ids.ID, nodeID ids.NodeID, _ uin
 
 func (i *ipTracker) updateMostRecentTrackedIP(node *trackedNode, ip *ips.ClaimedIPPort) {
 	node.ip = ip
+	node.additionalIPs = nil
 
 	oldCount := i.bloomAdditions[ip.NodeID]
 	if oldCount >= maxIPEntriesPerNode {
@@ -639,6 +744,7 @@ func getGossipableIPs[T any](
 			exceptIPs,
 			salt,
 			maxNumIPs,
+			i.additionalIPs,
 			ips,
 			nodeIDs,
 		)
@@ -648,3 +754,16 @@ func getGossipableIPs[T any](
 	}
 	return ips
 }
+
+// additionalIPs returns the additional IPs of the node that claimed [ip], if
+// [ip] is the most recently known IP of the node. The additional IPs are
+// gossiped alongside [ip].
+//
+// Assumes [i.lock] is held.
+func (i *ipTracker) additionalIPs(ip *ips.ClaimedIPPort) []*ips.ClaimedIPPort {
+	node, ok := i.tracked[ip.NodeID]
+	if !ok || node.ip == nil || node.ip.Timestamp != ip.Timestamp {
+		return nil
+	}
+	return node.additionalIPs
+}
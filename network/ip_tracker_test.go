@@ -4,6 +4,7 @@
 package network
 
 import (
+	"net/netip"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/peer"
 	"github.com/MetalBlockchain/metalgo/utils/bloom"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/ips"
@@ -37,6 +39,15 @@ func newerTestIP(ip *ips.ClaimedIPPort) *ips.ClaimedIPPort {
 	)
 }
 
+func additionalTestIP(ip *ips.ClaimedIPPort, port uint16) *ips.ClaimedIPPort {
+	return ips.NewClaimedIPPort(
+		ip.Cert,
+		netip.AddrPortFrom(ip.AddrPort.Addr(), port),
+		ip.Timestamp,
+		ip.Signature,
+	)
+}
+
 func requireEqual(t *testing.T, expected, actual *ipTracker) {
 	require := require.New(t)
 	require.Equal(expected.tracked, actual.tracked)
@@ -80,7 +91,7 @@ func TestIPTracker_ManuallyTrack(t *testing.T) {
 			name: "connected non-validator",
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			expectedChange: func(tracker *ipTracker) {
@@ -118,7 +129,7 @@ func TestIPTracker_ManuallyTrack(t *testing.T) {
 			name: "connected tracked validator",
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
 				return tracker
 			},
@@ -130,7 +141,7 @@ func TestIPTracker_ManuallyTrack(t *testing.T) {
 			name: "connected untracked validator",
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				tracker.OnValidatorAdded(subnetID, ip.NodeID, nil, ids.Empty, 0)
 				return tracker
 			},
@@ -203,7 +214,7 @@ func TestIPTracker_ManuallyGossip(t *testing.T) {
 			name: "connected tracked non-validator",
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			subnetID: constants.PrimaryNetworkID,
@@ -235,7 +246,7 @@ func TestIPTracker_ManuallyGossip(t *testing.T) {
 			name: "connected untracked non-validator",
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			subnetID: subnetID,
@@ -284,7 +295,7 @@ func TestIPTracker_ManuallyGossip(t *testing.T) {
 			name: "connected tracked validator",
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
 				return tracker
 			},
@@ -298,7 +309,7 @@ func TestIPTracker_ManuallyGossip(t *testing.T) {
 			name: "connected untracked validator",
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				tracker.OnValidatorAdded(subnetID, ip.NodeID, nil, ids.Empty, 0)
 				return tracker
 			},
@@ -324,6 +335,7 @@ func TestIPTracker_ManuallyGossip(t *testing.T) {
 
 func TestIPTracker_ShouldVerifyIP(t *testing.T) {
 	newerIP := newerTestIP(ip)
+	additionalIP := additionalTestIP(ip, 1)
 	tests := []struct {
 		name                          string
 		tracker                       func(t *testing.T) *ipTracker
@@ -396,6 +408,46 @@ func TestIPTracker_ShouldVerifyIP(t *testing.T) {
 			expectedTrackAllSubnets:       true,
 			expectedTrackRequestedSubnets: true,
 		},
+		{
+			name: "desired connection additional IP",
+			tracker: func(t *testing.T) *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
+				require.True(t, tracker.AddIP(ip))
+				return tracker
+			},
+			ip:                            additionalIP,
+			expectedTrackAllSubnets:       true,
+			expectedTrackRequestedSubnets: true,
+		},
+		{
+			name: "desired connection same additional IP",
+			tracker: func(t *testing.T) *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
+				require.True(t, tracker.AddIP(ip))
+				require.True(t, tracker.AddIP(additionalIP))
+				return tracker
+			},
+			ip:                            additionalIP,
+			expectedTrackAllSubnets:       false,
+			expectedTrackRequestedSubnets: false,
+		},
+		{
+			name: "desired connection too many additional IPs",
+			tracker: func(t *testing.T) *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
+				require.True(t, tracker.AddIP(ip))
+				for i := 0; i < peer.MaxAdditionalIPs; i++ {
+					require.True(t, tracker.AddIP(additionalTestIP(ip, uint16(i+1))))
+				}
+				return tracker
+			},
+			ip:                            additionalTestIP(ip, peer.MaxAdditionalIPs+1),
+			expectedTrackAllSubnets:       false,
+			expectedTrackRequestedSubnets: false,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -411,6 +463,7 @@ func TestIPTracker_ShouldVerifyIP(t *testing.T) {
 func TestIPTracker_AddIP(t *testing.T) {
 	subnetID := ids.GenerateTestID()
 	newerIP := newerTestIP(ip)
+	additionalIP := additionalTestIP(ip, 1)
 	tests := []struct {
 		name                      string
 		initialState              func(t *testing.T) *ipTracker
@@ -536,7 +589,7 @@ func TestIPTracker_AddIP(t *testing.T) {
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
 				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			ip:                        newerIP,
@@ -552,7 +605,7 @@ func TestIPTracker_AddIP(t *testing.T) {
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
 				tracker.OnValidatorAdded(subnetID, ip.NodeID, nil, ids.Empty, 0)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			ip:                        newerIP,
@@ -562,6 +615,50 @@ func TestIPTracker_AddIP(t *testing.T) {
 				tracker.bloomAdditions[newerIP.NodeID] = 2
 			},
 		},
+		{
+			name: "additional IP of tracked node",
+			initialState: func(t *testing.T) *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
+				require.True(t, tracker.AddIP(ip))
+				return tracker
+			},
+			ip:                        additionalIP,
+			expectedUpdatedAndDesired: true,
+			expectedChange: func(tracker *ipTracker) {
+				tracker.tracked[ip.NodeID].additionalIPs = []*ips.ClaimedIPPort{additionalIP}
+			},
+		},
+		{
+			name: "known additional IP of tracked node",
+			initialState: func(t *testing.T) *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
+				require.True(t, tracker.AddIP(ip))
+				require.True(t, tracker.AddIP(additionalIP))
+				return tracker
+			},
+			ip:                        additionalIP,
+			expectedUpdatedAndDesired: false,
+			expectedChange:            func(*ipTracker) {},
+		},
+		{
+			name: "newer IP replaces additional IPs of tracked node",
+			initialState: func(t *testing.T) *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
+				require.True(t, tracker.AddIP(ip))
+				require.True(t, tracker.AddIP(additionalIP))
+				return tracker
+			},
+			ip:                        newerIP,
+			expectedUpdatedAndDesired: true,
+			expectedChange: func(tracker *ipTracker) {
+				tracker.tracked[newerIP.NodeID].ip = newerIP
+				tracker.tracked[newerIP.NodeID].additionalIPs = nil
+				tracker.bloomAdditions[newerIP.NodeID] = 2
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -766,7 +863,7 @@ func TestIPTracker_Connected(t *testing.T) {
 			testState := test.initialState(t)
 			expectedState := test.initialState(t)
 
-			testState.Connected(test.ip, set.Of(constants.PrimaryNetworkID))
+			testState.Connected(test.ip, nil, set.Of(constants.PrimaryNetworkID))
 			test.expectedChange(expectedState)
 
 			requireEqual(t, expectedState, testState)
@@ -786,7 +883,7 @@ func TestIPTracker_Disconnected(t *testing.T) {
 			name: "not gossipable",
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			expectedChange: func(*ipTracker) {},
@@ -796,7 +893,7 @@ func TestIPTracker_Disconnected(t *testing.T) {
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
 				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			expectedChange: func(tracker *ipTracker) {
@@ -813,9 +910,9 @@ func TestIPTracker_Disconnected(t *testing.T) {
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
 				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				tracker.OnValidatorAdded(constants.PrimaryNetworkID, otherIP.NodeID, nil, ids.Empty, 0)
-				tracker.Connected(otherIP, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(otherIP, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			expectedChange: func(tracker *ipTracker) {
@@ -837,7 +934,7 @@ func TestIPTracker_Disconnected(t *testing.T) {
 				tracker := newTestIPTracker(t)
 				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
 				tracker.OnValidatorAdded(subnetID, ip.NodeID, nil, ids.Empty, 0)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID, subnetID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID, subnetID))
 				return tracker
 			},
 			expectedChange: func(tracker *ipTracker) {
@@ -900,7 +997,7 @@ func TestIPTracker_OnValidatorAdded(t *testing.T) {
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
 				tracker.ManuallyTrack(ip.NodeID)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			subnetID: constants.PrimaryNetworkID,
@@ -925,7 +1022,7 @@ func TestIPTracker_OnValidatorAdded(t *testing.T) {
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
 				tracker.ManuallyTrack(ip.NodeID)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				require.True(t, tracker.AddIP(newerIP))
 				return tracker
 			},
@@ -976,7 +1073,7 @@ func TestIPTracker_OnValidatorAdded(t *testing.T) {
 			name: "connected",
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			subnetID: constants.PrimaryNetworkID,
@@ -1004,7 +1101,7 @@ func TestIPTracker_OnValidatorAdded(t *testing.T) {
 			name: "connected to other subnet",
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			subnetID: subnetID,
@@ -1086,7 +1183,7 @@ func TestIPTracker_OnValidatorRemoved(t *testing.T) {
 				tracker := newTestIPTracker(t)
 				tracker.ManuallyTrack(ip.NodeID)
 				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			subnetID: constants.PrimaryNetworkID,
@@ -1107,7 +1204,7 @@ func TestIPTracker_OnValidatorRemoved(t *testing.T) {
 				tracker := newTestIPTracker(t)
 				tracker.ManuallyGossip(constants.PrimaryNetworkID, ip.NodeID)
 				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			subnetID:       constants.PrimaryNetworkID,
@@ -1119,7 +1216,7 @@ func TestIPTracker_OnValidatorRemoved(t *testing.T) {
 				tracker := newTestIPTracker(t)
 				tracker.ManuallyGossip(constants.PrimaryNetworkID, ip.NodeID)
 				tracker.OnValidatorAdded(subnetID, ip.NodeID, nil, ids.Empty, 0)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			subnetID: subnetID,
@@ -1134,9 +1231,9 @@ func TestIPTracker_OnValidatorRemoved(t *testing.T) {
 			initialState: func(t *testing.T) *ipTracker {
 				tracker := newTestIPTracker(t)
 				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
-				tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 				tracker.OnValidatorAdded(constants.PrimaryNetworkID, otherIP.NodeID, nil, ids.Empty, 0)
-				tracker.Connected(otherIP, set.Of(constants.PrimaryNetworkID))
+				tracker.Connected(otherIP, nil, set.Of(constants.PrimaryNetworkID))
 				return tracker
 			},
 			subnetID: constants.PrimaryNetworkID,
@@ -1216,12 +1313,12 @@ func TestIPTracker_BloomResetsDynamically(t *testing.T) {
 	require := require.New(t)
 
 	tracker := newTestIPTracker(t)
-	tracker.Connected(ip, set.Of(constants.PrimaryNetworkID))
+	tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID))
 	tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
 	tracker.OnValidatorRemoved(constants.PrimaryNetworkID, ip.NodeID, 0)
 
 	tracker.maxBloomCount = 1
-	tracker.Connected(otherIP, set.Of(constants.PrimaryNetworkID))
+	tracker.Connected(otherIP, nil, set.Of(constants.PrimaryNetworkID))
 	tracker.OnValidatorAdded(constants.PrimaryNetworkID, otherIP.NodeID, nil, ids.Empty, 0)
 	requireMetricsConsistent(t, tracker)
 
@@ -1254,8 +1351,8 @@ func TestIPTracker_GetGossipableIPs(t *testing.T) {
 	unknownSubnetID := ids.GenerateTestID()
 
 	tracker := newTestIPTracker(t)
-	tracker.Connected(ip, set.Of(constants.PrimaryNetworkID, subnetIDA))
-	tracker.Connected(otherIP, set.Of(constants.PrimaryNetworkID, subnetIDA, subnetIDB))
+	tracker.Connected(ip, nil, set.Of(constants.PrimaryNetworkID, subnetIDA))
+	tracker.Connected(otherIP, nil, set.Of(constants.PrimaryNetworkID, subnetIDA, subnetIDB))
 	tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
 	tracker.OnValidatorAdded(subnetIDA, otherIP.NodeID, nil, ids.Empty, 0)
 	tracker.OnValidatorAdded(subnetIDB, otherIP.NodeID, nil, ids.Empty, 0)
@@ -1378,3 +1475,100 @@ func TestIPTracker_GetGossipableIPs(t *testing.T) {
 		})
 	}
 }
+
+func TestIPTracker_AdditionalIPs(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		additionalIP = additionalTestIP(ip, 1)
+		staleIP      = ips.NewClaimedIPPort(
+			ip.Cert,
+			netip.AddrPortFrom(ip.AddrPort.Addr(), 2),
+			ip.Timestamp-1,
+			ip.Signature,
+		)
+		newerIP = newerTestIP(ip)
+	)
+
+	tracker := newTestIPTracker(t)
+	// Additional IPs that weren't claimed alongside the primary IP are
+	// ignored.
+	tracker.Connected(ip, []*ips.ClaimedIPPort{additionalIP, staleIP}, set.Of(constants.PrimaryNetworkID))
+
+	// The connection's additional IPs are tracked once the node is tracked.
+	tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
+	claimedIPs, wantsConnection := tracker.GetIPs(ip.NodeID)
+	require.True(wantsConnection)
+	require.Equal([]*ips.ClaimedIPPort{ip, additionalIP}, claimedIPs)
+
+	// The additional IPs are gossiped along with the primary IP, if they fit.
+	gossipableIPs := getGossipableIPs(
+		tracker,
+		tracker.subnet,
+		func(ids.ID) bool { return true },
+		ids.EmptyNodeID,
+		bloom.EmptyFilter,
+		nil,
+		1,
+	)
+	require.Equal([]*ips.ClaimedIPPort{ip}, gossipableIPs)
+
+	gossipableIPs = getGossipableIPs(
+		tracker,
+		tracker.subnet,
+		func(ids.ID) bool { return true },
+		ids.EmptyNodeID,
+		bloom.EmptyFilter,
+		nil,
+		2,
+	)
+	require.Equal([]*ips.ClaimedIPPort{ip, additionalIP}, gossipableIPs)
+
+	// A newer IP replaces all of the previously known IPs.
+	require.True(tracker.AddIP(newerIP))
+	claimedIPs, wantsConnection = tracker.GetIPs(ip.NodeID)
+	require.True(wantsConnection)
+	require.Equal([]*ips.ClaimedIPPort{newerIP}, claimedIPs)
+
+	gossipableIPs = getGossipableIPs(
+		tracker,
+		tracker.subnet,
+		func(ids.ID) bool { return true },
+		ids.EmptyNodeID,
+		bloom.EmptyFilter,
+		nil,
+		1,
+	)
+	require.Equal([]*ips.ClaimedIPPort{newerIP}, gossipableIPs)
+	requireMetricsConsistent(t, tracker)
+}
+
+func TestIPTracker_GetDialCandidates(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newTestIPTracker(t)
+	tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
+	tracker.OnValidatorAdded(constants.PrimaryNetworkID, otherIP.NodeID, nil, ids.Empty, 0)
+
+	// Nodes without a known IP aren't candidates.
+	require.Empty(tracker.GetDialCandidates(ids.EmptyNodeID, 2))
+
+	require.True(tracker.AddIP(ip))
+	require.True(tracker.AddIP(otherIP))
+	require.ElementsMatch(
+		[][]*ips.ClaimedIPPort{{ip}, {otherIP}},
+		tracker.GetDialCandidates(ids.EmptyNodeID, 2),
+	)
+	require.Len(tracker.GetDialCandidates(ids.EmptyNodeID, 1), 1)
+
+	// Excluded and connected nodes aren't candidates.
+	require.Equal(
+		[][]*ips.ClaimedIPPort{{otherIP}},
+		tracker.GetDialCandidates(ip.NodeID, 2),
+	)
+	tracker.Connected(otherIP, nil, set.Of(constants.PrimaryNetworkID))
+	require.Equal(
+		[][]*ips.ClaimedIPPort{{ip}},
+		tracker.GetDialCandidates(ids.EmptyNodeID, 2),
+	)
+}
@@ -31,6 +31,8 @@ type metrics struct {
 	inboundConnRateLimited       prometheus.Counter
 	inboundConnAllowed           prometheus.Counter
 	tlsConnRejected              prometheus.Counter
+	quicDialFallbacks            prometheus.Counter
+	peersRotated                 prometheus.Counter
 	numUselessPeerListBytes      prometheus.Counter
 	nodeUptimeWeightedAverage    prometheus.Gauge
 	nodeUptimeRewardingStake     prometheus.Gauge
@@ -93,6 +95,14 @@ func newMetrics(
 			Name: "tls_conn_rejected",
 			Help: "Times this node rejected a connection due to an unsupported TLS certificate",
 		}),
+		quicDialFallbacks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "quic_dial_fallbacks",
+			Help: "Times this node failed to connect to a peer over QUIC and fell back to TCP",
+		}),
+		peersRotated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "peers_rotated",
+			Help: "Times this node disconnected from a non-validator peer due to the peer's low connection quality",
+		}),
 		numUselessPeerListBytes: prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "num_useless_peerlist_bytes",
 			Help: "Amount of useless bytes (i.e. information about nodes we already knew/don't want to connect to) received in PeerList messages",
@@ -130,6 +140,8 @@ func newMetrics(
 		registerer.Register(m.acceptFailed),
 		registerer.Register(m.inboundConnAllowed),
 		registerer.Register(m.tlsConnRejected),
+		registerer.Register(m.quicDialFallbacks),
+		registerer.Register(m.peersRotated),
 		registerer.Register(m.numUselessPeerListBytes),
 		registerer.Register(m.inboundConnRateLimited),
 		registerer.Register(m.nodeUptimeWeightedAverage),
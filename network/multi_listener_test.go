@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiListener(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ipv4Listener = newTestListener(netip.MustParseAddrPort("127.0.0.1:9651"))
+		ipv6Listener = newTestListener(netip.MustParseAddrPort("[::1]:9651"))
+		listener     = NewMultiListener(ipv4Listener, ipv6Listener)
+	)
+	require.Equal(ipv4Listener.Addr(), listener.Addr())
+
+	for _, l := range []*testListener{ipv4Listener, ipv6Listener} {
+		conn, _ := net.Pipe()
+		l.inbound <- conn
+
+		acceptedConn, err := listener.Accept()
+		require.NoError(err)
+		require.Equal(conn, acceptedConn)
+	}
+
+	require.NoError(listener.Close())
+	_, err := listener.Accept()
+	require.ErrorIs(err, net.ErrClosed)
+
+	// Closing the multi listener closes all of the listeners.
+	for _, l := range []*testListener{ipv4Listener, ipv6Listener} {
+		_, err := l.Accept()
+		require.ErrorIs(err, errClosed)
+	}
+}
@@ -4,12 +4,14 @@
 package network
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"math"
 	"net"
 	"net/netip"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -85,6 +87,12 @@ type Network interface {
 	// info about the peers in [nodeIDs] that have finished the handshake.
 	PeerInfo(nodeIDs []ids.NodeID) []peer.Info
 
+	// PeerStats returns the per-op traffic statistics of peers. If [nodeIDs]
+	// is empty, returns the statistics of all peers that have finished the
+	// handshake. Otherwise, returns the statistics of the peers in [nodeIDs]
+	// that have finished the handshake.
+	PeerStats(nodeIDs []ids.NodeID) []peer.Stats
+
 	// NodeUptime returns given node's primary network UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime() (UptimeResult, error)
@@ -141,6 +149,9 @@ type network struct {
 
 	sendFailRateCalculator safemath.Averager
 
+	// peerScorer scores the quality of the connections to connected peers.
+	peerScorer *PeerScorer
+
 	// Tracks which peers know about which peers
 	ipTracker *ipTracker
 	peersLock sync.RWMutex
@@ -214,6 +225,7 @@ func NewNetwork(
 		config.ResourceTracker,
 		config.CPUTargeter,
 		config.DiskTargeter,
+		config.MemoryTargeter,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("initializing inbound message throttler failed with: %w", err)
@@ -229,7 +241,7 @@ func NewNetwork(
 		return nil, fmt.Errorf("initializing outbound message throttler failed with: %w", err)
 	}
 
-	peerMetrics, err := peer.NewMetrics(metricsRegisterer)
+	peerMetrics, err := peer.NewMetrics(metricsRegisterer, config.PeerStatsMetricsEnabled)
 	if err != nil {
 		return nil, fmt.Errorf("initializing peer metrics failed with: %w", err)
 	}
@@ -279,7 +291,13 @@ func NewNetwork(
 		ObjectedACPs:         config.ObjectedACPs.List(),
 		ResourceTracker:      config.ResourceTracker,
 		UptimeCalculator:     config.UptimeCalculator,
-		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey),
+		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.MyAdditionalIPPorts, config.TLSKey, config.BLSKey),
+		MyQUICPort:           config.MyQUICPort,
+	}
+
+	peerScorer := config.PeerScorer
+	if peerScorer == nil {
+		peerScorer = NewPeerScorer(config.PeerScoreHalflife)
 	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
@@ -303,6 +321,7 @@ func NewNetwork(
 			config.SendFailRateHalflife,
 			time.Now(),
 		)),
+		peerScorer: peerScorer,
 
 		trackedIPs:      make(map[ids.NodeID]*trackedIP),
 		ipTracker:       ipTracker,
@@ -452,6 +471,8 @@ func (n *network) Connected(nodeID ids.NodeID) {
 	n.connectedPeers.Add(peer)
 	n.peersLock.Unlock()
 
+	n.peerScorer.Connected(nodeID)
+
 	peerIP := peer.IP()
 	newIP := ips.NewClaimedIPPort(
 		peer.Cert(),
@@ -459,8 +480,18 @@ func (n *network) Connected(nodeID ids.NodeID) {
 		peerIP.Timestamp,
 		peerIP.TLSSignature,
 	)
+	peerAdditionalIPs := peer.AdditionalIPs()
+	newAdditionalIPs := make([]*ips.ClaimedIPPort, len(peerAdditionalIPs))
+	for i, additionalIP := range peerAdditionalIPs {
+		newAdditionalIPs[i] = ips.NewClaimedIPPort(
+			peer.Cert(),
+			additionalIP.AddrPort,
+			additionalIP.Timestamp,
+			additionalIP.TLSSignature,
+		)
+	}
 	trackedSubnets := peer.TrackedSubnets()
-	n.ipTracker.Connected(newIP, trackedSubnets)
+	n.ipTracker.Connected(newIP, newAdditionalIPs, trackedSubnets)
 
 	n.metrics.markConnected(peer)
 
@@ -719,12 +750,14 @@ func (n *network) track(ip *ips.ClaimedIPPort, trackAllSubnets bool) error {
 		return nil
 	}
 
+	claimedIPs, _ := n.ipTracker.GetIPs(ip.NodeID)
+	dialIPs := n.dialIPs(claimedIPs)
 	tracked, isTracked := n.trackedIPs[ip.NodeID]
 	if isTracked {
-		// Stop tracking the old IP and start tracking the new one.
-		tracked = tracked.trackNewIP(ip.AddrPort)
+		// Stop tracking the old IPs and start tracking the new ones.
+		tracked = tracked.trackNewIP(dialIPs...)
 	} else {
-		tracked = newTrackedIP(ip.AddrPort)
+		tracked = newTrackedIP(dialIPs...)
 	}
 	n.trackedIPs[ip.NodeID] = tracked
 	n.dial(ip.NodeID, tracked)
@@ -830,7 +863,7 @@ func (n *network) disconnectedFromConnecting(nodeID ids.NodeID) {
 	tracked, ok := n.trackedIPs[nodeID]
 	if ok {
 		if n.ipTracker.WantsConnection(nodeID) {
-			tracked := tracked.trackNewIP(tracked.ip)
+			tracked := tracked.trackNewIP(tracked.ips...)
 			n.trackedIPs[nodeID] = tracked
 			n.dial(nodeID, tracked)
 		} else {
@@ -845,6 +878,7 @@ func (n *network) disconnectedFromConnecting(nodeID ids.NodeID) {
 func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	n.ipTracker.Disconnected(nodeID)
 	n.router.Disconnected(nodeID)
+	n.peerScorer.Disconnected(nodeID)
 
 	n.peersLock.Lock()
 	defer n.peersLock.Unlock()
@@ -852,8 +886,11 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	n.connectedPeers.Remove(nodeID)
 
 	// The peer that is disconnecting from us finished the handshake
-	if ip, wantsConnection := n.ipTracker.GetIP(nodeID); wantsConnection {
-		tracked := newTrackedIP(ip.AddrPort)
+	if claimedIPs, wantsConnection := n.ipTracker.GetIPs(nodeID); wantsConnection {
+		tracked := newTrackedIP(n.dialIPs(claimedIPs)...)
+		// The peer advertised whether it accepts QUIC connections during the
+		// handshake, so it can be dialed over QUIC when reconnecting.
+		tracked.quicPort = peer.QUICPort()
 		n.trackedIPs[nodeID] = tracked
 		n.dial(nodeID, tracked)
 	}
@@ -861,8 +898,20 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	n.metrics.markDisconnected(peer)
 }
 
+// dialIPs returns the addresses of [claimedIPs] in the order that they should
+// be dialed.
+func (n *network) dialIPs(claimedIPs []*ips.ClaimedIPPort) []netip.AddrPort {
+	addrPorts := make([]netip.AddrPort, len(claimedIPs))
+	for i, claimedIP := range claimedIPs {
+		addrPorts[i] = claimedIP.AddrPort
+	}
+	n.config.DialIPPreference.Sort(addrPorts)
+	return addrPorts
+}
+
 // dial will spin up a new goroutine and attempt to establish a connection with
-// [nodeID] at [ip].
+// [nodeID] at [ip]. Each attempt tries the IPs of [ip] in order until one of
+// them succeeds.
 //
 // If the connection established at [ip] doesn't match [nodeID]:
 // - attempts to reach [nodeID] at [ip] will be halted.
@@ -883,7 +932,7 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 	n.peerConfig.Log.Verbo("attempting to dial node",
 		zap.Stringer("nodeID", nodeID),
-		zap.Stringer("ip", ip.ip),
+		zap.Stringers("ips", ip.ips),
 	)
 	go func() {
 		n.metrics.numTracked.Inc()
@@ -944,55 +993,90 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				n.config.MaxReconnectDelay,
 			)
 
-			// If the network is configured to disallow private IPs and the
-			// provided IP is private, we skip all attempts to initiate a
-			// connection.
-			//
-			// Invariant: We perform this check inside of the looping goroutine
-			// because this goroutine must clean up the trackedIPs entry if
-			// nodeID leaves the validator set. This is why we continue the loop
-			// rather than returning even though we will never initiate an
-			// outbound connection with this IP.
-			if !n.config.AllowPrivateIPs && !ips.IsPublic(ip.ip.Addr()) {
-				n.peerConfig.Log.Verbo("skipping connection dial",
-					zap.String("reason", "outbound connections to private IPs are prohibited"),
-					zap.Stringer("nodeID", nodeID),
-					zap.Stringer("peerIP", ip.ip),
-					zap.Duration("delay", ip.delay),
-				)
-				continue
-			}
-
-			conn, err := n.dialer.Dial(n.onCloseCtx, ip.ip)
-			if err != nil {
-				n.peerConfig.Log.Verbo(
-					"failed to reach peer, attempting again",
-					zap.Stringer("nodeID", nodeID),
-					zap.Stringer("peerIP", ip.ip),
-					zap.Duration("delay", ip.delay),
-				)
-				continue
+			if n.dialAttempt(nodeID, ip) {
+				return
 			}
+		}
+	}()
+}
 
-			n.peerConfig.Log.Verbo("starting to upgrade connection",
-				zap.String("direction", "outbound"),
+// dialAttempt attempts to establish a connection with [nodeID] at each of the
+// IPs of [ip], in order. Returns true if a connection was established and
+// upgraded.
+func (n *network) dialAttempt(nodeID ids.NodeID, ip *trackedIP) bool {
+	for _, addrPort := range ip.ips {
+		// If the network is configured to disallow private IPs and the
+		// provided IP is private, we skip all attempts to initiate a
+		// connection.
+		//
+		// Invariant: We perform this check on every attempt because the
+		// dialing goroutine must clean up the trackedIPs entry if nodeID
+		// leaves the validator set. This is why the dialing goroutine keeps
+		// looping even though we will never initiate an outbound connection
+		// with this IP.
+		if !n.config.AllowPrivateIPs && !ips.IsPublic(addrPort.Addr()) {
+			n.peerConfig.Log.Verbo("skipping connection dial",
+				zap.String("reason", "outbound connections to private IPs are prohibited"),
 				zap.Stringer("nodeID", nodeID),
-				zap.Stringer("peerIP", ip.ip),
+				zap.Stringer("peerIP", addrPort),
+				zap.Duration("delay", ip.getDelay()),
 			)
+			continue
+		}
 
-			err = n.upgrade(conn, n.clientUpgrader)
-			if err != nil {
-				n.peerConfig.Log.Verbo(
-					"failed to upgrade, attempting again",
-					zap.Stringer("nodeID", nodeID),
-					zap.Stringer("peerIP", ip.ip),
-					zap.Duration("delay", ip.delay),
-				)
-				continue
+		// If the peer accepts QUIC connections, attempt to connect over QUIC
+		// before falling back to TCP.
+		if n.config.QUICDialer != nil && ip.quicPort != 0 {
+			quicAddrPort := netip.AddrPortFrom(addrPort.Addr(), ip.quicPort)
+			if n.dialAndUpgrade(nodeID, ip, n.config.QUICDialer, quicAddrPort) {
+				return true
 			}
-			return
+			n.metrics.quicDialFallbacks.Inc()
 		}
-	}()
+
+		if n.dialAndUpgrade(nodeID, ip, n.dialer, addrPort) {
+			return true
+		}
+	}
+	return false
+}
+
+// dialAndUpgrade attempts to establish a connection with [nodeID] at
+// [addrPort] using [connDialer]. Returns true if the connection was established
+// and upgraded.
+func (n *network) dialAndUpgrade(
+	nodeID ids.NodeID,
+	ip *trackedIP,
+	connDialer dialer.Dialer,
+	addrPort netip.AddrPort,
+) bool {
+	conn, err := connDialer.Dial(n.onCloseCtx, addrPort)
+	if err != nil {
+		n.peerConfig.Log.Verbo(
+			"failed to reach peer, attempting again",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("peerIP", addrPort),
+			zap.Duration("delay", ip.getDelay()),
+		)
+		return false
+	}
+
+	n.peerConfig.Log.Verbo("starting to upgrade connection",
+		zap.String("direction", "outbound"),
+		zap.Stringer("nodeID", nodeID),
+		zap.Stringer("peerIP", addrPort),
+	)
+
+	if err := n.upgrade(conn, n.clientUpgrader); err != nil {
+		n.peerConfig.Log.Verbo(
+			"failed to upgrade, attempting again",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("peerIP", addrPort),
+			zap.Duration("delay", ip.getDelay()),
+		)
+		return false
+	}
+	return true
 }
 
 // upgrade the provided connection, which may be an inbound connection or an
@@ -1089,6 +1173,24 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		zap.Stringer("nodeID", nodeID),
 	)
 
+	var messageQueue peer.MessageQueue
+	if n.config.MessageQueueConfig.LanesEnabled {
+		messageQueue = peer.NewPrioritizedMessageQueue(
+			n.config.MessageQueueConfig,
+			n.peerConfig.Metrics,
+			nodeID,
+			n.peerConfig.Log,
+			n.outboundMsgThrottler,
+		)
+	} else {
+		messageQueue = peer.NewThrottledMessageQueue(
+			n.peerConfig.Metrics,
+			nodeID,
+			n.peerConfig.Log,
+			n.outboundMsgThrottler,
+		)
+	}
+
 	// peer.Start requires there is only ever one peer instance running with the
 	// same [peerConfig.InboundMsgThrottler]. This is guaranteed by the above
 	// de-duplications for [connectingPeers] and [connectedPeers].
@@ -1097,12 +1199,7 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		tlsConn,
 		cert,
 		nodeID,
-		peer.NewThrottledMessageQueue(
-			n.peerConfig.Metrics,
-			nodeID,
-			n.peerConfig.Log,
-			n.outboundMsgThrottler,
-		),
+		messageQueue,
 	)
 	n.connectingPeers.Add(peer)
 	n.peersLock.Unlock()
@@ -1119,6 +1216,16 @@ func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
 	return n.connectedPeers.Info(nodeIDs)
 }
 
+func (n *network) PeerStats(nodeIDs []ids.NodeID) []peer.Stats {
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	if len(nodeIDs) == 0 {
+		return n.connectedPeers.AllStats()
+	}
+	return n.connectedPeers.Stats(nodeIDs)
+}
+
 func (n *network) StartClose() {
 	n.closeOnce.Do(func() {
 		n.peerConfig.Log.Info("shutting down the p2p networking")
@@ -1208,6 +1315,14 @@ func (n *network) runTimers() {
 		updateUptimes.Stop()
 	}()
 
+	// If peer rotation is disabled, [rotatePeers] is never ready.
+	var rotatePeers <-chan time.Time
+	if n.config.PeerRotationFrequency > 0 {
+		rotatePeersTicker := time.NewTicker(n.config.PeerRotationFrequency)
+		defer rotatePeersTicker.Stop()
+		rotatePeers = rotatePeersTicker.C
+	}
+
 	for {
 		select {
 		case <-n.onCloseCtx.Done():
@@ -1231,7 +1346,77 @@ func (n *network) runTimers() {
 			}
 			n.metrics.nodeUptimeWeightedAverage.Set(primaryUptime.WeightedAveragePercentage)
 			n.metrics.nodeUptimeRewardingStake.Set(primaryUptime.RewardingStakePercentage)
+		case <-rotatePeers:
+			n.rotatePeers()
+		}
+	}
+}
+
+// rotatePeers disconnects from the worst scoring portion of the connected
+// peers that the node doesn't want a connection to, such as non-validators,
+// and immediately dials the same number of nodes whose IPs are known but that
+// aren't connected.
+func (n *network) rotatePeers() {
+	type scoredPeer struct {
+		peer  peer.Peer
+		score float64
+	}
+
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	var candidates []scoredPeer
+	for i := 0; i < n.connectedPeers.Len(); i++ {
+		p, _ := n.connectedPeers.GetByIndex(i)
+		nodeID := p.ID()
+		if n.ipTracker.WantsConnection(nodeID) {
+			continue
+		}
+		numAttempts, numFailures := p.SendAttempts()
+		n.peerScorer.RegisterSends(nodeID, numAttempts, numFailures)
+		candidates = append(candidates, scoredPeer{
+			peer:  p,
+			score: n.peerScorer.Score(nodeID, p.RTT()),
+		})
+	}
+
+	numToRotate := int(n.config.PeerRotationPortion * float64(len(candidates)))
+	if numToRotate == 0 {
+		return
+	}
+
+	slices.SortFunc(candidates, func(a, b scoredPeer) int {
+		return cmp.Compare(a.score, b.score)
+	})
+	for _, candidate := range candidates[:numToRotate] {
+		n.peerConfig.Log.Debug("disconnecting from peer",
+			zap.String("reason", "low connection quality"),
+			zap.Stringer("nodeID", candidate.peer.ID()),
+			zap.Float64("score", candidate.score),
+		)
+		candidate.peer.StartClose()
+	}
+	n.metrics.peersRotated.Add(float64(numToRotate))
+
+	// Replace the disconnected peers with nodes sampled from the IP tracker.
+	// Nodes that are already being dialed are redialed without their
+	// reconnection delay. Nodes that the node doesn't otherwise want a
+	// connection to are only dialed once.
+	for _, claimedIPs := range n.ipTracker.GetDialCandidates(n.config.MyNodeID, numToRotate) {
+		nodeID := claimedIPs[0].NodeID
+		if _, connecting := n.connectingPeers.GetByID(nodeID); connecting {
+			continue
 		}
+
+		dialIPs := n.dialIPs(claimedIPs)
+		if tracked, isTracked := n.trackedIPs[nodeID]; isTracked {
+			tracked = tracked.retryNow(dialIPs...)
+			n.trackedIPs[nodeID] = tracked
+			n.dial(nodeID, tracked)
+			continue
+		}
+
+		go n.dialAttempt(nodeID, newTrackedIP(dialIPs...))
 	}
 }
 
@@ -6,12 +6,14 @@ package network
 import (
 	"context"
 	"crypto"
+	"net"
 	"net/netip"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/ids"
@@ -54,6 +56,9 @@ var (
 		PeerListPullGossipFreq:  time.Second,
 		PeerListBloomResetFreq:  constants.DefaultNetworkPeerListBloomResetFreq,
 	}
+	defaultPeerRotationConfig = PeerRotationConfig{
+		PeerScoreHalflife: time.Minute,
+	}
 	defaultTimeoutConfig = TimeoutConfig{
 		PingPongTimeout:      30 * time.Second,
 		ReadHandshakeTimeout: 15 * time.Second,
@@ -100,6 +105,7 @@ var (
 	defaultConfig = Config{
 		HealthConfig:         defaultHealthConfig,
 		PeerListGossipConfig: defaultPeerListGossipConfig,
+		PeerRotationConfig:   defaultPeerRotationConfig,
 		TimeoutConfig:        defaultTimeoutConfig,
 		DelayConfig:          defaultDelayConfig,
 		ThrottlerConfig:      defaultThrottlerConfig,
@@ -123,12 +129,23 @@ var (
 		ResourceTracker:              newDefaultResourceTracker(),
 		CPUTargeter:                  nil, // Set in init
 		DiskTargeter:                 nil, // Set in init
+		MemoryTargeter:               nil, // Set in init
 	}
 )
 
 func init() {
 	defaultConfig.CPUTargeter = newDefaultTargeter(defaultConfig.ResourceTracker.CPUTracker())
 	defaultConfig.DiskTargeter = newDefaultTargeter(defaultConfig.ResourceTracker.DiskTracker())
+	defaultConfig.MemoryTargeter = tracker.NewTargeter(
+		logging.NoLog{},
+		&tracker.TargeterConfig{
+			VdrAlloc:           1000 * units.GiB,
+			MaxNonVdrUsage:     1000 * units.GiB,
+			MaxNonVdrNodeUsage: 1000 * units.GiB,
+		},
+		validators.NewManager(),
+		defaultConfig.ResourceTracker.MemoryTracker(),
+	)
 }
 
 func newDefaultTargeter(t tracker.Tracker) tracker.Targeter {
@@ -198,6 +215,8 @@ func newMessageCreator(t *testing.T) message.Creator {
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 		constants.DefaultNetworkCompressionType,
+		nil,
+		"",
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -522,6 +541,210 @@ func TestTrackDoesNotDialPrivateIPs(t *testing.T) {
 	wg.Wait()
 }
 
+func TestTrackDialsAdditionalIPs(t *testing.T) {
+	require := require.New(t)
+
+	dialer, listeners, nodeIDs, configs := newTestNetwork(t, 2)
+
+	// The primary IP of the first node can't be reached, so the node must be
+	// dialed on its additional IP.
+	unreachableIP := netip.AddrPortFrom(
+		netip.AddrFrom4([4]byte{10, 0, 0, 0}),
+		uint16(len(configs)+1),
+	)
+	configs[0].MyAdditionalIPPorts = []netip.AddrPort{configs[0].MyIPPort.Get()}
+	configs[0].MyIPPort.Set(unreachableIP)
+
+	vdrs := validators.NewManager()
+	for _, nodeID := range nodeIDs {
+		require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.GenerateTestID(), 1))
+	}
+
+	onConnected := make(chan struct{})
+	networks := make([]Network, len(configs))
+	for i, config := range configs {
+		config.Beacons = validators.NewManager()
+		config.Validators = vdrs
+
+		var connectedF func(ids.NodeID, *version.Application, ids.ID)
+		if i != 0 {
+			connectedF = func(nodeID ids.NodeID, _ *version.Application, subnetID ids.ID) {
+				if nodeID == nodeIDs[0] && subnetID == constants.PrimaryNetworkID {
+					close(onConnected)
+				}
+			}
+		} else {
+			connectedF = func(ids.NodeID, *version.Application, ids.ID) {}
+		}
+
+		net, err := NewNetwork(
+			config,
+			upgrade.InitiallyActiveTime,
+			newMessageCreator(t),
+			prometheus.NewRegistry(),
+			logging.NoLog{},
+			listeners[i],
+			dialer,
+			&testHandler{
+				InboundHandler: nil,
+				ConnectedF:     connectedF,
+				DisconnectedF:  func(ids.NodeID) {},
+			},
+		)
+		require.NoError(err)
+		networks[i] = net
+	}
+
+	config := configs[0]
+	signer := peer.NewIPSigner(config.MyIPPort, config.MyAdditionalIPPorts, config.TLSKey, config.BLSKey)
+	signedIPs, err := signer.GetSignedIPs()
+	require.NoError(err)
+
+	stakingCert, err := staking.ParseCertificate(config.TLSConfig.Certificates[0].Leaf.Raw)
+	require.NoError(err)
+
+	claimedIPs := make([]*ips.ClaimedIPPort, len(signedIPs))
+	for i, signedIP := range signedIPs {
+		claimedIPs[i] = ips.NewClaimedIPPort(
+			stakingCert,
+			signedIP.AddrPort,
+			signedIP.Timestamp,
+			signedIP.TLSSignature,
+		)
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(networks))
+	for _, net := range networks {
+		go func(net Network) {
+			defer wg.Done()
+
+			require.NoError(net.Dispatch())
+		}(net)
+	}
+
+	require.NoError(networks[1].Track(claimedIPs))
+	<-onConnected
+
+	network := networks[1].(*network)
+	trackedIPs, wantsConnection := network.ipTracker.GetIPs(nodeIDs[0])
+	require.True(wantsConnection)
+	require.Equal(claimedIPs, trackedIPs)
+
+	peerInfo := network.PeerInfo([]ids.NodeID{nodeIDs[0]})
+	require.Len(peerInfo, 1)
+	require.Equal(unreachableIP, peerInfo[0].PublicIP)
+	require.Equal(config.MyAdditionalIPPorts, peerInfo[0].AdditionalPublicIPs)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+// recordingDialer records the IPs that were dialed.
+type recordingDialer struct {
+	dialer.Dialer
+
+	lock   sync.Mutex
+	dialed []netip.AddrPort
+}
+
+func (d *recordingDialer) Dial(ctx context.Context, ip netip.AddrPort) (net.Conn, error) {
+	d.lock.Lock()
+	d.dialed = append(d.dialed, ip)
+	d.lock.Unlock()
+
+	return d.Dialer.Dial(ctx, ip)
+}
+
+func TestDialQUICFallsBackToTCP(t *testing.T) {
+	require := require.New(t)
+
+	dialer, listeners, nodeIDs, configs := newTestNetwork(t, 2)
+
+	// The first node can't be reached over QUIC.
+	const quicPort = 9651
+	configs[0].MyQUICPort = quicPort
+	quicDialer := &recordingDialer{
+		Dialer: newTestDialer(),
+	}
+	configs[1].QUICDialer = quicDialer
+
+	vdrs := validators.NewManager()
+	for _, nodeID := range nodeIDs {
+		require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.GenerateTestID(), 1))
+	}
+
+	onConnected := make(chan struct{})
+	networks := make([]*network, len(configs))
+	for i, config := range configs {
+		config.Beacons = validators.NewManager()
+		config.Validators = vdrs
+
+		connectedF := func(ids.NodeID, *version.Application, ids.ID) {}
+		if i != 0 {
+			connectedF = func(nodeID ids.NodeID, _ *version.Application, subnetID ids.ID) {
+				if nodeID == nodeIDs[0] && subnetID == constants.PrimaryNetworkID {
+					close(onConnected)
+				}
+			}
+		}
+
+		net, err := NewNetwork(
+			config,
+			upgrade.InitiallyActiveTime,
+			newMessageCreator(t),
+			prometheus.NewRegistry(),
+			logging.NoLog{},
+			listeners[i],
+			dialer,
+			&testHandler{
+				InboundHandler: nil,
+				ConnectedF:     connectedF,
+				DisconnectedF:  func(ids.NodeID) {},
+			},
+		)
+		require.NoError(err)
+		networks[i] = net.(*network)
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(networks))
+	for _, net := range networks {
+		go func(net Network) {
+			defer wg.Done()
+
+			require.NoError(net.Dispatch())
+		}(net)
+	}
+
+	tcpIP := configs[0].MyIPPort.Get()
+	tracked := newTrackedIP(tcpIP)
+	tracked.quicPort = quicPort
+	require.True(networks[1].dialAttempt(nodeIDs[0], tracked))
+	<-onConnected
+
+	require.Equal(
+		[]netip.AddrPort{
+			netip.AddrPortFrom(tcpIP.Addr(), quicPort),
+		},
+		quicDialer.dialed,
+	)
+	require.Equal(float64(1), testutil.ToFloat64(networks[1].metrics.quicDialFallbacks))
+
+	// The QUIC port advertised in the handshake is remembered so that the node
+	// is dialed over QUIC when reconnecting.
+	peer, ok := networks[1].connectedPeers.GetByID(nodeIDs[0])
+	require.True(ok)
+	require.Equal(uint16(quicPort), peer.QUICPort())
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
 func TestDialDeletesNonValidators(t *testing.T) {
 	require := require.New(t)
 
@@ -567,7 +790,7 @@ func TestDialDeletesNonValidators(t *testing.T) {
 	}
 
 	config := configs[0]
-	signer := peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey)
+	signer := peer.NewIPSigner(config.MyIPPort, config.MyAdditionalIPPorts, config.TLSKey, config.BLSKey)
 	ip, err := signer.GetSignedIP()
 	require.NoError(err)
 
@@ -637,10 +860,10 @@ func TestDialContext(t *testing.T) {
 		dialedIP, dialedListener           = dialer.NewListener()
 
 		neverDialedTrackedIP = &trackedIP{
-			ip: neverDialedIP,
+			ips: []netip.AddrPort{neverDialedIP},
 		}
 		dialedTrackedIP = &trackedIP{
-			ip: dialedIP,
+			ips: []netip.AddrPort{dialedIP},
 		}
 	)
 
@@ -753,3 +976,32 @@ func TestAllowConnectionAsAValidator(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestRotatePeersDisconnectsWorstScoringNonValidators(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil, nil})
+
+	// Rotating with two non-validator peers disconnects from one of them.
+	net0 := networks[0]
+	net0.config.PeerRotationPortion = .5
+	for _, nodeID := range nodeIDs[1:] {
+		require.NoError(net0.config.Validators.RemoveWeight(constants.PrimaryNetworkID, nodeID, 1))
+	}
+	net0.peerScorer.RegisterFailure(nodeIDs[2])
+
+	net0.peersLock.RLock()
+	peer1, _ := net0.connectedPeers.GetByID(nodeIDs[1])
+	peer2, _ := net0.connectedPeers.GetByID(nodeIDs[2])
+	net0.peersLock.RUnlock()
+
+	net0.rotatePeers()
+	require.NoError(peer2.AwaitClosed(context.Background()))
+	require.False(peer1.Closed())
+	require.Equal(float64(1), testutil.ToFloat64(net0.metrics.peersRotated))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
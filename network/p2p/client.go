@@ -48,7 +48,7 @@ func (c *Client) AppRequestAny(
 	appRequestBytes []byte,
 	onResponse AppResponseCallback,
 ) error {
-	sampled := c.options.nodeSampler.Sample(ctx, 1)
+	sampled := c.Sample(ctx, 1)
 	if len(sampled) != 1 {
 		return ErrNoPeers
 	}
@@ -57,6 +57,12 @@ func (c *Client) AppRequestAny(
 	return c.AppRequest(ctx, nodeIDs, appRequestBytes, onResponse)
 }
 
+// Sample returns up to [limit] nodes, chosen the same way as the node that
+// AppRequestAny sends its request to.
+func (c *Client) Sample(ctx context.Context, limit int) []ids.NodeID {
+	return c.options.nodeSampler.Sample(ctx, limit)
+}
+
 // AppRequest issues an arbitrary request to a node.
 // [onResponse] is invoked upon an error or a response.
 func (c *Client) AppRequest(
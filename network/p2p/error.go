@@ -30,4 +30,28 @@ var (
 		Code:    -4,
 		Message: "throttled",
 	}
+	// ErrSketchOverflow should be used to indicate that a sketch could not be
+	// reconciled because it differed too much from the handler's set
+	ErrSketchOverflow = &common.AppError{
+		Code:    -5,
+		Message: "sketch overflow",
+	}
+	// ErrUnknownStream should be used to indicate that a stream request failed
+	// due to the stream not being open
+	ErrUnknownStream = &common.AppError{
+		Code:    -6,
+		Message: "unknown stream",
+	}
+	// ErrTooManyStreams should be used to indicate that a stream could not be
+	// opened due to the requesting peer having too many open streams
+	ErrTooManyStreams = &common.AppError{
+		Code:    -7,
+		Message: "too many streams",
+	}
+	// ErrUnknownMethod should be used to indicate that a request failed due to
+	// it not matching a registered method
+	ErrUnknownMethod = &common.AppError{
+		Code:    -8,
+		Message: "unknown method",
+	}
 )
@@ -5,6 +5,7 @@ package gossip
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"sync"
@@ -21,6 +22,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/buffer"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 )
 
 const (
@@ -35,11 +37,19 @@ const (
 	sentType   = "sent"
 
 	defaultGossipableCount = 64
+
+	// maxBloomOnlyPeers is the maximum number of peers that are remembered to
+	// not support sketches.
+	maxBloomOnlyPeers = 1024
+	// bloomOnlyExpiry is how long a peer that doesn't support sketches is sent
+	// bloom filters before a sketch is tried again, in case it was upgraded.
+	bloomOnlyExpiry = time.Hour
 )
 
 var (
 	_ Gossiper = (*ValidatorGossiper)(nil)
 	_ Gossiper = (*PullGossiper[*testTx])(nil)
+	_ Gossiper = (*SketchPullGossiper[*testTx])(nil)
 	_ Gossiper = (*NoOpGossiper)(nil)
 
 	_ Set[*testTx] = (*FullSet[*testTx])(nil)
@@ -244,6 +254,11 @@ func (p *PullGossiper[_]) handleResponse(
 		return
 	}
 
+	p.handleGossip(nodeID, gossip)
+}
+
+// handleGossip adds the [gossip] received from [nodeID] to the set.
+func (p *PullGossiper[_]) handleGossip(nodeID ids.NodeID, gossip [][]byte) {
 	receivedBytes := 0
 	for _, bytes := range gossip {
 		receivedBytes += len(bytes)
@@ -282,6 +297,170 @@ func (p *PullGossiper[_]) handleResponse(
 	}
 }
 
+// NewSketchPullGossiper returns a gossiper that requests gossip by sending a
+// sketch of [sketchCells] cells rather than a bloom filter.
+//
+// The sketch mode is negotiated with each peer: if a peer responds without
+// reconciling the sketch, its handler doesn't support sketches and requests to
+// that peer fall back to bloom filters for [bloomOnlyExpiry]. If a sketch can't
+// be reconciled because the sets differ too much, the request is retried with
+// a bloom filter.
+func NewSketchPullGossiper[T Gossipable](
+	log logging.Logger,
+	marshaller Marshaller[T],
+	set Set[T],
+	client *p2p.Client,
+	metrics Metrics,
+	pollSize int,
+	sketchCells int,
+) (*SketchPullGossiper[T], error) {
+	if sketchCells <= 0 || sketchCells%sketchHashes != 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidNumCells, sketchCells)
+	}
+
+	return &SketchPullGossiper[T]{
+		PullGossiper: NewPullGossiper(log, marshaller, set, client, metrics, pollSize),
+		sketchCells:  sketchCells,
+		bloomOnly:    &cache.LRU[ids.NodeID, time.Time]{Size: maxBloomOnlyPeers},
+	}, nil
+}
+
+type SketchPullGossiper[T Gossipable] struct {
+	*PullGossiper[T]
+
+	sketchCells int
+
+	clock mockable.Clock
+	// bloomOnly maps the peers whose handlers don't support sketches to when
+	// that was found.
+	bloomOnly *cache.LRU[ids.NodeID, time.Time]
+}
+
+func (p *SketchPullGossiper[T]) Gossip(ctx context.Context) error {
+	var msgBytes []byte
+	for _, nodeID := range p.sample(ctx) {
+		if p.BloomOnly(nodeID) {
+			p.requestBloom(ctx, nodeID)
+			continue
+		}
+
+		// The sketch is only built if a peer is sent one.
+		if msgBytes == nil {
+			var err error
+			msgBytes, err = p.marshalSketch()
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := p.client.AppRequest(ctx, set.Of(nodeID), msgBytes, p.handleSketchResponse); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sample returns the peers to request gossip from. Like AppRequestAny, each
+// poll is sent to an independently sampled peer.
+func (p *SketchPullGossiper[_]) sample(ctx context.Context) []ids.NodeID {
+	nodeIDs := make([]ids.NodeID, 0, p.pollSize)
+	for i := 0; i < p.pollSize; i++ {
+		nodeIDs = append(nodeIDs, p.client.Sample(ctx, 1)...)
+	}
+	return nodeIDs
+}
+
+func (p *SketchPullGossiper[T]) marshalSketch() ([]byte, error) {
+	salt := ids.Empty
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, err
+	}
+
+	sketch, err := NewSketch(p.sketchCells, salt)
+	if err != nil {
+		return nil, err
+	}
+	p.set.Iterate(func(gossipable T) bool {
+		sketch.Add(gossipable.GossipID())
+		return true
+	})
+	return MarshalSketchAppRequest(sketch)
+}
+
+// BloomOnly returns true if sketches aren't currently sent to [nodeID] because
+// its handler didn't support them.
+func (p *SketchPullGossiper[_]) BloomOnly(nodeID ids.NodeID) bool {
+	foundAt, ok := p.bloomOnly.Get(nodeID)
+	if !ok {
+		return false
+	}
+	if p.clock.Time().Sub(foundAt) >= bloomOnlyExpiry {
+		p.bloomOnly.Evict(nodeID)
+		return false
+	}
+	return true
+}
+
+func (p *SketchPullGossiper[_]) handleSketchResponse(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	responseBytes []byte,
+	err error,
+) {
+	if errors.Is(err, p2p.ErrSketchOverflow) {
+		p.log.Debug(
+			"failed to reconcile sketch",
+			zap.Stringer("nodeID", nodeID),
+		)
+		p.requestBloom(ctx, nodeID)
+		return
+	}
+	if err != nil {
+		p.log.Debug(
+			"failed gossip request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	gossip, reconciled, err := ParseSketchAppResponse(responseBytes)
+	if err != nil {
+		p.log.Debug("failed to unmarshal gossip response", zap.Error(err))
+		return
+	}
+
+	if !reconciled {
+		p.log.Info(
+			"falling back to bloom filter gossip",
+			zap.String("reason", "handler does not support sketches"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		p.bloomOnly.Put(nodeID, p.clock.Time())
+		p.requestBloom(ctx, nodeID)
+		return
+	}
+
+	p.handleGossip(nodeID, gossip)
+}
+
+// requestBloom requests gossip from [nodeID] using a bloom filter.
+func (p *SketchPullGossiper[_]) requestBloom(ctx context.Context, nodeID ids.NodeID) {
+	msgBytes, err := MarshalAppRequest(p.set.GetFilter())
+	if err != nil {
+		p.log.Error("failed to marshal gossip request", zap.Error(err))
+		return
+	}
+
+	if err := p.client.AppRequest(ctx, set.Of(nodeID), msgBytes, p.handleResponse); err != nil {
+		p.log.Debug(
+			"failed to request gossip",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+}
+
 // NewPushGossiper returns an instance of PushGossiper
 func NewPushGossiper[T Gossipable](
 	marshaller Marshaller[T],
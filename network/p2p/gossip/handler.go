@@ -8,12 +8,15 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/utils/bloom"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
 )
 
 var _ p2p.Handler = (*Handler[*testTx])(nil)
@@ -35,6 +38,22 @@ func NewHandler[T Gossipable](
 	}
 }
 
+// NewSketchHandler returns a Handler that additionally reconciles requests
+// that include a sketch of at most [maxSketchCells] cells. Requests with
+// larger sketches are responded to with [p2p.ErrSketchOverflow].
+func NewSketchHandler[T Gossipable](
+	log logging.Logger,
+	marshaller Marshaller[T],
+	set Set[T],
+	metrics Metrics,
+	targetResponseSize int,
+	maxSketchCells int,
+) *Handler[T] {
+	h := NewHandler(log, marshaller, set, metrics, targetResponseSize)
+	h.maxSketchCells = maxSketchCells
+	return h
+}
+
 type Handler[T Gossipable] struct {
 	p2p.Handler
 	marshaller         Marshaller[T]
@@ -42,10 +61,22 @@ type Handler[T Gossipable] struct {
 	set                Set[T]
 	metrics            Metrics
 	targetResponseSize int
+	// maxSketchCells is the largest sketch that will be reconciled. If 0,
+	// sketches are ignored and requests are served using their bloom filter.
+	maxSketchCells int
 }
 
 func (h Handler[T]) AppRequest(_ context.Context, _ ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, *common.AppError) {
-	filter, salt, err := ParseAppRequest(requestBytes)
+	request := &sdk.PullGossipRequest{}
+	if err := proto.Unmarshal(requestBytes, request); err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+
+	if h.maxSketchCells > 0 && len(request.Sketch) > 0 {
+		return h.reconcile(request)
+	}
+
+	filter, salt, err := parseFilter(request)
 	if err != nil {
 		return nil, p2p.ErrUnexpected
 	}
@@ -89,6 +120,79 @@ func (h Handler[T]) AppRequest(_ context.Context, _ ids.NodeID, _ time.Time, req
 	return response, nil
 }
 
+// reconcile responds with the gossip that is in the set but is missing from
+// the sketch in [request].
+func (h Handler[T]) reconcile(request *sdk.PullGossipRequest) ([]byte, *common.AppError) {
+	salt, err := ids.ToID(request.Salt)
+	if err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+
+	if len(request.Sketch) > h.maxSketchCells*cellLen {
+		return nil, p2p.ErrSketchOverflow
+	}
+
+	remote, err := ParseSketch(request.Sketch, salt)
+	if err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+
+	sketch, err := NewSketch(remote.Len(), salt)
+	if err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+	h.set.Iterate(func(gossipable T) bool {
+		sketch.Add(gossipable.GossipID())
+		return true
+	})
+	if err := sketch.Subtract(remote); err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+
+	// Ids that were removed from the sketch are only known by the requesting
+	// peer, so they are ignored.
+	missing, _, ok := sketch.Decode()
+	if !ok {
+		return nil, p2p.ErrSketchOverflow
+	}
+
+	var (
+		missingSet   = set.Of(missing...)
+		responseSize = 0
+		gossipBytes  = make([][]byte, 0, len(missing))
+	)
+	h.set.Iterate(func(gossipable T) bool {
+		if !missingSet.Contains(gossipable.GossipID()) {
+			return true
+		}
+
+		var bytes []byte
+		bytes, err = h.marshaller.MarshalGossip(gossipable)
+		if err != nil {
+			return false
+		}
+
+		gossipBytes = append(gossipBytes, bytes)
+		responseSize += len(bytes)
+
+		return responseSize <= h.targetResponseSize
+	})
+	if err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+
+	if err := h.metrics.observeMessage(sentPullLabels, len(gossipBytes), responseSize); err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+
+	response, err := MarshalSketchAppResponse(gossipBytes)
+	if err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+
+	return response, nil
+}
+
 func (h Handler[_]) AppGossip(_ context.Context, nodeID ids.NodeID, gossipBytes []byte) {
 	gossip, err := ParseAppGossip(gossipBytes)
 	if err != nil {
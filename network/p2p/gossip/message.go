@@ -19,12 +19,28 @@ func MarshalAppRequest(filter, salt []byte) ([]byte, error) {
 	return proto.Marshal(request)
 }
 
+// MarshalSketchAppRequest returns a request for the gossip that is missing
+// from [sketch]. The request includes a full bloom filter so that handlers which
+// do not support sketches respond without any gossip.
+func MarshalSketchAppRequest(sketch *Sketch) ([]byte, error) {
+	request := &sdk.PullGossipRequest{
+		Salt:   sketch.salt[:],
+		Filter: bloom.FullFilter.Marshal(),
+		Sketch: sketch.Marshal(),
+	}
+	return proto.Marshal(request)
+}
+
 func ParseAppRequest(bytes []byte) (*bloom.ReadFilter, ids.ID, error) {
 	request := &sdk.PullGossipRequest{}
 	if err := proto.Unmarshal(bytes, request); err != nil {
 		return nil, ids.Empty, err
 	}
+	return parseFilter(request)
+}
 
+// parseFilter returns the bloom filter and salt of [request].
+func parseFilter(request *sdk.PullGossipRequest) (*bloom.ReadFilter, ids.ID, error) {
 	salt, err := ids.ToID(request.Salt)
 	if err != nil {
 		return nil, ids.Empty, err
@@ -46,6 +62,21 @@ func ParseAppResponse(bytes []byte) ([][]byte, error) {
 	return response.Gossip, err
 }
 
+func MarshalSketchAppResponse(gossip [][]byte) ([]byte, error) {
+	return proto.Marshal(&sdk.PullGossipResponse{
+		Gossip:     gossip,
+		Reconciled: true,
+	})
+}
+
+// ParseSketchAppResponse returns the gossip in the response and whether the
+// handler reconciled the sketch that was sent in the request.
+func ParseSketchAppResponse(bytes []byte) ([][]byte, bool, error) {
+	response := &sdk.PullGossipResponse{}
+	err := proto.Unmarshal(bytes, response)
+	return response.Gossip, response.Reconciled, err
+}
+
 func MarshalAppGossip(gossip [][]byte) ([]byte, error) {
 	return proto.Marshal(&sdk.PushGossip{
 		Gossip: gossip,
@@ -0,0 +1,216 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+const (
+	// sketchHashes is the number of cells each id is added to. The cells are
+	// split into [sketchHashes] partitions so that an id is never added to the
+	// same cell twice.
+	sketchHashes = 3
+
+	cellCountLen = 4
+	cellHashLen  = 8
+	cellLen      = cellCountLen + ids.IDLen + cellHashLen
+)
+
+var (
+	errInvalidNumCells  = fmt.Errorf("number of cells must be a positive multiple of %d", sketchHashes)
+	errInvalidSketchLen = fmt.Errorf("sketch length must be a multiple of %d", cellLen)
+	errMismatchedSketch = errors.New("mismatched sketch")
+)
+
+// Sketch is an invertible bloom lookup table of gossip ids.
+//
+// Subtracting the sketch of one set from the sketch of another set results in
+// a sketch of their symmetric difference, which can be decoded as long as the
+// difference is small relative to the number of cells. This allows two peers
+// to reconcile their sets with bandwidth proportional to the size of the
+// difference, rather than to the size of the sets.
+type Sketch struct {
+	salt  ids.ID
+	cells []sketchCell
+}
+
+type sketchCell struct {
+	// count is the number of ids added to the cell minus the number of ids
+	// removed from the cell.
+	count int32
+	// idSum is the xor of the ids in the cell.
+	idSum ids.ID
+	// hashSum is the xor of the checksums of the ids in the cell.
+	hashSum uint64
+}
+
+// NewSketch returns an empty sketch with [numCells] cells. Only sketches with
+// the same number of cells and [salt] can be subtracted from each other.
+func NewSketch(numCells int, salt ids.ID) (*Sketch, error) {
+	if numCells <= 0 || numCells%sketchHashes != 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidNumCells, numCells)
+	}
+	return &Sketch{
+		salt:  salt,
+		cells: make([]sketchCell, numCells),
+	}, nil
+}
+
+// ParseSketch parses a sketch that was marshalled with [Sketch.Marshal].
+func ParseSketch(bytes []byte, salt ids.ID) (*Sketch, error) {
+	if len(bytes)%cellLen != 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidSketchLen, len(bytes))
+	}
+	s, err := NewSketch(len(bytes)/cellLen, salt)
+	if err != nil {
+		return nil, err
+	}
+	for i := range s.cells {
+		cell := &s.cells[i]
+		cellBytes := bytes[i*cellLen : (i+1)*cellLen]
+		cell.count = int32(binary.BigEndian.Uint32(cellBytes))
+		copy(cell.idSum[:], cellBytes[cellCountLen:])
+		cell.hashSum = binary.BigEndian.Uint64(cellBytes[cellCountLen+ids.IDLen:])
+	}
+	return s, nil
+}
+
+// Len returns the number of cells in the sketch.
+func (s *Sketch) Len() int {
+	return len(s.cells)
+}
+
+func (s *Sketch) Add(gossipID ids.ID) {
+	s.update(gossipID, 1)
+}
+
+func (s *Sketch) Remove(gossipID ids.ID) {
+	s.update(gossipID, -1)
+}
+
+// Subtract removes every id in [other] from this sketch.
+func (s *Sketch) Subtract(other *Sketch) error {
+	if len(s.cells) != len(other.cells) || s.salt != other.salt {
+		return errMismatchedSketch
+	}
+	for i := range s.cells {
+		cell := &s.cells[i]
+		otherCell := &other.cells[i]
+		cell.count -= otherCell.count
+		cell.idSum = cell.idSum.XOR(otherCell.idSum)
+		cell.hashSum ^= otherCell.hashSum
+	}
+	return nil
+}
+
+// Decode returns the ids that were added to, and removed from, this sketch.
+// Returns false if the sketch contains too many ids to be decoded.
+//
+// Decoding does not modify the sketch.
+func (s *Sketch) Decode() ([]ids.ID, []ids.ID, bool) {
+	decoder := &Sketch{
+		salt:  s.salt,
+		cells: make([]sketchCell, len(s.cells)),
+	}
+	copy(decoder.cells, s.cells)
+
+	var (
+		added   []ids.ID
+		removed []ids.ID
+		pure    = make([]int, 0, len(decoder.cells))
+	)
+	for i := range decoder.cells {
+		if decoder.isPure(i) {
+			pure = append(pure, i)
+		}
+	}
+	for len(pure) > 0 {
+		i := pure[len(pure)-1]
+		pure = pure[:len(pure)-1]
+
+		// The cell may have been peeled since it was marked as pure.
+		if !decoder.isPure(i) {
+			continue
+		}
+
+		cell := decoder.cells[i]
+		gossipID := cell.idSum
+		if cell.count == 1 {
+			added = append(added, gossipID)
+		} else {
+			removed = append(removed, gossipID)
+		}
+
+		indices, _ := s.hash(gossipID)
+		decoder.update(gossipID, -cell.count)
+		for _, index := range indices {
+			if decoder.isPure(index) {
+				pure = append(pure, index)
+			}
+		}
+	}
+
+	for _, cell := range decoder.cells {
+		if cell != (sketchCell{}) {
+			return nil, nil, false
+		}
+	}
+	return added, removed, true
+}
+
+func (s *Sketch) Marshal() []byte {
+	bytes := make([]byte, len(s.cells)*cellLen)
+	for i, cell := range s.cells {
+		cellBytes := bytes[i*cellLen : (i+1)*cellLen]
+		binary.BigEndian.PutUint32(cellBytes, uint32(cell.count))
+		copy(cellBytes[cellCountLen:], cell.idSum[:])
+		binary.BigEndian.PutUint64(cellBytes[cellCountLen+ids.IDLen:], cell.hashSum)
+	}
+	return bytes
+}
+
+func (s *Sketch) update(gossipID ids.ID, count int32) {
+	indices, checksum := s.hash(gossipID)
+	for _, index := range indices {
+		cell := &s.cells[index]
+		cell.count += count
+		cell.idSum = cell.idSum.XOR(gossipID)
+		cell.hashSum ^= checksum
+	}
+}
+
+// isPure returns true if the cell at [index] contains exactly one id.
+func (s *Sketch) isPure(index int) bool {
+	cell := &s.cells[index]
+	if cell.count != 1 && cell.count != -1 {
+		return false
+	}
+	_, checksum := s.hash(cell.idSum)
+	return cell.hashSum == checksum
+}
+
+// hash returns the cells [gossipID] is added to along with its checksum.
+func (s *Sketch) hash(gossipID ids.ID) ([sketchHashes]int, uint64) {
+	hash := sha256.New()
+	// sha256.Write never returns errors
+	_, _ = hash.Write(gossipID[:])
+	_, _ = hash.Write(s.salt[:])
+	digest := hash.Sum(make([]byte, 0, sha256.Size))
+
+	var (
+		partitionLen = uint64(len(s.cells) / sketchHashes)
+		indices      [sketchHashes]int
+	)
+	for i := range indices {
+		offset := binary.BigEndian.Uint64(digest[i*8:]) % partitionLen
+		indices[i] = i*int(partitionLen) + int(offset)
+	}
+	return indices, binary.BigEndian.Uint64(digest[sketchHashes*8:])
+}
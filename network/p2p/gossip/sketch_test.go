@@ -0,0 +1,434 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/engine/enginetest"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+func TestSketchDecode(t *testing.T) {
+	tests := []struct {
+		name        string
+		numCells    int
+		numShared   int
+		numAdded    int
+		numRemoved  int
+		expectedErr bool
+	}{
+		{
+			name:     "empty",
+			numCells: 30,
+		},
+		{
+			name:      "identical",
+			numCells:  30,
+			numShared: 1000,
+		},
+		{
+			name:      "added",
+			numCells:  150,
+			numShared: 1000,
+			numAdded:  10,
+		},
+		{
+			name:       "removed",
+			numCells:   150,
+			numShared:  1000,
+			numRemoved: 10,
+		},
+		{
+			name:       "added and removed",
+			numCells:   300,
+			numShared:  1000,
+			numAdded:   15,
+			numRemoved: 15,
+		},
+		{
+			name:        "overflow",
+			numCells:    30,
+			numShared:   1000,
+			numAdded:    100,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			salt := ids.GenerateTestID()
+			local, err := NewSketch(tt.numCells, salt)
+			require.NoError(err)
+			remote, err := NewSketch(tt.numCells, salt)
+			require.NoError(err)
+
+			for i := 0; i < tt.numShared; i++ {
+				gossipID := ids.GenerateTestID()
+				local.Add(gossipID)
+				remote.Add(gossipID)
+			}
+
+			var expectedAdded, expectedRemoved []ids.ID
+			for i := 0; i < tt.numAdded; i++ {
+				gossipID := ids.GenerateTestID()
+				local.Add(gossipID)
+				expectedAdded = append(expectedAdded, gossipID)
+			}
+			for i := 0; i < tt.numRemoved; i++ {
+				gossipID := ids.GenerateTestID()
+				remote.Add(gossipID)
+				expectedRemoved = append(expectedRemoved, gossipID)
+			}
+
+			parsed, err := ParseSketch(remote.Marshal(), salt)
+			require.NoError(err)
+			require.NoError(local.Subtract(parsed))
+
+			added, removed, ok := local.Decode()
+			require.Equal(!tt.expectedErr, ok)
+			if tt.expectedErr {
+				return
+			}
+			require.ElementsMatch(expectedAdded, added)
+			require.ElementsMatch(expectedRemoved, removed)
+		})
+	}
+}
+
+func TestSketchRemove(t *testing.T) {
+	require := require.New(t)
+
+	sketch, err := NewSketch(3, ids.Empty)
+	require.NoError(err)
+
+	gossipID := ids.GenerateTestID()
+	sketch.Add(gossipID)
+	sketch.Remove(gossipID)
+
+	added, removed, ok := sketch.Decode()
+	require.True(ok)
+	require.Empty(added)
+	require.Empty(removed)
+	require.Equal(make([]byte, 3*cellLen), sketch.Marshal())
+}
+
+func TestSketchErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewSketch(0, ids.Empty)
+	require.ErrorIs(err, errInvalidNumCells)
+
+	_, err = NewSketch(4, ids.Empty)
+	require.ErrorIs(err, errInvalidNumCells)
+
+	_, err = ParseSketch(make([]byte, cellLen+1), ids.Empty)
+	require.ErrorIs(err, errInvalidSketchLen)
+
+	_, err = ParseSketch(nil, ids.Empty)
+	require.ErrorIs(err, errInvalidNumCells)
+
+	sketch, err := NewSketch(3, ids.Empty)
+	require.NoError(err)
+	otherSize, err := NewSketch(6, ids.Empty)
+	require.NoError(err)
+	otherSalt, err := NewSketch(3, ids.GenerateTestID())
+	require.NoError(err)
+	require.ErrorIs(sketch.Subtract(otherSize), errMismatchedSketch)
+	require.ErrorIs(sketch.Subtract(otherSalt), errMismatchedSketch)
+}
+
+// pullSimulation connects a requesting node to a responding node and records
+// the bytes exchanged between them.
+type pullSimulation struct {
+	requestSender  *enginetest.SenderStub
+	responseSender *enginetest.SenderStub
+
+	requestNetwork  *p2p.Network
+	responseNetwork *p2p.Network
+
+	requestSet  *testSet
+	responseSet *testSet
+
+	requestID     uint32
+	requestBytes  int
+	responseBytes int
+}
+
+func newPullSimulation(t *testing.T, handler func(Set[*testTx], Metrics) p2p.Handler) *pullSimulation {
+	require := require.New(t)
+
+	s := &pullSimulation{
+		requestSender: &enginetest.SenderStub{
+			SentAppRequest: make(chan []byte, 2),
+		},
+		responseSender: &enginetest.SenderStub{
+			SentAppResponse: make(chan []byte, 1),
+			SentAppError:    make(chan *common.AppError, 1),
+		},
+		requestID: 1,
+	}
+
+	var err error
+	s.requestNetwork, err = p2p.NewNetwork(logging.NoLog{}, s.requestSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(s.requestNetwork.Connected(context.Background(), ids.EmptyNodeID, nil))
+	s.responseNetwork, err = p2p.NewNetwork(logging.NoLog{}, s.responseSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+
+	s.requestSet = newSimulationSet(t)
+	s.responseSet = newSimulationSet(t)
+
+	metrics, err := NewMetrics(prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(s.responseNetwork.AddHandler(0x0, handler(s.responseSet, metrics)))
+	return s
+}
+
+func newSimulationSet(t *testing.T) *testSet {
+	bloom, err := NewBloomFilter(prometheus.NewRegistry(), "", 16*1024, 0.01, 0.05)
+	require.NoError(t, err)
+	return &testSet{
+		txs:   make(map[ids.ID]*testTx),
+		bloom: bloom,
+	}
+}
+
+// add adds [numShared] txs to both sets and [numMissing] txs to only the
+// responding set.
+func (s *pullSimulation) add(t *testing.T, numShared int, numMissing int) {
+	for i := 0; i < numShared; i++ {
+		tx := &testTx{id: ids.GenerateTestID()}
+		require.NoError(t, s.requestSet.Add(tx))
+		require.NoError(t, s.responseSet.Add(tx))
+	}
+	for i := 0; i < numMissing; i++ {
+		require.NoError(t, s.responseSet.Add(&testTx{id: ids.GenerateTestID()}))
+	}
+}
+
+// run gossips once and delivers messages until there are no more outstanding
+// requests.
+func (s *pullSimulation) run(t *testing.T, gossiper Gossiper) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	require.NoError(gossiper.Gossip(ctx))
+	for {
+		var request []byte
+		select {
+		case request = <-s.requestSender.SentAppRequest:
+		default:
+			return
+		}
+		s.requestBytes += len(request)
+		require.NoError(s.responseNetwork.AppRequest(ctx, ids.EmptyNodeID, s.requestID, time.Time{}, request))
+
+		select {
+		case response := <-s.responseSender.SentAppResponse:
+			s.responseBytes += len(response)
+			require.NoError(s.requestNetwork.AppResponse(ctx, ids.EmptyNodeID, s.requestID, response))
+		case appErr := <-s.responseSender.SentAppError:
+			require.NoError(s.requestNetwork.AppRequestFailed(ctx, ids.EmptyNodeID, s.requestID, appErr))
+		}
+		s.requestID += 2
+	}
+}
+
+func newBloomHandler(s Set[*testTx], metrics Metrics) p2p.Handler {
+	return NewHandler[*testTx](logging.NoLog{}, testMarshaller{}, s, metrics, units.MiB)
+}
+
+func newSketchHandler(s Set[*testTx], metrics Metrics) p2p.Handler {
+	return NewSketchHandler[*testTx](logging.NoLog{}, testMarshaller{}, s, metrics, units.MiB, 3000)
+}
+
+func newTestSketchPullGossiper(t *testing.T, s *pullSimulation, sketchCells int) *SketchPullGossiper[*testTx] {
+	metrics, err := NewMetrics(prometheus.NewRegistry(), "")
+	require.NoError(t, err)
+	gossiper, err := NewSketchPullGossiper[*testTx](
+		logging.NoLog{},
+		testMarshaller{},
+		s.requestSet,
+		s.requestNetwork.NewClient(0x0),
+		metrics,
+		1,
+		sketchCells,
+	)
+	require.NoError(t, err)
+	return gossiper
+}
+
+func TestSketchPullGossiper(t *testing.T) {
+	tests := []struct {
+		name              string
+		handler           func(Set[*testTx], Metrics) p2p.Handler
+		sketchCells       int
+		numShared         int
+		numMissing        int
+		expectedRequests  int
+		expectedBloomOnly bool
+	}{
+		{
+			name:             "reconciled",
+			handler:          newSketchHandler,
+			sketchCells:      300,
+			numShared:        100,
+			numMissing:       10,
+			expectedRequests: 1,
+		},
+		{
+			name:             "overflow falls back to bloom filter",
+			handler:          newSketchHandler,
+			sketchCells:      3,
+			numShared:        100,
+			numMissing:       10,
+			expectedRequests: 2,
+		},
+		{
+			name:              "unsupported handler falls back to bloom filter",
+			handler:           newBloomHandler,
+			sketchCells:       60,
+			numShared:         100,
+			numMissing:        10,
+			expectedRequests:  2,
+			expectedBloomOnly: true,
+		},
+		{
+			name: "sketch larger than handler max",
+			handler: func(s Set[*testTx], metrics Metrics) p2p.Handler {
+				return NewSketchHandler[*testTx](logging.NoLog{}, testMarshaller{}, s, metrics, units.MiB, 3)
+			},
+			sketchCells:      300,
+			numShared:        100,
+			numMissing:       10,
+			expectedRequests: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			s := newPullSimulation(t, tt.handler)
+			s.add(t, tt.numShared, tt.numMissing)
+			gossiper := newTestSketchPullGossiper(t, s, tt.sketchCells)
+
+			received := set.Set[*testTx]{}
+			s.requestSet.onAdd = func(tx *testTx) {
+				received.Add(tx)
+			}
+
+			s.run(t, gossiper)
+			require.Equal(tt.numShared+tt.numMissing, len(s.requestSet.txs))
+			require.Len(received, tt.numMissing)
+			require.Equal(tt.expectedRequests, int(s.requestID-1)/2)
+			require.Equal(tt.expectedBloomOnly, gossiper.BloomOnly(ids.EmptyNodeID))
+		})
+	}
+}
+
+func TestSketchPullGossiperBloomOnlyExpiry(t *testing.T) {
+	require := require.New(t)
+
+	s := newPullSimulation(t, newBloomHandler)
+	s.add(t, 100, 10)
+	gossiper := newTestSketchPullGossiper(t, s, 60)
+	gossiper.clock.Set(time.Unix(1_000, 0))
+
+	// The first request falls back to a bloom filter.
+	s.run(t, gossiper)
+	require.Equal(2, int(s.requestID-1)/2)
+	require.True(gossiper.BloomOnly(ids.EmptyNodeID))
+	require.False(gossiper.BloomOnly(ids.GenerateTestNodeID()))
+
+	// The peer is only sent a bloom filter while the fallback is remembered.
+	s.run(t, gossiper)
+	require.Equal(3, int(s.requestID-1)/2)
+
+	gossiper.clock.Set(time.Unix(1_000, 0).Add(bloomOnlyExpiry))
+	require.False(gossiper.BloomOnly(ids.EmptyNodeID))
+
+	// Once the fallback expires, a sketch is tried again.
+	s.run(t, gossiper)
+	require.Equal(5, int(s.requestID-1)/2)
+	require.True(gossiper.BloomOnly(ids.EmptyNodeID))
+}
+
+// TestPullGossipSimulation compares the bandwidth used by the bloom filter
+// and sketch modes to converge two sets. Sketches are cheaper while the sets
+// are mostly in sync, but fall back to bloom filters once the difference
+// exceeds what the sketch can decode.
+func TestPullGossipSimulation(t *testing.T) {
+	const sketchCells = 300
+
+	tests := []struct {
+		numShared           int
+		numMissing          int
+		expectedSketchCheap bool
+	}{
+		{
+			numShared:           1_000,
+			numMissing:          10,
+			expectedSketchCheap: true,
+		},
+		{
+			numShared:           10_000,
+			numMissing:          10,
+			expectedSketchCheap: true,
+		},
+		{
+			numShared:           10_000,
+			numMissing:          1_000,
+			expectedSketchCheap: false,
+		},
+	}
+	for _, tt := range tests {
+		require := require.New(t)
+
+		bloom := newPullSimulation(t, newSketchHandler)
+		bloom.add(t, tt.numShared, tt.numMissing)
+		metrics, err := NewMetrics(prometheus.NewRegistry(), "")
+		require.NoError(err)
+		bloom.run(t, NewPullGossiper[*testTx](
+			logging.NoLog{},
+			testMarshaller{},
+			bloom.requestSet,
+			bloom.requestNetwork.NewClient(0x0),
+			metrics,
+			1,
+		))
+
+		sketch := newPullSimulation(t, newSketchHandler)
+		sketch.add(t, tt.numShared, tt.numMissing)
+		sketch.run(t, newTestSketchPullGossiper(t, sketch, sketchCells))
+
+		// Bloom filter false positives may cause a few txs to be missed by
+		// either mode.
+		expectedLen := tt.numShared + tt.numMissing
+		require.InDelta(expectedLen, len(bloom.requestSet.txs), float64(tt.numMissing)/10)
+		require.InDelta(expectedLen, len(sketch.requestSet.txs), float64(tt.numMissing)/10)
+
+		bloomBytes := bloom.requestBytes + bloom.responseBytes
+		sketchBytes := sketch.requestBytes + sketch.responseBytes
+		t.Logf(
+			"shared=%d missing=%d: bloom sent %d bytes, sketch sent %d bytes",
+			tt.numShared,
+			tt.numMissing,
+			bloomBytes,
+			sketchBytes,
+		)
+		require.Equal(tt.expectedSketchCheap, sketchBytes < bloomBytes)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math/rand"
 	"strconv"
 	"sync"
 	"time"
@@ -66,6 +67,7 @@ type router struct {
 	handlers           map[uint64]*responder
 	pendingAppRequests map[uint32]pendingAppRequest
 	requestID          uint32
+	streamID           uint64
 }
 
 // newRouter returns a new instance of Router
@@ -82,6 +84,9 @@ func newRouter(
 		pendingAppRequests: make(map[uint32]pendingAppRequest),
 		// invariant: sdk uses odd-numbered requestIDs
 		requestID: 1,
+		// Stream IDs are randomly seeded so that streams opened after a
+		// restart don't collide with streams that peers still hold open.
+		streamID: rand.Uint64(), // #nosec G404
 	}
 }
 
@@ -0,0 +1,202 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+var errInvalidMaxChunks = errors.New("max chunks must be > 0")
+
+// StreamCallback is called upon receiving the next chunks of a stream opened
+// by Client. [done] is true once the stream has ended, after which the
+// callback will not be called again.
+// Callers should check [err] to see whether the stream failed or not. If the
+// stream failed, [done] is true.
+type StreamCallback func(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	chunks [][]byte,
+	done bool,
+	err error,
+)
+
+// Stream is a streamed response to a request issued by Client.
+//
+// Chunks are requested in batches. The next batch is only requested once
+// [onChunks] has returned for the previous batch, so a slow consumer slows
+// down the producer rather than buffering an unbounded number of chunks.
+// Each batch is a separate AppRequest and is therefore subject to the usual
+// request timeouts.
+type Stream struct {
+	client    *Client
+	nodeID    ids.NodeID
+	streamID  uint64
+	maxChunks uint32
+	onChunks  StreamCallback
+
+	// stopCancelOnDone unregisters the cancellation of the stream once the
+	// context passed to AppRequestStream is cancelled.
+	stopCancelOnDone func() bool
+
+	lock      sync.Mutex
+	cancelled bool
+	done      bool
+}
+
+// AppRequestStream opens a stream with [nodeID] that is served by a
+// StreamingHandler. Up to [maxChunks] chunks are requested at a time.
+//
+// The stream is cancelled if [ctx] is cancelled before the stream has ended.
+func (c *Client) AppRequestStream(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestBytes []byte,
+	maxChunks uint32,
+	onChunks StreamCallback,
+) (*Stream, error) {
+	if maxChunks == 0 {
+		return nil, errInvalidMaxChunks
+	}
+
+	c.router.lock.Lock()
+	streamID := c.router.streamID
+	c.router.streamID++
+	c.router.lock.Unlock()
+
+	s := &Stream{
+		client:    c,
+		nodeID:    nodeID,
+		streamID:  streamID,
+		maxChunks: maxChunks,
+		onChunks:  onChunks,
+	}
+	s.stopCancelOnDone = context.AfterFunc(ctx, func() {
+		_ = s.Cancel(context.WithoutCancel(ctx))
+	})
+	if err := s.request(ctx, &sdk.StreamRequest{
+		StreamId:  streamID,
+		Open:      true,
+		Request:   requestBytes,
+		MaxChunks: maxChunks,
+	}); err != nil {
+		s.stopCancelOnDone()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Cancel stops requesting chunks and notifies the peer that the stream can be
+// closed. [onChunks] will not be called after Cancel returns, unless it is
+// already executing.
+func (s *Stream) Cancel(ctx context.Context) error {
+	s.lock.Lock()
+	if s.cancelled || s.done {
+		s.lock.Unlock()
+		return nil
+	}
+	s.cancelled = true
+	s.lock.Unlock()
+
+	s.stopCancelOnDone()
+	return s.cancel(ctx)
+}
+
+func (s *Stream) cancel(ctx context.Context) error {
+	msgBytes, err := proto.Marshal(&sdk.StreamRequest{
+		StreamId: s.streamID,
+		Cancel:   true,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The peer's acknowledgement of the cancellation is ignored.
+	return s.client.AppRequest(
+		ctx,
+		set.Of(s.nodeID),
+		msgBytes,
+		func(context.Context, ids.NodeID, []byte, error) {},
+	)
+}
+
+func (s *Stream) request(ctx context.Context, request *sdk.StreamRequest) error {
+	msgBytes, err := proto.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	return s.client.AppRequest(ctx, set.Of(s.nodeID), msgBytes, s.handleResponse)
+}
+
+func (s *Stream) handleResponse(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	responseBytes []byte,
+	err error,
+) {
+	if s.isCancelled() {
+		return
+	}
+
+	if err != nil {
+		s.fail(ctx, err)
+		return
+	}
+
+	response := &sdk.StreamResponse{}
+	if err := proto.Unmarshal(responseBytes, response); err != nil {
+		s.fail(ctx, err)
+		return
+	}
+
+	if response.Done {
+		s.finish()
+	}
+
+	s.onChunks(ctx, nodeID, response.Chunks, response.Done, nil)
+	if response.Done {
+		return
+	}
+
+	// The caller may have cancelled the stream while handling the chunks.
+	if s.isCancelled() {
+		return
+	}
+
+	if err := s.request(ctx, &sdk.StreamRequest{
+		StreamId:  s.streamID,
+		MaxChunks: s.maxChunks,
+	}); err != nil {
+		s.fail(ctx, err)
+	}
+}
+
+func (s *Stream) fail(ctx context.Context, err error) {
+	s.finish()
+	s.onChunks(ctx, s.nodeID, nil, true, err)
+}
+
+func (s *Stream) finish() {
+	s.lock.Lock()
+	s.done = true
+	s.lock.Unlock()
+
+	s.stopCancelOnDone()
+}
+
+func (s *Stream) isCancelled() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.cancelled
+}
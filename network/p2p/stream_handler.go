@@ -0,0 +1,320 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/utils/iterator"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+)
+
+var _ Handler = (*StreamingHandler)(nil)
+
+// StreamHandler is the server-side logic for application protocols that
+// respond to a request with a sequence of chunks.
+type StreamHandler interface {
+	// AppRequestStream is called when a stream is opened. Returns the chunks
+	// to respond with or an application-defined error.
+	//
+	// The returned iterator is released once all of its chunks have been
+	// sent, or once the stream is cancelled or expires.
+	AppRequestStream(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		deadline time.Time,
+		requestBytes []byte,
+	) (iterator.Iterator[[]byte], *common.AppError)
+}
+
+type streamKey struct {
+	nodeID   ids.NodeID
+	streamID uint64
+}
+
+type openStream struct {
+	chunks iterator.Iterator[[]byte]
+	// next is a chunk that was read from [chunks] but didn't fit into the
+	// previous response. Only valid if [hasNext] is true.
+	next    []byte
+	hasNext bool
+	// busy is true while a request for this stream is being handled.
+	busy bool
+	// cancelled is true if the stream was cancelled while it was busy.
+	cancelled   bool
+	lastRequest time.Time
+}
+
+// NewStreamingHandler returns a Handler that serves streams opened by
+// Client.AppRequestStream using [handler].
+//
+// Each peer may have at most [maxStreamsPerPeer] streams open at a time.
+// Streams that aren't continued within [maxIdleTime] are closed. Responses are
+// limited to [maxResponseBytes] unless a single chunk is larger.
+func NewStreamingHandler(
+	handler StreamHandler,
+	log logging.Logger,
+	maxStreamsPerPeer int,
+	maxIdleTime time.Duration,
+	maxResponseBytes int,
+) *StreamingHandler {
+	return &StreamingHandler{
+		handler:           handler,
+		log:               log,
+		maxStreamsPerPeer: maxStreamsPerPeer,
+		maxIdleTime:       maxIdleTime,
+		maxResponseBytes:  maxResponseBytes,
+		streams:           make(map[streamKey]*openStream),
+		numStreams:        make(map[ids.NodeID]int),
+	}
+}
+
+// StreamingHandler keeps track of the streams that are open with each peer
+// and responds to each request with the next chunks of its stream.
+type StreamingHandler struct {
+	NoOpHandler
+
+	handler           StreamHandler
+	log               logging.Logger
+	maxStreamsPerPeer int
+	maxIdleTime       time.Duration
+	maxResponseBytes  int
+	clock             mockable.Clock
+
+	lock       sync.Mutex
+	streams    map[streamKey]*openStream
+	numStreams map[ids.NodeID]int
+	// expiryTimer closes idle streams. It is only scheduled while streams are
+	// open.
+	expiryTimer *time.Timer
+}
+
+func (s *StreamingHandler) AppRequest(ctx context.Context, nodeID ids.NodeID, deadline time.Time, requestBytes []byte) ([]byte, *common.AppError) {
+	request := &sdk.StreamRequest{}
+	if err := proto.Unmarshal(requestBytes, request); err != nil {
+		return nil, ErrUnexpected
+	}
+
+	key := streamKey{
+		nodeID:   nodeID,
+		streamID: request.StreamId,
+	}
+
+	switch {
+	case request.Cancel:
+		s.lock.Lock()
+		if stream, ok := s.streams[key]; ok {
+			if stream.busy {
+				// The stream will be closed once the in-flight request is
+				// handled.
+				stream.cancelled = true
+			} else {
+				s.remove(key, stream)
+			}
+		}
+		s.lock.Unlock()
+		return marshalStreamResponse(nil, true)
+	case request.Open:
+		stream, err := s.open(ctx, key, deadline, request.Request)
+		if err != nil {
+			return nil, err
+		}
+		return s.respond(key, stream, request.MaxChunks)
+	default:
+		s.lock.Lock()
+		s.expire()
+		stream, ok := s.streams[key]
+		if !ok || stream.busy {
+			s.lock.Unlock()
+			return nil, ErrUnknownStream
+		}
+		stream.busy = true
+		s.lock.Unlock()
+		return s.respond(key, stream, request.MaxChunks)
+	}
+}
+
+// open registers a new stream for [key] and marks it as busy.
+func (s *StreamingHandler) open(
+	ctx context.Context,
+	key streamKey,
+	deadline time.Time,
+	requestBytes []byte,
+) (*openStream, *common.AppError) {
+	s.lock.Lock()
+	s.expire()
+	if _, ok := s.streams[key]; ok {
+		s.lock.Unlock()
+		return nil, ErrUnexpected
+	}
+	if s.numStreams[key.nodeID] >= s.maxStreamsPerPeer {
+		s.lock.Unlock()
+		return nil, ErrTooManyStreams
+	}
+
+	// Reserve the stream before calling the handler so that the per-peer limit
+	// can't be exceeded by concurrent requests.
+	stream := &openStream{
+		busy:        true,
+		lastRequest: s.clock.Time(),
+	}
+	s.streams[key] = stream
+	s.numStreams[key.nodeID]++
+	s.lock.Unlock()
+
+	chunks, err := s.handler.AppRequestStream(ctx, key.nodeID, deadline, requestBytes)
+	if err != nil {
+		s.lock.Lock()
+		s.remove(key, stream)
+		s.lock.Unlock()
+		return nil, err
+	}
+	stream.chunks = chunks
+	return stream, nil
+}
+
+// respond reads up to [maxChunks] chunks from [stream].
+//
+// Invariant: [stream] must be marked as busy.
+func (s *StreamingHandler) respond(key streamKey, stream *openStream, maxChunks uint32) ([]byte, *common.AppError) {
+	var (
+		chunks       [][]byte
+		responseSize int
+		done         bool
+	)
+	for len(chunks) < int(max(maxChunks, 1)) {
+		chunk := stream.next
+		if !stream.hasNext {
+			if !stream.chunks.Next() {
+				done = true
+				break
+			}
+			chunk = stream.chunks.Value()
+		}
+		stream.next, stream.hasNext = nil, false
+
+		// Always send at least one chunk so that the stream makes progress.
+		if len(chunks) > 0 && responseSize+len(chunk) > s.maxResponseBytes {
+			stream.next, stream.hasNext = chunk, true
+			break
+		}
+		chunks = append(chunks, chunk)
+		responseSize += len(chunk)
+	}
+
+	s.lock.Lock()
+	if done || stream.cancelled {
+		s.remove(key, stream)
+	} else {
+		stream.busy = false
+		stream.lastRequest = s.clock.Time()
+		s.scheduleExpiry(s.maxIdleTime)
+	}
+	s.lock.Unlock()
+
+	s.log.Debug("responding to stream request",
+		zap.Stringer("nodeID", key.nodeID),
+		zap.Uint64("streamID", key.streamID),
+		zap.Int("numChunks", len(chunks)),
+		zap.Bool("done", done),
+	)
+	return marshalStreamResponse(chunks, done)
+}
+
+// expire closes the streams that haven't been continued within the idle
+// timeout.
+//
+// Invariant: [s.lock] must be held.
+func (s *StreamingHandler) expire() {
+	expiry := s.clock.Time().Add(-s.maxIdleTime)
+	for key, stream := range s.streams {
+		if stream.busy || !stream.lastRequest.Before(expiry) {
+			continue
+		}
+
+		s.log.Debug("closing idle stream",
+			zap.Stringer("nodeID", key.nodeID),
+			zap.Uint64("streamID", key.streamID),
+		)
+		s.remove(key, stream)
+	}
+}
+
+// scheduleExpiry makes sure that idle streams are closed within [delay], even
+// if no further requests are received.
+//
+// Invariant: [s.lock] must be held.
+func (s *StreamingHandler) scheduleExpiry(delay time.Duration) {
+	if s.expiryTimer != nil {
+		return
+	}
+	s.expiryTimer = time.AfterFunc(delay, s.expireIdle)
+}
+
+// expireIdle closes the idle streams and reschedules itself for the next
+// stream to become idle.
+func (s *StreamingHandler) expireIdle() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.expiryTimer = nil
+	s.expire()
+
+	var (
+		now       = s.clock.Time()
+		nextDelay time.Duration
+		hasIdle   bool
+	)
+	for _, stream := range s.streams {
+		if stream.busy {
+			continue
+		}
+		delay := max(stream.lastRequest.Add(s.maxIdleTime).Sub(now), 0)
+		if !hasIdle || delay < nextDelay {
+			nextDelay, hasIdle = delay, true
+		}
+	}
+	if hasIdle {
+		// Wait at least a millisecond to avoid spinning if the clock is
+		// behind the timer.
+		s.scheduleExpiry(max(nextDelay, time.Millisecond))
+	}
+}
+
+// remove closes the stream for [key].
+//
+// Invariant: [s.lock] must be held.
+func (s *StreamingHandler) remove(key streamKey, stream *openStream) {
+	if stream.chunks != nil {
+		stream.chunks.Release()
+	}
+	delete(s.streams, key)
+
+	numStreams := s.numStreams[key.nodeID] - 1
+	if numStreams == 0 {
+		delete(s.numStreams, key.nodeID)
+	} else {
+		s.numStreams[key.nodeID] = numStreams
+	}
+}
+
+func marshalStreamResponse(chunks [][]byte, done bool) ([]byte, *common.AppError) {
+	responseBytes, err := proto.Marshal(&sdk.StreamResponse{
+		Chunks: chunks,
+		Done:   done,
+	})
+	if err != nil {
+		return nil, ErrUnexpected
+	}
+	return responseBytes, nil
+}
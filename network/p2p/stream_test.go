@@ -0,0 +1,419 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/engine/enginetest"
+	"github.com/MetalBlockchain/metalgo/utils/iterator"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+var _ StreamHandler = (*testStreamHandler)(nil)
+
+type testStreamHandler struct {
+	chunks   [][]byte
+	err      *common.AppError
+	released int
+}
+
+func (t *testStreamHandler) AppRequestStream(context.Context, ids.NodeID, time.Time, []byte) (iterator.Iterator[[]byte], *common.AppError) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return &releaseCounter{
+		Iterator: iterator.FromSlice(t.chunks...),
+		released: &t.released,
+	}, nil
+}
+
+type releaseCounter struct {
+	iterator.Iterator[[]byte]
+	released *int
+}
+
+func (r *releaseCounter) Release() {
+	*r.released++
+	r.Iterator.Release()
+}
+
+type streamMessage struct {
+	requestID uint32
+	bytes     []byte
+	err       *common.AppError
+}
+
+type streamResult struct {
+	chunks [][]byte
+	done   bool
+	err    error
+}
+
+// streamTest connects a client to a StreamingHandler. Messages are only
+// delivered when [deliver] is called.
+type streamTest struct {
+	client        *Client
+	clientNetwork *Network
+	serverNetwork *Network
+	handler       *StreamingHandler
+
+	requests  chan streamMessage
+	responses chan streamMessage
+	results   []streamResult
+}
+
+func newStreamTest(t *testing.T, handler StreamHandler, maxResponseBytes int) *streamTest {
+	require := require.New(t)
+
+	s := &streamTest{
+		handler:   NewStreamingHandler(handler, logging.NoLog{}, 1, time.Minute, maxResponseBytes),
+		requests:  make(chan streamMessage, 8),
+		responses: make(chan streamMessage, 8),
+	}
+
+	clientSender := &enginetest.Sender{
+		SendAppRequestF: func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, bytes []byte) error {
+			s.requests <- streamMessage{requestID: requestID, bytes: bytes}
+			return nil
+		},
+	}
+	serverSender := &enginetest.Sender{
+		SendAppResponseF: func(_ context.Context, _ ids.NodeID, requestID uint32, bytes []byte) error {
+			s.responses <- streamMessage{requestID: requestID, bytes: bytes}
+			return nil
+		},
+		SendAppErrorF: func(_ context.Context, _ ids.NodeID, requestID uint32, code int32, message string) error {
+			s.responses <- streamMessage{
+				requestID: requestID,
+				err: &common.AppError{
+					Code:    code,
+					Message: message,
+				},
+			}
+			return nil
+		},
+	}
+
+	var err error
+	s.clientNetwork, err = NewNetwork(logging.NoLog{}, clientSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	s.serverNetwork, err = NewNetwork(logging.NoLog{}, serverSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(s.serverNetwork.AddHandler(0, s.handler))
+
+	s.client = s.clientNetwork.NewClient(0)
+	return s
+}
+
+func (s *streamTest) onChunks(_ context.Context, _ ids.NodeID, chunks [][]byte, done bool, err error) {
+	s.results = append(s.results, streamResult{
+		chunks: chunks,
+		done:   done,
+		err:    err,
+	})
+}
+
+// deliver sends the next pending request to the server and its response back
+// to the client. Returns false if there wasn't a pending request.
+func (s *streamTest) deliver(t *testing.T) bool {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var request streamMessage
+	select {
+	case request = <-s.requests:
+	default:
+		return false
+	}
+
+	require.NoError(s.serverNetwork.AppRequest(ctx, ids.EmptyNodeID, request.requestID, time.Time{}, request.bytes))
+	response := <-s.responses
+	if response.err != nil {
+		require.NoError(s.clientNetwork.AppRequestFailed(ctx, ids.EmptyNodeID, response.requestID, response.err))
+	} else {
+		require.NoError(s.clientNetwork.AppResponse(ctx, ids.EmptyNodeID, response.requestID, response.bytes))
+	}
+	return true
+}
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name             string
+		chunks           [][]byte
+		maxChunks        uint32
+		maxResponseBytes int
+		expected         []streamResult
+	}{
+		{
+			name:             "empty",
+			maxChunks:        2,
+			maxResponseBytes: 1024,
+			expected: []streamResult{
+				{done: true},
+			},
+		},
+		{
+			name:             "limited by max chunks",
+			chunks:           [][]byte{{0}, {1}, {2}, {3}, {4}},
+			maxChunks:        2,
+			maxResponseBytes: 1024,
+			expected: []streamResult{
+				{chunks: [][]byte{{0}, {1}}},
+				{chunks: [][]byte{{2}, {3}}},
+				{chunks: [][]byte{{4}}, done: true},
+			},
+		},
+		{
+			name:             "limited by max response bytes",
+			chunks:           [][]byte{{0, 0}, {1}, {2, 2, 2}, {3}},
+			maxChunks:        10,
+			maxResponseBytes: 3,
+			expected: []streamResult{
+				{chunks: [][]byte{{0, 0}, {1}}},
+				{chunks: [][]byte{{2, 2, 2}}},
+				{chunks: [][]byte{{3}}, done: true},
+			},
+		},
+		{
+			name:             "chunk larger than max response bytes",
+			chunks:           [][]byte{{0, 0, 0, 0}, {1}},
+			maxChunks:        10,
+			maxResponseBytes: 3,
+			expected: []streamResult{
+				{chunks: [][]byte{{0, 0, 0, 0}}},
+				{chunks: [][]byte{{1}}, done: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			handler := &testStreamHandler{
+				chunks: tt.chunks,
+			}
+			s := newStreamTest(t, handler, tt.maxResponseBytes)
+
+			_, err := s.client.AppRequestStream(context.Background(), ids.EmptyNodeID, []byte("request"), tt.maxChunks, s.onChunks)
+			require.NoError(err)
+			for s.deliver(t) {
+			}
+
+			require.Len(s.results, len(tt.expected))
+			for i, expected := range tt.expected {
+				require.Len(s.results[i].chunks, len(expected.chunks))
+				for j, chunk := range expected.chunks {
+					require.Equal(chunk, s.results[i].chunks[j])
+				}
+				require.Equal(expected.done, s.results[i].done)
+				require.NoError(s.results[i].err)
+			}
+			require.Equal(1, handler.released)
+			require.Empty(s.handler.streams)
+		})
+	}
+}
+
+func TestStreamHandlerError(t *testing.T) {
+	require := require.New(t)
+
+	s := newStreamTest(t, &testStreamHandler{err: errFoo}, 1024)
+
+	_, err := s.client.AppRequestStream(context.Background(), ids.EmptyNodeID, nil, 1, s.onChunks)
+	require.NoError(err)
+	require.True(s.deliver(t))
+	require.False(s.deliver(t))
+
+	require.Len(s.results, 1)
+	require.True(s.results[0].done)
+	require.ErrorIs(s.results[0].err, errFoo)
+	require.Empty(s.handler.streams)
+}
+
+func TestStreamCancel(t *testing.T) {
+	require := require.New(t)
+
+	handler := &testStreamHandler{
+		chunks: [][]byte{{0}, {1}, {2}},
+	}
+	s := newStreamTest(t, handler, 1024)
+
+	stream, err := s.client.AppRequestStream(context.Background(), ids.EmptyNodeID, nil, 1, s.onChunks)
+	require.NoError(err)
+	require.True(s.deliver(t))
+	require.Len(s.results, 1)
+	require.Len(s.handler.streams, 1)
+
+	// The request for the next chunk is in flight when the stream is
+	// cancelled, so its response must be dropped.
+	require.NoError(stream.Cancel(context.Background()))
+	require.True(s.deliver(t))
+	require.True(s.deliver(t))
+	require.False(s.deliver(t))
+
+	require.Len(s.results, 1)
+	require.Equal(1, handler.released)
+	require.Empty(s.handler.streams)
+}
+
+func TestStreamContextCancelled(t *testing.T) {
+	require := require.New(t)
+
+	handler := &testStreamHandler{
+		chunks: [][]byte{{0}, {1}, {2}},
+	}
+	s := newStreamTest(t, handler, 1024)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := s.client.AppRequestStream(ctx, ids.EmptyNodeID, nil, 1, s.onChunks)
+	require.NoError(err)
+	require.True(s.deliver(t))
+
+	cancel()
+	require.Eventually(func() bool {
+		return len(s.requests) == 2
+	}, time.Second, time.Millisecond)
+	for s.deliver(t) {
+	}
+
+	require.Len(s.results, 1)
+	require.Equal(1, handler.released)
+	require.Empty(s.handler.streams)
+}
+
+func TestStreamTooManyStreams(t *testing.T) {
+	require := require.New(t)
+
+	handler := &testStreamHandler{
+		chunks: [][]byte{{0}, {1}},
+	}
+	s := newStreamTest(t, handler, 1024)
+
+	_, err := s.client.AppRequestStream(context.Background(), ids.EmptyNodeID, nil, 1, s.onChunks)
+	require.NoError(err)
+	require.True(s.deliver(t))
+
+	var result streamResult
+	_, err = s.client.AppRequestStream(
+		context.Background(),
+		ids.EmptyNodeID,
+		nil,
+		1,
+		func(_ context.Context, _ ids.NodeID, chunks [][]byte, done bool, err error) {
+			result = streamResult{
+				chunks: chunks,
+				done:   done,
+				err:    err,
+			}
+		},
+	)
+	require.NoError(err)
+
+	// Deliver the request for the first stream's second chunk and the request
+	// to open the second stream.
+	require.True(s.deliver(t))
+	require.True(s.deliver(t))
+
+	require.True(result.done)
+	require.ErrorIs(result.err, ErrTooManyStreams)
+}
+
+func TestStreamIdleExpiry(t *testing.T) {
+	require := require.New(t)
+
+	handler := &testStreamHandler{
+		chunks: [][]byte{{0}, {1}},
+	}
+	s := newStreamTest(t, handler, 1024)
+
+	now := time.Now()
+	s.handler.clock.Set(now)
+
+	_, err := s.client.AppRequestStream(context.Background(), ids.EmptyNodeID, nil, 1, s.onChunks)
+	require.NoError(err)
+	require.True(s.deliver(t))
+
+	s.handler.clock.Set(now.Add(time.Minute + time.Second))
+	require.True(s.deliver(t))
+	require.False(s.deliver(t))
+
+	require.Len(s.results, 2)
+	require.True(s.results[1].done)
+	require.ErrorIs(s.results[1].err, ErrUnknownStream)
+	require.Equal(1, handler.released)
+	require.Empty(s.handler.streams)
+}
+
+func TestStreamIdleExpiryWithoutRequests(t *testing.T) {
+	require := require.New(t)
+
+	handler := &testStreamHandler{
+		chunks: [][]byte{{0}, {1}},
+	}
+	streamingHandler := NewStreamingHandler(handler, logging.NoLog{}, 1, time.Millisecond, 1024)
+
+	requestBytes, err := proto.Marshal(&sdk.StreamRequest{
+		Open:      true,
+		MaxChunks: 1,
+	})
+	require.NoError(err)
+	_, appErr := streamingHandler.AppRequest(context.Background(), ids.EmptyNodeID, time.Time{}, requestBytes)
+	require.Nil(appErr)
+
+	// The idle stream must be closed even though no further requests arrive.
+	require.Eventually(func() bool {
+		streamingHandler.lock.Lock()
+		defer streamingHandler.lock.Unlock()
+
+		return len(streamingHandler.streams) == 0
+	}, time.Second, time.Millisecond)
+	require.Equal(1, handler.released)
+}
+
+func TestStreamIDsRandomlySeeded(t *testing.T) {
+	require := require.New(t)
+
+	network0, err := NewNetwork(logging.NoLog{}, &enginetest.Sender{}, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	network1, err := NewNetwork(logging.NoLog{}, &enginetest.Sender{}, prometheus.NewRegistry(), "")
+	require.NoError(err)
+
+	// A restarted node must not reuse the stream IDs of its previous run.
+	require.NotEqual(network0.router.streamID, network1.router.streamID)
+}
+
+func TestStreamTimeout(t *testing.T) {
+	require := require.New(t)
+
+	s := newStreamTest(t, &testStreamHandler{}, 1024)
+
+	_, err := s.client.AppRequestStream(context.Background(), ids.EmptyNodeID, nil, 1, s.onChunks)
+	require.NoError(err)
+
+	// Timeouts are reported by the timeout manager as a failed request.
+	request := <-s.requests
+	require.NoError(s.clientNetwork.AppRequestFailed(context.Background(), ids.EmptyNodeID, request.requestID, common.ErrTimeout))
+
+	require.Len(s.results, 1)
+	require.True(s.results[0].done)
+	require.ErrorIs(s.results[0].err, common.ErrTimeout)
+}
+
+func TestAppRequestStreamInvalidMaxChunks(t *testing.T) {
+	s := newStreamTest(t, &testStreamHandler{}, 1024)
+
+	_, err := s.client.AppRequestStream(context.Background(), ids.EmptyNodeID, nil, 0, s.onChunks)
+	require.ErrorIs(t, err, errInvalidMaxChunks)
+}
@@ -0,0 +1,329 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/trace"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	methodLabel = "method"
+	ioLabel     = "io"
+	codeLabel   = "code"
+
+	sentIO     = "sent"
+	receivedIO = "received"
+
+	// successCode is the code label of requests that didn't fail
+	successCode = "0"
+)
+
+var (
+	_ Handler = (*TypedHandler)(nil)
+
+	ErrExistingMethod = errors.New("existing method")
+)
+
+// TypedMetrics tracks the requests of each method of typed handlers and
+// clients. A single instance may be shared by multiple handlers and clients.
+type TypedMetrics struct {
+	requestTime  *prometheus.GaugeVec
+	requestCount *prometheus.CounterVec
+}
+
+// NewTypedMetrics returns a new instance of TypedMetrics
+func NewTypedMetrics(registerer prometheus.Registerer, namespace string) (*TypedMetrics, error) {
+	m := &TypedMetrics{
+		requestTime: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "rpc_time",
+				Help:      "time spent on requests (ns)",
+			},
+			[]string{methodLabel, ioLabel},
+		),
+		requestCount: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "rpc_count",
+				Help:      "request count (n)",
+			},
+			[]string{methodLabel, ioLabel, codeLabel},
+		),
+	}
+	err := errors.Join(
+		registerer.Register(m.requestTime),
+		registerer.Register(m.requestCount),
+	)
+	return m, err
+}
+
+func (m *TypedMetrics) observe(method string, io string, err *common.AppError, start time.Time) {
+	code := successCode
+	if err != nil {
+		code = strconv.FormatInt(int64(err.Code), 10)
+	}
+
+	m.requestTime.With(prometheus.Labels{
+		methodLabel: method,
+		ioLabel:     io,
+	}).Add(float64(time.Since(start)))
+	m.requestCount.With(prometheus.Labels{
+		methodLabel: method,
+		ioLabel:     io,
+		codeLabel:   code,
+	}).Inc()
+}
+
+// TypedHandlerFunc handles a request for a single method of a TypedHandler.
+// Returned errors that are a *common.AppError are sent to the requesting peer
+// as is. Any other error is sent as ErrUnexpected.
+type TypedHandlerFunc[Req, Resp proto.Message] func(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	deadline time.Time,
+	request Req,
+) (Resp, error)
+
+type typedMethod struct {
+	name   string
+	handle func(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		deadline time.Time,
+		requestBytes []byte,
+	) ([]byte, error)
+}
+
+// NewTypedHandler returns a Handler that dispatches requests issued by
+// TypedClient to the methods added with AddTypedMethod.
+func NewTypedHandler(log logging.Logger, tracer trace.Tracer, metrics *TypedMetrics) *TypedHandler {
+	return &TypedHandler{
+		log:     log,
+		tracer:  tracer,
+		metrics: metrics,
+		methods: make(map[uint64]*typedMethod),
+	}
+}
+
+// TypedHandler multiplexes the methods of a protocol over a single Handler.
+type TypedHandler struct {
+	NoOpHandler
+
+	log     logging.Logger
+	tracer  trace.Tracer
+	metrics *TypedMetrics
+
+	lock    sync.RWMutex
+	methods map[uint64]*typedMethod
+}
+
+// AddTypedMethod registers [handler] to serve requests for [methodID]. [name]
+// is used to label metrics and traces.
+func AddTypedMethod[Req, Resp proto.Message](
+	h *TypedHandler,
+	methodID uint64,
+	name string,
+	handler TypedHandlerFunc[Req, Resp],
+) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if _, ok := h.methods[methodID]; ok {
+		return fmt.Errorf("failed to register method id %d: %w", methodID, ErrExistingMethod)
+	}
+
+	h.methods[methodID] = &typedMethod{
+		name: name,
+		handle: func(
+			ctx context.Context,
+			nodeID ids.NodeID,
+			deadline time.Time,
+			requestBytes []byte,
+		) ([]byte, error) {
+			request := newMessage[Req]()
+			if err := proto.Unmarshal(requestBytes, request); err != nil {
+				return nil, err
+			}
+
+			response, err := handler(ctx, nodeID, deadline, request)
+			if err != nil {
+				return nil, err
+			}
+			return proto.Marshal(response)
+		},
+	}
+	return nil
+}
+
+func (h *TypedHandler) AppRequest(ctx context.Context, nodeID ids.NodeID, deadline time.Time, requestBytes []byte) ([]byte, *common.AppError) {
+	start := time.Now()
+	methodID, bytesRead := binary.Uvarint(requestBytes)
+	if bytesRead <= 0 {
+		return nil, ErrUnknownMethod
+	}
+
+	h.lock.RLock()
+	method, ok := h.methods[methodID]
+	h.lock.RUnlock()
+	if !ok {
+		return nil, ErrUnknownMethod
+	}
+
+	ctx, span := h.tracer.Start(ctx, "p2p.TypedHandler.AppRequest", oteltrace.WithAttributes(
+		attribute.String("method", method.name),
+		attribute.Stringer("nodeID", nodeID),
+	))
+	defer span.End()
+
+	responseBytes, err := method.handle(ctx, nodeID, deadline, requestBytes[bytesRead:])
+	appErr := toAppError(err)
+	if appErr != nil {
+		span.SetAttributes(attribute.Int64("code", int64(appErr.Code)))
+		h.log.Debug("failed to handle request",
+			zap.String("method", method.name),
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		responseBytes = nil
+	}
+
+	h.metrics.observe(method.name, receivedIO, appErr, start)
+	return responseBytes, appErr
+}
+
+// TypedResponseCallback is called upon receiving the response to a request
+// issued by TypedClient.
+// Callers should check [err] to see whether the request failed or not.
+type TypedResponseCallback[Resp proto.Message] func(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	response Resp,
+	err error,
+)
+
+// NewTypedClient returns a client that issues requests for [methodID] to a
+// TypedHandler. [name] is used to label metrics and traces.
+func NewTypedClient[Req, Resp proto.Message](
+	client *Client,
+	methodID uint64,
+	name string,
+	tracer trace.Tracer,
+	metrics *TypedMetrics,
+) *TypedClient[Req, Resp] {
+	return &TypedClient[Req, Resp]{
+		client:       client,
+		methodPrefix: ProtocolPrefix(methodID),
+		name:         name,
+		tracer:       tracer,
+		metrics:      metrics,
+	}
+}
+
+// TypedClient issues requests for a single method of a TypedHandler.
+type TypedClient[Req, Resp proto.Message] struct {
+	client       *Client
+	methodPrefix []byte
+	name         string
+	tracer       trace.Tracer
+	metrics      *TypedMetrics
+}
+
+// AppRequestAny issues a request to an arbitrary node decided by Client.
+func (c *TypedClient[Req, Resp]) AppRequestAny(
+	ctx context.Context,
+	request Req,
+	onResponse TypedResponseCallback[Resp],
+) error {
+	sampled := c.client.options.nodeSampler.Sample(ctx, 1)
+	if len(sampled) != 1 {
+		return ErrNoPeers
+	}
+
+	return c.AppRequest(ctx, set.Of(sampled...), request, onResponse)
+}
+
+// AppRequest issues a request to each node in [nodeIDs].
+// [onResponse] is invoked once for each node upon an error or a response.
+func (c *TypedClient[Req, Resp]) AppRequest(
+	ctx context.Context,
+	nodeIDs set.Set[ids.NodeID],
+	request Req,
+	onResponse TypedResponseCallback[Resp],
+) error {
+	requestBytes, err := proto.Marshal(request)
+	if err != nil {
+		return err
+	}
+	requestBytes = PrefixMessage(c.methodPrefix, requestBytes)
+
+	for nodeID := range nodeIDs {
+		// The span is ended once the response, or failure, is received.
+		start := time.Now()
+		_, span := c.tracer.Start(ctx, "p2p.TypedClient.AppRequest", oteltrace.WithAttributes(
+			attribute.String("method", c.name),
+			attribute.Stringer("nodeID", nodeID),
+		))
+		err := c.client.AppRequest(
+			ctx,
+			set.Of(nodeID),
+			requestBytes,
+			func(ctx context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+				defer span.End()
+
+				response := newMessage[Resp]()
+				if err == nil {
+					err = proto.Unmarshal(responseBytes, response)
+				}
+
+				c.metrics.observe(c.name, sentIO, toAppError(err), start)
+				onResponse(ctx, nodeID, response, err)
+			},
+		)
+		if err != nil {
+			span.End()
+			return err
+		}
+	}
+	return nil
+}
+
+// newMessage returns a new, empty instance of the message type [T].
+func newMessage[T proto.Message]() T {
+	var zero T
+	return zero.ProtoReflect().New().Interface().(T)
+}
+
+// toAppError returns [err] as an AppError. Errors that aren't an AppError are
+// treated as ErrUnexpected.
+func toAppError(err error) *common.AppError {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *common.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return ErrUnexpected
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/engine/enginetest"
+	"github.com/MetalBlockchain/metalgo/trace"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+const (
+	signMethodID  = 0
+	proveMethodID = 1
+)
+
+type typedResult struct {
+	response *sdk.SignatureResponse
+	err      error
+}
+
+// newTypedTest returns a client for [methodID] that sends requests to
+// [handler]. Requests and responses are delivered asynchronously.
+func newTypedTest(
+	t *testing.T,
+	handler *TypedHandler,
+	methodID uint64,
+	metrics *TypedMetrics,
+) *TypedClient[*sdk.SignatureRequest, *sdk.SignatureResponse] {
+	require := require.New(t)
+
+	var (
+		clientSender  = &enginetest.Sender{}
+		serverSender  = &enginetest.Sender{}
+		clientNetwork *Network
+		serverNetwork *Network
+		err           error
+	)
+	clientSender.SendAppRequestF = func(ctx context.Context, _ set.Set[ids.NodeID], requestID uint32, bytes []byte) error {
+		go func() {
+			require.NoError(serverNetwork.AppRequest(ctx, ids.EmptyNodeID, requestID, time.Time{}, bytes))
+		}()
+		return nil
+	}
+	serverSender.SendAppResponseF = func(ctx context.Context, _ ids.NodeID, requestID uint32, bytes []byte) error {
+		go func() {
+			require.NoError(clientNetwork.AppResponse(ctx, ids.EmptyNodeID, requestID, bytes))
+		}()
+		return nil
+	}
+	serverSender.SendAppErrorF = func(ctx context.Context, _ ids.NodeID, requestID uint32, code int32, message string) error {
+		go func() {
+			require.NoError(clientNetwork.AppRequestFailed(ctx, ids.EmptyNodeID, requestID, &common.AppError{
+				Code:    code,
+				Message: message,
+			}))
+		}()
+		return nil
+	}
+
+	clientNetwork, err = NewNetwork(logging.NoLog{}, clientSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	serverNetwork, err = NewNetwork(logging.NoLog{}, serverSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(serverNetwork.AddHandler(0, handler))
+
+	return NewTypedClient[*sdk.SignatureRequest, *sdk.SignatureResponse](
+		clientNetwork.NewClient(0),
+		methodID,
+		"sign",
+		trace.Noop,
+		metrics,
+	)
+}
+
+func TestTypedRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		methodID      uint64
+		handlerErr    error
+		expectedErr   error
+		expectedCode  string
+		expectSuccess bool
+	}{
+		{
+			name:          "success",
+			methodID:      signMethodID,
+			expectSuccess: true,
+			expectedCode:  successCode,
+		},
+		{
+			name:         "app error",
+			methodID:     signMethodID,
+			handlerErr:   errFoo,
+			expectedErr:  errFoo,
+			expectedCode: "123",
+		},
+		{
+			name:         "wrapped app error",
+			methodID:     signMethodID,
+			handlerErr:   errors.Join(errors.New("context"), errFoo),
+			expectedErr:  errFoo,
+			expectedCode: "123",
+		},
+		{
+			name:         "unexpected error",
+			methodID:     signMethodID,
+			handlerErr:   errors.New("unexpected"),
+			expectedErr:  ErrUnexpected,
+			expectedCode: "-1",
+		},
+		{
+			name:         "unknown method",
+			methodID:     2,
+			expectedErr:  ErrUnknownMethod,
+			expectedCode: "-8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			metrics, err := NewTypedMetrics(prometheus.NewRegistry(), "")
+			require.NoError(err)
+
+			handler := NewTypedHandler(logging.NoLog{}, trace.Noop, metrics)
+			require.NoError(AddTypedMethod(
+				handler,
+				signMethodID,
+				"sign",
+				func(_ context.Context, _ ids.NodeID, _ time.Time, request *sdk.SignatureRequest) (*sdk.SignatureResponse, error) {
+					if tt.handlerErr != nil {
+						return nil, tt.handlerErr
+					}
+					return &sdk.SignatureResponse{
+						Signature: append(request.Message, request.Justification...),
+					}, nil
+				},
+			))
+			require.NoError(AddTypedMethod(
+				handler,
+				proveMethodID,
+				"prove",
+				func(context.Context, ids.NodeID, time.Time, *sdk.PullGossipRequest) (*sdk.PullGossipResponse, error) {
+					return &sdk.PullGossipResponse{}, nil
+				},
+			))
+
+			client := newTypedTest(t, handler, tt.methodID, metrics)
+
+			done := make(chan typedResult, 1)
+			require.NoError(client.AppRequest(
+				context.Background(),
+				set.Of(ids.EmptyNodeID),
+				&sdk.SignatureRequest{
+					Message:       []byte("foo"),
+					Justification: []byte("bar"),
+				},
+				func(_ context.Context, _ ids.NodeID, response *sdk.SignatureResponse, err error) {
+					done <- typedResult{
+						response: response,
+						err:      err,
+					}
+				},
+			))
+
+			result := <-done
+			require.ErrorIs(result.err, tt.expectedErr)
+			if tt.expectSuccess {
+				require.Equal([]byte("foobar"), result.response.Signature)
+			}
+
+			sentLabels := prometheus.Labels{
+				methodLabel: "sign",
+				ioLabel:     sentIO,
+				codeLabel:   tt.expectedCode,
+			}
+			require.Equal(1., testutil.ToFloat64(metrics.requestCount.With(sentLabels)))
+		})
+	}
+}
+
+func TestAddTypedMethodExisting(t *testing.T) {
+	require := require.New(t)
+
+	metrics, err := NewTypedMetrics(prometheus.NewRegistry(), "")
+	require.NoError(err)
+
+	handler := NewTypedHandler(logging.NoLog{}, trace.Noop, metrics)
+	f := func(context.Context, ids.NodeID, time.Time, *sdk.SignatureRequest) (*sdk.SignatureResponse, error) {
+		return &sdk.SignatureResponse{}, nil
+	}
+	require.NoError(AddTypedMethod(handler, signMethodID, "sign", f))
+	err = AddTypedMethod(handler, signMethodID, "sign", f)
+	require.ErrorIs(err, ErrExistingMethod)
+}
@@ -58,4 +58,9 @@ type Config struct {
 
 	// Signs my IP so I can send my signed IP address in the Handshake message
 	IPSigner *IPSigner
+
+	// MyQUICPort is the UDP port that this node accepts QUIC connections on.
+	// It is sent in the Handshake message so that the peer can dial this node
+	// over QUIC. If 0, this node only accepts TCP connections.
+	MyQUICPort uint16
 }
@@ -13,14 +13,15 @@ import (
 )
 
 type Info struct {
-	IP             netip.AddrPort  `json:"ip"`
-	PublicIP       netip.AddrPort  `json:"publicIP,omitempty"`
-	ID             ids.NodeID      `json:"nodeID"`
-	Version        string          `json:"version"`
-	LastSent       time.Time       `json:"lastSent"`
-	LastReceived   time.Time       `json:"lastReceived"`
-	ObservedUptime json.Uint32     `json:"observedUptime"`
-	TrackedSubnets set.Set[ids.ID] `json:"trackedSubnets"`
-	SupportedACPs  set.Set[uint32] `json:"supportedACPs"`
-	ObjectedACPs   set.Set[uint32] `json:"objectedACPs"`
+	IP                  netip.AddrPort   `json:"ip"`
+	PublicIP            netip.AddrPort   `json:"publicIP,omitempty"`
+	AdditionalPublicIPs []netip.AddrPort `json:"additionalPublicIPs,omitempty"`
+	ID                  ids.NodeID       `json:"nodeID"`
+	Version             string           `json:"version"`
+	LastSent            time.Time        `json:"lastSent"`
+	LastReceived        time.Time        `json:"lastReceived"`
+	ObservedUptime      json.Uint32      `json:"observedUptime"`
+	TrackedSubnets      set.Set[ids.ID]  `json:"trackedSubnets"`
+	SupportedACPs       set.Set[uint32]  `json:"supportedACPs"`
+	ObjectedACPs        set.Set[uint32]  `json:"objectedACPs"`
 }
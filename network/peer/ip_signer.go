@@ -15,27 +15,34 @@ import (
 
 // IPSigner will return a signedIP for the current value of our dynamic IP.
 type IPSigner struct {
-	ip        *utils.Atomic[netip.AddrPort]
-	clock     mockable.Clock
-	tlsSigner crypto.Signer
-	blsSigner *bls.SecretKey
+	ip *utils.Atomic[netip.AddrPort]
+	// additionalIPs are static IPs, other than [ip], that we can be reached on.
+	additionalIPs []netip.AddrPort
+	clock         mockable.Clock
+	tlsSigner     crypto.Signer
+	blsSigner     *bls.SecretKey
 
-	// Must be held while accessing [signedIP]
+	// Must be held while accessing [signedIPs]
 	signedIPLock sync.RWMutex
-	// Note that the values in [*signedIP] are constants and can be inspected
+	// Note that the values in [signedIPs] are constants and can be inspected
 	// without holding [signedIPLock].
-	signedIP *SignedIP
+	//
+	// If non-nil, the first entry is the signed value of [ip] and the remaining
+	// entries are the signed values of [additionalIPs].
+	signedIPs []*SignedIP
 }
 
 func NewIPSigner(
 	ip *utils.Atomic[netip.AddrPort],
+	additionalIPs []netip.AddrPort,
 	tlsSigner crypto.Signer,
 	blsSigner *bls.SecretKey,
 ) *IPSigner {
 	return &IPSigner{
-		ip:        ip,
-		tlsSigner: tlsSigner,
-		blsSigner: blsSigner,
+		ip:            ip,
+		additionalIPs: additionalIPs,
+		tlsSigner:     tlsSigner,
+		blsSigner:     blsSigner,
 	}
 }
 
@@ -45,38 +52,59 @@ func NewIPSigner(
 //
 // It's safe for multiple goroutines to concurrently call GetSignedIP.
 func (s *IPSigner) GetSignedIP() (*SignedIP, error) {
+	signedIPs, err := s.GetSignedIPs()
+	if err != nil {
+		return nil, err
+	}
+	return signedIPs[0], nil
+}
+
+// GetSignedIPs returns the signedIP of the current value of the provided
+// dynamicIP followed by the signedIPs of the additional IPs. All of the
+// returned IPs are signed at the same timestamp. If the dynamicIP hasn't
+// changed since the prior call to GetSignedIPs, then the same [SignedIP]s will
+// be returned.
+//
+// It's safe for multiple goroutines to concurrently call GetSignedIPs.
+func (s *IPSigner) GetSignedIPs() ([]*SignedIP, error) {
 	// Optimistically, the IP should already be signed. By grabbing a read lock
 	// here we enable full concurrency of new connections.
 	s.signedIPLock.RLock()
-	signedIP := s.signedIP
+	signedIPs := s.signedIPs
 	s.signedIPLock.RUnlock()
 	ip := s.ip.Get()
-	if signedIP != nil && signedIP.AddrPort == ip {
-		return signedIP, nil
+	if signedIPs != nil && signedIPs[0].AddrPort == ip {
+		return signedIPs, nil
 	}
 
 	// If our current IP hasn't been signed yet - then we should sign it.
 	s.signedIPLock.Lock()
 	defer s.signedIPLock.Unlock()
 
-	// It's possible that multiple threads read [n.signedIP] as incorrect at the
-	// same time, we should verify that we are the first thread to attempt to
-	// update it.
-	signedIP = s.signedIP
-	if signedIP != nil && signedIP.AddrPort == ip {
-		return signedIP, nil
+	// It's possible that multiple threads read [n.signedIPs] as incorrect at
+	// the same time, we should verify that we are the first thread to attempt
+	// to update it.
+	signedIPs = s.signedIPs
+	if signedIPs != nil && signedIPs[0].AddrPort == ip {
+		return signedIPs, nil
 	}
 
-	// We should now sign our new IP at the current timestamp.
-	unsignedIP := UnsignedIP{
-		AddrPort:  ip,
-		Timestamp: s.clock.Unix(),
-	}
-	signedIP, err := unsignedIP.Sign(s.tlsSigner, s.blsSigner)
-	if err != nil {
-		return nil, err
+	// We should now sign our new IPs at the current timestamp. The additional
+	// IPs are re-signed so that all of our IPs share the same timestamp.
+	timestamp := s.clock.Unix()
+	signedIPs = make([]*SignedIP, 0, 1+len(s.additionalIPs))
+	for _, addrPort := range append([]netip.AddrPort{ip}, s.additionalIPs...) {
+		unsignedIP := UnsignedIP{
+			AddrPort:  addrPort,
+			Timestamp: timestamp,
+		}
+		signedIP, err := unsignedIP.Sign(s.tlsSigner, s.blsSigner)
+		if err != nil {
+			return nil, err
+		}
+		signedIPs = append(signedIPs, signedIP)
 	}
 
-	s.signedIP = signedIP
-	return s.signedIP, nil
+	s.signedIPs = signedIPs
+	return s.signedIPs, nil
 }
@@ -31,7 +31,7 @@ func TestIPSigner(t *testing.T) {
 	blsKey, err := bls.NewSecretKey()
 	require.NoError(err)
 
-	s := NewIPSigner(dynIP, tlsKey, blsKey)
+	s := NewIPSigner(dynIP, nil, tlsKey, blsKey)
 
 	s.clock.Set(time.Unix(10, 0))
 
@@ -59,3 +59,58 @@ func TestIPSigner(t *testing.T) {
 	require.Equal(uint64(11), signedIP3.Timestamp)
 	require.NotEqual(signedIP2.TLSSignature, signedIP3.TLSSignature)
 }
+
+func TestIPSignerAdditionalIPs(t *testing.T) {
+	require := require.New(t)
+
+	dynIP := utils.NewAtomic(netip.AddrPortFrom(
+		netip.AddrFrom4([4]byte{1, 2, 3, 4}),
+		9651,
+	))
+	additionalIP := netip.AddrPortFrom(
+		netip.MustParseAddr("2001:db8::1"),
+		9651,
+	)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	cert, err := staking.ParseCertificate(tlsCert.Leaf.Raw)
+	require.NoError(err)
+
+	tlsKey := tlsCert.PrivateKey.(crypto.Signer)
+	blsKey, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	s := NewIPSigner(dynIP, []netip.AddrPort{additionalIP}, tlsKey, blsKey)
+
+	s.clock.Set(time.Unix(10, 0))
+
+	signedIPs1, err := s.GetSignedIPs()
+	require.NoError(err)
+	require.Len(signedIPs1, 2)
+	require.Equal(dynIP.Get(), signedIPs1[0].AddrPort)
+	require.Equal(additionalIP, signedIPs1[1].AddrPort)
+	for _, signedIP := range signedIPs1 {
+		require.Equal(uint64(10), signedIP.Timestamp)
+		require.NoError(signedIP.Verify(cert, time.Unix(10, 0)))
+	}
+
+	s.clock.Set(time.Unix(11, 0))
+
+	// Changing the dynamic IP re-signs all of the IPs at the new timestamp.
+	dynIP.Set(netip.AddrPortFrom(
+		netip.AddrFrom4([4]byte{5, 6, 7, 8}),
+		9651,
+	))
+
+	signedIPs2, err := s.GetSignedIPs()
+	require.NoError(err)
+	require.Len(signedIPs2, 2)
+	require.Equal(dynIP.Get(), signedIPs2[0].AddrPort)
+	require.Equal(additionalIP, signedIPs2[1].AddrPort)
+	for _, signedIP := range signedIPs2 {
+		require.Equal(uint64(11), signedIP.Timestamp)
+		require.NoError(signedIP.Verify(cert, time.Unix(11, 0)))
+	}
+	require.NotEqual(signedIPs1[1].TLSSignature, signedIPs2[1].TLSSignature)
+}
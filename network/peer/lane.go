@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+)
+
+const (
+	ConsensusLane Lane = iota
+	BootstrapLane
+	AppLane
+
+	numLanes = int(AppLane) + 1
+)
+
+var (
+	errZeroLaneWeight     = errors.New("lane weight must be > 0")
+	errLaneMaxBytesTooLow = fmt.Errorf("lane max bytes must be >= %d", constants.DefaultMaxMessageSize)
+)
+
+// Lane is the class of an outbound message. Each lane is queued separately so
+// that a burst of messages in one lane can not delay the messages of another
+// lane.
+type Lane int
+
+func (l Lane) String() string {
+	switch l {
+	case ConsensusLane:
+		return "consensus"
+	case BootstrapLane:
+		return "bootstrap"
+	case AppLane:
+		return "app"
+	default:
+		return "unknown"
+	}
+}
+
+// LaneConn is a connection that sends each lane over a separate stream, so
+// that the delivery of one lane isn't delayed by the other lanes.
+type LaneConn interface {
+	net.Conn
+
+	// LaneWriter returns the writer that messages in [lane] should be written
+	// to. Writes are sent immediately, so callers should buffer them.
+	LaneWriter(lane Lane) io.Writer
+}
+
+// LaneOf returns the lane that messages with [op] are sent in.
+//
+// Handshake messages are sent in the consensus lane as delaying them can cause
+// the connection to be dropped.
+func LaneOf(op message.Op) Lane {
+	switch op {
+	case message.GetStateSummaryFrontierOp,
+		message.StateSummaryFrontierOp,
+		message.GetAcceptedStateSummaryOp,
+		message.AcceptedStateSummaryOp,
+		message.GetAcceptedFrontierOp,
+		message.AcceptedFrontierOp,
+		message.GetAcceptedOp,
+		message.AcceptedOp,
+		message.GetAncestorsOp,
+		message.AncestorsOp:
+		return BootstrapLane
+	case message.AppRequestOp,
+		message.AppErrorOp,
+		message.AppResponseOp,
+		message.AppGossipOp:
+		return AppLane
+	default:
+		return ConsensusLane
+	}
+}
+
+type LaneConfig struct {
+	// Weight is the share of the outbound bandwidth this lane is given,
+	// relative to the other lanes, while multiple lanes have pending messages.
+	Weight uint64 `json:"weight"`
+
+	// MaxBytes is the maximum number of bytes that may be pending in this lane.
+	// Messages that would exceed this budget are dropped.
+	MaxBytes uint64 `json:"maxBytes"`
+}
+
+func (c *LaneConfig) Verify() error {
+	switch {
+	case c.Weight == 0:
+		return errZeroLaneWeight
+	case c.MaxBytes < constants.DefaultMaxMessageSize:
+		return errLaneMaxBytesTooLow
+	default:
+		return nil
+	}
+}
+
+type MessageQueueConfig struct {
+	// LanesEnabled causes outbound messages to be split into the consensus,
+	// bootstrap, and app lanes. If false, all outbound messages are sent in a
+	// single FIFO queue.
+	LanesEnabled bool `json:"lanesEnabled"`
+
+	ConsensusLane LaneConfig `json:"consensusLane"`
+	BootstrapLane LaneConfig `json:"bootstrapLane"`
+	AppLane       LaneConfig `json:"appLane"`
+}
+
+func (c *MessageQueueConfig) Verify() error {
+	if !c.LanesEnabled {
+		return nil
+	}
+	for lane, config := range c.lanes() {
+		if err := config.Verify(); err != nil {
+			return fmt.Errorf("invalid %s lane: %w", Lane(lane), err)
+		}
+	}
+	return nil
+}
+
+func (c *MessageQueueConfig) lanes() [numLanes]LaneConfig {
+	return [numLanes]LaneConfig{
+		ConsensusLane: c.ConsensusLane,
+		BootstrapLane: c.BootstrapLane,
+		AppLane:       c.AppLane,
+	}
+}
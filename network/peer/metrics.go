@@ -9,13 +9,16 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
 )
 
 const (
+	nodeIDLabel     = "nodeID"
 	ioLabel         = "io"
 	opLabel         = "op"
 	compressedLabel = "compressed"
+	laneLabel       = "lane"
 
 	sentLabel     = "sent"
 	receivedLabel = "received"
@@ -25,6 +28,9 @@ var (
 	opLabels             = []string{opLabel}
 	ioOpLabels           = []string{ioLabel, opLabel}
 	ioOpCompressedLabels = []string{ioLabel, opLabel, compressedLabel}
+	nodeIDIOOpLabels     = []string{nodeIDLabel, ioLabel, opLabel}
+	laneLabels           = []string{laneLabel}
+	nodeIDLaneLabels     = []string{nodeIDLabel, laneLabel}
 )
 
 type Metrics struct {
@@ -37,9 +43,24 @@ type Metrics struct {
 	Messages   *prometheus.CounterVec // io + op + compressed
 	Bytes      *prometheus.CounterVec // io + op
 	BytesSaved *prometheus.GaugeVec   // io + op
+
+	LanePendingMessages *prometheus.GaugeVec   // lane
+	LanePendingBytes    *prometheus.GaugeVec   // lane
+	LaneDropped         *prometheus.CounterVec // lane
+
+	// The per-peer metrics are only populated if per-peer metrics are
+	// enabled, as their cardinality grows with the number of peers.
+	PeerMessages            *prometheus.CounterVec // nodeID + io + op
+	PeerBytes               *prometheus.CounterVec // nodeID + io + op
+	PeerLanePendingMessages *prometheus.GaugeVec   // nodeID + lane
+	PeerLanePendingBytes    *prometheus.GaugeVec   // nodeID + lane
+	PeerLaneDropped         *prometheus.CounterVec // nodeID + lane
 }
 
-func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+// NewMetrics returns the peer metrics registered on [registerer]. If
+// [perPeerEnabled] is true, message and byte counters are additionally
+// reported for each connected peer.
+func NewMetrics(registerer prometheus.Registerer, perPeerEnabled bool) (*Metrics, error) {
 	m := &Metrics{
 		ClockSkewCount: prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "clock_skew_count",
@@ -81,8 +102,29 @@ func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
 			},
 			ioOpLabels,
 		),
+		LanePendingMessages: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "lane_pending_msgs",
+				Help: "number of outbound messages waiting to be sent, across all peers",
+			},
+			laneLabels,
+		),
+		LanePendingBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "lane_pending_bytes",
+				Help: "number of outbound message bytes waiting to be sent, across all peers",
+			},
+			laneLabels,
+		),
+		LaneDropped: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "lane_msgs_dropped",
+				Help: "number of outbound messages dropped before being sent",
+			},
+			laneLabels,
+		),
 	}
-	return m, errors.Join(
+	err := errors.Join(
 		registerer.Register(m.ClockSkewCount),
 		registerer.Register(m.ClockSkewSum),
 		registerer.Register(m.NumFailedToParse),
@@ -90,11 +132,61 @@ func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
 		registerer.Register(m.Messages),
 		registerer.Register(m.Bytes),
 		registerer.Register(m.BytesSaved),
+		registerer.Register(m.LanePendingMessages),
+		registerer.Register(m.LanePendingBytes),
+		registerer.Register(m.LaneDropped),
+	)
+	if !perPeerEnabled || err != nil {
+		return m, err
+	}
+
+	m.PeerMessages = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "peer_msgs",
+			Help: "number of handled messages per peer",
+		},
+		nodeIDIOOpLabels,
+	)
+	m.PeerBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "peer_msgs_bytes",
+			Help: "number of message bytes per peer",
+		},
+		nodeIDIOOpLabels,
+	)
+	m.PeerLanePendingMessages = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "peer_lane_pending_msgs",
+			Help: "number of outbound messages waiting to be sent per peer",
+		},
+		nodeIDLaneLabels,
+	)
+	m.PeerLanePendingBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "peer_lane_pending_bytes",
+			Help: "number of outbound message bytes waiting to be sent per peer",
+		},
+		nodeIDLaneLabels,
+	)
+	m.PeerLaneDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "peer_lane_msgs_dropped",
+			Help: "number of outbound messages dropped before being sent per peer",
+		},
+		nodeIDLaneLabels,
+	)
+	return m, errors.Join(
+		registerer.Register(m.PeerMessages),
+		registerer.Register(m.PeerBytes),
+		registerer.Register(m.PeerLanePendingMessages),
+		registerer.Register(m.PeerLanePendingBytes),
+		registerer.Register(m.PeerLaneDropped),
 	)
 }
 
-// Sent updates the metrics for having sent [msg].
-func (m *Metrics) Sent(msg message.OutboundMessage) {
+// Sent updates the metrics for having sent [msg] as [msgLen] bytes to
+// [nodeID].
+func (m *Metrics) Sent(nodeID ids.NodeID, msg message.OutboundMessage, msgLen uint32) {
 	op := msg.Op().String()
 	saved := msg.BytesSavedCompression()
 	compressed := saved != 0 // assume that if [saved] == 0, [msg] wasn't compressed
@@ -110,8 +202,9 @@ func (m *Metrics) Sent(msg message.OutboundMessage) {
 		ioLabel: sentLabel,
 		opLabel: op,
 	}
-	m.Bytes.With(bytesLabel).Add(float64(len(msg.Bytes())))
+	m.Bytes.With(bytesLabel).Add(float64(msgLen))
 	m.BytesSaved.With(bytesLabel).Add(float64(saved))
+	m.peerObserve(nodeID, sentLabel, op, int(msgLen))
 }
 
 func (m *Metrics) MultipleSendsFailed(op message.Op, count int) {
@@ -128,7 +221,9 @@ func (m *Metrics) SendFailed(msg message.OutboundMessage) {
 	}).Inc()
 }
 
-func (m *Metrics) Received(msg message.InboundMessage, msgLen uint32) {
+// Received updates the metrics for having received [msg] of [msgLen] bytes
+// from [nodeID].
+func (m *Metrics) Received(nodeID ids.NodeID, msg message.InboundMessage, msgLen uint32) {
 	op := msg.Op().String()
 	saved := msg.BytesSavedCompression()
 	compressed := saved != 0 // assume that if [saved] == 0, [msg] wasn't compressed
@@ -146,4 +241,88 @@ func (m *Metrics) Received(msg message.InboundMessage, msgLen uint32) {
 	}
 	m.Bytes.With(bytesLabel).Add(float64(msgLen))
 	m.BytesSaved.With(bytesLabel).Add(float64(saved))
+	m.peerObserve(nodeID, receivedLabel, op, int(msgLen))
+}
+
+// RemovePeer removes the per-peer metrics of [nodeID], if any are tracked.
+func (m *Metrics) RemovePeer(nodeID ids.NodeID) {
+	if m.PeerMessages == nil {
+		return
+	}
+
+	nodeIDLabels := prometheus.Labels{
+		nodeIDLabel: nodeID.String(),
+	}
+	m.PeerMessages.DeletePartialMatch(nodeIDLabels)
+	m.PeerBytes.DeletePartialMatch(nodeIDLabels)
+	m.PeerLanePendingMessages.DeletePartialMatch(nodeIDLabels)
+	m.PeerLanePendingBytes.DeletePartialMatch(nodeIDLabels)
+	m.PeerLaneDropped.DeletePartialMatch(nodeIDLabels)
+}
+
+// laneMetrics returns the metrics of [lane] of the queue of messages sent to
+// [nodeID].
+func (m *Metrics) laneMetrics(nodeID ids.NodeID, lane Lane) laneMetrics {
+	labels := prometheus.Labels{
+		laneLabel: lane.String(),
+	}
+	lm := laneMetrics{
+		pendingMessages: []prometheus.Gauge{m.LanePendingMessages.With(labels)},
+		pendingBytes:    []prometheus.Gauge{m.LanePendingBytes.With(labels)},
+		dropped:         []prometheus.Counter{m.LaneDropped.With(labels)},
+	}
+	if m.PeerMessages == nil {
+		return lm
+	}
+
+	peerLabels := prometheus.Labels{
+		nodeIDLabel: nodeID.String(),
+		laneLabel:   lane.String(),
+	}
+	lm.pendingMessages = append(lm.pendingMessages, m.PeerLanePendingMessages.With(peerLabels))
+	lm.pendingBytes = append(lm.pendingBytes, m.PeerLanePendingBytes.With(peerLabels))
+	lm.dropped = append(lm.dropped, m.PeerLaneDropped.With(peerLabels))
+	return lm
+}
+
+// laneMetrics reports the state of a lane both across all peers and, if
+// per-peer metrics are enabled, for a single peer.
+type laneMetrics struct {
+	pendingMessages []prometheus.Gauge
+	pendingBytes    []prometheus.Gauge
+	dropped         []prometheus.Counter
+}
+
+func (m *laneMetrics) pushed(msgLen uint64) {
+	for i := range m.pendingMessages {
+		m.pendingMessages[i].Inc()
+		m.pendingBytes[i].Add(float64(msgLen))
+	}
+}
+
+func (m *laneMetrics) popped(msgLen uint64) {
+	for i := range m.pendingMessages {
+		m.pendingMessages[i].Dec()
+		m.pendingBytes[i].Sub(float64(msgLen))
+	}
+}
+
+func (m *laneMetrics) droppedMessage() {
+	for _, dropped := range m.dropped {
+		dropped.Inc()
+	}
+}
+
+func (m *Metrics) peerObserve(nodeID ids.NodeID, io string, op string, numBytes int) {
+	if m.PeerMessages == nil {
+		return
+	}
+
+	labels := prometheus.Labels{
+		nodeIDLabel: nodeID.String(),
+		ioLabel:     io,
+		opLabel:     op,
+	}
+	m.PeerMessages.With(labels).Inc()
+	m.PeerBytes.With(labels).Add(float64(numBytes))
 }
@@ -16,7 +16,9 @@ var (
 	errMaxMessageLengthExceeded = errors.New("maximum message length exceeded")
 )
 
-func writeMsgLen(msgLen uint32, maxMsgLen uint32) ([wrappers.IntLen]byte, error) {
+// WriteMsgLen returns the length prefix that precedes a message of length
+// [msgLen] on the wire.
+func WriteMsgLen(msgLen uint32, maxMsgLen uint32) ([wrappers.IntLen]byte, error) {
 	if msgLen > maxMsgLen {
 		return [wrappers.IntLen]byte{}, fmt.Errorf(
 			"%w; the message length %d exceeds the specified limit %d",
@@ -32,10 +34,11 @@ func writeMsgLen(msgLen uint32, maxMsgLen uint32) ([wrappers.IntLen]byte, error)
 	return b, nil
 }
 
-func readMsgLen(b []byte, maxMsgLen uint32) (uint32, error) {
+// ReadMsgLen parses the length prefix [b] that was written by WriteMsgLen.
+func ReadMsgLen(b []byte, maxMsgLen uint32) (uint32, error) {
 	if len(b) != wrappers.IntLen {
 		return 0, fmt.Errorf(
-			"%w; readMsgLen only supports 4 bytes (got %d bytes)",
+			"%w; ReadMsgLen only supports 4 bytes (got %d bytes)",
 			errInvalidMessageLength,
 			len(b),
 		)
@@ -37,13 +37,13 @@ func TestWriteMsgLen(t *testing.T) {
 		},
 	}
 	for _, tv := range tt {
-		msgLenBytes, err := writeMsgLen(tv.msgLen, tv.msgLimit)
+		msgLenBytes, err := WriteMsgLen(tv.msgLen, tv.msgLimit)
 		require.ErrorIs(err, tv.expectedErr)
 		if tv.expectedErr != nil {
 			continue
 		}
 
-		msgLen, err := readMsgLen(msgLenBytes[:], tv.msgLimit)
+		msgLen, err := ReadMsgLen(msgLenBytes[:], tv.msgLimit)
 		require.NoError(err)
 		require.Equal(tv.msgLen, msgLen)
 	}
@@ -84,17 +84,17 @@ func TestReadMsgLen(t *testing.T) {
 		},
 	}
 	for _, tv := range tt {
-		msgLen, err := readMsgLen(tv.msgLenBytes, tv.msgLimit)
+		msgLen, err := ReadMsgLen(tv.msgLenBytes, tv.msgLimit)
 		require.ErrorIs(err, tv.expectedErr)
 		if tv.expectedErr != nil {
 			continue
 		}
 		require.Equal(tv.expectedMsgLen, msgLen)
 
-		msgLenBytes, err := writeMsgLen(msgLen, tv.msgLimit)
+		msgLenBytes, err := WriteMsgLen(msgLen, tv.msgLimit)
 		require.NoError(err)
 
-		msgLenAfterWrite, err := readMsgLen(msgLenBytes[:], tv.msgLimit)
+		msgLenAfterWrite, err := ReadMsgLen(msgLenBytes[:], tv.msgLimit)
 		require.NoError(err)
 		require.Equal(tv.expectedMsgLen, msgLenAfterWrite)
 	}
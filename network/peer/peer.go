@@ -39,6 +39,12 @@ const (
 	// maxNumTrackedSubnets limits how many subnets a peer can track to prevent
 	// excessive memory usage.
 	maxNumTrackedSubnets = 16
+	// MaxAdditionalIPs limits how many IPs, other than the primary IP, a peer
+	// can claim to prevent excessive memory usage.
+	MaxAdditionalIPs = 3
+	// rttSmoothingFactor is the inverse of the weight given to each new round
+	// trip time measurement.
+	rttSmoothingFactor = 8
 
 	disconnectingLog         = "disconnecting from peer"
 	failedToCreateMessageLog = "failed to create message"
@@ -82,10 +88,24 @@ type Peer interface {
 	// called after [Ready] returns true.
 	Info() Info
 
+	// Stats returns the number of messages and bytes, per op, that have been
+	// sent to and received from this peer.
+	Stats() Stats
+
 	// IP returns the claimed IP and signature provided by this peer during the
 	// handshake. It should only be called after [Ready] returns true.
 	IP() *SignedIP
 
+	// AdditionalIPs returns the claimed IPs, other than [IP], and signatures
+	// provided by this peer during the handshake. It should only be called
+	// after [Ready] returns true.
+	AdditionalIPs() []*SignedIP
+
+	// QUICPort returns the UDP port this peer accepts QUIC connections on, or 0
+	// if the peer only accepts TCP connections. It should only be called after
+	// [Ready] returns true.
+	QUICPort() uint16
+
 	// Version returns the claimed node version this peer is running. It should
 	// only be called after [Ready] returns true.
 	Version() *version.Application
@@ -99,6 +119,14 @@ type Peer interface {
 	// [Ready] returns true.
 	ObservedUptime() uint32
 
+	// RTT returns the smoothed round trip time of the Pings sent to this peer,
+	// or 0 if the peer hasn't responded to a Ping yet.
+	RTT() time.Duration
+
+	// SendAttempts returns the number of messages that have been attempted to
+	// be sent to this peer and the number of those that failed to be queued.
+	SendAttempts() (attempted uint64, failed uint64)
+
 	// Send attempts to send [msg] to the peer. The peer takes ownership of
 	// [msg] for reference counting. This returns false if the message is
 	// guaranteed not to be delivered to the peer.
@@ -140,6 +168,13 @@ type peer struct {
 
 	// ip is the claimed IP the peer gave us in the Handshake message.
 	ip *SignedIP
+	// additionalIPs are the claimed IPs, other than [ip], the peer gave us in
+	// the Handshake message. They are signed at the same timestamp as [ip].
+	additionalIPs []*SignedIP
+	// quicPort is the UDP port the peer gave us in the Handshake message that
+	// it accepts QUIC connections on. If 0, the peer doesn't accept QUIC
+	// connections.
+	quicPort uint16
 	// version is the claimed version the peer is running that we received in
 	// the Handshake message.
 	version *version.Application
@@ -149,6 +184,10 @@ type peer struct {
 	// options of ACPs provided in the Handshake message.
 	supportedACPs set.Set[uint32]
 	objectedACPs  set.Set[uint32]
+	// supportedZstdDicts are the IDs of the zstd dictionaries the peer sent us
+	// in the Handshake message. It is written by the reader goroutine and read
+	// by the writer goroutine.
+	supportedZstdDicts utils.Atomic[set.Set[uint32]]
 
 	// txIDOfVerifiedBLSKey is the txID that added the BLS key that was most
 	// recently verified to have signed the IP.
@@ -161,6 +200,18 @@ type peer struct {
 	// Our primary network uptime perceived by the peer
 	observedUptime utils.Atomic[uint32]
 
+	// pingSent is the time that the outstanding Ping was sent at, or the zero
+	// time if there isn't an outstanding Ping.
+	pingSent utils.Atomic[time.Time]
+	// rtt is the smoothed round trip time of the Pings sent to this peer.
+	// Only modified on the connection's reader routine.
+	rtt utils.Atomic[time.Duration]
+
+	// numSendAttempts and numSendFailures count the messages passed to Send
+	// and the messages that Send failed to queue.
+	numSendAttempts atomic.Uint64
+	numSendFailures atomic.Uint64
+
 	// True if this peer has sent us a valid Handshake message and
 	// is running a compatible version.
 	// Only modified on the connection's reader routine.
@@ -194,6 +245,9 @@ type peer struct {
 	// getPeerListChan signals that we should attempt to send a GetPeerList to
 	// this peer
 	getPeerListChan chan struct{}
+
+	// stats counts the messages and bytes exchanged with this peer
+	stats *statsTracker
 }
 
 // Start a new peer instance.
@@ -220,6 +274,7 @@ func Start(
 		onClosingCtxCancel: onClosingCtxCancel,
 		onClosed:           make(chan struct{}),
 		getPeerListChan:    make(chan struct{}, 1),
+		stats:              newStatsTracker(),
 	}
 
 	go p.readMessages()
@@ -270,24 +325,49 @@ func (p *peer) Info() Info {
 	primaryUptime := p.ObservedUptime()
 
 	ip, _ := ips.ParseAddrPort(p.conn.RemoteAddr().String())
+	additionalPublicIPs := make([]netip.AddrPort, len(p.additionalIPs))
+	for i, additionalIP := range p.additionalIPs {
+		additionalPublicIPs[i] = additionalIP.AddrPort
+	}
 	return Info{
-		IP:             ip,
-		PublicIP:       p.ip.AddrPort,
-		ID:             p.id,
-		Version:        p.version.String(),
-		LastSent:       p.LastSent(),
-		LastReceived:   p.LastReceived(),
-		ObservedUptime: json.Uint32(primaryUptime),
-		TrackedSubnets: p.trackedSubnets,
-		SupportedACPs:  p.supportedACPs,
-		ObjectedACPs:   p.objectedACPs,
+		IP:                  ip,
+		PublicIP:            p.ip.AddrPort,
+		AdditionalPublicIPs: additionalPublicIPs,
+		ID:                  p.id,
+		Version:             p.version.String(),
+		LastSent:            p.LastSent(),
+		LastReceived:        p.LastReceived(),
+		ObservedUptime:      json.Uint32(primaryUptime),
+		TrackedSubnets:      p.trackedSubnets,
+		SupportedACPs:       p.supportedACPs,
+		ObjectedACPs:        p.objectedACPs,
 	}
 }
 
+func (p *peer) Stats() Stats {
+	return p.stats.Stats(p.id)
+}
+
 func (p *peer) IP() *SignedIP {
 	return p.ip
 }
 
+func (p *peer) AdditionalIPs() []*SignedIP {
+	return p.additionalIPs
+}
+
+func (p *peer) QUICPort() uint16 {
+	return p.quicPort
+}
+
+func (p *peer) RTT() time.Duration {
+	return p.rtt.Get()
+}
+
+func (p *peer) SendAttempts() (uint64, uint64) {
+	return p.numSendAttempts.Load(), p.numSendFailures.Load()
+}
+
 func (p *peer) Version() *version.Application {
 	return p.version
 }
@@ -301,7 +381,12 @@ func (p *peer) ObservedUptime() uint32 {
 }
 
 func (p *peer) Send(ctx context.Context, msg message.OutboundMessage) bool {
-	return p.messageQueue.Push(ctx, msg)
+	sent := p.messageQueue.Push(ctx, msg)
+	p.numSendAttempts.Add(1)
+	if !sent {
+		p.numSendFailures.Add(1)
+	}
+	return sent
 }
 
 func (p *peer) StartSendGetPeerList() {
@@ -350,6 +435,7 @@ func (p *peer) close() {
 		return
 	}
 
+	p.Metrics.RemovePeer(p.id)
 	p.Network.Disconnected(p.id)
 	close(p.onClosed)
 }
@@ -389,7 +475,7 @@ func (p *peer) readMessages() {
 		}
 
 		// Parse the message length
-		msgLen, err := readMsgLen(msgLenBytes, constants.DefaultMaxMessageSize)
+		msgLen, err := ReadMsgLen(msgLenBytes, constants.DefaultMaxMessageSize)
 		if err != nil {
 			p.Log.Verbo("error parsing message length",
 				zap.Stringer("nodeID", p.id),
@@ -477,7 +563,8 @@ func (p *peer) readMessages() {
 
 		now := p.Clock.Time()
 		p.storeLastReceived(now)
-		p.Metrics.Received(msg, msgLen)
+		p.Metrics.Received(p.id, msg, msgLen)
+		p.stats.Received(msg.Op(), uint64(msgLen))
 
 		// Handle the message. Note that when we are done handling this message,
 		// we must call [msg.OnFinishedHandling()].
@@ -492,10 +579,22 @@ func (p *peer) writeMessages() {
 		p.close()
 	}()
 
-	writer := bufio.NewWriterSize(p.conn, p.Config.WriteBufferSize)
+	// If the connection sends each lane over a separate stream, each lane is
+	// buffered separately. Otherwise, all the lanes share the same writer.
+	var writers [numLanes]*bufio.Writer
+	if laneConn, ok := p.conn.(LaneConn); ok {
+		for lane := range writers {
+			writers[lane] = bufio.NewWriterSize(laneConn.LaneWriter(Lane(lane)), p.Config.WriteBufferSize)
+		}
+	} else {
+		writer := bufio.NewWriterSize(p.conn, p.Config.WriteBufferSize)
+		for lane := range writers {
+			writers[lane] = writer
+		}
+	}
 
 	// Make sure that the Handshake is the first message sent
-	mySignedIP, err := p.IPSigner.GetSignedIP()
+	mySignedIPs, err := p.IPSigner.GetSignedIPs()
 	if err != nil {
 		p.Log.Error("failed to get signed IP",
 			zap.Stringer("nodeID", p.id),
@@ -503,6 +602,7 @@ func (p *peer) writeMessages() {
 		)
 		return
 	}
+	mySignedIP := mySignedIPs[0]
 	if port := mySignedIP.AddrPort.Port(); port == 0 {
 		p.Log.Error("signed IP has invalid port",
 			zap.Stringer("nodeID", p.id),
@@ -511,6 +611,16 @@ func (p *peer) writeMessages() {
 		return
 	}
 
+	var (
+		myAdditionalSignedIPs    = mySignedIPs[1:]
+		myAdditionalIPs          = make([]netip.AddrPort, len(myAdditionalSignedIPs))
+		myAdditionalIPSignatures = make([][]byte, len(myAdditionalSignedIPs))
+	)
+	for i, signedIP := range myAdditionalSignedIPs {
+		myAdditionalIPs[i] = signedIP.AddrPort
+		myAdditionalIPSignatures[i] = signedIP.TLSSignature
+	}
+
 	myVersion := p.VersionCompatibility.Version()
 	knownPeersFilter, knownPeersSalt := p.Network.KnownPeers()
 
@@ -526,6 +636,9 @@ func (p *peer) writeMessages() {
 		mySignedIP.Timestamp,
 		mySignedIP.TLSSignature,
 		mySignedIP.BLSSignatureBytes,
+		myAdditionalIPs,
+		myAdditionalIPSignatures,
+		p.MyQUICPort,
 		p.MySubnets.List(),
 		p.SupportedACPs,
 		p.ObjectedACPs,
@@ -542,23 +655,25 @@ func (p *peer) writeMessages() {
 		return
 	}
 
-	p.writeMessage(writer, msg)
+	p.writeMessage(writers[ConsensusLane], msg)
 
 	for {
 		msg, ok := p.messageQueue.PopNow()
 		if ok {
-			p.writeMessage(writer, msg)
+			p.writeMessage(writers[LaneOf(msg.Op())], msg)
 			continue
 		}
 
 		// Make sure the peer was fully sent all prior messages before
 		// blocking.
-		if err := writer.Flush(); err != nil {
-			p.Log.Verbo("failed to flush writer",
-				zap.Stringer("nodeID", p.id),
-				zap.Error(err),
-			)
-			return
+		for _, writer := range writers {
+			if err := writer.Flush(); err != nil {
+				p.Log.Verbo("failed to flush writer",
+					zap.Stringer("nodeID", p.id),
+					zap.Error(err),
+				)
+				return
+			}
 		}
 
 		msg, ok = p.messageQueue.Pop()
@@ -567,12 +682,22 @@ func (p *peer) writeMessages() {
 			return
 		}
 
-		p.writeMessage(writer, msg)
+		p.writeMessage(writers[LaneOf(msg.Op())], msg)
 	}
 }
 
 func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
-	msgBytes := msg.Bytes()
+	msgBytes, err := msg.BytesFor(p.supportedZstdDicts.Get())
+	if err != nil {
+		p.Log.Warn("error compressing message",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("op", msg.Op()),
+			zap.Error(err),
+		)
+		p.Metrics.SendFailed(msg)
+		return
+	}
+
 	p.Log.Verbo("sending message",
 		zap.Stringer("op", msg.Op()),
 		zap.Stringer("nodeID", p.id),
@@ -589,7 +714,7 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	}
 
 	msgLen := uint32(len(msgBytes))
-	msgLenBytes, err := writeMsgLen(msgLen, constants.DefaultMaxMessageSize)
+	msgLenBytes, err := WriteMsgLen(msgLen, constants.DefaultMaxMessageSize)
 	if err != nil {
 		p.Log.Verbo("error writing message length",
 			zap.Stringer("nodeID", p.id),
@@ -610,7 +735,8 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 
 	now := p.Clock.Time()
 	p.storeLastSent(now)
-	p.Metrics.Sent(msg)
+	p.Metrics.Sent(p.id, msg, msgLen)
+	p.stats.Sent(msg.Op(), uint64(msgLen))
 }
 
 func (p *peer) sendNetworkMessages() {
@@ -669,7 +795,12 @@ func (p *peer) sendNetworkMessages() {
 				return
 			}
 
-			p.Send(p.onClosingCtx, pingMessage)
+			// The send time is recorded before the Ping is sent so that it is
+			// set by the time the Pong is received.
+			p.pingSent.Set(p.Clock.Time())
+			if !p.Send(p.onClosingCtx, pingMessage) {
+				p.pingSent.Set(time.Time{})
+			}
 		case <-p.onClosingCtx.Done():
 			return
 		}
@@ -803,7 +934,23 @@ func (p *peer) getUptime() uint32 {
 	return primaryUptimePercent
 }
 
-func (*peer) handlePong(*p2p.Pong) {}
+func (p *peer) handlePong(*p2p.Pong) {
+	pingSent := p.pingSent.Get()
+	if pingSent.IsZero() {
+		// The Pong doesn't correspond to an outstanding Ping, so it can't be
+		// used to measure the round trip time.
+		return
+	}
+	p.pingSent.Set(time.Time{})
+
+	rtt := p.Clock.Time().Sub(pingSent)
+	if smoothedRTT := p.rtt.Get(); smoothedRTT != 0 {
+		// Smooth the round trip time as done by TCP (RFC 6298), so that a
+		// single delayed Pong doesn't dominate the measurement.
+		rtt = smoothedRTT + (rtt-smoothedRTT)/rttSmoothingFactor
+	}
+	p.rtt.Set(rtt)
+}
 
 func (p *peer) handleHandshake(msg *p2p.Handshake) {
 	if p.gotHandshake.Get() {
@@ -908,6 +1055,8 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 		}
 	}
 
+	p.supportedZstdDicts.Set(set.Of(msg.SupportedZstdDictionaries...))
+
 	if p.supportedACPs.Overlaps(p.objectedACPs) {
 		p.Log.Debug(malformedMessageLog,
 			zap.Stringer("nodeID", p.id),
@@ -1019,6 +1168,66 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 	p.ip.BLSSignature = signature
 	p.ip.BLSSignatureBytes = msg.IpBlsSig
 
+	if numAdditionalIPs := len(msg.AdditionalIps); numAdditionalIPs > MaxAdditionalIPs {
+		p.Log.Debug(malformedMessageLog,
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.HandshakeOp),
+			zap.String("field", "additionalIPs"),
+			zap.Int("numAdditionalIPs", numAdditionalIPs),
+		)
+		p.StartClose()
+		return
+	}
+
+	p.additionalIPs = make([]*SignedIP, len(msg.AdditionalIps))
+	for i, additionalIP := range msg.AdditionalIps {
+		addr, ok := ips.AddrFromSlice(additionalIP.IpAddr)
+		if !ok || additionalIP.IpPort == 0 {
+			p.Log.Debug(malformedMessageLog,
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", message.HandshakeOp),
+				zap.String("field", "additionalIP"),
+				zap.Int("ipLen", len(additionalIP.IpAddr)),
+				zap.Uint32("port", additionalIP.IpPort),
+			)
+			p.StartClose()
+			return
+		}
+
+		p.additionalIPs[i] = &SignedIP{
+			UnsignedIP: UnsignedIP{
+				AddrPort: netip.AddrPortFrom(
+					addr,
+					uint16(additionalIP.IpPort),
+				),
+				Timestamp: msg.IpSigningTime,
+			},
+			TLSSignature: additionalIP.IpNodeIdSig,
+		}
+		if err := p.additionalIPs[i].Verify(p.cert, maxTimestamp); err != nil {
+			p.Log.Debug(malformedMessageLog,
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", message.HandshakeOp),
+				zap.String("field", "additionalIPTLSSignature"),
+				zap.Error(err),
+			)
+			p.StartClose()
+			return
+		}
+	}
+
+	if msg.QuicPort > math.MaxUint16 {
+		p.Log.Debug(malformedMessageLog,
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.HandshakeOp),
+			zap.String("field", "quicPort"),
+			zap.Uint32("port", msg.QuicPort),
+		)
+		p.StartClose()
+		return
+	}
+	p.quicPort = uint16(msg.QuicPort)
+
 	// If the peer is running an incompatible version or has an invalid BLS
 	// signature, disconnect from them prior to marking the handshake as
 	// completed.
@@ -6,8 +6,10 @@ package peer
 import (
 	"context"
 	"crypto"
+	"io"
 	"net"
 	"net/netip"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/uptime"
@@ -24,8 +27,10 @@ import (
 	"github.com/MetalBlockchain/metalgo/staking"
 	"github.com/MetalBlockchain/metalgo/upgrade"
 	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
+	"github.com/MetalBlockchain/metalgo/utils/json"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/math/meter"
 	"github.com/MetalBlockchain/metalgo/utils/resource"
@@ -51,6 +56,8 @@ func newMessageCreator(t *testing.T) message.Creator {
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 		constants.DefaultNetworkCompressionType,
+		nil,
+		"",
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -62,7 +69,7 @@ func newConfig(t *testing.T) Config {
 	t.Helper()
 	require := require.New(t)
 
-	metrics, err := NewMetrics(prometheus.NewRegistry())
+	metrics, err := NewMetrics(prometheus.NewRegistry(), false)
 	require.NoError(err)
 
 	resourceTracker, err := tracker.NewResourceTracker(
@@ -114,7 +121,7 @@ func newRawTestPeer(t *testing.T, config Config) *rawTestPeer {
 	bls, err := bls.NewSecretKey()
 	require.NoError(err)
 
-	config.IPSigner = NewIPSigner(ip, tls, bls)
+	config.IPSigner = NewIPSigner(ip, nil, tls, bls)
 
 	inboundMsgChan := make(chan message.InboundMessage)
 	config.Router = router.InboundHandlerFunc(func(_ context.Context, msg message.InboundMessage) {
@@ -206,6 +213,270 @@ func TestSend(t *testing.T) {
 	peer1.StartClose()
 	require.NoError(peer0.AwaitClosed(context.Background()))
 	require.NoError(peer1.AwaitClosed(context.Background()))
+
+	// Messages can't be queued once the peer is closed.
+	attempted, failed := peer0.SendAttempts()
+	require.False(peer0.Send(context.Background(), outboundGetMsg))
+	newAttempted, newFailed := peer0.SendAttempts()
+	require.Equal(attempted+1, newAttempted)
+	require.Equal(failed+1, newFailed)
+}
+
+func TestSendZstdDict(t *testing.T) {
+	dict := &compression.ZstdDict{
+		ID:      1,
+		Content: []byte("a dictionary that is shared by some of the peers"),
+	}
+	tests := []struct {
+		name          string
+		receiverDicts []*compression.ZstdDict
+	}{
+		{
+			name:          "receiver supports dictionary",
+			receiverDicts: []*compression.ZstdDict{dict},
+		},
+		{
+			name: "receiver doesn't support dictionary",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			senderConfig := newConfig(t)
+			senderMC, err := message.NewCreator(
+				logging.NoLog{},
+				prometheus.NewRegistry(),
+				compression.TypeZstdDict,
+				[]*compression.ZstdDict{dict},
+				"",
+				10*time.Second,
+			)
+			require.NoError(err)
+			senderConfig.MessageCreator = senderMC
+
+			receiverConfig := newConfig(t)
+			receiverMC, err := message.NewCreator(
+				logging.NoLog{},
+				prometheus.NewRegistry(),
+				constants.DefaultNetworkCompressionType,
+				tt.receiverDicts,
+				"",
+				10*time.Second,
+			)
+			require.NoError(err)
+			receiverConfig.MessageCreator = receiverMC
+
+			rawPeer0 := newRawTestPeer(t, senderConfig)
+			rawPeer1 := newRawTestPeer(t, receiverConfig)
+
+			peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+			awaitReady(t, peer0, peer1)
+
+			outboundMsg, err := senderMC.AppResponse(ids.Empty, 1, []byte("a response from the sender"))
+			require.NoError(err)
+
+			require.True(peer0.Send(context.Background(), outboundMsg))
+
+			inboundMsg := <-peer1.inboundMsgChan
+			require.Equal(message.AppResponseOp, inboundMsg.Op())
+
+			peer1.StartClose()
+			require.NoError(peer0.AwaitClosed(context.Background()))
+			require.NoError(peer1.AwaitClosed(context.Background()))
+		})
+	}
+}
+
+func TestAdditionalIPs(t *testing.T) {
+	require := require.New(t)
+
+	sharedConfig := newConfig(t)
+
+	rawPeer0 := newRawTestPeer(t, sharedConfig)
+	rawPeer1 := newRawTestPeer(t, sharedConfig)
+
+	additionalIPs := []netip.AddrPort{
+		netip.AddrPortFrom(netip.AddrFrom4([4]byte{127, 0, 0, 1}), 1),
+		netip.AddrPortFrom(netip.IPv6Loopback(), 2),
+	}
+	rawPeer0.config.IPSigner.additionalIPs = additionalIPs
+
+	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+	awaitReady(t, peer0, peer1)
+
+	peer0IPs, err := rawPeer0.config.IPSigner.GetSignedIPs()
+	require.NoError(err)
+	require.Equal(peer0IPs[0], peer1.IP())
+	require.Empty(peer0.AdditionalIPs())
+
+	peer0AdditionalIPs := peer1.AdditionalIPs()
+	require.Len(peer0AdditionalIPs, len(additionalIPs))
+	for i, additionalIP := range peer0AdditionalIPs {
+		require.Equal(additionalIPs[i], additionalIP.AddrPort)
+		require.Equal(peer0IPs[0].Timestamp, additionalIP.Timestamp)
+		require.Equal(peer0IPs[i+1].TLSSignature, additionalIP.TLSSignature)
+	}
+	require.Equal(additionalIPs, peer1.Info().AdditionalPublicIPs)
+
+	peer0.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestQUICPort(t *testing.T) {
+	require := require.New(t)
+
+	sharedConfig := newConfig(t)
+
+	rawPeer0 := newRawTestPeer(t, sharedConfig)
+	rawPeer1 := newRawTestPeer(t, sharedConfig)
+	rawPeer0.config.MyQUICPort = 9651
+
+	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+	awaitReady(t, peer0, peer1)
+
+	require.Equal(uint16(9651), peer1.QUICPort())
+	require.Zero(peer0.QUICPort())
+
+	peer0.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestRTT(t *testing.T) {
+	require := require.New(t)
+
+	p := &peer{
+		Config: &Config{},
+	}
+	now := time.Unix(1, 0)
+	p.Clock.Set(now)
+
+	// A Pong without an outstanding Ping is ignored.
+	p.handlePong(&p2p.Pong{})
+	require.Zero(p.RTT())
+
+	// The first measurement is used as is.
+	p.pingSent.Set(now)
+	p.Clock.Set(now.Add(80 * time.Millisecond))
+	p.handlePong(&p2p.Pong{})
+	require.Equal(80*time.Millisecond, p.RTT())
+
+	// A duplicate Pong doesn't change the round trip time.
+	p.Clock.Set(now.Add(time.Second))
+	p.handlePong(&p2p.Pong{})
+	require.Equal(80*time.Millisecond, p.RTT())
+
+	// Later measurements are smoothed.
+	now = p.Clock.Time()
+	p.pingSent.Set(now)
+	p.Clock.Set(now.Add(160 * time.Millisecond))
+	p.handlePong(&p2p.Pong{})
+	require.Equal(90*time.Millisecond, p.RTT())
+}
+
+// laneConn records the lanes that messages were written in. All the lanes are
+// written to the same underlying connection.
+type laneConn struct {
+	net.Conn
+
+	lock  sync.Mutex
+	lanes set.Set[Lane]
+}
+
+func (c *laneConn) LaneWriter(lane Lane) io.Writer {
+	return writerFunc(func(b []byte) (int, error) {
+		c.lock.Lock()
+		c.lanes.Add(lane)
+		c.lock.Unlock()
+		return c.Conn.Write(b)
+	})
+}
+
+func (c *laneConn) Lanes() set.Set[Lane] {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return set.Of(c.lanes.List()...)
+}
+
+type writerFunc func([]byte) (int, error)
+
+func (f writerFunc) Write(b []byte) (int, error) {
+	return f(b)
+}
+
+func TestSendLaneConn(t *testing.T) {
+	require := require.New(t)
+
+	sharedConfig := newConfig(t)
+
+	rawPeer0 := newRawTestPeer(t, sharedConfig)
+	rawPeer1 := newRawTestPeer(t, sharedConfig)
+
+	pipe0, pipe1 := net.Pipe()
+	conn0 := &laneConn{Conn: pipe0}
+	peer0 := startTestPeer(rawPeer0, rawPeer1, conn0)
+	peer1 := startTestPeer(rawPeer1, rawPeer0, pipe1)
+	awaitReady(t, peer0, peer1)
+
+	getMsg, err := sharedConfig.MessageCreator.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+	getAncestorsMsg, err := sharedConfig.MessageCreator.GetAncestors(ids.Empty, 2, time.Second, ids.Empty, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+	require.NoError(err)
+	appGossipMsg, err := sharedConfig.MessageCreator.AppGossip(ids.Empty, []byte("gossip"))
+	require.NoError(err)
+
+	for _, msg := range []message.OutboundMessage{getMsg, getAncestorsMsg, appGossipMsg} {
+		require.True(peer0.Send(context.Background(), msg))
+
+		inboundMsg := <-peer1.inboundMsgChan
+		require.Equal(msg.Op(), inboundMsg.Op())
+	}
+	require.Equal(set.Of(ConsensusLane, BootstrapLane, AppLane), conn0.Lanes())
+
+	peer0.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestStats(t *testing.T) {
+	require := require.New(t)
+
+	sharedConfig := newConfig(t)
+
+	rawPeer0 := newRawTestPeer(t, sharedConfig)
+	rawPeer1 := newRawTestPeer(t, sharedConfig)
+
+	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+	awaitReady(t, peer0, peer1)
+
+	outboundGetMsg, err := sharedConfig.MessageCreator.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+	numBytes := len(outboundGetMsg.Bytes())
+
+	require.True(peer0.Send(context.Background(), outboundGetMsg))
+	<-peer1.inboundMsgChan
+
+	expectedGetStats := OpStats{
+		Messages: 1,
+		Bytes:    json.Uint64(numBytes),
+	}
+
+	sentStats := peer0.Stats()
+	require.Equal(rawPeer1.config.MyNodeID, sentStats.ID)
+	require.Equal(expectedGetStats, sentStats.Sent[message.GetOp.String()])
+	require.NotContains(sentStats.Received, message.GetOp.String())
+
+	receivedStats := peer1.Stats()
+	require.Equal(rawPeer0.config.MyNodeID, receivedStats.ID)
+	require.Equal(expectedGetStats, receivedStats.Received[message.GetOp.String()])
+	require.NotContains(receivedStats.Sent, message.GetOp.String())
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
 }
 
 func TestPingUptimes(t *testing.T) {
@@ -0,0 +1,254 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
+	"github.com/MetalBlockchain/metalgo/utils/buffer"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+// laneQuantum is the number of bytes a lane with a weight of 1 may send each
+// scheduling round.
+const laneQuantum = 4 * units.KiB
+
+var _ MessageQueue = (*prioritizedMessageQueue)(nil)
+
+type lane struct {
+	weight   uint64
+	maxBytes uint64
+
+	// pendingBytes is the sum of the sizes of the messages in [queue].
+	pendingBytes uint64
+	// deficit is the number of bytes this lane may still send during its
+	// current scheduling round.
+	deficit uint64
+	queue   buffer.Deque[message.OutboundMessage]
+
+	metrics laneMetrics
+}
+
+// prioritizedMessageQueue splits outbound messages into lanes and schedules
+// between the lanes using deficit round robin, so that each lane is given a
+// share of the bandwidth proportional to its weight.
+type prioritizedMessageQueue struct {
+	metrics *Metrics
+	// [id] of the peer we're sending messages to
+	id                   ids.NodeID
+	log                  logging.Logger
+	outboundMsgThrottler throttling.OutboundMsgThrottler
+
+	// Signalled when a message is added to the queue and when Close() is
+	// called.
+	cond *sync.Cond
+
+	// closed flags whether the send queue has been closed.
+	// [cond.L] must be held while accessing [closed].
+	closed bool
+
+	// [cond.L] must be held while accessing [lanes], [current], and
+	// [numPending].
+	lanes      [numLanes]lane
+	current    int
+	numPending int
+}
+
+// NewPrioritizedMessageQueue returns a queue that sends the consensus,
+// bootstrap, and app lanes according to [config]. Messages are dropped if
+// either the [outboundMsgThrottler] or the byte budget of their lane is
+// exceeded.
+func NewPrioritizedMessageQueue(
+	config MessageQueueConfig,
+	metrics *Metrics,
+	id ids.NodeID,
+	log logging.Logger,
+	outboundMsgThrottler throttling.OutboundMsgThrottler,
+) MessageQueue {
+	q := &prioritizedMessageQueue{
+		metrics:              metrics,
+		id:                   id,
+		log:                  log,
+		outboundMsgThrottler: outboundMsgThrottler,
+		cond:                 sync.NewCond(&sync.Mutex{}),
+	}
+	for i, laneConfig := range config.lanes() {
+		q.lanes[i] = lane{
+			weight:   laneConfig.Weight,
+			maxBytes: laneConfig.MaxBytes,
+			queue:    buffer.NewUnboundedDeque[message.OutboundMessage](initialQueueSize),
+			metrics:  metrics.laneMetrics(id, Lane(i)),
+		}
+	}
+	return q
+}
+
+func (q *prioritizedMessageQueue) Push(ctx context.Context, msg message.OutboundMessage) bool {
+	l := &q.lanes[LaneOf(msg.Op())]
+	if err := ctx.Err(); err != nil {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+			zap.Error(err),
+		)
+		q.dropped(l, msg)
+		return false
+	}
+
+	// Acquire space on the outbound message queue, or drop [msg] if we can't.
+	if !q.outboundMsgThrottler.Acquire(msg, q.id) {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "rate-limiting"),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+		)
+		q.dropped(l, msg)
+		return false
+	}
+
+	// Invariant: must call q.outboundMsgThrottler.Release(msg, q.id) when [msg]
+	// is popped or, if this queue closes before [msg] is popped, when this
+	// queue closes.
+
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "closed queue"),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+		)
+		q.outboundMsgThrottler.Release(msg, q.id)
+		q.dropped(l, msg)
+		return false
+	}
+
+	msgLen := uint64(len(msg.Bytes()))
+	if l.pendingBytes+msgLen > l.maxBytes {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "lane full"),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+		)
+		q.outboundMsgThrottler.Release(msg, q.id)
+		q.dropped(l, msg)
+		return false
+	}
+
+	l.queue.PushRight(msg)
+	l.pendingBytes += msgLen
+	l.metrics.pushed(msgLen)
+	q.numPending++
+	q.cond.Signal()
+	return true
+}
+
+func (q *prioritizedMessageQueue) Pop() (message.OutboundMessage, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for {
+		if q.closed {
+			return nil, false
+		}
+		if q.numPending > 0 {
+			// There is a message
+			break
+		}
+		// Wait until there is a message
+		q.cond.Wait()
+	}
+
+	return q.pop(), true
+}
+
+func (q *prioritizedMessageQueue) PopNow() (message.OutboundMessage, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed || q.numPending == 0 {
+		// There isn't a message
+		return nil, false
+	}
+
+	return q.pop(), true
+}
+
+// pop returns the next message according to the deficit round robin schedule.
+//
+// Invariant: [q.numPending] must be > 0.
+func (q *prioritizedMessageQueue) pop() message.OutboundMessage {
+	for {
+		l := &q.lanes[q.current]
+		if msg, ok := l.queue.PeekLeft(); ok {
+			msgLen := uint64(len(msg.Bytes()))
+			if msgLen <= l.deficit {
+				_, _ = l.queue.PopLeft()
+				l.deficit -= msgLen
+				l.pendingBytes -= msgLen
+				if l.queue.Len() == 0 {
+					// Lanes can't accumulate credit while they are idle.
+					l.deficit = 0
+				}
+				l.metrics.popped(msgLen)
+				q.numPending--
+
+				q.outboundMsgThrottler.Release(msg, q.id)
+				return msg
+			}
+		}
+
+		// The current lane has exhausted its deficit, so the next lane with
+		// pending messages is given its quantum.
+		q.current = (q.current + 1) % numLanes
+		next := &q.lanes[q.current]
+		if next.queue.Len() > 0 {
+			next.deficit += next.weight * laneQuantum
+		}
+	}
+}
+
+func (q *prioritizedMessageQueue) Close() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	q.closed = true
+
+	for i := range q.lanes {
+		l := &q.lanes[i]
+		for l.queue.Len() > 0 {
+			msg, _ := l.queue.PopLeft()
+			msgLen := uint64(len(msg.Bytes()))
+			l.metrics.popped(msgLen)
+			q.outboundMsgThrottler.Release(msg, q.id)
+			q.dropped(l, msg)
+		}
+		l.queue = nil
+		l.pendingBytes = 0
+	}
+	q.numPending = 0
+
+	q.cond.Broadcast()
+}
+
+func (q *prioritizedMessageQueue) dropped(l *lane, msg message.OutboundMessage) {
+	l.metrics.droppedMessage()
+	q.metrics.SendFailed(msg)
+}
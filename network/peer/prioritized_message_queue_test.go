@@ -0,0 +1,287 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+func newTestMessageQueueConfig() MessageQueueConfig {
+	return MessageQueueConfig{
+		LanesEnabled: true,
+		ConsensusLane: LaneConfig{
+			Weight:   4,
+			MaxBytes: constants.DefaultMaxMessageSize,
+		},
+		BootstrapLane: LaneConfig{
+			Weight:   2,
+			MaxBytes: constants.DefaultMaxMessageSize,
+		},
+		AppLane: LaneConfig{
+			Weight:   1,
+			MaxBytes: constants.DefaultMaxMessageSize,
+		},
+	}
+}
+
+func newTestPrioritizedMessageQueue(t *testing.T, config MessageQueueConfig) (MessageQueue, *Metrics, message.Creator) {
+	t.Helper()
+	require := require.New(t)
+
+	metrics, err := NewMetrics(prometheus.NewRegistry(), false)
+	require.NoError(err)
+
+	mc, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		compression.TypeNone,
+		nil,
+		"",
+		10*time.Second,
+	)
+	require.NoError(err)
+
+	q := NewPrioritizedMessageQueue(
+		config,
+		metrics,
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	)
+	return q, metrics, mc
+}
+
+func TestLaneOf(t *testing.T) {
+	tests := []struct {
+		op   message.Op
+		lane Lane
+	}{
+		{op: message.PingOp, lane: ConsensusLane},
+		{op: message.PeerListOp, lane: ConsensusLane},
+		{op: message.PullQueryOp, lane: ConsensusLane},
+		{op: message.ChitsOp, lane: ConsensusLane},
+		{op: message.GetAcceptedFrontierOp, lane: BootstrapLane},
+		{op: message.GetAncestorsOp, lane: BootstrapLane},
+		{op: message.AncestorsOp, lane: BootstrapLane},
+		{op: message.StateSummaryFrontierOp, lane: BootstrapLane},
+		{op: message.AppRequestOp, lane: AppLane},
+		{op: message.AppGossipOp, lane: AppLane},
+	}
+	for _, test := range tests {
+		t.Run(test.op.String(), func(t *testing.T) {
+			require.Equal(t, test.lane, LaneOf(test.op))
+		})
+	}
+}
+
+func TestMessageQueueConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*MessageQueueConfig)
+		expectedErr error
+	}{
+		{
+			name:        "valid",
+			modify:      func(*MessageQueueConfig) {},
+			expectedErr: nil,
+		},
+		{
+			name: "disabled",
+			modify: func(c *MessageQueueConfig) {
+				c.LanesEnabled = false
+				c.AppLane.Weight = 0
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "zero weight",
+			modify: func(c *MessageQueueConfig) {
+				c.AppLane.Weight = 0
+			},
+			expectedErr: errZeroLaneWeight,
+		},
+		{
+			name: "max bytes below max message size",
+			modify: func(c *MessageQueueConfig) {
+				c.BootstrapLane.MaxBytes = constants.DefaultMaxMessageSize - 1
+			},
+			expectedErr: errLaneMaxBytesTooLow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := newTestMessageQueueConfig()
+			test.modify(&config)
+			require.ErrorIs(t, config.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestPrioritizedMessageQueueConsensusNotBlocked(t *testing.T) {
+	require := require.New(t)
+
+	q, _, mc := newTestPrioritizedMessageQueue(t, newTestMessageQueueConfig())
+
+	for i := 0; i < 100; i++ {
+		msg, err := mc.AppGossip(ids.Empty, make([]byte, 16*units.KiB))
+		require.NoError(err)
+		require.True(q.Push(context.Background(), msg))
+	}
+
+	chits, err := mc.Chits(ids.Empty, 1, ids.Empty, ids.Empty, ids.Empty, 0)
+	require.NoError(err)
+	require.True(q.Push(context.Background(), chits))
+
+	// The chits message must be sent after at most one app gossip message,
+	// rather than after all of the queued app gossip.
+	var numPopped int
+	for {
+		msg, ok := q.PopNow()
+		require.True(ok)
+		numPopped++
+		if msg.Op() == message.ChitsOp {
+			break
+		}
+	}
+	require.LessOrEqual(numPopped, 2)
+}
+
+func TestPrioritizedMessageQueueWeightedShare(t *testing.T) {
+	require := require.New(t)
+
+	config := newTestMessageQueueConfig()
+	q, _, mc := newTestPrioritizedMessageQueue(t, config)
+
+	const (
+		numMessages = 100
+		msgSize     = 8 * units.KiB
+	)
+	for i := 0; i < numMessages; i++ {
+		ancestors, err := mc.Ancestors(ids.Empty, 1, [][]byte{make([]byte, msgSize)})
+		require.NoError(err)
+		require.True(q.Push(context.Background(), ancestors))
+
+		appGossip, err := mc.AppGossip(ids.Empty, make([]byte, msgSize))
+		require.NoError(err)
+		require.True(q.Push(context.Background(), appGossip))
+	}
+
+	// While both lanes are backlogged, the bootstrap lane should be given
+	// twice the bandwidth of the app lane.
+	var bytesSent [numLanes]int
+	for i := 0; i < numMessages; i++ {
+		msg, ok := q.PopNow()
+		require.True(ok)
+		bytesSent[LaneOf(msg.Op())] += len(msg.Bytes())
+	}
+	require.Zero(bytesSent[ConsensusLane])
+
+	ratio := float64(bytesSent[BootstrapLane]) / float64(bytesSent[AppLane])
+	expectedRatio := float64(config.BootstrapLane.Weight) / float64(config.AppLane.Weight)
+	require.InDelta(expectedRatio, ratio, 0.1)
+}
+
+func TestPrioritizedMessageQueueLaneBudget(t *testing.T) {
+	require := require.New(t)
+
+	q, metrics, mc := newTestPrioritizedMessageQueue(t, newTestMessageQueueConfig())
+
+	appGossip, err := mc.AppGossip(ids.Empty, make([]byte, constants.DefaultMaxMessageSize/2))
+	require.NoError(err)
+
+	// The first message fits into the app lane budget, the second does not.
+	require.True(q.Push(context.Background(), appGossip))
+	require.False(q.Push(context.Background(), appGossip))
+
+	// The consensus lane has its own budget.
+	ping, err := mc.Ping(0)
+	require.NoError(err)
+	require.True(q.Push(context.Background(), ping))
+
+	appLabel := prometheus.Labels{laneLabel: AppLane.String()}
+	consensusLabel := prometheus.Labels{laneLabel: ConsensusLane.String()}
+	require.Equal(1., testutil.ToFloat64(metrics.LaneDropped.With(appLabel)))
+	require.Equal(1., testutil.ToFloat64(metrics.LanePendingMessages.With(appLabel)))
+	require.Equal(float64(len(appGossip.Bytes())), testutil.ToFloat64(metrics.LanePendingBytes.With(appLabel)))
+	require.Zero(testutil.ToFloat64(metrics.LaneDropped.With(consensusLabel)))
+	require.Equal(1., testutil.ToFloat64(metrics.LanePendingMessages.With(consensusLabel)))
+
+	// Closing the queue drops the pending messages.
+	q.Close()
+	for _, lane := range []prometheus.Labels{appLabel, consensusLabel} {
+		require.Zero(testutil.ToFloat64(metrics.LanePendingMessages.With(lane)))
+		require.Zero(testutil.ToFloat64(metrics.LanePendingBytes.With(lane)))
+	}
+	require.Equal(2., testutil.ToFloat64(metrics.LaneDropped.With(appLabel)))
+	require.Equal(1., testutil.ToFloat64(metrics.LaneDropped.With(consensusLabel)))
+
+	_, ok := q.Pop()
+	require.False(ok)
+	require.False(q.Push(context.Background(), ping))
+}
+
+func TestPrioritizedMessageQueuePeerLaneMetrics(t *testing.T) {
+	require := require.New(t)
+
+	metrics, err := NewMetrics(prometheus.NewRegistry(), true)
+	require.NoError(err)
+
+	mc, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		compression.TypeNone,
+		nil,
+		"",
+		10*time.Second,
+	)
+	require.NoError(err)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+	q0 := NewPrioritizedMessageQueue(newTestMessageQueueConfig(), metrics, nodeID0, logging.NoLog{}, throttling.NewNoOutboundThrottler())
+	q1 := NewPrioritizedMessageQueue(newTestMessageQueueConfig(), metrics, nodeID1, logging.NoLog{}, throttling.NewNoOutboundThrottler())
+
+	appGossip, err := mc.AppGossip(ids.Empty, make([]byte, constants.DefaultMaxMessageSize/2))
+	require.NoError(err)
+	require.True(q0.Push(context.Background(), appGossip))
+	require.False(q0.Push(context.Background(), appGossip))
+
+	ping, err := mc.Ping(0)
+	require.NoError(err)
+	require.True(q1.Push(context.Background(), ping))
+
+	appLabels := prometheus.Labels{
+		nodeIDLabel: nodeID0.String(),
+		laneLabel:   AppLane.String(),
+	}
+	consensusLabels := prometheus.Labels{
+		nodeIDLabel: nodeID1.String(),
+		laneLabel:   ConsensusLane.String(),
+	}
+	require.Equal(1., testutil.ToFloat64(metrics.PeerLanePendingMessages.With(appLabels)))
+	require.Equal(float64(len(appGossip.Bytes())), testutil.ToFloat64(metrics.PeerLanePendingBytes.With(appLabels)))
+	require.Equal(1., testutil.ToFloat64(metrics.PeerLaneDropped.With(appLabels)))
+	require.Equal(1., testutil.ToFloat64(metrics.PeerLanePendingMessages.With(consensusLabels)))
+	require.Zero(testutil.ToFloat64(metrics.PeerLaneDropped.With(consensusLabels)))
+
+	// The lane totals are still reported across all peers.
+	require.Equal(1., testutil.ToFloat64(metrics.LaneDropped.With(prometheus.Labels{laneLabel: AppLane.String()})))
+
+	metrics.RemovePeer(nodeID0)
+	require.Equal(3, testutil.CollectAndCount(metrics.PeerLanePendingMessages))
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"sync"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/utils/json"
+)
+
+// OpStats is the amount of traffic of a single op exchanged with a peer.
+type OpStats struct {
+	Messages json.Uint64 `json:"messages"`
+	Bytes    json.Uint64 `json:"bytes"`
+}
+
+// Stats describes the traffic exchanged with a peer since the connection was
+// established. The maps are keyed by the string representation of the op.
+type Stats struct {
+	ID       ids.NodeID         `json:"nodeID"`
+	Sent     map[string]OpStats `json:"sent"`
+	Received map[string]OpStats `json:"received"`
+}
+
+type opCounter struct {
+	messages uint64
+	bytes    uint64
+}
+
+// statsTracker counts the messages and bytes sent to and received from a
+// single peer. It is safe for concurrent use.
+type statsTracker struct {
+	lock     sync.Mutex
+	sent     map[message.Op]*opCounter
+	received map[message.Op]*opCounter
+}
+
+func newStatsTracker() *statsTracker {
+	return &statsTracker{
+		sent:     make(map[message.Op]*opCounter),
+		received: make(map[message.Op]*opCounter),
+	}
+}
+
+func (s *statsTracker) Sent(op message.Op, numBytes uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	countOp(s.sent, op, numBytes)
+}
+
+func (s *statsTracker) Received(op message.Op, numBytes uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	countOp(s.received, op, numBytes)
+}
+
+func (s *statsTracker) Stats(nodeID ids.NodeID) Stats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return Stats{
+		ID:       nodeID,
+		Sent:     opStats(s.sent),
+		Received: opStats(s.received),
+	}
+}
+
+func countOp(counters map[message.Op]*opCounter, op message.Op, numBytes uint64) {
+	counter, ok := counters[op]
+	if !ok {
+		counter = &opCounter{}
+		counters[op] = counter
+	}
+	counter.messages++
+	counter.bytes += numBytes
+}
+
+func opStats(counters map[message.Op]*opCounter) map[string]OpStats {
+	stats := make(map[string]OpStats, len(counters))
+	for op, counter := range counters {
+		stats[op.String()] = OpStats{
+			Messages: json.Uint64(counter.messages),
+			Bytes:    json.Uint64(counter.bytes),
+		}
+	}
+	return stats
+}
@@ -79,13 +79,15 @@ func StartTestPeer(
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 		constants.DefaultNetworkCompressionType,
+		nil,
+		"",
 		10*time.Second,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	metrics, err := NewMetrics(prometheus.NewRegistry())
+	metrics, err := NewMetrics(prometheus.NewRegistry(), false)
 	if err != nil {
 		return nil, err
 	}
@@ -129,6 +131,7 @@ func StartTestPeer(
 					netip.IPv6Loopback(),
 					1,
 				)),
+				nil,
 				tlsKey,
 				blsKey,
 			),
@@ -26,6 +26,18 @@ type Upgrader interface {
 	Upgrade(net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error)
 }
 
+// AuthenticatedConn is a connection whose transport authenticates the peer's
+// staking certificate. Upgrading an AuthenticatedConn calls Authenticate rather
+// than performing a TLS handshake.
+type AuthenticatedConn interface {
+	net.Conn
+
+	// Authenticate authenticates the peer, if it hasn't been authenticated
+	// already, and returns the staking certificate that the peer
+	// authenticated with.
+	Authenticate() (*staking.Certificate, error)
+}
+
 type tlsServerUpgrader struct {
 	config       *tls.Config
 	invalidCerts prometheus.Counter
@@ -39,6 +51,9 @@ func NewTLSServerUpgrader(config *tls.Config, invalidCerts prometheus.Counter) U
 }
 
 func (t *tlsServerUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	if conn, ok := conn.(AuthenticatedConn); ok {
+		return authenticatedConnToIDAndCert(conn)
+	}
 	return connToIDAndCert(tls.Server(conn, t.config), t.invalidCerts)
 }
 
@@ -55,6 +70,9 @@ func NewTLSClientUpgrader(config *tls.Config, invalidCerts prometheus.Counter) U
 }
 
 func (t *tlsClientUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	if conn, ok := conn.(AuthenticatedConn); ok {
+		return authenticatedConnToIDAndCert(conn)
+	}
 	return connToIDAndCert(tls.Client(conn, t.config), t.invalidCerts)
 }
 
@@ -78,3 +96,16 @@ func connToIDAndCert(conn *tls.Conn, invalidCerts prometheus.Counter) (ids.NodeI
 	nodeID := ids.NodeIDFromCert(peerCert)
 	return nodeID, conn, peerCert, nil
 }
+
+func authenticatedConnToIDAndCert(conn AuthenticatedConn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	peerCert, err := conn.Authenticate()
+	if err != nil {
+		return ids.EmptyNodeID, nil, nil, err
+	}
+	if peerCert == nil {
+		return ids.EmptyNodeID, nil, nil, errNoCert
+	}
+
+	nodeID := ids.NodeIDFromCert(peerCert)
+	return nodeID, conn, peerCert, nil
+}
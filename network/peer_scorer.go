@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+
+	safemath "github.com/MetalBlockchain/metalgo/utils/math"
+)
+
+// halfScoreRTT is the round trip time that halves the score of a peer.
+const halfScoreRTT = 250 * time.Millisecond
+
+var (
+	_ benchlist.Manager   = (*scoringBenchlist)(nil)
+	_ benchlist.Benchable = (*scoringBenchable)(nil)
+)
+
+// PeerScorer scores the quality of the connections to peers based on how
+// often requests sent to them time out, how often messages can't be queued to
+// be sent to them, how often they have been benched, and the round trip time
+// of their Pings.
+//
+// Only connected peers are scored, so that outcomes reported after a peer
+// disconnects don't leak memory.
+type PeerScorer struct {
+	clock    mockable.Clock
+	halflife time.Duration
+
+	lock  sync.Mutex
+	peers map[ids.NodeID]*peerHistory
+}
+
+type peerHistory struct {
+	// requestFailureRate is the rate that requests sent to the peer time out.
+	requestFailureRate safemath.Averager
+	// sendFailureRate is the rate that messages fail to be queued to be sent
+	// to the peer, which is typically caused by the peer's send queue being
+	// full.
+	sendFailureRate safemath.Averager
+	// numSendAttempts and numSendFailures are the peer's send counts as of
+	// the last time they were registered.
+	numSendAttempts uint64
+	numSendFailures uint64
+	// numBenchings is the number of times the peer was benched on any chain
+	// while connected.
+	numBenchings int
+}
+
+// NewPeerScorer returns a scorer whose failure rates are averaged with the
+// provided [halflife].
+func NewPeerScorer(halflife time.Duration) *PeerScorer {
+	return &PeerScorer{
+		halflife: halflife,
+		peers:    make(map[ids.NodeID]*peerHistory),
+	}
+}
+
+// Connected starts scoring [nodeID].
+func (s *PeerScorer) Connected(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.clock.Time()
+	s.peers[nodeID] = &peerHistory{
+		requestFailureRate: safemath.NewAverager(0, s.halflife, now),
+		sendFailureRate:    safemath.NewAverager(0, s.halflife, now),
+	}
+}
+
+// Disconnected stops scoring [nodeID].
+func (s *PeerScorer) Disconnected(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.peers, nodeID)
+}
+
+// RegisterResponse registers that a request sent to [nodeID] was responded to
+// within the timeout.
+func (s *PeerScorer) RegisterResponse(nodeID ids.NodeID) {
+	s.observeRequest(nodeID, 0)
+}
+
+// RegisterFailure registers that a request sent to [nodeID] timed out.
+func (s *PeerScorer) RegisterFailure(nodeID ids.NodeID) {
+	s.observeRequest(nodeID, 1)
+}
+
+func (s *PeerScorer) observeRequest(nodeID ids.NodeID, failed float64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if history, ok := s.peers[nodeID]; ok {
+		history.requestFailureRate.Observe(failed, s.clock.Time())
+	}
+}
+
+// RegisterSends registers the number of messages that have been attempted to
+// be sent to [nodeID] since it connected and the number of those that failed
+// to be queued. The peer counts its own sends, so that sending a message
+// doesn't contend on the scorer, and the counts are sampled periodically.
+func (s *PeerScorer) RegisterSends(nodeID ids.NodeID, numAttempts, numFailures uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	history, ok := s.peers[nodeID]
+	if !ok || numAttempts <= history.numSendAttempts {
+		return
+	}
+
+	newAttempts := numAttempts - history.numSendAttempts
+	newFailures := numFailures - history.numSendFailures
+	history.numSendAttempts = numAttempts
+	history.numSendFailures = numFailures
+	history.sendFailureRate.Observe(float64(newFailures)/float64(newAttempts), s.clock.Time())
+}
+
+// RegisterBenched registers that [nodeID] was benched on a chain.
+func (s *PeerScorer) RegisterBenched(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if history, ok := s.peers[nodeID]; ok {
+		history.numBenchings++
+	}
+}
+
+// Score returns the score, in [0, 1], of the connection to [nodeID] whose
+// round trip time is [rtt]. Higher scores are better. Peers that aren't being
+// scored have the best possible score.
+func (s *PeerScorer) Score(nodeID ids.NodeID, rtt time.Duration) float64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	score := float64(halfScoreRTT) / float64(halfScoreRTT+max(rtt, 0))
+	history, ok := s.peers[nodeID]
+	if !ok {
+		return score
+	}
+	score *= 1 - history.requestFailureRate.Read()
+	score *= 1 - history.sendFailureRate.Read()
+	return score / float64(1+history.numBenchings)
+}
+
+// NewScoringBenchlist returns a benchlist manager that reports the outcome of
+// requests to [scorer] in addition to [manager].
+func NewScoringBenchlist(manager benchlist.Manager, scorer *PeerScorer) benchlist.Manager {
+	return &scoringBenchlist{
+		Manager: manager,
+		scorer:  scorer,
+	}
+}
+
+type scoringBenchlist struct {
+	benchlist.Manager
+	scorer *PeerScorer
+}
+
+func (b *scoringBenchlist) RegisterResponse(chainID ids.ID, nodeID ids.NodeID) {
+	b.scorer.RegisterResponse(nodeID)
+	b.Manager.RegisterResponse(chainID, nodeID)
+}
+
+func (b *scoringBenchlist) RegisterFailure(chainID ids.ID, nodeID ids.NodeID) {
+	b.scorer.RegisterFailure(nodeID)
+	b.Manager.RegisterFailure(chainID, nodeID)
+}
+
+// NewScoringBenchable returns a benchable that reports benchings to [scorer]
+// in addition to [benchable].
+func NewScoringBenchable(benchable benchlist.Benchable, scorer *PeerScorer) benchlist.Benchable {
+	return &scoringBenchable{
+		Benchable: benchable,
+		scorer:    scorer,
+	}
+}
+
+type scoringBenchable struct {
+	benchlist.Benchable
+	scorer *PeerScorer
+}
+
+func (b *scoringBenchable) Benched(chainID ids.ID, nodeID ids.NodeID) {
+	b.scorer.RegisterBenched(nodeID)
+	b.Benchable.Benched(chainID, nodeID)
+}
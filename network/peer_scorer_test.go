@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+)
+
+func TestPeerScorer(t *testing.T) {
+	const delta = 1e-9
+
+	require := require.New(t)
+
+	scorer := NewPeerScorer(time.Minute)
+	scorer.clock.Set(time.Unix(1, 0))
+	nodeID := ids.GenerateTestNodeID()
+
+	// Outcomes of peers that aren't connected are ignored.
+	scorer.RegisterFailure(nodeID)
+	scorer.RegisterSends(nodeID, 1, 1)
+	scorer.RegisterBenched(nodeID)
+	require.Equal(1., scorer.Score(nodeID, 0))
+
+	scorer.Connected(nodeID)
+	require.Equal(1., scorer.Score(nodeID, 0))
+	require.Equal(.5, scorer.Score(nodeID, halfScoreRTT))
+
+	scorer.RegisterResponse(nodeID)
+	scorer.RegisterFailure(nodeID)
+	require.InDelta(2./3, scorer.Score(nodeID, 0), delta)
+
+	// Only the sends since the previous sample are observed.
+	scorer.RegisterSends(nodeID, 2, 2)
+	scorer.RegisterSends(nodeID, 4, 2)
+	require.InDelta(4./9, scorer.Score(nodeID, 0), delta)
+
+	// Samples without new sends are ignored.
+	scorer.RegisterSends(nodeID, 4, 2)
+	require.InDelta(4./9, scorer.Score(nodeID, 0), delta)
+
+	scorer.RegisterBenched(nodeID)
+	require.InDelta(2./9, scorer.Score(nodeID, 0), delta)
+
+	// Reconnecting resets the score.
+	scorer.Disconnected(nodeID)
+	scorer.Connected(nodeID)
+	require.Equal(1., scorer.Score(nodeID, 0))
+}
+
+type recordingBenchable struct {
+	benched []ids.NodeID
+}
+
+func (b *recordingBenchable) Benched(_ ids.ID, nodeID ids.NodeID) {
+	b.benched = append(b.benched, nodeID)
+}
+
+func (*recordingBenchable) Unbenched(ids.ID, ids.NodeID) {}
+
+func TestScoringBenchlist(t *testing.T) {
+	require := require.New(t)
+
+	scorer := NewPeerScorer(time.Minute)
+	scorer.clock.Set(time.Unix(1, 0))
+	nodeID := ids.GenerateTestNodeID()
+	scorer.Connected(nodeID)
+
+	manager := NewScoringBenchlist(benchlist.NewNoBenchlist(), scorer)
+	manager.RegisterFailure(ids.Empty, nodeID)
+	require.Equal(.5, scorer.Score(nodeID, 0))
+
+	benchable := &recordingBenchable{}
+	NewScoringBenchable(benchable, scorer).Benched(ids.Empty, nodeID)
+	require.Equal([]ids.NodeID{nodeID}, benchable.benched)
+	require.Equal(.25, scorer.Score(nodeID, 0))
+}
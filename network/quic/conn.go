@@ -0,0 +1,287 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	xquic "golang.org/x/net/quic"
+
+	"github.com/MetalBlockchain/metalgo/network/peer"
+	"github.com/MetalBlockchain/metalgo/staking"
+	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const numLanes = int(peer.AppLane) + 1
+
+var (
+	_ peer.AuthenticatedConn = (*Conn)(nil)
+	_ peer.LaneConn          = (*Conn)(nil)
+)
+
+// Conn is a QUIC connection to a peer.
+//
+// Each lane is sent over its own stream, so that a lost packet of one lane
+// doesn't delay the delivery of the other lanes. Reads return the messages of
+// all the lanes, each prefixed with its length, in the order that they were
+// fully received.
+//
+// The streams are only opened once the peer is authenticated, so the
+// connection must not be written to before Authenticate succeeds.
+type Conn struct {
+	conn       *xquic.Conn
+	localAddr  net.Addr
+	remoteAddr net.Addr
+
+	authenticate func() ([numLanes]*xquic.Stream, *staking.Certificate, error)
+	authOnce     sync.Once
+	authErr      error
+	peerCert     *staking.Certificate
+
+	// messages receives the length prefixed messages read from the streams.
+	messages chan []byte
+	// unread is the remainder of the message that is currently being read.
+	// It is only accessed by the goroutine calling Read.
+	unread       []byte
+	readDeadline utils.Atomic[time.Time]
+
+	// writeLock protects streams and cancelWrite.
+	writeLock   sync.Mutex
+	streams     [numLanes]*xquic.Stream
+	cancelWrite context.CancelFunc
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// newConn returns a connection that opens its streams once [authenticate]
+// succeeds.
+func newConn(
+	conn *xquic.Conn,
+	localAddr net.Addr,
+	remoteAddr net.Addr,
+	authenticate func() ([numLanes]*xquic.Stream, *staking.Certificate, error),
+) *Conn {
+	return &Conn{
+		conn:         conn,
+		localAddr:    localAddr,
+		remoteAddr:   remoteAddr,
+		authenticate: authenticate,
+		messages:     make(chan []byte),
+		closed:       make(chan struct{}),
+	}
+}
+
+// Authenticate authenticates the peer, if it hasn't been authenticated
+// already, and returns the staking certificate that the peer authenticated
+// with. If authentication fails, the connection is closed.
+func (c *Conn) Authenticate() (*staking.Certificate, error) {
+	c.authOnce.Do(func() {
+		streams, peerCert, err := c.authenticate()
+		if err != nil {
+			c.authErr = err
+			c.conn.Abort(err)
+			_ = c.Close()
+			return
+		}
+
+		c.peerCert = peerCert
+
+		c.writeLock.Lock()
+		defer c.writeLock.Unlock()
+
+		c.streams = streams
+		for _, stream := range streams {
+			stream.SetReadContext(context.Background())
+			stream.SetWriteContext(context.Background())
+			go c.readMessages(stream)
+		}
+	})
+	return c.peerCert, c.authErr
+}
+
+// readMessages reads length prefixed messages from [stream] until the
+// connection is closed.
+func (c *Conn) readMessages(stream *xquic.Stream) {
+	defer c.Close()
+
+	for {
+		msgLenBytes := make([]byte, wrappers.IntLen)
+		if _, err := io.ReadFull(stream, msgLenBytes); err != nil {
+			return
+		}
+
+		msgLen, err := peer.ReadMsgLen(msgLenBytes, constants.DefaultMaxMessageSize)
+		if err != nil {
+			return
+		}
+
+		msg := make([]byte, wrappers.IntLen+msgLen)
+		copy(msg, msgLenBytes)
+		if _, err := io.ReadFull(stream, msg[wrappers.IntLen:]); err != nil {
+			return
+		}
+
+		select {
+		case c.messages <- msg:
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+func (c *Conn) Read(b []byte) (int, error) {
+	if len(c.unread) == 0 {
+		var timeout <-chan time.Time
+		if deadline := c.readDeadline.Get(); !deadline.IsZero() {
+			timer := time.NewTimer(time.Until(deadline))
+			defer timer.Stop()
+			timeout = timer.C
+		}
+
+		select {
+		case msg := <-c.messages:
+			c.unread = msg
+		case <-c.closed:
+			return 0, net.ErrClosed
+		case <-timeout:
+			return 0, os.ErrDeadlineExceeded
+		}
+	}
+
+	n := copy(b, c.unread)
+	c.unread = c.unread[n:]
+	return n, nil
+}
+
+// Write sends [b] in the consensus lane.
+func (c *Conn) Write(b []byte) (int, error) {
+	return c.LaneWriter(peer.ConsensusLane).Write(b)
+}
+
+// LaneWriter returns a writer that sends the messages of [lane] over the
+// lane's stream.
+func (c *Conn) LaneWriter(lane peer.Lane) io.Writer {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	return streamWriter{stream: c.streams[lane]}
+}
+
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+
+		c.writeLock.Lock()
+		if c.cancelWrite != nil {
+			c.cancelWrite()
+		}
+		c.writeLock.Unlock()
+
+		c.conn.Abort(nil)
+	})
+	return nil
+}
+
+func (c *Conn) LocalAddr() net.Addr {
+	return c.localAddr
+}
+
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+func (c *Conn) SetDeadline(t time.Time) error {
+	return errors.Join(
+		c.SetReadDeadline(t),
+		c.SetWriteDeadline(t),
+	)
+}
+
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.readDeadline.Set(t)
+	return nil
+}
+
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	select {
+	case <-c.closed:
+		return net.ErrClosed
+	default:
+	}
+
+	if c.cancelWrite != nil {
+		c.cancelWrite()
+	}
+
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if !t.IsZero() {
+		ctx, cancel = context.WithDeadline(ctx, t)
+	}
+	c.cancelWrite = cancel
+	for _, stream := range c.streams {
+		if stream != nil {
+			stream.SetWriteContext(ctx)
+		}
+	}
+	return nil
+}
+
+type streamWriter struct {
+	stream *xquic.Stream
+}
+
+// Write sends [b] immediately, as callers are expected to buffer their writes.
+func (w streamWriter) Write(b []byte) (int, error) {
+	n, err := w.stream.Write(b)
+	if err != nil {
+		return n, err
+	}
+	w.stream.Flush()
+	return n, nil
+}
+
+// writeMsg writes [msg] to [stream] prefixed with its length.
+func writeMsg(stream *xquic.Stream, msg []byte) error {
+	msgLenBytes, err := peer.WriteMsgLen(uint32(len(msg)), constants.DefaultMaxMessageSize)
+	if err != nil {
+		return err
+	}
+	if _, err := stream.Write(msgLenBytes[:]); err != nil {
+		return err
+	}
+	if _, err := stream.Write(msg); err != nil {
+		return err
+	}
+	stream.Flush()
+	return nil
+}
+
+// readMsg reads a message written by [writeMsg] from [stream].
+func readMsg(stream *xquic.Stream, maxMsgLen uint32) ([]byte, error) {
+	msgLenBytes := make([]byte, wrappers.IntLen)
+	if _, err := io.ReadFull(stream, msgLenBytes); err != nil {
+		return nil, err
+	}
+
+	msgLen, err := peer.ReadMsgLen(msgLenBytes, maxMsgLen)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := make([]byte, msgLen)
+	_, err = io.ReadFull(stream, msg)
+	return msg, err
+}
@@ -0,0 +1,355 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package quic connects to peers over QUIC.
+//
+// The QUIC TLS handshake authenticates the server's staking certificate to the
+// client. Because the resulting connection doesn't expose the certificate the
+// client authenticated with, the client additionally proves ownership of its
+// staking key by signing a challenge chosen by the server along with the
+// server's certificate. Including the server's certificate prevents the proof
+// from being relayed to a different server.
+package quic
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	xquic "golang.org/x/net/quic"
+
+	"github.com/MetalBlockchain/metalgo/network/peer"
+	"github.com/MetalBlockchain/metalgo/staking"
+	"github.com/MetalBlockchain/metalgo/utils/hashing"
+)
+
+const (
+	// NextProto is the ALPN protocol negotiated by QUIC peer connections.
+	NextProto = "metalgo/p2p"
+
+	challengeLen = 32
+	// maxCertLen is the max length of a staking certificate, which is enforced
+	// by the staking package when parsing the certificate.
+	maxCertLen = staking.MaxCertificateLen
+	// maxSignatureLen is larger than the signature of any supported staking
+	// key.
+	maxSignatureLen = 1024
+
+	keepAlivePeriod = 15 * time.Second
+	maxIdleTimeout  = time.Minute
+	closeTimeout    = time.Second
+)
+
+var (
+	_ net.Listener = (*Transport)(nil)
+
+	authDomain = []byte("metalgo quic peer authentication")
+
+	errNoTLSCertificate = errors.New("tls config has no certificate")
+	errNoPeerCert       = errors.New("quic handshake finished with no peer certificate")
+	errUnexpectedLane   = errors.New("unexpected lane")
+)
+
+// Transport listens for, and dials, authenticated QUIC connections to peers.
+//
+// Transport implements net.Listener, so that it can be used alongside the TCP
+// listener, and its Dial method matches dialer.Dialer. Inbound connections are
+// returned by Accept before the client is authenticated, so that they are
+// rate-limited the same way as TCP connections before any signatures are
+// verified. They are authenticated when they are upgraded.
+type Transport struct {
+	endpoint         *xquic.Endpoint
+	config           *xquic.Config
+	signer           crypto.Signer
+	cert             *x509.Certificate
+	handshakeTimeout time.Duration
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+}
+
+// Listen creates a transport that accepts QUIC connections on [address].
+//
+// [tlsConfig] must contain this node's staking certificate and [signer] must be
+// its staking key. Connections, including their authentication, that aren't
+// authenticated within [handshakeTimeout] are dropped.
+func Listen(
+	address string,
+	tlsConfig *tls.Config,
+	signer crypto.Signer,
+	handshakeTimeout time.Duration,
+) (*Transport, error) {
+	if len(tlsConfig.Certificates) == 0 || len(tlsConfig.Certificates[0].Certificate) == 0 {
+		return nil, errNoTLSCertificate
+	}
+	cert, err := x509.ParseCertificate(tlsConfig.Certificates[0].Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.NextProtos = []string{NextProto}
+	config := &xquic.Config{
+		TLSConfig:        tlsConfig,
+		HandshakeTimeout: handshakeTimeout,
+		MaxIdleTimeout:   maxIdleTimeout,
+		KeepAlivePeriod:  keepAlivePeriod,
+	}
+	endpoint, err := xquic.Listen("udp", address, config)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Transport{
+		endpoint:         endpoint,
+		config:           config,
+		signer:           signer,
+		cert:             cert,
+		handshakeTimeout: handshakeTimeout,
+		ctx:              ctx,
+		cancel:           cancel,
+	}, nil
+}
+
+// accept authenticates the client of [conn]. The client opens the stream of
+// each lane, in order, by sending the lane. The consensus lane's stream is
+// used to authenticate the client before the other streams are opened.
+func (t *Transport) accept(conn *xquic.Conn) ([numLanes]*xquic.Stream, *staking.Certificate, error) {
+	var streams [numLanes]*xquic.Stream
+	ctx, cancel := context.WithTimeout(t.ctx, t.handshakeTimeout)
+	defer cancel()
+
+	consensusStream, err := acceptLane(ctx, conn, peer.ConsensusLane)
+	if err != nil {
+		return streams, nil, err
+	}
+
+	challenge := make([]byte, challengeLen)
+	if _, err := rand.Read(challenge); err != nil {
+		return streams, nil, err
+	}
+	if err := writeMsg(consensusStream, challenge); err != nil {
+		return streams, nil, err
+	}
+
+	certBytes, err := readMsg(consensusStream, maxCertLen)
+	if err != nil {
+		return streams, nil, err
+	}
+	signature, err := readMsg(consensusStream, maxSignatureLen)
+	if err != nil {
+		return streams, nil, err
+	}
+
+	peerCert, err := staking.ParseCertificate(certBytes)
+	if err != nil {
+		return streams, nil, err
+	}
+	msg := authMessage(challenge, t.cert.Raw)
+	if err := staking.CheckSignature(peerCert, msg, signature); err != nil {
+		return streams, nil, err
+	}
+
+	streams[peer.ConsensusLane] = consensusStream
+	for lane := peer.ConsensusLane + 1; int(lane) < numLanes; lane++ {
+		streams[lane], err = acceptLane(ctx, conn, lane)
+		if err != nil {
+			return streams, nil, err
+		}
+	}
+
+	return streams, peerCert, nil
+}
+
+// Dial connects to, and authenticates with, the peer listening on [ip]. The
+// returned connection is authenticated with the peer's staking certificate.
+func (t *Transport) Dial(ctx context.Context, ip netip.AddrPort) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.handshakeTimeout)
+	defer cancel()
+
+	// The server's certificate is recorded during the TLS handshake, after
+	// crypto/tls has verified that the server owns the certificate's key.
+	var serverCert *x509.Certificate
+	config := t.config.Clone()
+	config.TLSConfig = config.TLSConfig.Clone()
+	config.TLSConfig.VerifyConnection = func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return errNoPeerCert
+		}
+		serverCert = state.PeerCertificates[0]
+		return nil
+	}
+
+	conn, err := t.endpoint.Dial(ctx, "udp", ip.String(), config)
+	if err != nil {
+		return nil, err
+	}
+
+	streams, peerCert, err := t.dial(ctx, conn, serverCert)
+	if err != nil {
+		conn.Abort(err)
+		return nil, err
+	}
+
+	c := newConn(
+		conn,
+		t.Addr(),
+		net.UDPAddrFromAddrPort(ip),
+		func() ([numLanes]*xquic.Stream, *staking.Certificate, error) {
+			return streams, peerCert, nil
+		},
+	)
+	if _, err := c.Authenticate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (t *Transport) dial(
+	ctx context.Context,
+	conn *xquic.Conn,
+	serverCert *x509.Certificate,
+) ([numLanes]*xquic.Stream, *staking.Certificate, error) {
+	var streams [numLanes]*xquic.Stream
+	if serverCert == nil {
+		return streams, nil, errNoPeerCert
+	}
+	peerCert, err := staking.ParseCertificate(serverCert.Raw)
+	if err != nil {
+		return streams, nil, err
+	}
+
+	consensusStream, err := openLane(ctx, conn, peer.ConsensusLane)
+	if err != nil {
+		return streams, nil, err
+	}
+
+	challenge, err := readMsg(consensusStream, challengeLen)
+	if err != nil {
+		return streams, nil, err
+	}
+	msg := authMessage(challenge, serverCert.Raw)
+	signature, err := t.signer.Sign(rand.Reader, hashing.ComputeHash256(msg), crypto.SHA256)
+	if err != nil {
+		return streams, nil, err
+	}
+	if err := writeMsg(consensusStream, t.cert.Raw); err != nil {
+		return streams, nil, err
+	}
+	if err := writeMsg(consensusStream, signature); err != nil {
+		return streams, nil, err
+	}
+
+	streams[peer.ConsensusLane] = consensusStream
+	for lane := peer.ConsensusLane + 1; int(lane) < numLanes; lane++ {
+		streams[lane], err = openLane(ctx, conn, lane)
+		if err != nil {
+			return streams, nil, err
+		}
+	}
+
+	return streams, peerCert, nil
+}
+
+// Accept returns the next inbound connection. The client of the connection is
+// authenticated by calling Authenticate.
+func (t *Transport) Accept() (net.Conn, error) {
+	conn, err := t.endpoint.Accept(t.ctx)
+	if err != nil {
+		if t.ctx.Err() != nil {
+			return nil, net.ErrClosed
+		}
+		return nil, err
+	}
+
+	return newConn(
+		conn,
+		t.Addr(),
+		net.UDPAddrFromAddrPort(conn.RemoteAddr()),
+		func() ([numLanes]*xquic.Stream, *staking.Certificate, error) {
+			return t.accept(conn)
+		},
+	), nil
+}
+
+func (t *Transport) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		t.cancel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+		defer cancel()
+		err = t.endpoint.Close(ctx)
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = nil
+		}
+	})
+	return err
+}
+
+func (t *Transport) Addr() net.Addr {
+	return net.UDPAddrFromAddrPort(t.endpoint.LocalAddr())
+}
+
+// Port returns the UDP port that the transport is listening on.
+func (t *Transport) Port() uint16 {
+	return t.endpoint.LocalAddr().Port()
+}
+
+// openLane opens the stream of [lane] by sending the lane to the server. Reads
+// and writes on the stream are bounded by [ctx] until the connection is
+// created.
+func openLane(ctx context.Context, conn *xquic.Conn, lane peer.Lane) (*xquic.Stream, error) {
+	stream, err := conn.NewStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stream.SetReadContext(ctx)
+	stream.SetWriteContext(ctx)
+	if err := stream.WriteByte(byte(lane)); err != nil {
+		return nil, err
+	}
+	stream.Flush()
+	return stream, nil
+}
+
+// acceptLane accepts the next stream opened by the client and verifies that it
+// is the stream of [lane]. Reads and writes on the stream are bounded by [ctx]
+// until the connection is created.
+func acceptLane(ctx context.Context, conn *xquic.Conn, lane peer.Lane) (*xquic.Stream, error) {
+	stream, err := conn.AcceptStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stream.SetReadContext(ctx)
+	stream.SetWriteContext(ctx)
+
+	gotLane, err := stream.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if peer.Lane(gotLane) != lane {
+		return nil, fmt.Errorf("%w: expected %s but got %d", errUnexpectedLane, lane, gotLane)
+	}
+	return stream, nil
+}
+
+// authMessage returns the message the client signs to prove ownership of its
+// staking key to the server with certificate [serverCert].
+func authMessage(challenge []byte, serverCert []byte) []byte {
+	msg := make([]byte, 0, len(authDomain)+len(challenge)+len(serverCert))
+	msg = append(msg, authDomain...)
+	msg = append(msg, challenge...)
+	return append(msg, serverCert...)
+}
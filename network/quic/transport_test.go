@@ -0,0 +1,220 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/peer"
+	"github.com/MetalBlockchain/metalgo/staking"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const testTimeout = 10 * time.Second
+
+type testTransport struct {
+	*Transport
+	nodeID ids.NodeID
+}
+
+func newTestTransport(t *testing.T, signer crypto.Signer) *testTransport {
+	t.Helper()
+	require := require.New(t)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	cert, err := staking.ParseCertificate(tlsCert.Leaf.Raw)
+	require.NoError(err)
+
+	if signer == nil {
+		signer = tlsCert.PrivateKey.(crypto.Signer)
+	}
+	transport, err := Listen(
+		"127.0.0.1:0",
+		peer.TLSConfig(*tlsCert, nil),
+		signer,
+		testTimeout,
+	)
+	require.NoError(err)
+	t.Cleanup(func() {
+		require.NoError(transport.Close())
+	})
+
+	return &testTransport{
+		Transport: transport,
+		nodeID:    ids.NodeIDFromCert(cert),
+	}
+}
+
+func (t *testTransport) addrPort() netip.AddrPort {
+	return netip.AddrPortFrom(netip.AddrFrom4([4]byte{127, 0, 0, 1}), t.Port())
+}
+
+func connect(t *testing.T, client, server *testTransport) (net.Conn, net.Conn) {
+	t.Helper()
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	// The server only authenticates the client once the connection is
+	// upgraded, which the client waits for while dialing.
+	serverConns := make(chan net.Conn, 1)
+	go func() {
+		defer close(serverConns)
+
+		serverConn, err := server.Accept()
+		if err != nil {
+			return
+		}
+		if _, err := serverConn.(peer.AuthenticatedConn).Authenticate(); err != nil {
+			return
+		}
+		serverConns <- serverConn
+	}()
+
+	clientConn, err := client.Dial(ctx, server.addrPort())
+	require.NoError(err)
+	serverConn, ok := <-serverConns
+	require.True(ok)
+	return clientConn, serverConn
+}
+
+func writeFrame(t *testing.T, w io.Writer, msg string) {
+	t.Helper()
+
+	frame := binary.BigEndian.AppendUint32(nil, uint32(len(msg)))
+	frame = append(frame, msg...)
+	_, err := w.Write(frame)
+	require.NoError(t, err)
+}
+
+func readFrame(t *testing.T, r io.Reader) string {
+	t.Helper()
+	require := require.New(t)
+
+	msgLenBytes := make([]byte, wrappers.IntLen)
+	_, err := io.ReadFull(r, msgLenBytes)
+	require.NoError(err)
+
+	msg := make([]byte, binary.BigEndian.Uint32(msgLenBytes))
+	_, err = io.ReadFull(r, msg)
+	require.NoError(err)
+	return string(msg)
+}
+
+func TestDialAuthenticatesPeers(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestTransport(t, nil)
+	server := newTestTransport(t, nil)
+	clientConn, serverConn := connect(t, client, server)
+	defer func() {
+		require.NoError(clientConn.Close())
+		require.NoError(serverConn.Close())
+	}()
+
+	clientUpgrader := peer.NewTLSClientUpgrader(nil, prometheus.NewCounter(prometheus.CounterOpts{}))
+	serverNodeID, _, _, err := clientUpgrader.Upgrade(clientConn)
+	require.NoError(err)
+	require.Equal(server.nodeID, serverNodeID)
+
+	serverUpgrader := peer.NewTLSServerUpgrader(nil, prometheus.NewCounter(prometheus.CounterOpts{}))
+	clientNodeID, _, _, err := serverUpgrader.Upgrade(serverConn)
+	require.NoError(err)
+	require.Equal(client.nodeID, clientNodeID)
+
+	remoteAddr, err := netip.ParseAddrPort(serverConn.RemoteAddr().String())
+	require.NoError(err)
+	require.Equal(client.Port(), remoteAddr.Port())
+}
+
+func TestLanes(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestTransport(t, nil)
+	server := newTestTransport(t, nil)
+	clientConn, serverConn := connect(t, client, server)
+	defer func() {
+		require.NoError(clientConn.Close())
+		require.NoError(serverConn.Close())
+	}()
+
+	laneConn := clientConn.(peer.LaneConn)
+	writeFrame(t, laneConn.LaneWriter(peer.AppLane), "app")
+	writeFrame(t, laneConn.LaneWriter(peer.BootstrapLane), "bootstrap")
+	writeFrame(t, clientConn, "consensus")
+
+	require.NoError(serverConn.SetReadDeadline(time.Now().Add(testTimeout)))
+	received := make(map[string]bool)
+	for range numLanes {
+		received[readFrame(t, serverConn)] = true
+	}
+	require.Equal(
+		map[string]bool{
+			"app":       true,
+			"bootstrap": true,
+			"consensus": true,
+		},
+		received,
+	)
+}
+
+func TestReadDeadline(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestTransport(t, nil)
+	server := newTestTransport(t, nil)
+	clientConn, serverConn := connect(t, client, server)
+	defer func() {
+		require.NoError(clientConn.Close())
+		require.NoError(serverConn.Close())
+	}()
+
+	require.NoError(serverConn.SetReadDeadline(time.Now().Add(10 * time.Millisecond)))
+	_, err := serverConn.Read(make([]byte, 1))
+	require.ErrorIs(err, os.ErrDeadlineExceeded)
+}
+
+func TestInvalidProofRejected(t *testing.T) {
+	require := require.New(t)
+
+	// The client signs with a key that doesn't match its certificate, so it
+	// can't prove that it owns the certificate.
+	otherCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	client := newTestTransport(t, otherCert.PrivateKey.(crypto.Signer))
+	server := newTestTransport(t, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	go func() {
+		clientConn, err := client.Dial(ctx, server.addrPort())
+		if err == nil {
+			_ = clientConn.Close()
+		}
+	}()
+
+	serverConn, err := server.Accept()
+	require.NoError(err)
+	_, err = serverConn.(peer.AuthenticatedConn).Authenticate()
+	require.ErrorIs(err, staking.ErrECDSAVerificationFailure)
+
+	// The connection is closed once the proof fails to verify.
+	_, err = serverConn.Read(make([]byte, 1))
+	require.ErrorIs(err, net.ErrClosed)
+}
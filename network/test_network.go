@@ -85,6 +85,8 @@ func NewTestNetwork(
 		logging.NoLog{},
 		metrics,
 		constants.DefaultNetworkCompressionType,
+		nil,
+		"",
 		constants.DefaultNetworkMaximumInboundTimeout,
 	)
 	if err != nil {
@@ -130,6 +132,11 @@ func NewTestNetwork(
 				PeerListPullGossipFreq:  constants.DefaultNetworkPeerListPullGossipFreq,
 				PeerListBloomResetFreq:  constants.DefaultNetworkPeerListBloomResetFreq,
 			},
+			PeerRotationConfig: PeerRotationConfig{
+				PeerRotationFrequency: constants.DefaultNetworkPeerRotationFrequency,
+				PeerRotationPortion:   constants.DefaultNetworkPeerRotationPortion,
+				PeerScoreHalflife:     constants.DefaultNetworkPeerScoreHalflife,
+			},
 			TimeoutConfig: TimeoutConfig{
 				PingPongTimeout:      constants.DefaultPingPongTimeout,
 				ReadHandshakeTimeout: constants.DefaultNetworkReadHandshakeTimeout,
@@ -159,6 +166,9 @@ func NewTestNetwork(
 					DiskThrottlerConfig: throttling.SystemThrottlerConfig{
 						MaxRecheckDelay: constants.DefaultInboundThrottlerDiskMaxRecheckDelay,
 					},
+					MemoryThrottlerConfig: throttling.SystemThrottlerConfig{
+						MaxRecheckDelay: constants.DefaultInboundThrottlerMemoryMaxRecheckDelay,
+					},
 					MaxProcessingMsgsPerNode: constants.DefaultInboundThrottlerMaxProcessingMsgsPerNode,
 				},
 				OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
@@ -216,6 +226,16 @@ func NewTestNetwork(
 				currentValidators,
 				resourceTracker.DiskTracker(),
 			),
+			MemoryTargeter: tracker.NewTargeter(
+				logging.NoLog{},
+				&tracker.TargeterConfig{
+					VdrAlloc:           1000 * units.GiB,
+					MaxNonVdrUsage:     1000 * units.GiB,
+					MaxNonVdrNodeUsage: 1000 * units.GiB,
+				},
+				currentValidators,
+				resourceTracker.MemoryTracker(),
+			),
 		},
 		upgrade.InitiallyActiveTime,
 		msgCreator,
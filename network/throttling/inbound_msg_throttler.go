@@ -12,6 +12,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 )
 
 var _ InboundMsgThrottler = (*inboundMsgThrottler)(nil)
@@ -47,6 +48,7 @@ type InboundMsgThrottlerConfig struct {
 	BandwidthThrottlerConfig `json:"bandwidthThrottlerConfig"`
 	CPUThrottlerConfig       SystemThrottlerConfig `json:"cpuThrottlerConfig"`
 	DiskThrottlerConfig      SystemThrottlerConfig `json:"diskThrottlerConfig"`
+	MemoryThrottlerConfig    SystemThrottlerConfig `json:"memoryThrottlerConfig"`
 	MaxProcessingMsgsPerNode uint64                `json:"maxProcessingMsgsPerNode"`
 }
 
@@ -59,6 +61,7 @@ func NewInboundMsgThrottler(
 	resourceTracker tracker.ResourceTracker,
 	cpuTargeter tracker.Targeter,
 	diskTargeter tracker.Targeter,
+	memoryTargeter tracker.Targeter,
 ) (InboundMsgThrottler, error) {
 	byteThrottler, err := newInboundMsgByteThrottler(
 		log,
@@ -104,12 +107,25 @@ func NewInboundMsgThrottler(
 	if err != nil {
 		return nil, err
 	}
+	memoryTracker := resourceTracker.MemoryTracker()
+	memoryThrottler, err := NewSystemThrottler(
+		"memory",
+		registerer,
+		throttlerConfig.MemoryThrottlerConfig,
+		memoryTracker,
+		memoryTargeter,
+	)
+	if err != nil {
+		return nil, err
+	}
 	return &inboundMsgThrottler{
 		byteThrottler:      byteThrottler,
 		bufferThrottler:    bufferThrottler,
 		bandwidthThrottler: bandwidthThrottler,
 		cpuThrottler:       cpuThrottler,
 		diskThrottler:      diskThrottler,
+		memoryThrottler:    memoryThrottler,
+		memoryTracker:      memoryTracker,
 	}, nil
 }
 
@@ -124,11 +140,13 @@ func NewInboundMsgThrottler(
 //     that we're currently processing takes up n units of space on the buffer.
 //  3. Bandwidth. The bandwidth rate-limiting is implemented using a token
 //     bucket, where each token is 1 byte. See BandwidthThrottler.
+//  4. CPU, disk and memory usage caused by each node. See SystemThrottler.
 //
 // A call to Acquire([msgSize], [nodeID]) blocks until we've secured
 // enough of both these resources to read a message of size [msgSize] from
 // [nodeID].
 type inboundMsgThrottler struct {
+	clock mockable.Clock
 	// Rate-limits based on number of messages from a given node that we're
 	// currently processing.
 	bufferThrottler *inboundMsgBufferThrottler
@@ -141,6 +159,10 @@ type inboundMsgThrottler struct {
 	cpuThrottler SystemThrottler
 	// Rate-limits based on disk usage caused by a given node.
 	diskThrottler SystemThrottler
+	// Rate-limits based on memory usage caused by a given node.
+	memoryThrottler SystemThrottler
+	// Attributes the memory held by messages being processed to their sender.
+	memoryTracker tracker.MemoryTracker
 }
 
 // Returns when we can read a message of size [msgSize] from node [nodeID].
@@ -157,9 +179,14 @@ func (t *inboundMsgThrottler) Acquire(ctx context.Context, msgSize uint64, nodeI
 	t.cpuThrottler.Acquire(ctx, nodeID)
 	// Wait until our disk usage drops to an acceptable level.
 	t.diskThrottler.Acquire(ctx, nodeID)
+	// Wait until our memory usage drops to an acceptable level.
+	t.memoryThrottler.Acquire(ctx, nodeID)
 	// Acquire space on the inbound message byte buffer
 	byteRelease := t.byteThrottler.Acquire(ctx, msgSize, nodeID)
+	// The message is held in memory until it is released.
+	t.memoryTracker.StartBuffering(nodeID, t.clock.Time(), msgSize)
 	return func() {
+		t.memoryTracker.StopBuffering(nodeID, t.clock.Time(), msgSize)
 		bufferRelease()
 		byteRelease()
 	}
@@ -0,0 +1,195 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+)
+
+const subnetIDLabel = "subnetID"
+
+var (
+	_ SubnetInboundMsgThrottler = (*subnetInboundMsgThrottler)(nil)
+	_ SubnetInboundMsgThrottler = (*noSubnetInboundMsgThrottler)(nil)
+
+	errReservedExceedsAlloc = errors.New("primary network reserved size exceeds alloc size")
+)
+
+// SubnetInboundMsgThrottler limits the number of bytes of inbound messages
+// that may be processing at once on behalf of each subnet. Unlike the
+// InboundMsgThrottler, messages are never blocked. A message that would exceed
+// its subnet's allocation should be dropped.
+type SubnetInboundMsgThrottler interface {
+	// Acquire attempts to allocate [msgSize] bytes for a message sent to a
+	// chain of [subnetID]. If the bytes can't be allocated, false is returned.
+	// Otherwise, the returned release function must be called when done
+	// processing the message.
+	// It's safe for multiple goroutines to concurrently call Acquire.
+	Acquire(msgSize uint64, subnetID ids.ID) (ReleaseFunc, bool)
+}
+
+type SubnetInboundMsgThrottlerConfig struct {
+	// AllocSize is the max number of bytes of inbound messages that may be
+	// processing at once across all subnets, including the primary network.
+	AllocSize uint64 `json:"allocSize"`
+	// PrimaryNetworkReservedSize is the number of bytes of [AllocSize] that
+	// can't be used by subnets other than the primary network.
+	PrimaryNetworkReservedSize uint64 `json:"primaryNetworkReservedSize"`
+}
+
+func (c *SubnetInboundMsgThrottlerConfig) Verify() error {
+	if c.PrimaryNetworkReservedSize > c.AllocSize {
+		return errReservedExceedsAlloc
+	}
+	return nil
+}
+
+// NewSubnetInboundMsgThrottler returns a throttler that allows each subnet in
+// [subnetAllocSizes] to use at most its allocation. Subnets without an
+// allocation are only limited by [config].
+//
+// Messages for the primary network are never dropped, but they count towards
+// [config.AllocSize].
+func NewSubnetInboundMsgThrottler(
+	registerer prometheus.Registerer,
+	config SubnetInboundMsgThrottlerConfig,
+	subnetAllocSizes map[ids.ID]uint64,
+) (SubnetInboundMsgThrottler, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	t := &subnetInboundMsgThrottler{
+		maxBytes:         config.AllocSize,
+		maxSubnetBytes:   config.AllocSize - config.PrimaryNetworkReservedSize,
+		subnetAllocSizes: subnetAllocSizes,
+		subnetBytesUsed:  make(map[ids.ID]uint64),
+	}
+	if err := t.metrics.initialize(registerer); err != nil {
+		return nil, err
+	}
+	for subnetID, allocSize := range subnetAllocSizes {
+		t.metrics.allocSize.WithLabelValues(subnetID.String()).Set(float64(allocSize))
+	}
+	return t, nil
+}
+
+type subnetInboundMsgThrottler struct {
+	metrics subnetInboundMsgThrottlerMetrics
+
+	// Max number of bytes that may be processing across all subnets.
+	maxBytes uint64
+	// Max number of bytes that may be processing across all subnets other
+	// than the primary network.
+	maxSubnetBytes uint64
+	// Subnet ID --> Max number of bytes that may be processing for the
+	// subnet. If a subnet isn't in the map, it doesn't have its own limit.
+	subnetAllocSizes map[ids.ID]uint64
+
+	lock sync.Mutex
+	// Number of bytes processing across all subnets.
+	bytesUsed uint64
+	// Number of bytes processing across all subnets other than the primary
+	// network.
+	subnetsBytesUsed uint64
+	// Subnet ID --> Number of bytes processing for the subnet.
+	subnetBytesUsed map[ids.ID]uint64
+}
+
+func (t *subnetInboundMsgThrottler) Acquire(msgSize uint64, subnetID ids.ID) (ReleaseFunc, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if subnetID != constants.PrimaryNetworkID {
+		allocSize, hasAllocSize := t.subnetAllocSizes[subnetID]
+		switch {
+		case t.bytesUsed+msgSize > t.maxBytes,
+			t.subnetsBytesUsed+msgSize > t.maxSubnetBytes,
+			hasAllocSize && t.subnetBytesUsed[subnetID]+msgSize > allocSize:
+			t.metrics.dropped.WithLabelValues(subnetID.String()).Inc()
+			return nil, false
+		}
+		t.subnetsBytesUsed += msgSize
+	}
+
+	t.bytesUsed += msgSize
+	t.subnetBytesUsed[subnetID] += msgSize
+	t.metrics.bytesUsed.WithLabelValues(subnetID.String()).Set(float64(t.subnetBytesUsed[subnetID]))
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.release(msgSize, subnetID)
+		})
+	}, true
+}
+
+func (t *subnetInboundMsgThrottler) release(msgSize uint64, subnetID ids.ID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.bytesUsed -= msgSize
+	if subnetID != constants.PrimaryNetworkID {
+		t.subnetsBytesUsed -= msgSize
+	}
+	t.subnetBytesUsed[subnetID] -= msgSize
+	bytesUsed := t.subnetBytesUsed[subnetID]
+	if bytesUsed == 0 {
+		delete(t.subnetBytesUsed, subnetID)
+	}
+	t.metrics.bytesUsed.WithLabelValues(subnetID.String()).Set(float64(bytesUsed))
+}
+
+type subnetInboundMsgThrottlerMetrics struct {
+	bytesUsed *prometheus.GaugeVec
+	allocSize *prometheus.GaugeVec
+	dropped   *prometheus.CounterVec
+}
+
+func (m *subnetInboundMsgThrottlerMetrics) initialize(reg prometheus.Registerer) error {
+	m.bytesUsed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "subnet_throttler_inbound_bytes_used",
+			Help: "Bytes of inbound messages currently processing for a subnet",
+		},
+		[]string{subnetIDLabel},
+	)
+	m.allocSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "subnet_throttler_inbound_alloc_size",
+			Help: "Max bytes of inbound messages that may be processing at once for a subnet",
+		},
+		[]string{subnetIDLabel},
+	)
+	m.dropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subnet_throttler_inbound_dropped",
+			Help: "Number of inbound messages dropped because a subnet exceeded its allocation",
+		},
+		[]string{subnetIDLabel},
+	)
+	return errors.Join(
+		reg.Register(m.bytesUsed),
+		reg.Register(m.allocSize),
+		reg.Register(m.dropped),
+	)
+}
+
+// NewNoSubnetInboundMsgThrottler returns a SubnetInboundMsgThrottler where
+// Acquire always succeeds.
+func NewNoSubnetInboundMsgThrottler() SubnetInboundMsgThrottler {
+	return &noSubnetInboundMsgThrottler{}
+}
+
+type noSubnetInboundMsgThrottler struct{}
+
+func (*noSubnetInboundMsgThrottler) Acquire(uint64, ids.ID) (ReleaseFunc, bool) {
+	return noopRelease, true
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+)
+
+func TestSubnetInboundMsgThrottlerConfigVerify(t *testing.T) {
+	require := require.New(t)
+
+	config := SubnetInboundMsgThrottlerConfig{
+		AllocSize:                  1024,
+		PrimaryNetworkReservedSize: 1024,
+	}
+	require.NoError(config.Verify())
+
+	config.PrimaryNetworkReservedSize++
+	err := config.Verify()
+	require.ErrorIs(err, errReservedExceedsAlloc)
+
+	_, err = NewSubnetInboundMsgThrottler(prometheus.NewRegistry(), config, nil)
+	require.ErrorIs(err, errReservedExceedsAlloc)
+}
+
+func TestSubnetInboundMsgThrottler(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		subnetID0 = ids.GenerateTestID()
+		subnetID1 = ids.GenerateTestID()
+		subnetID2 = ids.GenerateTestID()
+	)
+	throttlerIntf, err := NewSubnetInboundMsgThrottler(
+		prometheus.NewRegistry(),
+		SubnetInboundMsgThrottlerConfig{
+			AllocSize:                  1024,
+			PrimaryNetworkReservedSize: 512,
+		},
+		map[ids.ID]uint64{
+			subnetID0: 256,
+		},
+	)
+	require.NoError(err)
+	throttler := throttlerIntf.(*subnetInboundMsgThrottler)
+
+	// subnetID0 is limited by its own allocation
+	release0, ok := throttler.Acquire(256, subnetID0)
+	require.True(ok)
+	_, ok = throttler.Acquire(1, subnetID0)
+	require.False(ok)
+
+	// Subnets without an allocation are limited by the bytes that aren't
+	// reserved for the primary network.
+	release1, ok := throttler.Acquire(256, subnetID1)
+	require.True(ok)
+	_, ok = throttler.Acquire(1, subnetID2)
+	require.False(ok)
+
+	// The primary network can use the reserved bytes
+	releasePrimary, ok := throttler.Acquire(512, constants.PrimaryNetworkID)
+	require.True(ok)
+	require.Equal(uint64(1024), throttler.bytesUsed)
+	require.Equal(uint64(512), throttler.subnetsBytesUsed)
+
+	// The primary network is never dropped
+	releaseExtra, ok := throttler.Acquire(256, constants.PrimaryNetworkID)
+	require.True(ok)
+
+	// Releasing subnet bytes makes them available to the other subnets, as
+	// long as the primary network isn't using them.
+	release1()
+	release1() // Releasing twice is a noop
+	require.Equal(uint64(256), throttler.subnetsBytesUsed)
+	_, ok = throttler.Acquire(1, subnetID2)
+	require.False(ok)
+
+	releaseExtra()
+	releasePrimary()
+	release2, ok := throttler.Acquire(256, subnetID2)
+	require.True(ok)
+
+	require.Equal(float64(256), testutil.ToFloat64(throttler.metrics.bytesUsed.WithLabelValues(subnetID0.String())))
+	require.Equal(float64(256), testutil.ToFloat64(throttler.metrics.bytesUsed.WithLabelValues(subnetID2.String())))
+	require.Equal(float64(256), testutil.ToFloat64(throttler.metrics.allocSize.WithLabelValues(subnetID0.String())))
+	require.Equal(float64(1), testutil.ToFloat64(throttler.metrics.dropped.WithLabelValues(subnetID0.String())))
+	require.Equal(float64(2), testutil.ToFloat64(throttler.metrics.dropped.WithLabelValues(subnetID2.String())))
+
+	release0()
+	release2()
+	require.Zero(throttler.bytesUsed)
+	require.Zero(throttler.subnetsBytesUsed)
+	require.Empty(throttler.subnetBytesUsed)
+}
@@ -18,24 +18,40 @@ type trackedIP struct {
 	delayLock sync.RWMutex
 	delay     time.Duration
 
-	ip netip.AddrPort
+	// ips are the IPs of the node, in the order they should be dialed.
+	ips []netip.AddrPort
+	// quicPort is the UDP port that the node accepts QUIC connections on. If
+	// 0, the node is only dialed over TCP.
+	quicPort uint16
 
 	stopTrackingOnce sync.Once
 	onStopTracking   chan struct{}
 }
 
-func newTrackedIP(ip netip.AddrPort) *trackedIP {
+func newTrackedIP(ips ...netip.AddrPort) *trackedIP {
 	return &trackedIP{
-		ip:             ip,
+		ips:            ips,
 		onStopTracking: make(chan struct{}),
 	}
 }
 
-func (ip *trackedIP) trackNewIP(newIP netip.AddrPort) *trackedIP {
+func (ip *trackedIP) trackNewIP(newIPs ...netip.AddrPort) *trackedIP {
 	ip.stopTracking()
 	return &trackedIP{
 		delay:          ip.getDelay(),
-		ip:             newIP,
+		ips:            newIPs,
+		quicPort:       ip.quicPort,
+		onStopTracking: make(chan struct{}),
+	}
+}
+
+// retryNow stops tracking [ip] and returns a copy of it, with [newIPs], that is
+// dialed without any reconnection delay.
+func (ip *trackedIP) retryNow(newIPs ...netip.AddrPort) *trackedIP {
+	ip.stopTracking()
+	return &trackedIP{
+		ips:            newIPs,
+		quicPort:       ip.quicPort,
 		onStopTracking: make(chan struct{}),
 	}
 }
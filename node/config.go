@@ -13,6 +13,8 @@ import (
 	"github.com/MetalBlockchain/metalgo/genesis"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/network"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/journal"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
@@ -60,9 +62,11 @@ type APIConfig struct {
 }
 
 type IPConfig struct {
-	PublicIP                  string        `json:"publicIP"`
-	PublicIPResolutionService string        `json:"publicIPResolutionService"`
-	PublicIPResolutionFreq    time.Duration `json:"publicIPResolutionFreq"`
+	PublicIP string `json:"publicIP"`
+	// If more than one service is given, a public IP is only accepted if a
+	// majority of the services resolved it.
+	PublicIPResolutionServices []string      `json:"publicIPResolutionServices"`
+	PublicIPResolutionFreq     time.Duration `json:"publicIPResolutionFreq"`
 	// The host portion of the address to listen on. The port to
 	// listen on will be sourced from IPPort.
 	//
@@ -70,6 +74,12 @@ type IPConfig struct {
 	// - If populated, listen only on the specified address.
 	ListenHost string `json:"listenHost"`
 	ListenPort uint16 `json:"listenPort"`
+	// QUICListenPort is the UDP port to accept QUIC connections on, if QUIC
+	// is enabled.
+	QUICListenPort uint16 `json:"quicListenPort"`
+	// AdditionalListenAddresses are listened on in addition to the above
+	// address. They are advertised to peers as is.
+	AdditionalListenAddresses []netip.AddrPort `json:"additionalListenAddresses"`
 }
 
 type StakingConfig struct {
@@ -104,6 +114,14 @@ type BootstrapConfig struct {
 	// ancestors while responding to a GetAncestors message
 	BootstrapMaxTimeGetAncestors time.Duration `json:"bootstrapMaxTimeGetAncestors"`
 
+	// Max number of GetAncestors requests that can be outstanding at once
+	// while bootstrapping
+	BootstrapMaxOutstandingRequests int `json:"bootstrapMaxOutstandingRequests"`
+
+	// Paths of the block archives that chains read blocks from while
+	// bootstrapping, before fetching them from peers
+	BootstrapBlockArchives []string `json:"bootstrapBlockArchives"`
+
 	Bootstrappers []genesis.Bootstrapper `json:"bootstrappers"`
 }
 
@@ -152,6 +170,11 @@ type Config struct {
 
 	ProfilerConfig profiler.Config `json:"profilerConfig"`
 
+	// ConsensusJournalChains are the IDs or aliases of the chains whose
+	// consensus events are recorded to a journal.
+	ConsensusJournalChains set.Set[string] `json:"consensusJournalChains"`
+	ConsensusJournalConfig journal.Config  `json:"consensusJournalConfig"`
+
 	LoggingConfig logging.Config `json:"loggingConfig"`
 
 	PluginDir string `json:"pluginDir"`
@@ -162,6 +185,10 @@ type Config struct {
 	// Metrics
 	MeterVMEnabled bool `json:"meterVMEnabled"`
 
+	// Limits the bytes of unrequested inbound messages processing for the
+	// subnets other than the primary network
+	SubnetInboundMsgThrottlerConfig throttling.SubnetInboundMsgThrottlerConfig `json:"subnetInboundMsgThrottlerConfig"`
+
 	RouterHealthConfig       router.HealthConfig `json:"routerHealthConfig"`
 	ConsensusShutdownTimeout time.Duration       `json:"consensusShutdownTimeout"`
 	// Poll for new frontiers every [FrontierPollFrequency]
@@ -169,6 +196,10 @@ type Config struct {
 	// ConsensusAppConcurrency defines the maximum number of goroutines to
 	// handle App messages per chain.
 	ConsensusAppConcurrency int `json:"consensusAppConcurrency"`
+	// ConsensusSamplingLatencyBias is the probability that a validator sampled
+	// for a poll is replaced by the lower latency of two validators of equal
+	// stake.
+	ConsensusSamplingLatencyBias float64 `json:"consensusSamplingLatencyBias"`
 
 	TrackedSubnets set.Set[ids.ID] `json:"trackedSubnets"`
 
@@ -200,6 +231,8 @@ type Config struct {
 
 	DiskTargeterConfig tracker.TargeterConfig `json:"diskTargeterConfig"`
 
+	MemoryTargeterConfig tracker.TargeterConfig `json:"memoryTargeterConfig"`
+
 	RequiredAvailableDiskSpace         uint64 `json:"requiredAvailableDiskSpace"`
 	WarningThresholdAvailableDiskSpace uint64 `json:"warningThresholdAvailableDiskSpace"`
 
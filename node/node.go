@@ -48,6 +48,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/network"
 	"github.com/MetalBlockchain/metalgo/network/dialer"
 	"github.com/MetalBlockchain/metalgo/network/peer"
+	"github.com/MetalBlockchain/metalgo/network/quic"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
@@ -109,8 +110,9 @@ var (
 	genesisHashKey     = []byte("genesisID")
 	ungracefulShutdown = []byte("ungracefulShutdown")
 
-	indexerDBPrefix  = []byte{0x00}
-	keystoreDBPrefix = []byte("keystore")
+	indexerDBPrefix   = []byte{0x00}
+	keystoreDBPrefix  = []byte("keystore")
+	benchlistDBPrefix = []byte("benchlist")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -214,6 +216,8 @@ func New(
 		n.Log,
 		networkRegisterer,
 		n.Config.NetworkConfig.CompressionType,
+		n.Config.NetworkConfig.CompressionZstdDicts,
+		n.Config.NetworkConfig.CompressionZstdSamplesDir,
 		n.Config.NetworkConfig.MaximumInboundMessageTimeout,
 	)
 	if err != nil {
@@ -230,6 +234,7 @@ func New(
 	}
 	n.initCPUTargeter(&config.CPUTargeterConfig)
 	n.initDiskTargeter(&config.DiskTargeterConfig)
+	n.initMemoryTargeter(&config.MemoryTargeterConfig)
 	if err := n.initNetworking(networkRegisterer); err != nil { // Set up networking layer.
 		return nil, fmt.Errorf("problem initializing networking: %w", err)
 	}
@@ -389,7 +394,7 @@ type Node struct {
 
 	resourceManager resource.Manager
 
-	// Tracks the CPU/disk usage caused by processing
+	// Tracks the CPU/disk/memory usage caused by processing
 	// messages of each peer.
 	resourceTracker tracker.ResourceTracker
 
@@ -401,6 +406,10 @@ type Node struct {
 	// we rate-limit them.
 	diskTargeter tracker.Targeter
 
+	// Specifies how much memory usage each peer can cause before
+	// we rate-limit them.
+	memoryTargeter tracker.Targeter
+
 	// Closed when a sufficient amount of bootstrap nodes are connected to
 	onSufficientlyConnected chan struct{}
 }
@@ -436,6 +445,25 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 	if err != nil {
 		return err
 	}
+
+	// Listen on the additional addresses so that the node can be reached
+	// over multiple IPs, such as both IPv4 and IPv6.
+	if additionalAddresses := n.Config.AdditionalListenAddresses; len(additionalAddresses) != 0 {
+		listeners := make([]net.Listener, 0, 1+len(additionalAddresses))
+		listeners = append(listeners, listener)
+		for _, additionalAddress := range additionalAddresses {
+			additionalListener, err := net.Listen(constants.NetworkType, additionalAddress.String())
+			if err != nil {
+				for _, listener := range listeners {
+					_ = listener.Close()
+				}
+				return fmt.Errorf("couldn't listen on additional address %s: %w", additionalAddress, err)
+			}
+			listeners = append(listeners, additionalListener)
+		}
+		listener = network.NewMultiListener(listeners...)
+	}
+
 	// Wrap listener so it will only accept a certain number of incoming connections per second
 	listener = throttling.NewThrottledListener(listener, n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec)
 
@@ -462,9 +490,9 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 			stakingPort,
 		))
 		n.ipUpdater = dynamicip.NewNoUpdater()
-	case n.Config.PublicIPResolutionService != "":
+	case len(n.Config.PublicIPResolutionServices) != 0:
 		// Use dynamic IP resolution.
-		resolver, err := dynamicip.NewResolver(n.Config.PublicIPResolutionService)
+		resolver, err := dynamicip.NewQuorumResolver(n.Config.PublicIPResolutionServices)
 		if err != nil {
 			return fmt.Errorf("couldn't create IP resolver: %w", err)
 		}
@@ -511,6 +539,7 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 
 	n.Log.Info("initializing networking",
 		zap.Stringer("ip", atomicIP.Get()),
+		zap.Stringers("additionalIPs", n.Config.AdditionalListenAddresses),
 	)
 
 	tlsKey, ok := n.Config.StakingTLSCert.PrivateKey.(crypto.Signer)
@@ -549,16 +578,55 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 
 	tlsConfig := peer.TLSConfig(n.Config.StakingTLSCert, n.tlsKeyLogWriterCloser)
 
+	// Peers learn that this node accepts QUIC connections from the handshake
+	// of a TCP connection, so they only dial this node over QUIC when
+	// reconnecting.
+	if n.Config.NetworkConfig.QUICEnabled {
+		n.Log.Warn("QUIC connections are experimental")
+
+		quicAddress := net.JoinHostPort(n.Config.ListenHost, strconv.FormatUint(uint64(n.Config.QUICListenPort), 10))
+		quicTransport, err := quic.Listen(
+			quicAddress,
+			tlsConfig,
+			tlsKey,
+			n.Config.NetworkConfig.ReadHandshakeTimeout,
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't listen for QUIC connections on %s: %w", quicAddress, err)
+		}
+
+		// Inbound QUIC connections are only authenticated when they are
+		// upgraded, so they are throttled the same way as TCP connections.
+		listener = network.NewMultiListener(
+			listener,
+			throttling.NewThrottledListener(quicTransport, n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec),
+		)
+		n.Config.NetworkConfig.MyQUICPort = quicTransport.Port()
+		n.Config.NetworkConfig.QUICDialer = quicTransport
+
+		n.Log.Info("accepting QUIC connections",
+			zap.Stringer("address", quicTransport.Addr()),
+		)
+	}
+
 	// Create chain router
 	n.chainRouter = &router.ChainRouter{}
 	if n.Config.TraceConfig.Enabled {
 		n.chainRouter = router.Trace(n.chainRouter, n.tracer)
 	}
 
+	// Scores connections based on the outcome of requests, which are
+	// reported to the benchlist.
+	peerScorer := network.NewPeerScorer(n.Config.NetworkConfig.PeerScoreHalflife)
+	n.Config.NetworkConfig.PeerScorer = peerScorer
+
 	// Configure benchlist
 	n.Config.BenchlistConfig.Validators = n.vdrs
-	n.Config.BenchlistConfig.Benchable = n.chainRouter
+	n.Config.BenchlistConfig.Benchable = network.NewScoringBenchable(n.chainRouter, peerScorer)
 	n.Config.BenchlistConfig.BenchlistRegisterer = metrics.NewLabelGatherer(chains.ChainLabel)
+	if n.Config.BenchlistConfig.Persist {
+		n.Config.BenchlistConfig.DB = prefixdb.New(benchlistDBPrefix, n.DB)
+	}
 
 	err = n.MetricsGatherer.Register(
 		benchlistNamespace,
@@ -568,7 +636,10 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 		return err
 	}
 
-	n.benchlistManager = benchlist.NewManager(&n.Config.BenchlistConfig)
+	n.benchlistManager = network.NewScoringBenchlist(
+		benchlist.NewManager(&n.Config.BenchlistConfig),
+		peerScorer,
+	)
 
 	n.uptimeCalculator = uptime.NewLockedCalculator()
 
@@ -617,6 +688,7 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 	// add node configs to network config
 	n.Config.NetworkConfig.MyNodeID = n.ID
 	n.Config.NetworkConfig.MyIPPort = atomicIP
+	n.Config.NetworkConfig.MyAdditionalIPPorts = n.Config.AdditionalListenAddresses
 	n.Config.NetworkConfig.NetworkID = n.Config.NetworkID
 	n.Config.NetworkConfig.Validators = n.vdrs
 	n.Config.NetworkConfig.Beacons = n.bootstrappers
@@ -629,6 +701,7 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
+	n.Config.NetworkConfig.MemoryTargeter = n.memoryTargeter
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -940,8 +1013,8 @@ func (n *Node) initMetrics() error {
 func (n *Node) initNAT() {
 	n.Log.Info("initializing NAT")
 
-	if n.Config.PublicIP == "" && n.Config.PublicIPResolutionService == "" {
-		n.router = nat.GetRouter()
+	if n.Config.PublicIP == "" && len(n.Config.PublicIPResolutionServices) == 0 {
+		n.router = nat.GetRouter(n.Config.ListenPort)
 		if !n.router.SupportsNAT() {
 			n.Log.Warn("UPnP and NAT-PMP router attach failed, " +
 				"you may not be listening publicly. " +
@@ -1111,6 +1184,21 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 	}
 	go n.Log.RecoverAndPanic(n.timeoutManager.Dispatch)
 
+	subnetAllocSizes := make(map[ids.ID]uint64)
+	for subnetID, subnetConfig := range n.Config.SubnetConfigs {
+		if subnetID != constants.PrimaryNetworkID && subnetConfig.InboundMsgAllocSize > 0 {
+			subnetAllocSizes[subnetID] = subnetConfig.InboundMsgAllocSize
+		}
+	}
+	subnetThrottler, err := throttling.NewSubnetInboundMsgThrottler(
+		requestsReg,
+		n.Config.SubnetInboundMsgThrottlerConfig,
+		subnetAllocSizes,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize subnet inbound message throttler: %w", err)
+	}
+
 	// Routes incoming messages from peers to the appropriate chain
 	err = n.chainRouter.Initialize(
 		n.ID,
@@ -1122,6 +1210,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		n.Config.TrackedSubnets,
 		n.Shutdown,
 		n.Config.RouterHealthConfig,
+		subnetThrottler,
 		requestsReg,
 	)
 	if err != nil {
@@ -1170,15 +1259,20 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			ChainConfigs:                            n.Config.ChainConfigs,
 			FrontierPollFrequency:                   n.Config.FrontierPollFrequency,
 			ConsensusAppConcurrency:                 n.Config.ConsensusAppConcurrency,
+			ConsensusSamplingLatencyBias:            n.Config.ConsensusSamplingLatencyBias,
 			BootstrapMaxTimeGetAncestors:            n.Config.BootstrapMaxTimeGetAncestors,
 			BootstrapAncestorsMaxContainersSent:     n.Config.BootstrapAncestorsMaxContainersSent,
 			BootstrapAncestorsMaxContainersReceived: n.Config.BootstrapAncestorsMaxContainersReceived,
+			BootstrapMaxOutstandingRequests:         n.Config.BootstrapMaxOutstandingRequests,
+			BootstrapBlockArchives:                  n.Config.BootstrapBlockArchives,
 			Upgrades:                                n.Config.UpgradeConfig,
 			ResourceTracker:                         n.resourceTracker,
 			StateSyncBeacons:                        n.Config.StateSyncIDs,
 			TracingEnabled:                          n.Config.TraceConfig.Enabled,
 			Tracer:                                  n.tracer,
 			ChainDataDir:                            n.Config.ChainDataDir,
+			ConsensusJournalChains:                  n.Config.ConsensusJournalChains,
+			ConsensusJournalConfig:                  n.Config.ConsensusJournalConfig,
 			Subnets:                                 subnets,
 		},
 	)
@@ -1352,6 +1446,7 @@ func (n *Node) initAdminAPI() error {
 			Log:          n.Log,
 			DB:           n.DB,
 			ChainManager: n.chainManager,
+			Benchlist:    n.benchlistManager,
 			HTTPServer:   n.APIServer,
 			ProfileDir:   n.Config.ProfilerConfig.Dir,
 			LogFactory:   n.LogFactory,
@@ -1419,6 +1514,7 @@ func (n *Node) initInfoAPI() error {
 		n.Config.NetworkConfig.MyIPPort,
 		n.Net,
 		n.benchlistManager,
+		n.uptimeCalculator,
 	)
 	if err != nil {
 		return err
@@ -1495,6 +1591,13 @@ func (n *Node) initHealthAPI() error {
 		return fmt.Errorf("couldn't register resource health check: %w", err)
 	}
 
+	if len(n.Config.PublicIPResolutionServices) != 0 {
+		err = n.health.RegisterHealthCheck("publicIP", n.ipUpdater, health.ApplicationTag)
+		if err != nil {
+			return fmt.Errorf("couldn't register public IP health check: %w", err)
+		}
+	}
+
 	handler, err := health.NewGetAndPostHandler(n.Log, n.health)
 	if err != nil {
 		return err
@@ -1642,6 +1745,19 @@ func (n *Node) initDiskTargeter(
 	)
 }
 
+// Initialize [n.memoryTargeter].
+// Assumes [n.resourceTracker] is already initialized.
+func (n *Node) initMemoryTargeter(
+	config *tracker.TargeterConfig,
+) {
+	n.memoryTargeter = tracker.NewTargeter(
+		n.Log,
+		config,
+		n.vdrs,
+		n.resourceTracker.MemoryTracker(),
+	)
+}
+
 // Shutdown this node
 // May be called multiple times
 func (n *Node) Shutdown(exitCode int) {
@@ -61,4 +61,13 @@ type Consensus interface {
 	// RecordPoll collects the results of a network poll. Assumes all decisions
 	// have been previously added. Returns if a critical error has occurred.
 	RecordPoll(context.Context, bag.Bag[ids.ID]) error
+
+	// RejectProcessing rejects all the processing decisions, leaving the last
+	// accepted decision as the preference. Returns if a critical error has
+	// occurred.
+	RejectProcessing(context.Context) error
+
+	// State returns a snapshot of the last accepted block and the processing
+	// blocks.
+	State() State
 }
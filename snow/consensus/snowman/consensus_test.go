@@ -21,6 +21,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman/snowmantest"
 	"github.com/MetalBlockchain/metalgo/snow/snowtest"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
+	"github.com/MetalBlockchain/metalgo/utils/json"
 )
 
 type testFunc func(*testing.T, Factory)
@@ -28,6 +29,7 @@ type testFunc func(*testing.T, Factory)
 var (
 	testFuncs = []testFunc{
 		InitializeTest,
+		ReinitializeTest,
 		NumProcessingTest,
 		AddToTailTest,
 		AddToNonTailTest,
@@ -46,7 +48,9 @@ var (
 		RecordPollTransitiveVotingTest,
 		RecordPollDivergedVotingWithNoConflictingBitTest,
 		RecordPollChangePreferredChainTest,
+		RejectProcessingTest,
 		LastAcceptedTest,
+		StateTest,
 		MetricsProcessingErrorTest,
 		MetricsAcceptedErrorTest,
 		MetricsRejectedErrorTest,
@@ -106,6 +110,55 @@ func InitializeTest(t *testing.T, factory Factory) {
 	require.Zero(sm.NumProcessing())
 }
 
+// Make sure that initializing again drops all the processing blocks
+func ReinitializeTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	sm := factory.New()
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		Beta:                  3,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(
+		ctx,
+		params,
+		snowmantest.GenesisID,
+		snowmantest.GenesisHeight,
+		snowmantest.GenesisTimestamp,
+	))
+
+	block0 := snowmantest.BuildChild(snowmantest.Genesis)
+	block1 := snowmantest.BuildChild(block0)
+	require.NoError(sm.Add(block0))
+	require.NoError(sm.Add(block1))
+	require.Equal(2, sm.NumProcessing())
+
+	// [block0] was accepted outside of consensus, such as during bootstrapping.
+	require.NoError(sm.Initialize(
+		ctx,
+		params,
+		block0.ID(),
+		block0.Height(),
+		block0.Timestamp(),
+	))
+	require.Zero(sm.NumProcessing())
+	require.Equal(block0.ID(), sm.Preference())
+	require.False(sm.IsPreferred(block1.ID()))
+
+	lastAcceptedID, lastAcceptedHeight := sm.LastAccepted()
+	require.Equal(block0.ID(), lastAcceptedID)
+	require.Equal(block0.Height(), lastAcceptedHeight)
+}
+
 // Make sure that the number of processing blocks is tracked correctly
 func NumProcessingTest(t *testing.T, factory Factory) {
 	require := require.New(t)
@@ -632,6 +685,80 @@ func RecordPollRejectTransitivelyTest(t *testing.T, factory Factory) {
 	require.Equal(snowtest.Rejected, block2.Status)
 }
 
+func RejectProcessingTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	sm := factory.New()
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		Beta:                  2,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(
+		ctx,
+		params,
+		snowmantest.GenesisID,
+		snowmantest.GenesisHeight,
+		snowmantest.GenesisTimestamp,
+	))
+
+	block0 := snowmantest.BuildChild(snowmantest.Genesis)
+	block1 := snowmantest.BuildChild(block0)
+	block2 := snowmantest.BuildChild(block1)
+	block3 := snowmantest.BuildChild(block0)
+
+	require.NoError(sm.Add(block0))
+	require.NoError(sm.Add(block1))
+	require.NoError(sm.Add(block2))
+	require.NoError(sm.Add(block3))
+
+	// Current graph structure:
+	//   G
+	//   |
+	//   0
+	//  / \
+	// 1   3
+	// |
+	// 2
+	// Tail = 2
+
+	// Accept block0
+	votes := bag.Of(block0.ID())
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+	require.Equal(snowtest.Accepted, block0.Status)
+	require.Equal(3, sm.NumProcessing())
+
+	require.NoError(sm.RejectProcessing(context.Background()))
+
+	// Current graph structure:
+	// 0
+	// Tail = 0
+
+	require.Zero(sm.NumProcessing())
+	require.Equal(block0.ID(), sm.Preference())
+	require.True(sm.IsPreferred(block0.ID()))
+	require.False(sm.IsPreferred(block1.ID()))
+	_, ok := sm.PreferenceAtHeight(block1.Height())
+	require.False(ok)
+	require.Equal(snowtest.Rejected, block1.Status)
+	require.Equal(snowtest.Rejected, block2.Status)
+	require.Equal(snowtest.Rejected, block3.Status)
+
+	// New blocks can be issued on top of the last accepted block.
+	block4 := snowmantest.BuildChild(block0)
+	require.NoError(sm.Add(block4))
+	require.Equal(block4.ID(), sm.Preference())
+}
+
 func RecordPollTransitivelyResetConfidenceTest(t *testing.T, factory Factory) {
 	require := require.New(t)
 
@@ -1012,6 +1139,92 @@ func RecordPollChangePreferredChainTest(t *testing.T, factory Factory) {
 	require.Equal(a2Block.ID(), pref)
 }
 
+// Make sure that the state reports the last accepted and processing blocks
+func StateTest(t *testing.T, factory Factory) {
+	sm := factory.New()
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		Beta:                  2,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(
+		ctx,
+		params,
+		snowmantest.GenesisID,
+		snowmantest.GenesisHeight,
+		snowmantest.GenesisTimestamp,
+	))
+
+	block0 := snowmantest.BuildChild(snowmantest.Genesis)
+	block1 := snowmantest.BuildChild(block0)
+	block1Conflict := snowmantest.BuildChild(block0)
+
+	require.NoError(sm.Add(block0))
+	require.NoError(sm.Add(block1))
+	require.NoError(sm.Add(block1Conflict))
+	require.NoError(sm.RecordPoll(context.Background(), bag.Of(block1Conflict.IDV)))
+
+	state := sm.State()
+	require.Equal(snowmantest.GenesisID, state.LastAcceptedID)
+	require.Equal(json.Uint64(snowmantest.GenesisHeight), state.LastAcceptedHeight)
+	require.Equal(block1Conflict.IDV, state.Preference)
+	require.Equal(json.Uint64(1), state.NumPolls)
+
+	// Only the blocks with children have a snowball instance.
+	require.Len(state.Blocks, 4)
+	require.NotEmpty(state.Blocks[0].ChildConsensus)
+	require.NotEmpty(state.Blocks[1].ChildConsensus)
+	for i := range state.Blocks {
+		state.Blocks[i].ChildConsensus = ""
+	}
+
+	heightTwoBlocks := []BlockState{
+		{
+			ID:        block1.IDV,
+			ParentID:  block0.IDV,
+			Height:    json.Uint64(block1.HeightV),
+			Preferred: false,
+		},
+		{
+			ID:        block1Conflict.IDV,
+			ParentID:  block0.IDV,
+			Height:    json.Uint64(block1Conflict.HeightV),
+			Preferred: true,
+		},
+	}
+	if block1Conflict.IDV.Compare(block1.IDV) < 0 {
+		heightTwoBlocks[0], heightTwoBlocks[1] = heightTwoBlocks[1], heightTwoBlocks[0]
+	}
+	require.Equal(
+		append(
+			[]BlockState{
+				{
+					ID:        snowmantest.GenesisID,
+					Height:    json.Uint64(snowmantest.GenesisHeight),
+					Preferred: true,
+				},
+				{
+					ID:        block0.IDV,
+					ParentID:  snowmantest.GenesisID,
+					Height:    json.Uint64(block0.HeightV),
+					Preferred: true,
+				},
+			},
+			heightTwoBlocks...,
+		),
+		state.Blocks,
+	)
+}
+
 func LastAcceptedTest(t *testing.T, factory Factory) {
 	sm := factory.New()
 	require := require.New(t)
@@ -159,6 +159,17 @@ func newMetrics(
 	return m, errs.Err
 }
 
+// Reset drops all the processing blocks and sets the metrics for the provided
+// last accepted block.
+func (m *metrics) Reset(lastAcceptedHeight uint64, lastAcceptedTime time.Time) {
+	m.currentMaxVerifiedHeight = lastAcceptedHeight
+	m.maxVerifiedHeight.Set(float64(lastAcceptedHeight))
+	m.lastAcceptedHeight.Set(float64(lastAcceptedHeight))
+	m.lastAcceptedTimestamp.Set(float64(lastAcceptedTime.Unix()))
+	m.processingBlocks = linked.NewHashmap[ids.ID, processingStart]()
+	m.numProcessing.Set(0)
+}
+
 func (m *metrics) Issued(blkID ids.ID, pollNumber uint64) {
 	m.processingBlocks.Put(blkID, processingStart{
 		time:       time.Now(),
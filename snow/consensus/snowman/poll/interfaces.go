@@ -5,10 +5,12 @@ package poll
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
 	"github.com/MetalBlockchain/metalgo/utils/formatting"
+	"github.com/MetalBlockchain/metalgo/utils/json"
 )
 
 // Set is a collection of polls
@@ -16,9 +18,34 @@ type Set interface {
 	fmt.Stringer
 
 	Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool
-	Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.ID]
-	Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID]
+	Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []Result
+	Drop(requestID uint32, vdr ids.NodeID) []Result
+	// Clear removes all outstanding polls without finishing them.
+	Clear()
 	Len() int
+	// Polls describes the outstanding polls, from oldest to newest.
+	Polls() []Info
+}
+
+// Result is the outcome of a finished poll.
+type Result struct {
+	RequestID uint32
+	Votes     bag.Bag[ids.ID]
+}
+
+// Info describes an outstanding poll.
+type Info struct {
+	RequestID json.Uint32 `json:"requestID"`
+	StartTime time.Time   `json:"startTime"`
+	// Votes is the number of votes received for each block so far.
+	Votes map[ids.ID]int `json:"votes"`
+	// Responded are the validators that voted in the poll.
+	Responded []ids.NodeID `json:"responded"`
+	// Dropped are the validators whose votes will never be received, such as
+	// validators whose queries timed out.
+	Dropped []ids.NodeID `json:"dropped"`
+	// Outstanding are the validators that haven't responded yet.
+	Outstanding []ids.NodeID `json:"outstanding"`
 }
 
 // Poll is an outstanding poll
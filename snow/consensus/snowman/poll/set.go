@@ -13,7 +13,9 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
+	"github.com/MetalBlockchain/metalgo/utils/json"
 	"github.com/MetalBlockchain/metalgo/utils/linked"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/metric"
@@ -27,21 +29,67 @@ var (
 type pollHolder interface {
 	GetPoll() Poll
 	StartTime() time.Time
+	Info(requestID uint32) Info
 }
 
 type poll struct {
 	Poll
 	start time.Time
+
+	// responded, dropped, and outstanding partition the polled validators
+	// based on whether they have responded to the poll.
+	responded   bag.Bag[ids.NodeID]
+	dropped     bag.Bag[ids.NodeID]
+	outstanding bag.Bag[ids.NodeID]
 }
 
-func (p poll) GetPoll() Poll {
+func (p *poll) GetPoll() Poll {
 	return p
 }
 
-func (p poll) StartTime() time.Time {
+func (p *poll) StartTime() time.Time {
 	return p.start
 }
 
+func (p *poll) Vote(vdr ids.NodeID, vote ids.ID) {
+	if p.outstanding.Count(vdr) > 0 {
+		p.outstanding.Remove(vdr)
+		p.responded.Add(vdr)
+	}
+	p.Poll.Vote(vdr, vote)
+}
+
+func (p *poll) Drop(vdr ids.NodeID) {
+	if p.outstanding.Count(vdr) > 0 {
+		p.outstanding.Remove(vdr)
+		p.dropped.Add(vdr)
+	}
+	p.Poll.Drop(vdr)
+}
+
+func (p *poll) Info(requestID uint32) Info {
+	result := p.Result()
+	votes := make(map[ids.ID]int, result.Len())
+	for _, blkID := range result.List() {
+		votes[blkID] = result.Count(blkID)
+	}
+
+	responded := p.responded.List()
+	dropped := p.dropped.List()
+	outstanding := p.outstanding.List()
+	utils.Sort(responded)
+	utils.Sort(dropped)
+	utils.Sort(outstanding)
+	return Info{
+		RequestID:   json.Uint32(requestID),
+		StartTime:   p.start,
+		Votes:       votes,
+		Responded:   responded,
+		Dropped:     dropped,
+		Outstanding: outstanding,
+	}
+}
+
 type set struct {
 	log      logging.Logger
 	numPolls prometheus.Gauge
@@ -100,9 +148,10 @@ func (s *set) Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool {
 		zap.Stringer("validators", &vdrs),
 	)
 
-	s.polls.Put(requestID, poll{
-		Poll:  s.factory.New(vdrs), // create the new poll
-		start: time.Now(),
+	s.polls.Put(requestID, &poll{
+		Poll:        s.factory.New(vdrs), // create the new poll
+		start:       time.Now(),
+		outstanding: bag.Of(vdrs.List()...),
 	})
 	s.numPolls.Inc() // increase the metrics
 	return true
@@ -110,7 +159,7 @@ func (s *set) Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool {
 
 // Vote registers the connections response to a query for [id]. If there was no
 // query, or the response has already be registered, nothing is performed.
-func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.ID] {
+func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []Result {
 	holder, exists := s.polls.Get(requestID)
 	if !exists {
 		s.log.Verbo("dropping vote",
@@ -138,8 +187,8 @@ func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.
 }
 
 // processFinishedPolls checks for other dependent finished polls and returns them all if finished
-func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
-	var results []bag.Bag[ids.ID]
+func (s *set) processFinishedPolls() []Result {
+	var results []Result
 
 	// iterate from oldest to newest
 	iter := s.polls.NewIterator()
@@ -159,7 +208,10 @@ func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
 		s.durPolls.Observe(float64(time.Since(holder.StartTime())))
 		s.numPolls.Dec() // decrease the metrics
 
-		results = append(results, p.Result())
+		results = append(results, Result{
+			RequestID: iter.Key(),
+			Votes:     p.Result(),
+		})
 		s.polls.Delete(iter.Key())
 	}
 
@@ -170,7 +222,7 @@ func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
 
 // Drop registers the connections response to a query for [id]. If there was no
 // query, or the response has already be registered, nothing is performed.
-func (s *set) Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID] {
+func (s *set) Drop(requestID uint32, vdr ids.NodeID) []Result {
 	holder, exists := s.polls.Get(requestID)
 	if !exists {
 		s.log.Verbo("dropping vote",
@@ -196,11 +248,26 @@ func (s *set) Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID] {
 	return s.processFinishedPolls()
 }
 
+// Clear removes all the outstanding polls
+func (s *set) Clear() {
+	s.polls = linked.NewHashmap[uint32, pollHolder]()
+	s.numPolls.Set(0)
+}
+
 // Len returns the number of outstanding polls
 func (s *set) Len() int {
 	return s.polls.Len()
 }
 
+func (s *set) Polls() []Info {
+	polls := make([]Info, 0, s.polls.Len())
+	iter := s.polls.NewIterator()
+	for iter.Next() {
+		polls = append(polls, iter.Value().Info(iter.Key()))
+	}
+	return polls
+}
+
 func (s *set) String() string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("current polls: (Size = %d)", s.polls.Len()))
@@ -11,6 +11,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
+	"github.com/MetalBlockchain/metalgo/utils/json"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 )
 
@@ -91,8 +92,10 @@ func TestCreateAndFinishPollOutOfOrder_NewerFinishesFirst(t *testing.T) {
 
 	results := s.Vote(1, vdr3, blkID1) // poll 1 finished, poll 2 should be finished as well
 	require.Len(results, 2)
-	require.Equal(blkID1, results[0].List()[0])
-	require.Equal(blkID2, results[1].List()[0])
+	require.Equal(uint32(1), results[0].RequestID)
+	require.Equal(blkID1, results[0].Votes.List()[0])
+	require.Equal(uint32(2), results[1].RequestID)
+	require.Equal(blkID2, results[1].Votes.List()[0])
 }
 
 func TestCreateAndFinishPollOutOfOrder_OlderFinishesFirst(t *testing.T) {
@@ -124,11 +127,11 @@ func TestCreateAndFinishPollOutOfOrder_OlderFinishesFirst(t *testing.T) {
 
 	results := s.Vote(1, vdr3, blkID1) // poll 1 finished, poll 2 still remaining
 	require.Len(results, 1)            // because 1 is the oldest
-	require.Equal(blkID1, results[0].List()[0])
+	require.Equal(blkID1, results[0].Votes.List()[0])
 
 	results = s.Vote(2, vdr1, blkID2) // poll 2 finished
 	require.Len(results, 1)           // because 2 is the oldest now
-	require.Equal(blkID2, results[0].List()[0])
+	require.Equal(blkID2, results[0].Votes.List()[0])
 }
 
 func TestCreateAndFinishPollOutOfOrder_UnfinishedPollsGaps(t *testing.T) {
@@ -170,9 +173,9 @@ func TestCreateAndFinishPollOutOfOrder_UnfinishedPollsGaps(t *testing.T) {
 	require.Empty(s.Vote(1, vdr2, blkID1))
 	results := s.Vote(1, vdr3, blkID1)
 	require.Len(results, 3)
-	require.Equal(blkID1, results[0].List()[0])
-	require.Equal(blkID2, results[1].List()[0])
-	require.Equal(blkID3, results[2].List()[0])
+	require.Equal(blkID1, results[0].Votes.List()[0])
+	require.Equal(blkID2, results[1].Votes.List()[0])
+	require.Equal(blkID3, results[2].Votes.List()[0])
 }
 
 func TestCreateAndFinishSuccessfulPoll(t *testing.T) {
@@ -201,10 +204,10 @@ func TestCreateAndFinishSuccessfulPoll(t *testing.T) {
 
 	results := s.Vote(0, vdr2, blkID1)
 	require.Len(results, 1)
-	list := results[0].List()
+	list := results[0].Votes.List()
 	require.Len(list, 1)
 	require.Equal(blkID1, list[0])
-	require.Equal(2, results[0].Count(blkID1))
+	require.Equal(2, results[0].Votes.Count(blkID1))
 }
 
 func TestCreateAndFinishFailedPoll(t *testing.T) {
@@ -233,7 +236,7 @@ func TestCreateAndFinishFailedPoll(t *testing.T) {
 
 	results := s.Drop(0, vdr2)
 	require.Len(results, 1)
-	require.Empty(results[0].List())
+	require.Empty(results[0].Votes.List())
 }
 
 func TestSetString(t *testing.T) {
@@ -256,3 +259,64 @@ func TestSetString(t *testing.T) {
 	require.True(s.Add(0, vdrs))
 	require.Equal(expected, s.String())
 }
+
+func TestSetPolls(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4) // k = 4
+	alpha := 3
+
+	factory := newEarlyTermNoTraversalTestFactory(require, alpha)
+	log := logging.NoLog{}
+	registerer := prometheus.NewRegistry()
+	s, err := NewSet(factory, log, registerer)
+	require.NoError(err)
+
+	require.True(s.Add(1, vdrs))
+	require.True(s.Add(2, vdrs))
+	require.Empty(s.Vote(1, vdr1, blkID1))
+	require.Empty(s.Vote(1, vdr2, blkID2))
+	require.Empty(s.Drop(1, vdr3))
+
+	polls := s.Polls()
+	require.Len(polls, 2)
+
+	poll := polls[0]
+	require.Equal(json.Uint32(1), poll.RequestID)
+	require.Equal(map[ids.ID]int{blkID1: 1, blkID2: 1}, poll.Votes)
+	require.Equal([]ids.NodeID{vdr1, vdr2}, poll.Responded)
+	require.Equal([]ids.NodeID{vdr3}, poll.Dropped)
+	require.Equal([]ids.NodeID{vdr4}, poll.Outstanding)
+
+	poll = polls[1]
+	require.Equal(json.Uint32(2), poll.RequestID)
+	require.Empty(poll.Votes)
+	require.Empty(poll.Responded)
+	require.Empty(poll.Dropped)
+	require.Equal([]ids.NodeID{vdr1, vdr2, vdr3, vdr4}, poll.Outstanding)
+}
+
+func TestSetClear(t *testing.T) {
+	require := require.New(t)
+
+	alpha := 2
+
+	factory := newEarlyTermNoTraversalTestFactory(require, alpha)
+	log := logging.NoLog{}
+	registerer := prometheus.NewRegistry()
+	s, err := NewSet(factory, log, registerer)
+	require.NoError(err)
+
+	require.True(s.Add(1, bag.Of(vdr1, vdr2))) // k = 2
+	require.True(s.Add(2, bag.Of(vdr1, vdr2)))
+	require.Empty(s.Vote(1, vdr1, blkID1))
+
+	s.Clear()
+	require.Zero(s.Len())
+	require.Empty(s.Vote(1, vdr2, blkID1))
+
+	// Request IDs of cleared polls can be reused.
+	require.True(s.Add(1, bag.Of(vdr1, vdr2)))
+	require.Empty(s.Vote(1, vdr1, blkID1))
+	require.Len(s.Vote(1, vdr2, blkID1), 1)
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"context"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+)
+
+var _ snowman.Block = (*block)(nil)
+
+// block is a block without any state transition. Every validator has its own
+// instance of each block, as consensus accepts or rejects it.
+type block struct {
+	id     ids.ID
+	parent ids.ID
+	height uint64
+}
+
+func (b *block) ID() ids.ID {
+	return b.id
+}
+
+func (*block) Accept(context.Context) error {
+	return nil
+}
+
+func (*block) Reject(context.Context) error {
+	return nil
+}
+
+func (b *block) Parent() ids.ID {
+	return b.parent
+}
+
+func (*block) Verify(context.Context) error {
+	return nil
+}
+
+func (b *block) Bytes() []byte {
+	return b.id[:]
+}
+
+func (b *block) Height() uint64 {
+	return b.height
+}
+
+func (*block) Timestamp() time.Time {
+	return time.Time{}
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"slices"
+	"time"
+)
+
+// Result aggregates the outcome of all the trials of a simulation.
+type Result struct {
+	Trials int
+	// TotalWeight is the stake of all the validators.
+	TotalWeight uint64
+	// ByzantineWeight is the Byzantine stake, summed over all the trials.
+	ByzantineWeight uint64
+	// HonestValidators is the number of honest validators, summed over all
+	// the trials.
+	HonestValidators int
+	// SafetyFailures is the number of trials in which honest validators
+	// finalized conflicting blocks.
+	SafetyFailures int
+	// LivenessFailures is the number of trials in which at least one honest
+	// validator didn't finalize before the timeout.
+	LivenessFailures int
+	// Latencies are the amounts of time it took each honest validator to
+	// finalize, in increasing order. Validators that didn't finalize are
+	// omitted.
+	Latencies []time.Duration
+	// Polls is the number of polls started by honest validators.
+	Polls uint64
+	// Messages is the number of queries and responses sent for the polls.
+	Messages uint64
+}
+
+func (r *Result) sortLatencies() {
+	slices.Sort(r.Latencies)
+}
+
+// ByzantineFraction returns the average portion of the stake that was
+// Byzantine.
+func (r *Result) ByzantineFraction() float64 {
+	if r.Trials == 0 || r.TotalWeight == 0 {
+		return 0
+	}
+	return float64(r.ByzantineWeight) / float64(r.Trials) / float64(r.TotalWeight)
+}
+
+// SafetyFailureProbability returns the portion of trials in which honest
+// validators finalized conflicting blocks.
+func (r *Result) SafetyFailureProbability() float64 {
+	if r.Trials == 0 {
+		return 0
+	}
+	return float64(r.SafetyFailures) / float64(r.Trials)
+}
+
+// LivenessFailureProbability returns the portion of trials that didn't
+// finalize before the timeout.
+func (r *Result) LivenessFailureProbability() float64 {
+	if r.Trials == 0 {
+		return 0
+	}
+	return float64(r.LivenessFailures) / float64(r.Trials)
+}
+
+// LatencyPercentile returns the finality latency that the [p] portion of the
+// finalized honest validators didn't exceed. [p] must be in [0, 1].
+func (r *Result) LatencyPercentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	i := int(p * float64(len(r.Latencies)-1))
+	return r.Latencies[i]
+}
+
+// MeanLatency returns the average finality latency of the finalized honest
+// validators.
+func (r *Result) MeanLatency() time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	var sum time.Duration
+	for _, latency := range r.Latencies {
+		sum += latency
+	}
+	return sum / time.Duration(len(r.Latencies))
+}
+
+// PollsPerValidator returns the average number of polls an honest validator
+// started to decide the conflicting blocks.
+func (r *Result) PollsPerValidator() float64 {
+	if r.HonestValidators == 0 {
+		return 0
+	}
+	return float64(r.Polls) / float64(r.HonestValidators)
+}
+
+// MessagesPerValidator returns the average number of messages that were sent
+// on behalf of an honest validator's polls to decide the conflicting blocks.
+func (r *Result) MessagesPerValidator() float64 {
+	if r.HonestValidators == 0 {
+		return 0
+	}
+	return float64(r.Messages) / float64(r.HonestValidators)
+}
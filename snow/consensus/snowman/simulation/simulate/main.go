@@ -0,0 +1,178 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman/simulation"
+)
+
+const defaultWeight = 2_000 * 1_000_000_000 // 2k tokens in nano units
+
+var (
+	errUnknownParameter   = errors.New("unknown parameter")
+	errMalformedParameter = errors.New("parameter must be formatted as name=value")
+)
+
+// parameterSets is a flag that can be provided multiple times, each time with
+// a candidate set of consensus parameters.
+type parameterSets []snowball.Parameters
+
+func (p *parameterSets) String() string {
+	return fmt.Sprint(*p)
+}
+
+// Set parses a comma separated list of name=value pairs. Parameters that
+// aren't provided use the default values.
+func (p *parameterSets) Set(value string) error {
+	params := snowball.DefaultParameters
+	for _, field := range strings.Split(value, ",") {
+		name, rawValue, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			return fmt.Errorf("%w: %q", errMalformedParameter, field)
+		}
+		if name == "maxItemProcessingTime" {
+			d, err := time.ParseDuration(rawValue)
+			if err != nil {
+				return err
+			}
+			params.MaxItemProcessingTime = d
+			continue
+		}
+
+		v, err := strconv.Atoi(rawValue)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		switch name {
+		case "k":
+			params.K = v
+		case "alphaPreference":
+			params.AlphaPreference = v
+		case "alphaConfidence":
+			params.AlphaConfidence = v
+		case "beta":
+			params.Beta = v
+		case "concurrentRepolls":
+			params.ConcurrentRepolls = v
+		default:
+			return fmt.Errorf("%w: %q", errUnknownParameter, name)
+		}
+	}
+	*p = append(*p, params)
+	return nil
+}
+
+// This simulates snowman consensus on a network of validators for each of the
+// provided candidate parameter sets and reports the finality latency
+// distribution, failure probabilities and message overhead of each of them:
+//
+//	simulate --validators 200 --weights zipf --byzantine 0.2 \
+//		--params k=20,alphaPreference=15,alphaConfidence=15,beta=20 \
+//		--params k=30,alphaPreference=20,alphaConfidence=24,beta=16
+func main() {
+	var (
+		params     parameterSets
+		validators = flag.Int("validators", 100, "number of validators. Ignored if explicit weights are provided")
+		weights    = flag.String("weights", "uniform", "stake distribution of the validators: uniform, linear, zipf, or a comma separated list of weights")
+		byzantine  = flag.Float64("byzantine", 0, "maximum portion of the stake controlled by Byzantine validators")
+		latency    = flag.Duration("latency", 50*time.Millisecond, "minimum one-way latency of a message")
+		jitter     = flag.Duration("jitter", 100*time.Millisecond, "maximum additional one-way latency of a message, chosen uniformly at random")
+		timeout    = flag.Duration("timeout", time.Minute, "simulated time after which a trial that hasn't finalized is counted as a liveness failure")
+		trials     = flag.Int("trials", 100, "number of trials to simulate for each parameter set")
+		seed       = flag.Int64("seed", 0, "seed of the simulation")
+	)
+	flag.Var(&params, "params", "candidate parameters, as a comma separated list of name=value pairs. Can be provided multiple times. Unspecified parameters use the default values")
+	flag.Parse()
+
+	if len(params) == 0 {
+		params = parameterSets{snowball.DefaultParameters}
+	}
+
+	vdrWeights, err := parseWeights(*weights, *validators)
+	if err != nil {
+		log.Fatalf("failed to parse --weights: %v", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "k\talphaPref\talphaConf\tbeta\trepolls\tbyzantine\tmean\tp50\tp90\tp99\tmax\tsafetyFail\tlivenessFail\tpolls/vdr\tmsgs/vdr\t")
+	for _, p := range params {
+		result, err := simulation.Run(simulation.Config{
+			Parameters:        p,
+			Weights:           vdrWeights,
+			ByzantineFraction: *byzantine,
+			Latency:           *latency,
+			Jitter:            *jitter,
+			Timeout:           *timeout,
+			Trials:            *trials,
+			Seed:              *seed,
+		})
+		if err != nil {
+			log.Fatalf("failed to simulate k=%d, alphaPreference=%d, alphaConfidence=%d, beta=%d: %v",
+				p.K, p.AlphaPreference, p.AlphaConfidence, p.Beta, err)
+		}
+
+		fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\t%.3f\t%s\t%s\t%s\t%s\t%s\t%.4f\t%.4f\t%.1f\t%.1f\t\n",
+			p.K,
+			p.AlphaPreference,
+			p.AlphaConfidence,
+			p.Beta,
+			p.ConcurrentRepolls,
+			result.ByzantineFraction(),
+			result.MeanLatency().Round(time.Millisecond),
+			result.LatencyPercentile(.5).Round(time.Millisecond),
+			result.LatencyPercentile(.9).Round(time.Millisecond),
+			result.LatencyPercentile(.99).Round(time.Millisecond),
+			result.LatencyPercentile(1).Round(time.Millisecond),
+			result.SafetyFailureProbability(),
+			result.LivenessFailureProbability(),
+			result.PollsPerValidator(),
+			result.MessagesPerValidator(),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatalf("failed to write results: %v", err)
+	}
+}
+
+// parseWeights returns the weights of [numValidators] validators following
+// the named distribution, or the explicitly listed weights.
+func parseWeights(distribution string, numValidators int) ([]uint64, error) {
+	weights := make([]uint64, numValidators)
+	switch distribution {
+	case "uniform":
+		for i := range weights {
+			weights[i] = defaultWeight
+		}
+	case "linear":
+		for i := range weights {
+			weights[i] = uint64(i+1) * defaultWeight
+		}
+	case "zipf":
+		for i := range weights {
+			weights[i] = uint64(numValidators) * defaultWeight / uint64(i+1)
+		}
+	default:
+		fields := strings.Split(distribution, ",")
+		weights = make([]uint64, len(fields))
+		for i, field := range fields {
+			weight, err := strconv.ParseUint(strings.TrimSpace(field), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			weights[i] = weight
+		}
+	}
+	return weights, nil
+}
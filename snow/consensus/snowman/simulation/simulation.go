@@ -0,0 +1,401 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulation estimates how a set of snowball parameters performs on a
+// network by repeatedly simulating snowman consensus on a pair of conflicting
+// blocks. Validators sample peers by stake, messages are delayed by a
+// configurable latency, and a portion of the stake can be controlled by
+// Byzantine validators that try to keep the network split.
+//
+// Every honest validator keeps ConcurrentRepolls polls outstanding until it
+// finalizes. A poll finishes once all of the sampled validators have
+// responded, and each honest validator responds with its preference at the
+// time the query arrives. All randomness is derived from a single seed, so a
+// simulation is reproducible.
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+	"github.com/MetalBlockchain/metalgo/utils/bag"
+	"github.com/MetalBlockchain/metalgo/utils/heap"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/math"
+	"github.com/MetalBlockchain/metalgo/utils/sampler"
+)
+
+var (
+	errNoValidators             = errors.New("at least one validator is required")
+	errZeroWeight               = errors.New("validator weights must be positive")
+	errKTooLarge                = errors.New("k must not exceed the number of validators")
+	errInvalidByzantineFraction = errors.New("byzantine fraction must be in [0, 1)")
+	errNegativeLatency          = errors.New("latency must be non-negative")
+	errNonPositiveTimeout       = errors.New("timeout must be positive")
+	errNonPositiveTrials        = errors.New("number of trials must be positive")
+	errNoHonestValidators       = errors.New("no honest validators")
+)
+
+type Config struct {
+	// Parameters are the consensus parameters used by every honest validator.
+	Parameters snowball.Parameters
+	// Weights are the stake weights of the validators.
+	Weights []uint64
+	// ByzantineFraction is the maximum portion of the stake that is controlled
+	// by Byzantine validators. The Byzantine validators are chosen at random
+	// in every trial. They always vote for the block that is preferred by the
+	// least honest stake.
+	ByzantineFraction float64
+	// Latency is the minimum amount of time it takes for a message to be
+	// delivered.
+	Latency time.Duration
+	// Jitter is the maximum amount of time, chosen uniformly at random, that is
+	// added to the latency of each message.
+	Jitter time.Duration
+	// Timeout is the amount of simulated time after which a trial is stopped.
+	// If an honest validator hasn't finalized by then, the trial is counted as
+	// a liveness failure.
+	Timeout time.Duration
+	// Trials is the number of times the network is simulated.
+	Trials int
+	// Seed that all the randomness of the simulation is derived from.
+	Seed int64
+}
+
+func (c *Config) Verify() error {
+	if err := c.Parameters.Verify(); err != nil {
+		return err
+	}
+	switch {
+	case len(c.Weights) == 0:
+		return errNoValidators
+	case c.Parameters.K > len(c.Weights):
+		return fmt.Errorf("%w: k = %d, validators = %d", errKTooLarge, c.Parameters.K, len(c.Weights))
+	case c.ByzantineFraction < 0 || c.ByzantineFraction >= 1:
+		return fmt.Errorf("%w: %f", errInvalidByzantineFraction, c.ByzantineFraction)
+	case c.Latency < 0 || c.Jitter < 0:
+		return errNegativeLatency
+	case c.Timeout <= 0:
+		return errNonPositiveTimeout
+	case c.Trials <= 0:
+		return errNonPositiveTrials
+	}
+	for _, weight := range c.Weights {
+		if weight == 0 {
+			return errZeroWeight
+		}
+	}
+	return nil
+}
+
+type event struct {
+	time time.Duration
+	// seq breaks ties between events scheduled at the same time, so that
+	// events are always processed in the order they were scheduled.
+	seq uint64
+	f   func() error
+}
+
+func lessEvent(a, b *event) bool {
+	if a.time != b.time {
+		return a.time < b.time
+	}
+	return a.seq < b.seq
+}
+
+type validator struct {
+	weight    uint64
+	byzantine bool
+	// consensus is only used by honest validators. It is re-initialized at
+	// the start of every trial.
+	consensus *snowman.Topological
+	ctx       *snow.ConsensusContext
+	finalized bool
+}
+
+type poll struct {
+	votes   bag.Bag[ids.ID]
+	pending int
+}
+
+// Run simulates the network described by [config] and returns the aggregated
+// results of all the trials.
+func Run(config Config) (*Result, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	s := &simulation{
+		config: config,
+		rng:    rand.New(rand.NewSource(config.Seed)), //#nosec G404
+		result: &Result{
+			Trials: config.Trials,
+		},
+	}
+	if err := s.initialize(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < config.Trials; i++ {
+		if err := s.runTrial(); err != nil {
+			return nil, err
+		}
+	}
+	s.result.sortLatencies()
+	return s.result, nil
+}
+
+type simulation struct {
+	config     Config
+	rng        *rand.Rand
+	sampler    sampler.WeightedWithoutReplacement
+	validators []*validator
+	result     *Result
+
+	// The following fields are reset at the start of every trial.
+	now   time.Duration
+	seq   uint64
+	queue heap.Queue[*event]
+	// blocks are the conflicting blocks that are being decided on.
+	blocks [2]ids.ID
+	// honestWeight is the honest stake that prefers each of [blocks].
+	honestWeight [2]uint64
+	numHonest    int
+	numFinalized int
+}
+
+func (s *simulation) initialize() error {
+	s.sampler = sampler.NewDeterministicWeightedWithoutReplacement(s.rng)
+	if err := s.sampler.Initialize(s.config.Weights); err != nil {
+		return err
+	}
+
+	s.validators = make([]*validator, len(s.config.Weights))
+	for i, weight := range s.config.Weights {
+		totalWeight, err := math.Add(s.result.TotalWeight, weight)
+		if err != nil {
+			return err
+		}
+		s.result.TotalWeight = totalWeight
+
+		log := logging.NoLog{}
+		s.validators[i] = &validator{
+			weight:    weight,
+			consensus: &snowman.Topological{},
+			ctx: &snow.ConsensusContext{
+				Context: &snow.Context{
+					Log: log,
+				},
+				Registerer:    prometheus.NewRegistry(),
+				BlockAcceptor: snow.NewAcceptorGroup(log),
+			},
+		}
+	}
+	return nil
+}
+
+// chooseByzantine marks random validators as Byzantine until no more
+// validators can be added without exceeding the Byzantine fraction. Returns
+// the Byzantine weight.
+func (s *simulation) chooseByzantine() uint64 {
+	maxByzantineWeight := uint64(s.config.ByzantineFraction * float64(s.result.TotalWeight))
+
+	var byzantineWeight uint64
+	for _, i := range s.rng.Perm(len(s.validators)) {
+		vdr := s.validators[i]
+		vdr.byzantine = false
+		if byzantineWeight+vdr.weight <= maxByzantineWeight {
+			vdr.byzantine = true
+			byzantineWeight += vdr.weight
+		}
+	}
+	return byzantineWeight
+}
+
+func (s *simulation) runTrial() error {
+	s.now = 0
+	s.seq = 0
+	s.queue = heap.NewQueue(lessEvent)
+	s.blocks = [2]ids.ID{
+		ids.Empty.Prefix(s.rng.Uint64()),
+		ids.Empty.Prefix(s.rng.Uint64()),
+	}
+	s.honestWeight = [2]uint64{}
+	s.numHonest = 0
+	s.numFinalized = 0
+	s.result.ByzantineWeight += s.chooseByzantine()
+
+	genesisID := ids.Empty
+	for _, vdr := range s.validators {
+		vdr.finalized = false
+		if vdr.byzantine {
+			continue
+		}
+		s.numHonest++
+
+		if err := vdr.consensus.Initialize(vdr.ctx, s.config.Parameters, genesisID, 0, time.Time{}); err != nil {
+			return err
+		}
+
+		// The first block that is added is preferred, so adding the blocks
+		// in a random order splits the initial preferences of the network.
+		order := s.rng.Perm(len(s.blocks))
+		for _, i := range order {
+			blk := &block{
+				id:     s.blocks[i],
+				parent: genesisID,
+				height: 1,
+			}
+			if err := vdr.consensus.Add(blk); err != nil {
+				return err
+			}
+		}
+		s.honestWeight[order[0]] += vdr.weight
+	}
+	if s.numHonest == 0 {
+		return errNoHonestValidators
+	}
+	s.result.HonestValidators += s.numHonest
+
+	for i, vdr := range s.validators {
+		if vdr.byzantine {
+			continue
+		}
+		for j := 0; j < s.config.Parameters.ConcurrentRepolls; j++ {
+			if err := s.startPoll(i); err != nil {
+				return err
+			}
+		}
+	}
+
+	for s.numFinalized < s.numHonest {
+		e, ok := s.queue.Pop()
+		if !ok || e.time > s.config.Timeout {
+			break
+		}
+		s.now = e.time
+		if err := e.f(); err != nil {
+			return err
+		}
+	}
+
+	if s.numFinalized < s.numHonest {
+		s.result.LivenessFailures++
+	}
+
+	accepted := make(map[ids.ID]struct{})
+	for _, vdr := range s.validators {
+		if !vdr.finalized {
+			continue
+		}
+		lastAcceptedID, _ := vdr.consensus.LastAccepted()
+		accepted[lastAcceptedID] = struct{}{}
+	}
+	if len(accepted) > 1 {
+		s.result.SafetyFailures++
+	}
+	return nil
+}
+
+func (s *simulation) schedule(delay time.Duration, f func() error) {
+	s.queue.Push(&event{
+		time: s.now + delay,
+		seq:  s.seq,
+		f:    f,
+	})
+	s.seq++
+}
+
+// latency returns the amount of time it takes for a message to be delivered.
+func (s *simulation) latency() time.Duration {
+	if s.config.Jitter == 0 {
+		return s.config.Latency
+	}
+	return s.config.Latency + time.Duration(s.rng.Int63n(int64(s.config.Jitter)+1))
+}
+
+// startPoll sends a query from validator [i] to K validators sampled by
+// stake.
+func (s *simulation) startPoll(i int) error {
+	indices, ok := s.sampler.Sample(s.config.Parameters.K)
+	if !ok {
+		return errKTooLarge
+	}
+
+	s.result.Polls++
+	s.result.Messages += uint64(2 * len(indices))
+	p := &poll{
+		pending: len(indices),
+	}
+	for _, j := range indices {
+		s.schedule(s.latency(), func() error {
+			vote := s.vote(j)
+			s.schedule(s.latency(), func() error {
+				return s.recordVote(i, p, vote)
+			})
+			return nil
+		})
+	}
+	return nil
+}
+
+// vote returns the block that validator [i] responds with when queried.
+func (s *simulation) vote(i int) ids.ID {
+	vdr := s.validators[i]
+	if !vdr.byzantine {
+		return vdr.consensus.Preference()
+	}
+	// Byzantine validators vote for the block that is preferred by the least
+	// honest stake to try to keep the network split.
+	if s.honestWeight[1] < s.honestWeight[0] {
+		return s.blocks[1]
+	}
+	return s.blocks[0]
+}
+
+// recordVote registers [vote] in poll [p] of validator [i]. Once all of the
+// votes of the poll have been received, the poll is applied and a new poll is
+// started if the validator hasn't finalized.
+func (s *simulation) recordVote(i int, p *poll, vote ids.ID) error {
+	p.votes.Add(vote)
+	p.pending--
+	vdr := s.validators[i]
+	if p.pending > 0 || vdr.finalized {
+		return nil
+	}
+
+	oldPreference := vdr.consensus.Preference()
+	if err := vdr.consensus.RecordPoll(context.Background(), p.votes); err != nil {
+		return err
+	}
+	if newPreference := vdr.consensus.Preference(); newPreference != oldPreference {
+		s.moveHonestWeight(vdr.weight, oldPreference, newPreference)
+	}
+
+	if vdr.consensus.NumProcessing() == 0 {
+		vdr.finalized = true
+		s.numFinalized++
+		s.result.Latencies = append(s.result.Latencies, s.now)
+		return nil
+	}
+	return s.startPoll(i)
+}
+
+func (s *simulation) moveHonestWeight(weight uint64, from, to ids.ID) {
+	for i, blkID := range s.blocks {
+		switch blkID {
+		case from:
+			s.honestWeight[i] -= weight
+		case to:
+			s.honestWeight[i] += weight
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
+)
+
+var testParameters = snowball.Parameters{
+	K:                     5,
+	AlphaPreference:       4,
+	AlphaConfidence:       4,
+	Beta:                  5,
+	ConcurrentRepolls:     2,
+	OptimalProcessing:     1,
+	MaxOutstandingItems:   1,
+	MaxItemProcessingTime: 1,
+}
+
+func uniformWeights(n int) []uint64 {
+	weights := make([]uint64, n)
+	for i := range weights {
+		weights[i] = 1
+	}
+	return weights
+}
+
+func TestConfigVerify(t *testing.T) {
+	valid := Config{
+		Parameters: testParameters,
+		Weights:    uniformWeights(5),
+		Timeout:    time.Minute,
+		Trials:     1,
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr error
+	}{
+		{
+			name:        "valid",
+			modify:      func(*Config) {},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid parameters",
+			modify: func(c *Config) {
+				c.Parameters.Beta = 0
+			},
+			expectedErr: snowball.ErrParametersInvalid,
+		},
+		{
+			name: "no validators",
+			modify: func(c *Config) {
+				c.Weights = nil
+			},
+			expectedErr: errNoValidators,
+		},
+		{
+			name: "k exceeds validators",
+			modify: func(c *Config) {
+				c.Weights = uniformWeights(4)
+			},
+			expectedErr: errKTooLarge,
+		},
+		{
+			name: "zero weight",
+			modify: func(c *Config) {
+				c.Weights[2] = 0
+			},
+			expectedErr: errZeroWeight,
+		},
+		{
+			name: "byzantine fraction too high",
+			modify: func(c *Config) {
+				c.ByzantineFraction = 1
+			},
+			expectedErr: errInvalidByzantineFraction,
+		},
+		{
+			name: "negative jitter",
+			modify: func(c *Config) {
+				c.Jitter = -1
+			},
+			expectedErr: errNegativeLatency,
+		},
+		{
+			name: "no timeout",
+			modify: func(c *Config) {
+				c.Timeout = 0
+			},
+			expectedErr: errNonPositiveTimeout,
+		},
+		{
+			name: "no trials",
+			modify: func(c *Config) {
+				c.Trials = 0
+			},
+			expectedErr: errNonPositiveTrials,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := valid
+			config.Weights = uniformWeights(5)
+			test.modify(&config)
+			err := config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestRunHonestNetwork(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		numValidators = 20
+		numTrials     = 10
+		latency       = 10 * time.Millisecond
+	)
+	result, err := Run(Config{
+		Parameters: testParameters,
+		Weights:    uniformWeights(numValidators),
+		Latency:    latency,
+		Jitter:     latency,
+		Timeout:    time.Minute,
+		Trials:     numTrials,
+	})
+	require.NoError(err)
+
+	require.Zero(result.ByzantineWeight)
+	require.Zero(result.SafetyFailures)
+	require.Zero(result.LivenessFailures)
+	require.Equal(numValidators*numTrials, result.HonestValidators)
+	require.Len(result.Latencies, numValidators*numTrials)
+	require.True(slices.IsSorted(result.Latencies))
+
+	// Every validator must have received the responses of at least Beta
+	// successful polls, and each poll takes at least one round trip.
+	minLatency := 2 * latency
+	require.GreaterOrEqual(result.LatencyPercentile(0), minLatency)
+	require.GreaterOrEqual(result.PollsPerValidator(), float64(testParameters.Beta))
+	require.Equal(2*uint64(testParameters.K)*result.Polls, result.Messages)
+}
+
+func TestRunByzantine(t *testing.T) {
+	require := require.New(t)
+
+	const numValidators = 20
+	result, err := Run(Config{
+		Parameters:        testParameters,
+		Weights:           uniformWeights(numValidators),
+		ByzantineFraction: .2,
+		Latency:           10 * time.Millisecond,
+		Timeout:           time.Second,
+		Trials:            5,
+	})
+	require.NoError(err)
+
+	require.Equal(.2, result.ByzantineFraction())
+	require.Equal(16*5, result.HonestValidators)
+	require.LessOrEqual(len(result.Latencies), result.HonestValidators)
+}
+
+func TestRunDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	run := func(seed int64) *Result {
+		result, err := Run(Config{
+			Parameters:        testParameters,
+			Weights:           []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			ByzantineFraction: .1,
+			Latency:           10 * time.Millisecond,
+			Jitter:            50 * time.Millisecond,
+			Timeout:           10 * time.Second,
+			Trials:            5,
+			Seed:              seed,
+		})
+		require.NoError(err)
+		return result
+	}
+
+	result := run(1)
+	require.Equal(result, run(1))
+	require.NotEqual(result, run(2))
+}
+
+func TestResultLatencyPercentile(t *testing.T) {
+	require := require.New(t)
+
+	result := &Result{}
+	require.Zero(result.LatencyPercentile(.5))
+	require.Zero(result.MeanLatency())
+
+	result.Latencies = []time.Duration{1, 2, 3, 4, 5}
+	require.Equal(time.Duration(1), result.LatencyPercentile(0))
+	require.Equal(time.Duration(3), result.LatencyPercentile(.5))
+	require.Equal(time.Duration(5), result.LatencyPercentile(1))
+	require.Equal(time.Duration(3), result.MeanLatency())
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/json"
+)
+
+// State is a snapshot of the state of a snowman instance.
+type State struct {
+	LastAcceptedID     ids.ID      `json:"lastAcceptedID"`
+	LastAcceptedHeight json.Uint64 `json:"lastAcceptedHeight"`
+	// Preference is the tail of the strongly preferred sequence of blocks.
+	Preference ids.ID `json:"preference"`
+	// NumPolls is the number of polls that have been recorded.
+	NumPolls json.Uint64 `json:"numPolls"`
+	// Blocks are the last accepted block followed by the processing blocks,
+	// ordered by height.
+	Blocks []BlockState `json:"blocks"`
+}
+
+// BlockState is a snapshot of the state of the last accepted block or of a
+// processing block.
+type BlockState struct {
+	ID ids.ID `json:"id"`
+	// ParentID is empty if the block is the last accepted block and its parent
+	// is no longer tracked.
+	ParentID ids.ID      `json:"parentID"`
+	Height   json.Uint64 `json:"height"`
+	// Preferred is true if the block is the preferred block at its height.
+	Preferred bool `json:"preferred"`
+	// ChildConsensus describes the snowball instance, including its
+	// confidence counters, that decides between the children of the block.
+	// It is empty if the block doesn't have any children.
+	ChildConsensus string `json:"childConsensus,omitempty"`
+}
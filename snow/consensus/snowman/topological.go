@@ -4,9 +4,11 @@
 package snowman
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"go.uber.org/zap"
@@ -15,6 +17,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
+	"github.com/MetalBlockchain/metalgo/utils/json"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 )
 
@@ -109,14 +112,20 @@ func (ts *Topological) Initialize(
 		return err
 	}
 
-	ts.metrics, err = newMetrics(
-		ctx.Log,
-		ctx.Registerer,
-		lastAcceptedHeight,
-		lastAcceptedTime,
-	)
-	if err != nil {
-		return err
+	// Initialize is called again if the chain is re-bootstrapped, in which
+	// case the metrics have already been registered.
+	if ts.metrics == nil {
+		ts.metrics, err = newMetrics(
+			ctx.Log,
+			ctx.Registerer,
+			lastAcceptedHeight,
+			lastAcceptedTime,
+		)
+		if err != nil {
+			return err
+		}
+	} else {
+		ts.metrics.Reset(lastAcceptedHeight, lastAcceptedTime)
 	}
 
 	ts.leaves = set.Set[ids.ID]{}
@@ -128,6 +137,7 @@ func (ts *Topological) Initialize(
 	ts.blocks = map[ids.ID]*snowmanBlock{
 		lastAcceptedID: {t: ts},
 	}
+	ts.preferredIDs.Clear()
 	ts.preferredHeights = make(map[uint64]ids.ID)
 	ts.preference = lastAcceptedID
 	return nil
@@ -213,6 +223,71 @@ func (ts *Topological) PreferenceAtHeight(height uint64) (ids.ID, bool) {
 	return blkID, ok
 }
 
+func (ts *Topological) RejectProcessing(ctx context.Context) error {
+	lastAccepted := ts.blocks[ts.lastAcceptedID]
+	rejects := make([]ids.ID, 0, len(lastAccepted.children))
+	for childID, child := range lastAccepted.children {
+		ts.ctx.Log.Trace("rejecting block",
+			zap.String("reason", "abandoned"),
+			zap.Stringer("blkID", childID),
+			zap.Uint64("height", child.Height()),
+		)
+		if err := child.Reject(ctx); err != nil {
+			return err
+		}
+		ts.metrics.Rejected(childID, ts.pollNumber, len(child.Bytes()))
+
+		// Track which blocks have been directly rejected
+		rejects = append(rejects, childID)
+	}
+
+	// reject all the descendants of the blocks we just rejected
+	if err := ts.rejectTransitively(ctx, rejects); err != nil {
+		return err
+	}
+
+	lastAccepted.shouldFalter = false
+	lastAccepted.sb = nil
+	lastAccepted.children = nil
+	ts.preferredIDs.Clear()
+	clear(ts.preferredHeights)
+	ts.preference = ts.lastAcceptedID
+	return nil
+}
+
+func (ts *Topological) State() State {
+	blocks := make([]BlockState, 0, len(ts.blocks))
+	for blkID, n := range ts.blocks {
+		blkState := BlockState{
+			ID:        blkID,
+			Height:    json.Uint64(ts.lastAcceptedHeight),
+			Preferred: ts.IsPreferred(blkID),
+		}
+		if n.blk != nil {
+			blkState.ParentID = n.blk.Parent()
+			blkState.Height = json.Uint64(n.blk.Height())
+		}
+		if n.sb != nil {
+			blkState.ChildConsensus = n.sb.String()
+		}
+		blocks = append(blocks, blkState)
+	}
+	slices.SortFunc(blocks, func(a, b BlockState) int {
+		if c := cmp.Compare(a.Height, b.Height); c != 0 {
+			return c
+		}
+		return a.ID.Compare(b.ID)
+	})
+
+	return State{
+		LastAcceptedID:     ts.lastAcceptedID,
+		LastAcceptedHeight: json.Uint64(ts.lastAcceptedHeight),
+		Preference:         ts.preference,
+		NumPolls:           json.Uint64(ts.pollNumber),
+		Blocks:             blocks,
+	}
+}
+
 // The votes bag contains at most K votes for blocks in the tree. If there is a
 // vote for a block that isn't in the tree, the vote is dropped.
 //
@@ -38,3 +38,12 @@ func (c *tracedConsensus) RecordPoll(ctx context.Context, votes bag.Bag[ids.ID])
 
 	return c.Consensus.RecordPoll(ctx, votes)
 }
+
+func (c *tracedConsensus) RejectProcessing(ctx context.Context) error {
+	ctx, span := c.tracer.Start(ctx, "tracedConsensus.RejectProcessing", oteltrace.WithAttributes(
+		attribute.Int("numProcessing", c.Consensus.NumProcessing()),
+	))
+	defer span.End()
+
+	return c.Consensus.RejectProcessing(ctx)
+}
@@ -317,6 +317,15 @@ func (*Bootstrapper) Notify(context.Context, common.Message) error {
 	return nil
 }
 
+func (b *Bootstrapper) LastRequestID() uint32 {
+	return b.requestID
+}
+
+// Abandon is a no-op, as Start resets any work in progress.
+func (*Bootstrapper) Abandon(context.Context) error {
+	return nil
+}
+
 func (b *Bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 	b.Ctx.Log.Info("starting bootstrap")
 
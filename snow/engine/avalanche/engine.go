@@ -62,6 +62,15 @@ func (*engine) Start(context.Context, uint32) error {
 	return errUnexpectedStart
 }
 
+// LastRequestID returns 0 because this engine never sends requests.
+func (*engine) LastRequestID() uint32 {
+	return 0
+}
+
+func (*engine) Abandon(context.Context) error {
+	return nil
+}
+
 func (e *engine) Context() *snow.ConsensusContext {
 	return e.ctx
 }
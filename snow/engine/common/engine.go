@@ -25,6 +25,13 @@ type Engine interface {
 	// Start engine operations from given request ID
 	Start(ctx context.Context, startReqID uint32) error
 
+	// Returns the last request ID used by the engine
+	LastRequestID() uint32
+
+	// Abandon drops the engine's undecided work because the chain is about to
+	// be re-bootstrapped.
+	Abandon(ctx context.Context) error
+
 	// Returns nil if the engine is healthy.
 	// Periodically called and reported through the health API
 	health.Checker
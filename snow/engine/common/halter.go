@@ -20,6 +20,11 @@ func (h *Halter) Halt() {
 	atomic.StoreUint32(&h.halted, 1)
 }
 
+// Resume clears the effect of any prior calls to Halt.
+func (h *Halter) Resume() {
+	atomic.StoreUint32(&h.halted, 0)
+}
+
 func (h *Halter) Halted() bool {
 	return atomic.LoadUint32(&h.halted) == 1
 }
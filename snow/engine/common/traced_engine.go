@@ -368,6 +368,17 @@ func (e *tracedEngine) Start(ctx context.Context, startReqID uint32) error {
 	return e.engine.Start(ctx, startReqID)
 }
 
+func (e *tracedEngine) LastRequestID() uint32 {
+	return e.engine.LastRequestID()
+}
+
+func (e *tracedEngine) Abandon(ctx context.Context) error {
+	ctx, span := e.tracer.Start(ctx, "tracedEngine.Abandon")
+	defer span.End()
+
+	return e.engine.Abandon(ctx)
+}
+
 func (e *tracedEngine) HealthCheck(ctx context.Context) (interface{}, error) {
 	ctx, span := e.tracer.Start(ctx, "tracedEngine.HealthCheck")
 	defer span.End()
@@ -45,6 +45,8 @@ var (
 	errQueryFailed                   = errors.New("unexpectedly called QueryFailed")
 	errChits                         = errors.New("unexpectedly called Chits")
 	errStart                         = errors.New("unexpectedly called Start")
+	errLastRequestID                 = errors.New("unexpectedly called LastRequestID")
+	errAbandon                       = errors.New("unexpectedly called Abandon")
 
 	_ common.Engine = (*Engine)(nil)
 )
@@ -54,6 +56,8 @@ type Engine struct {
 	T *testing.T
 
 	CantStart,
+	CantLastRequestID,
+	CantAbandon,
 
 	CantIsBootstrapped,
 	CantTimeout,
@@ -106,6 +110,8 @@ type Engine struct {
 	CantGetVM bool
 
 	StartF                       func(ctx context.Context, startReqID uint32) error
+	LastRequestIDF               func() uint32
+	AbandonF                     func(context.Context) error
 	IsBootstrappedF              func() bool
 	ContextF                     func() *snow.ConsensusContext
 	HaltF                        func(context.Context)
@@ -137,6 +143,8 @@ type Engine struct {
 
 func (e *Engine) Default(cant bool) {
 	e.CantStart = cant
+	e.CantLastRequestID = cant
+	e.CantAbandon = cant
 	e.CantIsBootstrapped = cant
 	e.CantTimeout = cant
 	e.CantGossip = cant
@@ -189,6 +197,29 @@ func (e *Engine) Start(ctx context.Context, startReqID uint32) error {
 	return errStart
 }
 
+func (e *Engine) LastRequestID() uint32 {
+	if e.LastRequestIDF != nil {
+		return e.LastRequestIDF()
+	}
+	if e.CantLastRequestID && e.T != nil {
+		require.FailNow(e.T, errLastRequestID.Error())
+	}
+	return 0
+}
+
+func (e *Engine) Abandon(ctx context.Context) error {
+	if e.AbandonF != nil {
+		return e.AbandonF(ctx)
+	}
+	if !e.CantAbandon {
+		return nil
+	}
+	if e.T != nil {
+		require.FailNow(e.T, errAbandon.Error())
+	}
+	return errAbandon
+}
+
 func (e *Engine) Timeout(ctx context.Context) error {
 	if e.TimeoutF != nil {
 		return e.TimeoutF(ctx)
@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package archive reads and writes block archives, which allow a node to
+// bootstrap a chain from blocks that were exported from another node rather
+// than fetching them from its peers.
+//
+// An archive is laid out as:
+//
+//	header:  magic | version (uint16) | chain ID
+//	blocks:  (length (uint32) | block bytes)...
+//	index:   offset of each block (uint64)...
+//	trailer: start height (uint64) | number of blocks (uint64) | index offset (uint64)
+//
+// The blocks are stored in increasing height order, so a block can be read by
+// its height without reading the rest of the archive.
+package archive
+
+import (
+	"errors"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const (
+	magic   = "metalblk"
+	version = 0
+
+	headerLen  = len(magic) + wrappers.ShortLen + ids.IDLen
+	trailerLen = 3 * wrappers.LongLen
+)
+
+var (
+	errBlockTooLarge      = errors.New("block too large")
+	errInvalidMagic       = errors.New("invalid magic")
+	errUnsupportedVersion = errors.New("unsupported version")
+	errCorruptArchive     = errors.New("corrupt archive")
+)
@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman/snowmantest"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block/blocktest"
+	"github.com/MetalBlockchain/metalgo/utils/perms"
+)
+
+func TestArchive(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		path     = filepath.Join(t.TempDir(), "blocks.archive")
+		chainID  = ids.GenerateTestID()
+		blksByte = [][]byte{{1}, {}, {2, 3, 4}}
+	)
+	w, err := Create(path, chainID, 5)
+	require.NoError(err)
+	for _, blkBytes := range blksByte {
+		require.NoError(w.Add(blkBytes))
+	}
+	require.Equal(uint64(len(blksByte)), w.NumBlocks())
+	require.NoError(w.Close())
+
+	r, err := Open(path)
+	require.NoError(err)
+	defer func() {
+		require.NoError(r.Close())
+	}()
+
+	require.Equal(chainID, r.ChainID())
+	require.Equal(uint64(5), r.StartHeight())
+	require.Equal(uint64(len(blksByte)), r.NumBlocks())
+	for i, expected := range blksByte {
+		blkBytes, err := r.GetBlock(5 + uint64(i))
+		require.NoError(err)
+		require.Equal(expected, blkBytes)
+	}
+
+	_, err = r.GetBlock(4)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = r.GetBlock(8)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestOpenInvalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		contents    []byte
+		expectedErr error
+	}{
+		{
+			name:        "too small",
+			contents:    []byte(magic),
+			expectedErr: errCorruptArchive,
+		},
+		{
+			name:        "invalid magic",
+			contents:    make([]byte, headerLen+trailerLen),
+			expectedErr: errInvalidMagic,
+		},
+		{
+			name:        "invalid index",
+			contents:    append([]byte(magic), make([]byte, headerLen+trailerLen)...),
+			expectedErr: errCorruptArchive,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "blocks.archive")
+			require.NoError(t, os.WriteFile(path, test.contents, perms.ReadWrite))
+
+			_, err := Open(path)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		dir      = t.TempDir()
+		paths    []string
+		chainIDs = []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+	)
+	for i, chainID := range chainIDs {
+		path := filepath.Join(dir, chainID.String())
+		w, err := Create(path, chainID, uint64(i))
+		require.NoError(err)
+		require.NoError(w.Close())
+		paths = append(paths, path)
+	}
+
+	r, ok, err := Find(paths, chainIDs[1])
+	require.NoError(err)
+	require.True(ok)
+	require.Equal(chainIDs[1], r.ChainID())
+	require.Equal(uint64(1), r.StartHeight())
+	require.NoError(r.Close())
+
+	_, ok, err = Find(paths[:1], chainIDs[1])
+	require.NoError(err)
+	require.False(ok)
+}
+
+func TestReadBlocks(t *testing.T) {
+	require := require.New(t)
+
+	blks := snowmantest.BuildChain(4)
+	vm := &blocktest.VM{
+		GetBlockIDAtHeightF: snowmantest.MakeGetBlockIDAtHeightF(blks),
+		GetBlockF: func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+			for _, blk := range blks {
+				if blk.ID() == blkID {
+					return blk, nil
+				}
+			}
+			return nil, database.ErrNotFound
+		},
+	}
+	for _, blk := range blks {
+		require.NoError(blk.Accept(context.Background()))
+	}
+
+	blksBytes, err := ReadBlocks(context.Background(), vm, 1, 3, 1024)
+	require.NoError(err)
+	require.Equal([][]byte{blks[1].Bytes(), blks[2].Bytes(), blks[3].Bytes()}, blksBytes)
+
+	// At least one block is returned, even if it exceeds the byte limit.
+	blksBytes, err = ReadBlocks(context.Background(), vm, 1, 3, 1)
+	require.NoError(err)
+	require.Equal([][]byte{blks[1].Bytes()}, blksBytes)
+
+	_, err = ReadBlocks(context.Background(), vm, 3, 4, 1024)
+	require.ErrorIs(err, database.ErrNotFound)
+}
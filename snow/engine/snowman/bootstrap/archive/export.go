@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+// ReadBlocks returns the bytes of the accepted blocks of [vm] in the height
+// range [startHeight, endHeight]. Fewer blocks are returned if including the
+// next block would exceed [maxBytes], but at least one block is returned if
+// the range isn't empty.
+func ReadBlocks(
+	ctx context.Context,
+	vm block.ChainVM,
+	startHeight uint64,
+	endHeight uint64,
+	maxBytes int,
+) ([][]byte, error) {
+	var (
+		blksBytes [][]byte
+		numBytes  int
+	)
+	for height := startHeight; height <= endHeight; height++ {
+		blkID, err := vm.GetBlockIDAtHeight(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block ID at height %d: %w", height, err)
+		}
+		blk, err := vm.GetBlock(ctx, blkID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block %s: %w", blkID, err)
+		}
+
+		blkBytes := blk.Bytes()
+		numBytes += len(blkBytes) + wrappers.IntLen
+		if len(blksBytes) > 0 && numBytes > maxBytes {
+			break
+		}
+		blksBytes = append(blksBytes, blkBytes)
+
+		// Avoid overflowing if [endHeight] is the max height.
+		if height == endHeight {
+			break
+		}
+	}
+	return blksBytes, nil
+}
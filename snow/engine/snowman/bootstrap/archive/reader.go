@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+// Reader reads blocks from a block archive by their height.
+//
+// Reader is safe for concurrent use.
+type Reader struct {
+	file *os.File
+
+	chainID     ids.ID
+	startHeight uint64
+	numBlocks   uint64
+	indexOffset uint64
+}
+
+// Open the block archive at [path].
+func Open(path string) (*Reader, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := newReader(file)
+	if err != nil {
+		return nil, errors.Join(
+			fmt.Errorf("failed to read %s: %w", path, err),
+			file.Close(),
+		)
+	}
+	return r, nil
+}
+
+func newReader(file *os.File) (*Reader, error) {
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := uint64(stat.Size())
+	if size < uint64(headerLen+trailerLen) {
+		return nil, fmt.Errorf("%w: size %d is too small", errCorruptArchive, size)
+	}
+
+	header := make([]byte, headerLen)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(header[:len(magic)], []byte(magic)) {
+		return nil, errInvalidMagic
+	}
+	header = header[len(magic):]
+	if v := binary.BigEndian.Uint16(header); v != version {
+		return nil, fmt.Errorf("%w: %d", errUnsupportedVersion, v)
+	}
+	header = header[wrappers.ShortLen:]
+
+	trailer := make([]byte, trailerLen)
+	if _, err := file.ReadAt(trailer, int64(size-trailerLen)); err != nil {
+		return nil, err
+	}
+
+	r := &Reader{
+		file:        file,
+		chainID:     ids.ID(header),
+		startHeight: binary.BigEndian.Uint64(trailer),
+		numBlocks:   binary.BigEndian.Uint64(trailer[wrappers.LongLen:]),
+		indexOffset: binary.BigEndian.Uint64(trailer[2*wrappers.LongLen:]),
+	}
+	indexLen := r.numBlocks * wrappers.LongLen
+	if r.indexOffset < uint64(headerLen) || r.indexOffset+indexLen != size-trailerLen {
+		return nil, fmt.Errorf("%w: invalid index", errCorruptArchive)
+	}
+	return r, nil
+}
+
+// ChainID returns the ID of the chain whose blocks are in the archive.
+func (r *Reader) ChainID() ids.ID {
+	return r.chainID
+}
+
+// StartHeight returns the height of the first block in the archive.
+func (r *Reader) StartHeight() uint64 {
+	return r.startHeight
+}
+
+// NumBlocks returns the number of blocks in the archive.
+func (r *Reader) NumBlocks() uint64 {
+	return r.numBlocks
+}
+
+// GetBlock returns the bytes of the block at [height]. If the archive doesn't
+// contain a block at [height], [database.ErrNotFound] is returned.
+func (r *Reader) GetBlock(height uint64) ([]byte, error) {
+	if height < r.startHeight || height-r.startHeight >= r.numBlocks {
+		return nil, database.ErrNotFound
+	}
+
+	offsetBytes := make([]byte, wrappers.LongLen)
+	indexEntryOffset := r.indexOffset + (height-r.startHeight)*wrappers.LongLen
+	if _, err := r.file.ReadAt(offsetBytes, int64(indexEntryOffset)); err != nil {
+		return nil, err
+	}
+	offset := binary.BigEndian.Uint64(offsetBytes)
+	if offset < uint64(headerLen) || offset+wrappers.IntLen > r.indexOffset {
+		return nil, fmt.Errorf("%w: invalid offset %d of height %d", errCorruptArchive, offset, height)
+	}
+
+	blkLenBytes := make([]byte, wrappers.IntLen)
+	if _, err := r.file.ReadAt(blkLenBytes, int64(offset)); err != nil {
+		return nil, err
+	}
+	blkLen := binary.BigEndian.Uint32(blkLenBytes)
+	blkOffset := offset + wrappers.IntLen
+	if blkLen > constants.DefaultMaxMessageSize || blkOffset+uint64(blkLen) > r.indexOffset {
+		return nil, fmt.Errorf("%w: invalid length %d of height %d", errCorruptArchive, blkLen, height)
+	}
+
+	blkBytes := make([]byte, blkLen)
+	_, err := r.file.ReadAt(blkBytes, int64(blkOffset))
+	return blkBytes, err
+}
+
+func (r *Reader) Close() error {
+	return r.file.Close()
+}
+
+// Find opens the first archive at [paths] that contains the blocks of
+// [chainID]. If none of the archives contain the blocks of [chainID], false is
+// returned.
+func Find(paths []string, chainID ids.ID) (*Reader, bool, error) {
+	for _, path := range paths {
+		r, err := Open(path)
+		if err != nil {
+			return nil, false, err
+		}
+		if r.ChainID() == chainID {
+			return r, true, nil
+		}
+		if err := r.Close(); err != nil {
+			return nil, false, err
+		}
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archive
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/perms"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+// Writer writes the blocks of a chain, in increasing height order, to a block
+// archive.
+type Writer struct {
+	file   *os.File
+	writer *bufio.Writer
+
+	startHeight uint64
+	// offset is the number of bytes written to the file
+	offset uint64
+	// offsets of each block written to the file
+	offsets []uint64
+}
+
+// Create a block archive at [path] for the blocks of [chainID], starting at
+// [startHeight].
+func Create(path string, chainID ids.ID, startHeight uint64) (*Writer, error) {
+	file, err := perms.Create(path, perms.ReadWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Writer{
+		file:        file,
+		writer:      bufio.NewWriter(file),
+		startHeight: startHeight,
+	}
+	header := make([]byte, 0, headerLen)
+	header = append(header, magic...)
+	header = binary.BigEndian.AppendUint16(header, version)
+	header = append(header, chainID[:]...)
+	if err := w.write(header); err != nil {
+		return nil, errors.Join(err, file.Close())
+	}
+	return w, nil
+}
+
+// Add the next block to the archive.
+func (w *Writer) Add(blkBytes []byte) error {
+	blkLen := uint64(len(blkBytes))
+	if blkLen > constants.DefaultMaxMessageSize {
+		return fmt.Errorf("%w: %d > %d", errBlockTooLarge, blkLen, constants.DefaultMaxMessageSize)
+	}
+
+	w.offsets = append(w.offsets, w.offset)
+	blkLenBytes := binary.BigEndian.AppendUint32(nil, uint32(blkLen))
+	if err := w.write(blkLenBytes); err != nil {
+		return err
+	}
+	return w.write(blkBytes)
+}
+
+// NumBlocks returns the number of blocks added to the archive.
+func (w *Writer) NumBlocks() uint64 {
+	return uint64(len(w.offsets))
+}
+
+// Close writes the index of the archive and closes the file.
+func (w *Writer) Close() error {
+	indexOffset := w.offset
+	index := make([]byte, 0, len(w.offsets)*wrappers.LongLen+trailerLen)
+	for _, offset := range w.offsets {
+		index = binary.BigEndian.AppendUint64(index, offset)
+	}
+	index = binary.BigEndian.AppendUint64(index, w.startHeight)
+	index = binary.BigEndian.AppendUint64(index, w.NumBlocks())
+	index = binary.BigEndian.AppendUint64(index, indexOffset)
+	return errors.Join(
+		w.write(index),
+		w.writer.Flush(),
+		w.file.Close(),
+	)
+}
+
+func (w *Writer) write(b []byte) error {
+	n, err := w.writer.Write(b)
+	w.offset += uint64(n)
+	return err
+}
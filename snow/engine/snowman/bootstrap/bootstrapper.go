@@ -4,6 +4,7 @@
 package bootstrap
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -24,6 +25,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/bootstrap/interval"
 	"github.com/MetalBlockchain/metalgo/utils/bimap"
+	"github.com/MetalBlockchain/metalgo/utils/heap"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 	"github.com/MetalBlockchain/metalgo/utils/timer"
 	"github.com/MetalBlockchain/metalgo/version"
@@ -42,12 +44,27 @@ const (
 	maxOutstandingBroadcastRequests = 50
 
 	epsilon = 1e-6 // small amount to add to time to avoid division by 0
+
+	// minBlocksToPipeline is the minimum number of fetched blocks that must
+	// directly follow the last accepted block for them to be executed while
+	// other blocks are still being fetched. This happens when bootstrapping
+	// resumes blocks that an interrupted run fetched but didn't execute.
+	minBlocksToPipeline = 5000
+
+	// maxPeerSelectionAttempts is the number of times a peer is sampled when
+	// looking for a peer without an outstanding request.
+	maxPeerSelectionAttempts = 8
+
+	// unknownHeight is used to prioritize missing blocks whose height isn't
+	// known after all other missing blocks.
+	unknownHeight = math.MaxUint64
 )
 
 var (
 	_ common.BootstrapableEngine = (*Bootstrapper)(nil)
 
-	errUnexpectedTimeout = errors.New("unexpected timeout fired")
+	errUnexpectedTimeout             = errors.New("unexpected timeout fired")
+	errInvalidMaxOutstandingRequests = errors.New("max outstanding requests must be positive")
 )
 
 // bootstrapper repeatedly performs the bootstrapping protocol.
@@ -99,13 +116,23 @@ type Bootstrapper struct {
 	// tracks which validators were asked for which containers in which requests
 	outstandingRequests     *bimap.BiMap[common.Request, ids.ID]
 	outstandingRequestTimes map[common.Request]time.Time
+	// number of outstanding requests sent to each peer
+	outstandingPeerRequests map[ids.NodeID]int
 
 	// number of state transitions executed
 	executedStateTransitions uint64
-	awaitingTimeout          bool
+	// number of blocks executed while other blocks were still being fetched
+	// during the current bootstrapping round
+	pipelinedStateTransitions uint64
+	awaitingTimeout           bool
 
 	tree            *interval.Tree
 	missingBlockIDs set.Set[ids.ID]
+	// Heights of missing blocks, if known
+	missingBlockHeights map[ids.ID]uint64
+	// Missing blocks that will be fetched once there are fewer than
+	// [MaxOutstandingRequests] outstanding requests, ordered by height
+	pendingBlockIDs heap.Map[ids.ID, uint64]
 
 	// bootstrappedOnce ensures that the [Bootstrapped] callback is only invoked
 	// once, even if bootstrapping is retried.
@@ -118,6 +145,10 @@ type Bootstrapper struct {
 }
 
 func New(config Config, onFinished func(ctx context.Context, lastReqID uint32) error) (*Bootstrapper, error) {
+	if config.MaxOutstandingRequests <= 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidMaxOutstandingRequests, config.MaxOutstandingRequests)
+	}
+
 	metrics, err := newMetrics(config.Ctx.Registerer)
 	return &Bootstrapper{
 		shouldHalt:                  config.ShouldHalt,
@@ -136,6 +167,10 @@ func New(config Config, onFinished func(ctx context.Context, lastReqID uint32) e
 
 		outstandingRequests:     bimap.New[common.Request, ids.ID](),
 		outstandingRequestTimes: make(map[common.Request]time.Time),
+		outstandingPeerRequests: make(map[ids.NodeID]int),
+
+		missingBlockHeights: make(map[ids.ID]uint64),
+		pendingBlockIDs:     heap.NewMap[ids.ID, uint64](cmp.Less[uint64]),
 
 		executedStateTransitions: math.MaxInt,
 		onFinished:               onFinished,
@@ -153,6 +188,15 @@ func (b *Bootstrapper) Clear(context.Context) error {
 	return database.AtomicClear(b.DB, b.DB)
 }
 
+func (b *Bootstrapper) LastRequestID() uint32 {
+	return b.requestID
+}
+
+// Abandon is a no-op, as Start resets any work in progress.
+func (*Bootstrapper) Abandon(context.Context) error {
+	return nil
+}
+
 func (b *Bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 	b.Ctx.State.Set(snow.EngineState{
 		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
@@ -173,6 +217,19 @@ func (b *Bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 		zap.Uint64("lastAcceptedHeight", lastAcceptedHeight),
 	)
 
+	// Start is called again if the chain is re-bootstrapped, in which case any
+	// progress from the prior run must be discarded.
+	b.started = false
+	b.awaitingTimeout = false
+	b.minority = bootstrapper.Noop
+	b.majority = bootstrapper.Noop
+	b.clearRequests()
+	b.missingBlockHeights = make(map[ids.ID]uint64)
+	b.pendingBlockIDs = heap.NewMap[ids.ID, uint64](cmp.Less[uint64])
+	b.numPendingRequests.Set(0)
+	b.pipelinedStateTransitions = 0
+	b.executedStateTransitions = math.MaxInt
+
 	// Set the starting height
 	b.startingHeight = lastAcceptedHeight
 	b.requestID = startReqID
@@ -206,6 +263,10 @@ func (b *Bootstrapper) Disconnected(ctx context.Context, nodeID ids.NodeID) erro
 	if err := b.VM.Disconnected(ctx, nodeID); err != nil {
 		return err
 	}
+
+	nodeIDStr := nodeID.String()
+	b.peerReceivedBytes.DeleteLabelValues(nodeIDStr)
+	b.peerRequestTime.DeleteLabelValues(nodeIDStr)
 	return b.StartupTracker.Disconnected(ctx, nodeID)
 }
 
@@ -406,14 +467,28 @@ func (b *Bootstrapper) startSyncing(ctx context.Context, acceptedBlockIDs []ids.
 	return b.tryStartExecuting(ctx)
 }
 
-// Get block [blkID] and its ancestors from a validator
+// Get block [blkID] and its ancestors from a validator. If there are already
+// [MaxOutstandingRequests] outstanding requests, the block is fetched once a
+// request finishes.
 func (b *Bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
 	// Make sure we haven't already requested this block
 	if b.outstandingRequests.HasValue(blkID) {
 		return nil
 	}
 
-	nodeID, ok := b.PeerTracker.SelectPeer()
+	if b.outstandingRequests.Len() >= b.MaxOutstandingRequests {
+		height, ok := b.missingBlockHeights[blkID]
+		if !ok {
+			height = unknownHeight
+		}
+		b.pendingBlockIDs.Push(blkID, height)
+		b.numPendingRequests.Set(float64(b.pendingBlockIDs.Len()))
+		return nil
+	}
+	b.pendingBlockIDs.Remove(blkID)
+	b.numPendingRequests.Set(float64(b.pendingBlockIDs.Len()))
+
+	nodeID, ok := b.selectPeer()
 	if !ok {
 		// If we aren't connected to any peers, we send a request to ourself
 		// which is guaranteed to fail. We send this message to use the message
@@ -431,10 +506,65 @@ func (b *Bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
 	}
 	b.outstandingRequests.Put(request, blkID)
 	b.outstandingRequestTimes[request] = time.Now()
+	b.outstandingPeerRequests[nodeID]++
+	b.numOutstandingRequests.Set(float64(b.outstandingRequests.Len()))
 	b.Config.Sender.SendGetAncestors(ctx, nodeID, b.requestID, blkID) // request block and ancestors
 	return nil
 }
 
+// fetchPending fetches the pending missing blocks, lowest height first, until
+// there are [MaxOutstandingRequests] outstanding requests.
+func (b *Bootstrapper) fetchPending(ctx context.Context) error {
+	for b.pendingBlockIDs.Len() > 0 && b.outstandingRequests.Len() < b.MaxOutstandingRequests {
+		blkID, _, _ := b.pendingBlockIDs.Pop()
+		// The block may have been fetched as part of another range.
+		if !b.missingBlockIDs.Contains(blkID) {
+			delete(b.missingBlockHeights, blkID)
+			continue
+		}
+		if err := b.fetch(ctx, blkID); err != nil {
+			return err
+		}
+	}
+	b.numPendingRequests.Set(float64(b.pendingBlockIDs.Len()))
+	return nil
+}
+
+// selectPeer returns a peer to fetch blocks from. Peers that don't have an
+// outstanding request are preferred, so that ranges are fetched from different
+// peers in parallel.
+func (b *Bootstrapper) selectPeer() (ids.NodeID, bool) {
+	var (
+		nodeID ids.NodeID
+		ok     bool
+	)
+	for range maxPeerSelectionAttempts {
+		nodeID, ok = b.PeerTracker.SelectPeer()
+		if !ok || b.outstandingPeerRequests[nodeID] == 0 {
+			break
+		}
+	}
+	return nodeID, ok
+}
+
+// removeRequest removes the outstanding [request]. Returns the ID of the
+// requested block and the time that the request was sent, if the request was
+// outstanding.
+func (b *Bootstrapper) removeRequest(request common.Request) (ids.ID, time.Time, bool) {
+	blkID, ok := b.outstandingRequests.DeleteKey(request)
+	if !ok {
+		return ids.Empty, time.Time{}, false
+	}
+
+	requestTime := b.outstandingRequestTimes[request]
+	delete(b.outstandingRequestTimes, request)
+	if b.outstandingPeerRequests[request.NodeID]--; b.outstandingPeerRequests[request.NodeID] <= 0 {
+		delete(b.outstandingPeerRequests, request.NodeID)
+	}
+	b.numOutstandingRequests.Set(float64(b.outstandingRequests.Len()))
+	return blkID, requestTime, true
+}
+
 // Ancestors handles the receipt of multiple containers. Should be received in
 // response to a GetAncestors message to [nodeID] with request ID [requestID]
 func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, blks [][]byte) error {
@@ -443,7 +573,7 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 		NodeID:    nodeID,
 		RequestID: requestID,
 	}
-	wantedBlkID, ok := b.outstandingRequests.DeleteKey(request)
+	wantedBlkID, requestTime, ok := b.removeRequest(request)
 	if !ok { // this message isn't in response to a request we made
 		b.Ctx.Log.Debug("received unexpected Ancestors",
 			zap.Stringer("nodeID", nodeID),
@@ -451,8 +581,6 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 		)
 		return nil
 	}
-	requestTime := b.outstandingRequestTimes[request]
-	delete(b.outstandingRequestTimes, request)
 
 	lenBlks := len(blks)
 	if lenBlks == 0 {
@@ -523,10 +651,19 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 	)
 	b.PeerTracker.RegisterResponse(nodeID, bandwidth)
 
+	nodeIDStr := nodeID.String()
+	b.peerReceivedBytes.WithLabelValues(nodeIDStr).Add(float64(numBytes))
+	b.peerRequestTime.WithLabelValues(nodeIDStr).Add(requestLatency)
+
 	if err := b.process(ctx, requestedBlock, ancestors); err != nil {
 		return err
 	}
 
+	// Executing blocks blocks this handler, so the pending blocks are requested
+	// first to keep fetching from peers while the blocks are executed.
+	if err := b.fetchPending(ctx); err != nil {
+		return err
+	}
 	return b.tryStartExecuting(ctx)
 }
 
@@ -535,7 +672,7 @@ func (b *Bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID
 		NodeID:    nodeID,
 		RequestID: requestID,
 	}
-	blkID, ok := b.outstandingRequests.DeleteKey(request)
+	blkID, _, ok := b.removeRequest(request)
 	if !ok {
 		b.Ctx.Log.Debug("unexpectedly called GetAncestorsFailed",
 			zap.Stringer("nodeID", nodeID),
@@ -543,13 +680,15 @@ func (b *Bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID
 		)
 		return nil
 	}
-	delete(b.outstandingRequestTimes, request)
 
 	// This node timed out their request.
 	b.PeerTracker.RegisterFailure(nodeID)
 
 	// Send another request for this
-	return b.fetch(ctx, blkID)
+	if err := b.fetch(ctx, blkID); err != nil {
+		return err
+	}
+	return b.fetchPending(ctx)
 }
 
 // process a series of consecutive blocks starting at [blk].
@@ -559,17 +698,50 @@ func (b *Bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID
 //   - ancestors is a set of blocks that can be used to optimistically lookup
 //     parent blocks. This enables the engine to process multiple blocks without
 //     relying on the VM to have stored blocks during `ParseBlock`.
+//
+// If the parent of the last processed block is missing, it is read from the
+// archive if possible, and fetched from peers otherwise.
 func (b *Bootstrapper) process(
 	ctx context.Context,
 	blk snowman.Block,
 	ancestors map[ids.ID]snowman.Block,
 ) error {
+	for {
+		missingBlockID, missingHeight, foundNewMissingID, err := b.processBatch(ctx, blk, ancestors)
+		if err != nil || !foundNewMissingID {
+			return err
+		}
+
+		b.missingBlockIDs.Add(missingBlockID)
+		b.missingBlockHeights[missingBlockID] = missingHeight
+
+		var ok bool
+		blk, ancestors, ok, err = b.getArchivedAncestors(ctx, missingBlockID, missingHeight)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// Attempt to fetch the newly discovered block
+			return b.fetch(ctx, missingBlockID)
+		}
+	}
+}
+
+// processBatch writes a series of consecutive blocks starting at [blk] and
+// returns the ID and height of the newly discovered missing block, if any.
+func (b *Bootstrapper) processBatch(
+	ctx context.Context,
+	blk snowman.Block,
+	ancestors map[ids.ID]snowman.Block,
+) (ids.ID, uint64, bool, error) {
 	lastAccepted, err := b.getLastAccepted(ctx)
 	if err != nil {
-		return err
+		return ids.Empty, 0, false, err
 	}
 
 	numPreviouslyFetched := b.tree.Len()
+	delete(b.missingBlockHeights, blk.ID())
+	b.pendingBlockIDs.Remove(blk.ID())
 
 	batch := b.DB.NewBatch()
 	missingBlockID, foundNewMissingID, err := process(
@@ -581,7 +753,7 @@ func (b *Bootstrapper) process(
 		ancestors,
 	)
 	if err != nil {
-		return err
+		return ids.Empty, 0, false, err
 	}
 
 	// Update metrics and log statuses
@@ -617,74 +789,116 @@ func (b *Bootstrapper) process(
 	}
 
 	if err := batch.Write(); err != nil || !foundNewMissingID {
-		return err
+		return ids.Empty, 0, false, err
+	}
+
+	// The missing block is the parent of the lowest block that was processed.
+	lowest := blk
+	for {
+		parent, ok := ancestors[lowest.Parent()]
+		if !ok {
+			break
+		}
+		lowest = parent
+	}
+	return missingBlockID, lowest.Height() - 1, true, nil
+}
+
+// getArchivedAncestors reads the block [blkID] at [height], and its ancestors,
+// from the archive. Returns false if the archive doesn't contain [blkID].
+func (b *Bootstrapper) getArchivedAncestors(
+	ctx context.Context,
+	blkID ids.ID,
+	height uint64,
+) (snowman.Block, map[ids.ID]snowman.Block, bool, error) {
+	if b.Archive == nil {
+		return nil, nil, false, nil
+	}
+
+	lastAccepted, err := b.getLastAccepted(ctx)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	var blksBytes [][]byte
+	for h := height; h > lastAccepted.Height() && len(blksBytes) < b.AncestorsMaxContainersReceived; h-- {
+		blkBytes, err := b.Archive.GetBlock(h)
+		if errors.Is(err, database.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			b.Ctx.Log.Warn("failed to read block from archive",
+				zap.Uint64("height", h),
+				zap.Error(err),
+			)
+			break
+		}
+		blksBytes = append(blksBytes, blkBytes)
+	}
+	if len(blksBytes) == 0 {
+		return nil, nil, false, nil
+	}
+
+	blocks, err := block.BatchedParseBlock(ctx, b.VM, blksBytes)
+	if err != nil {
+		b.Ctx.Log.Warn("failed to parse archived blocks",
+			zap.Uint64("height", height),
+			zap.Error(err),
+		)
+		return nil, nil, false, nil
+	}
+
+	requestedBlock := blocks[0]
+	if actualID := requestedBlock.ID(); actualID != blkID {
+		b.Ctx.Log.Warn("archived block doesn't match the accepted block",
+			zap.Uint64("height", height),
+			zap.Stringer("expectedBlkID", blkID),
+			zap.Stringer("blkID", actualID),
+		)
+		return nil, nil, false, nil
 	}
 
-	b.missingBlockIDs.Add(missingBlockID)
-	// Attempt to fetch the newly discovered block
-	return b.fetch(ctx, missingBlockID)
+	ancestors := make(map[ids.ID]snowman.Block, len(blocks)-1)
+	for _, block := range blocks[1:] {
+		ancestors[block.ID()] = block
+	}
+	return requestedBlock, ancestors, true, nil
 }
 
 // tryStartExecuting executes all pending blocks if there are no more blocks
 // being fetched. After executing all pending blocks it will either restart
 // bootstrapping, or transition into normal operations.
+//
+// If blocks are still being fetched, the fetched blocks that directly follow
+// the last accepted block are executed, so that execution is pipelined with
+// fetching the remaining blocks.
 func (b *Bootstrapper) tryStartExecuting(ctx context.Context) error {
-	if numMissingBlockIDs := b.missingBlockIDs.Len(); numMissingBlockIDs != 0 {
-		return nil
-	}
-
 	if b.Ctx.State.Get().State == snow.NormalOp || b.awaitingTimeout {
 		return nil
 	}
 
-	lastAccepted, err := b.getLastAccepted(ctx)
-	if err != nil {
-		return err
-	}
-
-	log := b.Ctx.Log.Info
-	if b.restarted {
-		log = b.Ctx.Log.Debug
+	if numMissingBlockIDs := b.missingBlockIDs.Len(); numMissingBlockIDs != 0 {
+		return b.tryExecuteContiguous(ctx)
 	}
 
 	numToExecute := b.tree.Len()
-	err = execute(
-		ctx,
-		b.ShouldHalt,
-		log,
-		b.DB,
-		&parseAcceptor{
-			parser:      b.nonVerifyingParser,
-			ctx:         b.Ctx,
-			numAccepted: b.numAccepted,
-		},
-		b.tree,
-		lastAccepted.Height(),
-	)
-	if err != nil {
-		// If a fatal error has occurred, include the last accepted block
-		// information.
-		lastAccepted, lastAcceptedErr := b.getLastAccepted(ctx)
-		if lastAcceptedErr != nil {
-			return fmt.Errorf("%w after %w", lastAcceptedErr, err)
-		}
-		return fmt.Errorf("%w with last accepted %s (height=%d)",
-			err,
-			lastAccepted.ID(),
-			lastAccepted.Height(),
-		)
+	if err := b.execute(ctx, math.MaxUint64); err != nil {
+		return err
 	}
 	if b.shouldHalt() {
 		return nil
 	}
 
+	numExecuted := b.pipelinedStateTransitions + numToExecute
+	b.pipelinedStateTransitions = 0
+
 	previouslyExecuted := b.executedStateTransitions
-	b.executedStateTransitions = numToExecute
+	b.executedStateTransitions = numExecuted
 
 	// Note that executedBlocks < c*previouslyExecuted ( 0 <= c < 1 ) is enforced
 	// so that the bootstrapping process will terminate even as new blocks are
 	// being issued.
-	if numToExecute > 0 && numToExecute < previouslyExecuted/2 {
+	if numExecuted > 0 && numExecuted < previouslyExecuted/2 {
 		return b.restartBootstrapping(ctx)
 	}
 
@@ -700,6 +914,10 @@ func (b *Bootstrapper) tryStartExecuting(ctx context.Context) error {
 	// If the subnet hasn't finished bootstrapping, this chain should remain
 	// syncing.
 	if !b.Config.BootstrapTracker.IsBootstrapped() {
+		log := b.Ctx.Log.Info
+		if b.restarted {
+			log = b.Ctx.Log.Debug
+		}
 		log("waiting for the remaining chains in this subnet to finish syncing")
 		// Restart bootstrapping after [bootstrappingDelay] to keep up to date
 		// on the latest tip.
@@ -710,6 +928,83 @@ func (b *Bootstrapper) tryStartExecuting(ctx context.Context) error {
 	return b.onFinished(ctx, b.requestID)
 }
 
+// tryExecuteContiguous executes the fetched blocks that directly follow the
+// last accepted block, if there are at least [minBlocksToPipeline] of them.
+//
+// Blocks are executed synchronously, so callers should request any pending
+// blocks before calling tryExecuteContiguous.
+func (b *Bootstrapper) tryExecuteContiguous(ctx context.Context) error {
+	lastAccepted, err := b.getLastAccepted(ctx)
+	if err != nil {
+		return err
+	}
+
+	intervals := b.tree.Flatten()
+	if len(intervals) == 0 {
+		return nil
+	}
+
+	// The intervals are sorted by height, so only the first interval can
+	// follow the last accepted block.
+	var (
+		first              = intervals[0]
+		lastAcceptedHeight = lastAccepted.Height()
+	)
+	if first.LowerBound > lastAcceptedHeight+1 || first.UpperBound < lastAcceptedHeight+minBlocksToPipeline {
+		return nil
+	}
+
+	numPreviouslyTracked := b.tree.Len()
+	if err := b.execute(ctx, first.UpperBound); err != nil {
+		return err
+	}
+	b.pipelinedStateTransitions += numPreviouslyTracked - b.tree.Len()
+	return nil
+}
+
+// execute the fetched blocks up to, and including, [maxHeight].
+func (b *Bootstrapper) execute(ctx context.Context, maxHeight uint64) error {
+	lastAccepted, err := b.getLastAccepted(ctx)
+	if err != nil {
+		return err
+	}
+
+	log := b.Ctx.Log.Info
+	if b.restarted {
+		log = b.Ctx.Log.Debug
+	}
+
+	err = execute(
+		ctx,
+		b.ShouldHalt,
+		log,
+		b.DB,
+		&parseAcceptor{
+			parser:      b.nonVerifyingParser,
+			ctx:         b.Ctx,
+			numAccepted: b.numAccepted,
+		},
+		b.tree,
+		lastAccepted.Height(),
+		maxHeight,
+	)
+	if err == nil {
+		return nil
+	}
+
+	// If a fatal error has occurred, include the last accepted block
+	// information.
+	lastAccepted, lastAcceptedErr := b.getLastAccepted(ctx)
+	if lastAcceptedErr != nil {
+		return fmt.Errorf("%w after %w", lastAcceptedErr, err)
+	}
+	return fmt.Errorf("%w with last accepted %s (height=%d)",
+		err,
+		lastAccepted.ID(),
+		lastAccepted.Height(),
+	)
+}
+
 func (b *Bootstrapper) getLastAccepted(ctx context.Context) (snowman.Block, error) {
 	lastAcceptedID, err := b.VM.LastAccepted(ctx)
 	if err != nil {
@@ -737,9 +1032,16 @@ func (b *Bootstrapper) Timeout(ctx context.Context) error {
 func (b *Bootstrapper) restartBootstrapping(ctx context.Context) error {
 	b.Ctx.Log.Debug("Checking for new frontiers")
 	b.restarted = true
+	b.clearRequests()
+	return b.startBootstrapping(ctx)
+}
+
+// clearRequests forgets about all the outstanding requests.
+func (b *Bootstrapper) clearRequests() {
 	b.outstandingRequests = bimap.New[common.Request, ids.ID]()
 	b.outstandingRequestTimes = make(map[common.Request]time.Time)
-	return b.startBootstrapping(ctx)
+	b.outstandingPeerRequests = make(map[ids.NodeID]int)
+	b.numOutstandingRequests.Set(0)
 }
 
 func (b *Bootstrapper) Notify(_ context.Context, msg common.Message) error {
@@ -105,6 +105,7 @@ func newConfig(t *testing.T) (Config, ids.NodeID, *enginetest.Sender, *blocktest
 		BootstrapTracker:               bootstrapTracker,
 		Timer:                          &enginetest.Timer{},
 		AncestorsMaxContainersReceived: 2000,
+		MaxOutstandingRequests:         16,
 		DB:                             memdb.New(),
 		VM:                             vm,
 	}, peer, sender, vm, halter.Halt
@@ -157,6 +158,7 @@ func TestBootstrapperStartsOnlyIfEnoughStakeIsConnected(t *testing.T) {
 		BootstrapTracker:               &enginetest.BootstrapTracker{},
 		Timer:                          &enginetest.Timer{},
 		AncestorsMaxContainersReceived: 2000,
+		MaxOutstandingRequests:         16,
 		DB:                             memdb.New(),
 		VM:                             vm,
 	}
@@ -338,6 +340,263 @@ func TestBootstrapperPartialFetch(t *testing.T) {
 	require.Equal(snow.NormalOp, config.Ctx.State.Get().State)
 }
 
+type testArchive map[uint64][]byte
+
+func (a testArchive) GetBlock(height uint64) ([]byte, error) {
+	blkBytes, ok := a[height]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return blkBytes, nil
+}
+
+func TestBootstrapperArchive(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm, _ := newConfig(t)
+
+	blks := snowmantest.BuildChain(5)
+	initializeVMWithBlockchain(vm, blks)
+
+	// The archive contains blk1 and blk2, and an unrelated block at height 3,
+	// which must be ignored.
+	config.Archive = testArchive{
+		1: blks[1].Bytes(),
+		2: blks[2].Bytes(),
+		3: snowmantest.BuildChild(blks[2]).Bytes(),
+	}
+
+	bs, err := New(
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+
+	require.NoError(bs.Start(context.Background(), 0))
+
+	var (
+		requestID uint32
+		requested []ids.ID
+	)
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, reqID uint32, blkID ids.ID) {
+		require.Equal(peerID, nodeID)
+		requestID = reqID
+		requested = append(requested, blkID)
+	}
+
+	// The tip is always fetched from the network.
+	require.NoError(bs.startSyncing(context.Background(), blocksToIDs(blks[4:5])))
+	require.Equal([]ids.ID{blks[4].ID()}, requested)
+
+	// The archived block at height 3 doesn't match, so blk3 is fetched from
+	// the network.
+	require.NoError(bs.Ancestors(context.Background(), peerID, requestID, blocksToBytes(blks[4:5])))
+	require.Equal([]ids.ID{blks[4].ID(), blks[3].ID()}, requested)
+
+	// The ancestors of blk3 are read from the archive.
+	require.NoError(bs.Ancestors(context.Background(), peerID, requestID, blocksToBytes(blks[3:4])))
+	require.Equal([]ids.ID{blks[4].ID(), blks[3].ID()}, requested)
+
+	require.Equal(snow.Bootstrapping, config.Ctx.State.Get().State)
+	snowmantest.RequireStatusIs(require, snowtest.Accepted, blks...)
+}
+
+func TestBootstrapperMaxOutstandingRequests(t *testing.T) {
+	require := require.New(t)
+
+	config, _, sender, vm, _ := newConfig(t)
+	config.MaxOutstandingRequests = 1
+
+	blks := snowmantest.BuildChain(1)
+	initializeVMWithBlockchain(vm, blks)
+
+	bs, err := New(config, nil)
+	require.NoError(err)
+	require.NoError(bs.Start(context.Background(), 0))
+
+	var requested []ids.ID
+	sender.SendGetAncestorsF = func(_ context.Context, _ ids.NodeID, _ uint32, blkID ids.ID) {
+		requested = append(requested, blkID)
+	}
+
+	var (
+		outstandingBlkID = ids.GenerateTestID()
+		highBlkID        = ids.GenerateTestID()
+		lowBlkID         = ids.GenerateTestID()
+		unknownBlkID     = ids.GenerateTestID()
+		fetchedBlkID     = ids.GenerateTestID()
+		missingBlkIDs    = []ids.ID{outstandingBlkID, highBlkID, lowBlkID, unknownBlkID, fetchedBlkID}
+	)
+	bs.missingBlockIDs.Add(missingBlkIDs...)
+	bs.missingBlockHeights[highBlkID] = 10
+	bs.missingBlockHeights[lowBlkID] = 5
+	for _, blkID := range missingBlkIDs {
+		require.NoError(bs.fetch(context.Background(), blkID))
+	}
+	require.Equal([]ids.ID{outstandingBlkID}, requested)
+	require.Equal(len(missingBlkIDs)-1, bs.pendingBlockIDs.Len())
+
+	// Blocks that are no longer missing are not fetched.
+	bs.missingBlockIDs.Remove(fetchedBlkID)
+
+	// Pending blocks are fetched lowest height first, once a request finishes.
+	for _, expectedBlkID := range []ids.ID{lowBlkID, highBlkID, unknownBlkID} {
+		request, ok := bs.outstandingRequests.GetKey(outstandingBlkID)
+		require.True(ok)
+		_, _, ok = bs.removeRequest(request)
+		require.True(ok)
+
+		require.NoError(bs.fetchPending(context.Background()))
+		require.Equal(expectedBlkID, requested[len(requested)-1])
+		require.Equal(1, bs.outstandingRequests.Len())
+		outstandingBlkID = expectedBlkID
+	}
+
+	request, ok := bs.outstandingRequests.GetKey(outstandingBlkID)
+	require.True(ok)
+	_, _, ok = bs.removeRequest(request)
+	require.True(ok)
+
+	require.NoError(bs.fetchPending(context.Background()))
+	require.Len(requested, 4)
+	require.Zero(bs.pendingBlockIDs.Len())
+}
+
+// Blocks that were fetched before bootstrapping was interrupted are resumed
+// in parallel with the new accepted frontier.
+func TestBootstrapperResumeInterruptedFetch(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm, halt := newConfig(t)
+	config.MaxOutstandingRequests = 2
+
+	blks := snowmantest.BuildChain(7)
+	initializeVMWithBlockchain(vm, blks)
+
+	onFinished := func(context.Context, uint32) error {
+		config.Ctx.State.Set(snow.EngineState{
+			Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+			State: snow.NormalOp,
+		})
+		return nil
+	}
+	bs, err := New(config, onFinished)
+	require.NoError(err)
+	require.NoError(bs.Start(context.Background(), 0))
+
+	requestIDs := make(map[ids.ID]uint32)
+	sender.SendGetAncestorsF = func(_ context.Context, _ ids.NodeID, reqID uint32, blkID ids.ID) {
+		requestIDs[blkID] = reqID
+	}
+
+	// The first run fetches blk4 and blk3 before it is interrupted.
+	require.NoError(bs.startSyncing(context.Background(), blocksToIDs(blks[4:5])))
+	halt()
+	require.NoError(bs.Ancestors(context.Background(), peerID, requestIDs[blks[4].ID()], blocksToBytes(blks[3:5])))
+	require.Contains(requestIDs, blks[2].ID())
+
+	// The second run resumes fetching below blk3, in parallel with fetching the
+	// new accepted frontier.
+	var halter common.Halter
+	config.ShouldHalt = halter.Halted
+	config.Ctx.Registerer = prometheus.NewRegistry()
+	bs, err = New(config, onFinished)
+	require.NoError(err)
+	require.NoError(bs.Start(context.Background(), 0))
+	require.Equal(set.Of(blks[2].ID()), bs.missingBlockIDs)
+
+	clear(requestIDs)
+	require.NoError(bs.startSyncing(context.Background(), blocksToIDs(blks[6:7])))
+	require.Len(requestIDs, 2)
+	require.Contains(requestIDs, blks[2].ID())
+	require.Contains(requestIDs, blks[6].ID())
+
+	require.NoError(bs.Ancestors(context.Background(), peerID, requestIDs[blks[6].ID()], blocksToBytes(blks[5:7])))
+	require.NoError(bs.Ancestors(context.Background(), peerID, requestIDs[blks[2].ID()], blocksToBytes(blks[1:3])))
+	require.Equal(snow.Bootstrapping, config.Ctx.State.Get().State)
+	snowmantest.RequireStatusIs(require, snowtest.Accepted, blks...)
+}
+
+// Blocks that were fetched, but not executed, before bootstrapping was
+// interrupted are executed while the new accepted frontier is fetched.
+func TestBootstrapperPipelinedExecution(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm, halt := newConfig(t)
+	config.AncestorsMaxContainersReceived = minBlocksToPipeline + 1
+
+	blks := snowmantest.BuildChain(minBlocksToPipeline + 3)
+	initializeVMWithBlockchain(vm, blks)
+
+	var (
+		lowRange  = blks[1 : minBlocksToPipeline+1]
+		highRange = blks[minBlocksToPipeline+1:]
+	)
+
+	onFinished := func(context.Context, uint32) error {
+		config.Ctx.State.Set(snow.EngineState{
+			Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+			State: snow.NormalOp,
+		})
+		return nil
+	}
+	bs, err := New(config, onFinished)
+	require.NoError(err)
+	require.NoError(bs.Start(context.Background(), 0))
+
+	var (
+		requestIDs       = make(map[ids.ID]uint32)
+		executedWhenSent []bool
+	)
+	sender.SendGetAncestorsF = func(_ context.Context, _ ids.NodeID, reqID uint32, blkID ids.ID) {
+		requestIDs[blkID] = reqID
+		executedWhenSent = append(executedWhenSent, lowRange[0].Status == snowtest.Accepted)
+	}
+
+	// The first run fetches the low range, but is interrupted before executing
+	// it.
+	lowTipID := lowRange[len(lowRange)-1].ID()
+	require.NoError(bs.startSyncing(context.Background(), []ids.ID{lowTipID}))
+	halt()
+	require.NoError(bs.Ancestors(context.Background(), peerID, requestIDs[lowTipID], blocksToBytes(lowRange)))
+	snowmantest.RequireStatusIs(require, snowtest.Undecided, lowRange...)
+
+	// The second run executes the low range while the new accepted frontier is
+	// being fetched.
+	var halter common.Halter
+	config.ShouldHalt = halter.Halted
+	config.Ctx.Registerer = prometheus.NewRegistry()
+	bs, err = New(config, onFinished)
+	require.NoError(err)
+	require.NoError(bs.Start(context.Background(), 0))
+	require.Zero(bs.missingBlockIDs.Len())
+
+	highTipID := highRange[len(highRange)-1].ID()
+	require.NoError(bs.startSyncing(context.Background(), []ids.ID{highTipID}))
+	require.Equal([]bool{false, false}, executedWhenSent)
+	require.True(bs.outstandingRequests.HasValue(highTipID))
+	snowmantest.RequireStatusIs(require, snowtest.Accepted, lowRange...)
+	snowmantest.RequireStatusIs(require, snowtest.Undecided, highRange...)
+	require.Equal(uint64(minBlocksToPipeline), bs.pipelinedStateTransitions)
+
+	require.NoError(bs.Ancestors(context.Background(), peerID, requestIDs[highTipID], blocksToBytes(highRange)))
+	snowmantest.RequireStatusIs(require, snowtest.Accepted, blks...)
+}
+
+func TestBootstrapperInvalidMaxOutstandingRequests(t *testing.T) {
+	config, _, _, _, _ := newConfig(t)
+	config.MaxOutstandingRequests = 0
+
+	_, err := New(config, nil)
+	require.ErrorIs(t, err, errInvalidMaxOutstandingRequests)
+}
+
 // There are multiple needed blocks and some validators do not have all the
 // blocks.
 func TestBootstrapperEmptyResponse(t *testing.T) {
@@ -475,6 +734,57 @@ func TestBootstrapperFinalized(t *testing.T) {
 	require.Equal(snow.NormalOp, config.Ctx.State.Get().State)
 }
 
+func TestBootstrapperStartAfterFinished(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm, _ := newConfig(t)
+
+	blks := snowmantest.BuildChain(3)
+	initializeVMWithBlockchain(vm, blks)
+
+	bs, err := New(
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+
+	require.NoError(bs.Start(context.Background(), 0))
+	require.NoError(bs.startSyncing(context.Background(), blocksToIDs(blks[0:1])))
+	require.Equal(snow.NormalOp, config.Ctx.State.Get().State)
+
+	// Starting the bootstrapper again re-bootstraps the chain from the last
+	// accepted block.
+	var frontierRequestID uint32
+	sender.SendGetAcceptedFrontierF = func(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32) {
+		require.Equal(set.Of(peerID), nodeIDs)
+		frontierRequestID = requestID
+	}
+	require.NoError(bs.Start(context.Background(), 10))
+	require.Equal(snow.Bootstrapping, config.Ctx.State.Get().State)
+	require.Equal(uint32(11), frontierRequestID)
+
+	requestIDs := map[ids.ID]uint32{}
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, reqID uint32, blkID ids.ID) {
+		require.Equal(peerID, nodeID)
+		requestIDs[blkID] = reqID
+	}
+	require.NoError(bs.startSyncing(context.Background(), blocksToIDs(blks[2:3])))
+
+	reqID, ok := requestIDs[blks[2].ID()]
+	require.True(ok)
+	require.NoError(bs.Ancestors(context.Background(), peerID, reqID, blocksToBytes(blks[1:3])))
+	snowmantest.RequireStatusIs(require, snowtest.Accepted, blks...)
+
+	require.NoError(bs.startSyncing(context.Background(), blocksToIDs(blks[2:3])))
+	require.Equal(snow.NormalOp, config.Ctx.State.Get().State)
+}
+
 func TestRestartBootstrapping(t *testing.T) {
 	require := require.New(t)
 
@@ -692,6 +1002,7 @@ func TestBootstrapNoParseOnNew(t *testing.T) {
 		BootstrapTracker:               bootstrapTracker,
 		Timer:                          &enginetest.Timer{},
 		AncestorsMaxContainersReceived: 2000,
+		MaxOutstandingRequests:         16,
 		DB:                             intervalDB,
 		VM:                             vm,
 	}
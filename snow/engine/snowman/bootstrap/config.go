@@ -13,6 +13,13 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 )
 
+// Archive provides the bytes of accepted blocks by their height.
+type Archive interface {
+	// GetBlock returns [database.ErrNotFound] if the block at [height] is not
+	// in the archive.
+	GetBlock(height uint64) ([]byte, error)
+}
+
 type Config struct {
 	common.AllGetsServer
 
@@ -32,6 +39,12 @@ type Config struct {
 	// containers in an ancestors message it receives.
 	AncestorsMaxContainersReceived int
 
+	// MaxOutstandingRequests is the maximum number of GetAncestors requests
+	// that can be outstanding at once. The ranges left by an interrupted
+	// bootstrap are fetched in parallel with the accepted frontier, preferring
+	// different peers, up to this limit.
+	MaxOutstandingRequests int
+
 	// Database used to track the fetched, but not yet executed, blocks during
 	// bootstrapping.
 	DB database.Database
@@ -41,6 +54,12 @@ type Config struct {
 	// NonVerifyingParse parses blocks without verifying them.
 	NonVerifyingParse block.ParseFunc
 
+	// Archive, if non-nil, is checked for the blocks of a missing height
+	// before fetching them from peers. Archived blocks are only used if they
+	// are ancestors of a block that was fetched from peers, so the tip of the
+	// archive is always validated against the network.
+	Archive Archive
+
 	Bootstrapped func()
 
 	ShouldHalt func() bool
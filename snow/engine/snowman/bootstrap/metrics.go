@@ -9,8 +9,18 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+const nodeIDLabel = "nodeID"
+
+var nodeIDLabels = []string{nodeIDLabel}
+
 type metrics struct {
 	numFetched, numAccepted prometheus.Counter
+
+	numOutstandingRequests, numPendingRequests prometheus.Gauge
+
+	// peerReceivedBytes and peerRequestTime can be used to calculate the
+	// throughput of each peer that blocks are fetched from.
+	peerReceivedBytes, peerRequestTime *prometheus.CounterVec
 }
 
 func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
@@ -23,11 +33,37 @@ func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
 			Name: "bs_accepted",
 			Help: "Number of blocks accepted during bootstrapping",
 		}),
+		numOutstandingRequests: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "bs_outstanding_requests",
+			Help: "Number of outstanding requests for blocks during bootstrapping",
+		}),
+		numPendingRequests: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "bs_pending_requests",
+			Help: "Number of missing blocks waiting for an outstanding request to finish before being requested during bootstrapping",
+		}),
+		peerReceivedBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "bs_peer_received_bytes",
+				Help: "Number of bytes of blocks received from each peer during bootstrapping",
+			},
+			nodeIDLabels,
+		),
+		peerRequestTime: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "bs_peer_request_time",
+				Help: "Time (in seconds) spent waiting for responses from each peer during bootstrapping",
+			},
+			nodeIDLabels,
+		),
 	}
 
 	err := errors.Join(
 		registerer.Register(m.numFetched),
 		registerer.Register(m.numAccepted),
+		registerer.Register(m.numOutstandingRequests),
+		registerer.Register(m.numPendingRequests),
+		registerer.Register(m.peerReceivedBytes),
+		registerer.Register(m.peerRequestTime),
 	)
 	return m, err
 }
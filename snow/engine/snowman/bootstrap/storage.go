@@ -117,11 +117,12 @@ func process(
 	}
 }
 
-// execute all the blocks tracked by the tree. If a block is in the tree but is
-// already accepted based on the lastAcceptedHeight, it will be removed from the
-// tree but not executed.
+// execute all the blocks tracked by the tree up to, and including,
+// maxHeight. If a block is in the tree but is already accepted based on the
+// lastAcceptedHeight, it will be removed from the tree but not executed.
 //
-// execute assumes that getMissingBlockIDs would return an empty set.
+// execute assumes that getMissingBlockIDs would return no blocks with heights
+// <= maxHeight.
 //
 // TODO: Replace usage of haltable with context cancellation.
 func execute(
@@ -132,8 +133,18 @@ func execute(
 	nonVerifyingParser block.Parser,
 	tree *interval.Tree,
 	lastAcceptedHeight uint64,
+	maxHeight uint64,
 ) error {
-	totalNumberToProcess := tree.Len()
+	var (
+		numPreviouslyTracked = tree.Len()
+		totalNumberToProcess uint64
+	)
+	for _, i := range tree.Flatten() {
+		if i.LowerBound > maxHeight {
+			break
+		}
+		totalNumberToProcess += min(i.UpperBound, maxHeight) - i.LowerBound + 1
+	}
 	if totalNumberToProcess >= minBlocksToCompact {
 		log("compacting database before executing blocks...")
 		if err := db.Compact(nil, nil); err != nil {
@@ -170,7 +181,7 @@ func execute(
 		iterator.Release()
 
 		var (
-			numProcessed = totalNumberToProcess - tree.Len()
+			numProcessed = numPreviouslyTracked - tree.Len()
 			halted       = shouldHalt()
 		)
 		if numProcessed >= minBlocksToCompact && !halted {
@@ -203,6 +214,9 @@ func execute(
 		}
 
 		height := blk.Height()
+		if height > maxHeight {
+			break
+		}
 		if err := interval.Remove(batch, tree, height); err != nil {
 			return err
 		}
@@ -238,7 +252,7 @@ func execute(
 
 		if now := time.Now(); now.After(timeOfNextLog) {
 			var (
-				numProcessed = totalNumberToProcess - tree.Len()
+				numProcessed = numPreviouslyTracked - tree.Len()
 				eta          = timer.EstimateETA(startTime, numProcessed, totalNumberToProcess)
 			)
 			log("executing blocks",
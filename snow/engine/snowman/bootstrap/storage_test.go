@@ -6,6 +6,7 @@ package bootstrap
 import (
 	"bytes"
 	"context"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -275,6 +276,7 @@ func TestExecute(t *testing.T) {
 				parser,
 				tree,
 				test.lastAcceptedHeight,
+				math.MaxUint64,
 			))
 			for _, height := range test.expectedProcessingHeights {
 				require.Equal(snowtest.Undecided, blocks[height].Status)
@@ -294,6 +296,38 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+func TestExecuteMaxHeight(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	tree, err := interval.NewTree(db)
+	require.NoError(err)
+
+	blocks := snowmantest.BuildChain(7)
+	parser := makeParser(blocks)
+	for _, blk := range blocks[1:] {
+		_, err := interval.Add(db, tree, 0, blk.Height(), blk.Bytes())
+		require.NoError(err)
+	}
+
+	var halter common.Halter
+	require.NoError(execute(
+		context.Background(),
+		halter.Halted,
+		logging.NoLog{}.Info,
+		db,
+		parser,
+		tree,
+		0,
+		4,
+	))
+	snowmantest.RequireStatusIs(require, snowtest.Accepted, blocks[:5]...)
+	snowmantest.RequireStatusIs(require, snowtest.Undecided, blocks[5:]...)
+	require.Equal(uint64(2), tree.Len())
+	require.False(tree.Contains(4))
+	require.True(tree.Contains(5))
+}
+
 type testParser func(context.Context, []byte) (snowman.Block, error)
 
 func (f testParser) ParseBlock(ctx context.Context, bytes []byte) (snowman.Block, error) {
@@ -10,6 +10,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/journal"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 )
 
@@ -25,4 +26,10 @@ type Config struct {
 	Params              snowball.Parameters
 	Consensus           snowman.Consensus
 	PartialSync         bool
+	// Journal records the consensus events of the chain. If nil, no events
+	// are recorded.
+	Journal journal.Journal
+	// Sampler selects the validators that are queried in each poll. If nil,
+	// validators are sampled by stake.
+	Sampler validators.Sampler
 }
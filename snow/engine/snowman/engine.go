@@ -5,6 +5,7 @@ package snowman
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -21,6 +22,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/common/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/ancestor"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/job"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/journal"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
 	"github.com/MetalBlockchain/metalgo/utils/bimap"
@@ -132,6 +134,13 @@ func New(config Config) (*Engine, error) {
 		return nil, err
 	}
 
+	if config.Journal == nil {
+		config.Journal = journal.NoJournal
+	}
+	if config.Sampler == nil {
+		config.Sampler = config.Validators
+	}
+
 	return &Engine{
 		Config:                      config,
 		metrics:                     metrics,
@@ -441,7 +450,10 @@ func (e *Engine) Shutdown(ctx context.Context) error {
 	e.Ctx.Lock.Lock()
 	defer e.Ctx.Lock.Unlock()
 
-	return e.VM.Shutdown(ctx)
+	return errors.Join(
+		e.VM.Shutdown(ctx),
+		e.Journal.Close(),
+	)
 }
 
 func (e *Engine) Notify(ctx context.Context, msg common.Message) error {
@@ -465,8 +477,33 @@ func (e *Engine) Context() *snow.ConsensusContext {
 	return e.Ctx
 }
 
+func (e *Engine) LastRequestID() uint32 {
+	return e.requestID
+}
+
+// Abandon rejects all the processing blocks, as bootstrapping may accept blocks
+// that conflict with them, and resets the VM's preference to the last accepted
+// block.
+func (e *Engine) Abandon(ctx context.Context) error {
+	if err := e.Consensus.RejectProcessing(ctx); err != nil {
+		return err
+	}
+	lastAcceptedID, _ := e.Consensus.LastAccepted()
+	return e.VM.SetPreference(ctx, lastAcceptedID)
+}
+
 func (e *Engine) Start(ctx context.Context, startReqID uint32) error {
 	e.requestID = startReqID
+
+	// The engine is started again if the chain is re-bootstrapped, in which
+	// case any requests sent before bootstrapping will never be answered.
+	e.polls.Clear()
+	e.blkReqs = bimap.New[common.Request, ids.ID]()
+	e.blkReqSourceMetric = make(map[common.Request]prometheus.Counter)
+	e.pending = make(map[ids.ID]snowman.Block)
+	e.unverifiedIDToAncestor = ancestor.NewTree()
+	e.blocked = job.NewScheduler[ids.ID]()
+
 	lastAcceptedID, err := e.VM.LastAccepted(ctx)
 	if err != nil {
 		return err
@@ -556,6 +593,26 @@ func (e *Engine) HealthCheck(ctx context.Context) (interface{}, error) {
 	return intf, fmt.Errorf("vm: %w ; consensus: %w", vmErr, consensusErr)
 }
 
+// ConsensusState is a snapshot of the state of the consensus engine.
+type ConsensusState struct {
+	snowman.State
+	// Polls are the outstanding polls, from oldest to newest.
+	Polls []poll.Info `json:"polls"`
+}
+
+// ConsensusState returns a snapshot of the state of consensus. The chain's
+// lock is only held while the state is copied, so the snapshot can be
+// serialized without blocking the engine.
+func (e *Engine) ConsensusState() ConsensusState {
+	e.Ctx.Lock.Lock()
+	defer e.Ctx.Lock.Unlock()
+
+	return ConsensusState{
+		State: e.Consensus.State(),
+		Polls: e.polls.Polls(),
+	}
+}
+
 func (e *Engine) executeDeferredWork(ctx context.Context) error {
 	if err := e.buildBlocks(ctx); err != nil {
 		return err
@@ -881,7 +938,7 @@ func (e *Engine) sendQuery(
 		zap.Stringer("validators", e.Validators),
 	)
 
-	vdrIDs, err := e.Validators.Sample(e.Ctx.SubnetID, e.Params.K)
+	vdrIDs, err := e.Sampler.Sample(e.Ctx.SubnetID, e.Params.K)
 	if err != nil {
 		e.Ctx.Log.Warn("dropped query for block",
 			zap.String("reason", "insufficient number of validators"),
@@ -914,6 +971,13 @@ func (e *Engine) sendQuery(
 		return
 	}
 
+	e.Journal.Record(journal.Event{
+		Type:       journal.PollStarted,
+		BlockID:    &blkID,
+		RequestID:  e.requestID,
+		Validators: vdrIDs,
+	})
+
 	vdrSet := set.Of(vdrIDs...)
 	if push {
 		e.Sender.SendPushQuery(ctx, vdrSet, e.requestID, blkBytes, nextHeightToAccept)
@@ -964,6 +1028,7 @@ func (e *Engine) deliver(
 	// By ensuring that the parent is either processing or accepted, it is
 	// guaranteed that the parent was successfully verified. This means that
 	// calling Verify on this block is allowed.
+	prevPreference := e.Consensus.Preference()
 	blkAdded, err := e.addUnverifiedBlockToConsensus(ctx, nodeID, blk, issuedMetric)
 	if err != nil {
 		return err
@@ -998,6 +1063,7 @@ func (e *Engine) deliver(
 		}
 	}
 
+	e.recordPreferenceChange(prevPreference)
 	if err := e.VM.SetPreference(ctx, e.Consensus.Preference()); err != nil {
 		return err
 	}
@@ -1101,10 +1167,36 @@ func (e *Engine) addUnverifiedBlockToConsensus(
 		zap.Stringer("blkID", blkID),
 		zap.Uint64("height", blkHeight),
 	)
-	return true, e.Consensus.Add(&memoryBlock{
+	if err := e.Consensus.Add(&memoryBlock{
 		Block:   blk,
 		metrics: e.metrics,
 		tree:    e.unverifiedIDToAncestor,
+		journal: e.Journal,
+	}); err != nil {
+		return true, err
+	}
+
+	parentID := blk.Parent()
+	e.Journal.Record(journal.Event{
+		Type:     journal.BlockAdded,
+		BlockID:  &blkID,
+		ParentID: &parentID,
+		Height:   blkHeight,
+		NodeID:   &nodeID,
+	})
+	return true, nil
+}
+
+// recordPreferenceChange records the preference in the journal if it changed
+// from [prevPreference].
+func (e *Engine) recordPreferenceChange(prevPreference ids.ID) {
+	preference := e.Consensus.Preference()
+	if preference == prevPreference {
+		return
+	}
+	e.Journal.Record(journal.Event{
+		Type:    journal.PreferenceChanged,
+		BlockID: &preference,
 	})
 }
 
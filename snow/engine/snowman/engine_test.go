@@ -28,8 +28,10 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/ancestor"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block/blocktest"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/getter"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/journal"
 	"github.com/MetalBlockchain/metalgo/snow/snowtest"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
+	"github.com/MetalBlockchain/metalgo/utils/json"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 	"github.com/MetalBlockchain/metalgo/version"
@@ -121,6 +123,186 @@ func setup(t *testing.T, config Config) (ids.NodeID, validators.Manager, *engine
 	return vdr, config.Validators, sender, vm, te
 }
 
+func TestEngineConsensusState(t *testing.T) {
+	require := require.New(t)
+
+	peerID, _, sender, vm, engine := setup(t, DefaultConfig(t))
+
+	blk := snowmantest.BuildChild(snowmantest.Genesis)
+
+	var queryRequestID uint32
+	sender.SendPullQueryF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ ids.ID, _ uint64) {
+		queryRequestID = requestID
+	}
+	vm.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		require.Equal(blk.Bytes(), b)
+		return blk, nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case snowmantest.GenesisID:
+			return snowmantest.Genesis, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	// Issuing [blk] causes a poll to be started.
+	require.NoError(engine.Put(context.Background(), peerID, 0, blk.Bytes()))
+
+	state := engine.ConsensusState()
+	require.Equal(snowmantest.GenesisID, state.LastAcceptedID)
+	require.Equal(blk.ID(), state.Preference)
+	require.Len(state.Blocks, 2)
+	require.Equal(blk.ID(), state.Blocks[1].ID)
+	require.Len(state.Polls, 1)
+
+	poll := state.Polls[0]
+	require.Equal(json.Uint32(queryRequestID), poll.RequestID)
+	require.Empty(poll.Responded)
+	require.Equal([]ids.NodeID{peerID}, poll.Outstanding)
+}
+
+func TestEngineRestart(t *testing.T) {
+	require := require.New(t)
+
+	peerID, _, sender, vm, engine := setup(t, DefaultConfig(t))
+
+	blk := snowmantest.BuildChild(snowmantest.Genesis)
+
+	sender.SendPullQueryF = func(context.Context, set.Set[ids.NodeID], uint32, ids.ID, uint64) {}
+	vm.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		require.Equal(blk.Bytes(), b)
+		return blk, nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case snowmantest.GenesisID:
+			return snowmantest.Genesis, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	// Issuing [blk] causes a poll to be started.
+	require.NoError(engine.Put(context.Background(), peerID, 0, blk.Bytes()))
+	require.Len(engine.ConsensusState().Polls, 1)
+
+	// Abandoning the engine, as is done before the chain is re-bootstrapped,
+	// rejects the processing block and resets the VM's preference.
+	var preference ids.ID
+	vm.SetPreferenceF = func(_ context.Context, blkID ids.ID) error {
+		preference = blkID
+		return nil
+	}
+	require.NoError(engine.Abandon(context.Background()))
+	require.Equal(snowtest.Rejected, blk.Status)
+	require.Equal(snowmantest.GenesisID, preference)
+	require.Zero(engine.Consensus.NumProcessing())
+
+	// Restarting the engine, as is done after the chain is re-bootstrapped,
+	// drops the outstanding poll.
+	vm.LastAcceptedF = snowmantest.MakeLastAcceptedBlockF(
+		[]*snowmantest.Block{snowmantest.Genesis},
+	)
+	require.NoError(engine.Start(context.Background(), 100))
+
+	state := engine.ConsensusState()
+	require.Equal(snowmantest.GenesisID, state.LastAcceptedID)
+	require.Equal(snowmantest.GenesisID, state.Preference)
+	require.Len(state.Blocks, 1)
+	require.Empty(state.Polls)
+}
+
+type testJournal struct {
+	events []journal.Event
+}
+
+func (j *testJournal) Record(event journal.Event) {
+	j.events = append(j.events, event)
+}
+
+func (*testJournal) Close() error {
+	return nil
+}
+
+func TestEngineJournal(t *testing.T) {
+	require := require.New(t)
+
+	consensusJournal := &testJournal{}
+	config := DefaultConfig(t)
+	config.Journal = consensusJournal
+	peerID, _, sender, vm, engine := setup(t, config)
+
+	blk := snowmantest.BuildChild(snowmantest.Genesis)
+
+	var queryRequestID uint32
+	sender.SendPullQueryF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ ids.ID, _ uint64) {
+		queryRequestID = requestID
+	}
+	vm.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		require.Equal(blk.Bytes(), b)
+		return blk, nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case snowmantest.GenesisID:
+			return snowmantest.Genesis, nil
+		case blk.ID():
+			return blk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	require.NoError(engine.Put(context.Background(), peerID, 0, blk.Bytes()))
+	require.NoError(engine.Chits(context.Background(), peerID, queryRequestID, blk.ID(), blk.ID(), blk.ID(), blk.Height()))
+
+	blkID := blk.ID()
+	parentID := blk.Parent()
+	require.Equal(
+		[]journal.Event{
+			{
+				Type:     journal.BlockAdded,
+				BlockID:  &blkID,
+				ParentID: &parentID,
+				Height:   blk.Height(),
+				NodeID:   &peerID,
+			},
+			{
+				Type:    journal.PreferenceChanged,
+				BlockID: &blkID,
+			},
+			{
+				Type:       journal.PollStarted,
+				BlockID:    &blkID,
+				RequestID:  queryRequestID,
+				Validators: []ids.NodeID{peerID},
+			},
+			{
+				Type:      journal.VoteReceived,
+				BlockID:   &blkID,
+				RequestID: queryRequestID,
+				NodeID:    &peerID,
+			},
+			{
+				Type:    journal.BlockAccepted,
+				BlockID: &blkID,
+				Height:  blk.Height(),
+			},
+			{
+				Type:      journal.PollFinished,
+				BlockID:   &blkID,
+				RequestID: queryRequestID,
+				Votes: map[ids.ID]int{
+					blkID: 1,
+				},
+			},
+		},
+		consensusJournal.events,
+	)
+}
+
 func TestEngineDropsAttemptToIssueBlockAfterFailedRequest(t *testing.T) {
 	require := require.New(t)
 
@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package journal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/bag"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+type EventType string
+
+const (
+	// BlockAdded is recorded when a block issued by [NodeID] is added to
+	// consensus.
+	BlockAdded EventType = "blockAdded"
+	// PollStarted is recorded when the [Validators] are queried for their
+	// preference after [BlockID] is issued.
+	PollStarted EventType = "pollStarted"
+	// VoteReceived is recorded when the vote of [NodeID] for [BlockID] is
+	// applied to poll [RequestID].
+	VoteReceived EventType = "voteReceived"
+	// VoteDropped is recorded when [NodeID] failed to respond to poll
+	// [RequestID] with a vote that could be applied.
+	VoteDropped EventType = "voteDropped"
+	// PollFinished is recorded when the [Votes] of poll [RequestID] are
+	// recorded in consensus. [BlockID] is the preference after the votes were
+	// recorded.
+	PollFinished EventType = "pollFinished"
+	// PreferenceChanged is recorded when [BlockID] becomes the preferred tip.
+	PreferenceChanged EventType = "preferenceChanged"
+	// BlockAccepted is recorded when [BlockID] is accepted.
+	BlockAccepted EventType = "blockAccepted"
+	// BlockRejected is recorded when [BlockID] is rejected.
+	BlockRejected EventType = "blockRejected"
+)
+
+// Event is a single consensus event. Only the fields that are relevant to the
+// type of the event are populated.
+type Event struct {
+	Time       time.Time      `json:"time"`
+	Type       EventType      `json:"type"`
+	BlockID    *ids.ID        `json:"blockID,omitempty"`
+	ParentID   *ids.ID        `json:"parentID,omitempty"`
+	Height     uint64         `json:"height,omitempty"`
+	RequestID  uint32         `json:"requestID,omitempty"`
+	NodeID     *ids.NodeID    `json:"nodeID,omitempty"`
+	Validators []ids.NodeID   `json:"validators,omitempty"`
+	Votes      map[ids.ID]int `json:"votes,omitempty"`
+}
+
+// Votes returns the number of votes for each block in [votes].
+func Votes(votes bag.Bag[ids.ID]) map[ids.ID]int {
+	counts := make(map[ids.ID]int, votes.Len())
+	for _, blkID := range votes.List() {
+		counts[blkID] = votes.Count(blkID)
+	}
+	return counts
+}
+
+// References returns true if the event is about any of [blkIDs].
+func (e *Event) References(blkIDs set.Set[ids.ID]) bool {
+	if e.BlockID != nil && blkIDs.Contains(*e.BlockID) {
+		return true
+	}
+	if e.ParentID != nil && blkIDs.Contains(*e.ParentID) {
+		return true
+	}
+	for blkID := range e.Votes {
+		if blkIDs.Contains(blkID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Event) String() string {
+	var sb strings.Builder
+	sb.WriteString(e.Time.Format(time.RFC3339Nano))
+	sb.WriteByte(' ')
+	sb.WriteString(string(e.Type))
+	if e.BlockID != nil {
+		fmt.Fprintf(&sb, " block=%s", e.BlockID)
+	}
+	if e.ParentID != nil {
+		fmt.Fprintf(&sb, " parent=%s", e.ParentID)
+	}
+	if e.Height != 0 {
+		fmt.Fprintf(&sb, " height=%d", e.Height)
+	}
+	if e.RequestID != 0 {
+		fmt.Fprintf(&sb, " requestID=%d", e.RequestID)
+	}
+	if e.NodeID != nil {
+		fmt.Fprintf(&sb, " nodeID=%s", e.NodeID)
+	}
+	if len(e.Validators) > 0 {
+		fmt.Fprintf(&sb, " validators=%v", e.Validators)
+	}
+	if len(e.Votes) > 0 {
+		blkIDs := make([]ids.ID, 0, len(e.Votes))
+		for blkID := range e.Votes {
+			blkIDs = append(blkIDs, blkID)
+		}
+		utils.Sort(blkIDs)
+
+		sb.WriteString(" votes=[")
+		for i, blkID := range blkIDs {
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			fmt.Fprintf(&sb, "%s:%d", blkID, e.Votes[blkID])
+		}
+		sb.WriteByte(']')
+	}
+	return sb.String()
+}
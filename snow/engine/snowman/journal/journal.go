@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package journal
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+)
+
+// FileExtension is the extension of the file that a chain's journal is
+// written to.
+const FileExtension = ".jsonl"
+
+var (
+	_ Journal = (*fileJournal)(nil)
+	_ Journal = noJournal{}
+
+	// NoJournal is a journal that drops all events.
+	NoJournal Journal = noJournal{}
+)
+
+// Journal records the consensus events of a chain, so that the reason that a
+// block was accepted or rejected can be reconstructed after the fact.
+type Journal interface {
+	// Record writes [event] to the journal. The time of the event is set by
+	// the journal.
+	Record(event Event)
+
+	io.Closer
+}
+
+// Config of the journals written by the node.
+type Config struct {
+	// Directory that the journals are written to.
+	Directory string `json:"directory"`
+	// MaxSize is the size, in megabytes, that a journal file can grow to
+	// before it is rotated.
+	MaxSize int `json:"maxSize"`
+	// MaxFiles is the number of rotated journal files to keep.
+	MaxFiles int `json:"maxFiles"`
+}
+
+// Path returns the path of the file that the journal of [chainID] is written
+// to. Rotated files are compressed and written next to it.
+func (c Config) Path(chainID ids.ID) string {
+	return filepath.Join(c.Directory, chainID.String()+FileExtension)
+}
+
+type fileJournal struct {
+	log    logging.Logger
+	clock  mockable.Clock
+	writer io.WriteCloser
+}
+
+// New returns a journal of [chainID] that writes one JSON encoded event per
+// line to a rotating file. Failures to write events are logged to [log], as
+// the journal is only used for debugging.
+func New(log logging.Logger, config Config, chainID ids.ID) Journal {
+	return newJournal(
+		log,
+		&lumberjack.Logger{
+			Filename:   config.Path(chainID),
+			MaxSize:    config.MaxSize,  // megabytes
+			MaxBackups: config.MaxFiles, // files
+			Compress:   true,
+		},
+	)
+}
+
+func newJournal(log logging.Logger, writer io.WriteCloser) *fileJournal {
+	return &fileJournal{
+		log:    log,
+		writer: writer,
+	}
+}
+
+func (j *fileJournal) Record(event Event) {
+	event.Time = j.clock.Time().UTC()
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		j.log.Warn("failed to marshal consensus journal event",
+			zap.String("type", string(event.Type)),
+			zap.Error(err),
+		)
+		return
+	}
+
+	eventBytes = append(eventBytes, '\n')
+	if _, err := j.writer.Write(eventBytes); err != nil {
+		j.log.Warn("failed to write consensus journal event",
+			zap.String("type", string(event.Type)),
+			zap.Error(err),
+		)
+	}
+}
+
+func (j *fileJournal) Close() error {
+	return j.writer.Close()
+}
+
+type noJournal struct{}
+
+func (noJournal) Record(Event) {}
+
+func (noJournal) Close() error {
+	return nil
+}
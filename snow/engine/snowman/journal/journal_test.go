@@ -0,0 +1,189 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package journal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/perms"
+)
+
+type nopCloser struct {
+	*bytes.Buffer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+func TestRecordAndRead(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		buf    = &bytes.Buffer{}
+		j      = newJournal(logging.NoLog{}, nopCloser{buf})
+		now    = time.Unix(1_700_000_000, 0).UTC()
+		blkID  = ids.GenerateTestID()
+		nodeID = ids.GenerateTestNodeID()
+		event  = Event{
+			Type:      VoteReceived,
+			BlockID:   &blkID,
+			RequestID: 5,
+			NodeID:    &nodeID,
+		}
+	)
+	j.clock.Set(now)
+	j.Record(event)
+	j.Record(Event{
+		Type:      PollFinished,
+		RequestID: 5,
+		Votes: map[ids.ID]int{
+			blkID: 2,
+		},
+	})
+	require.NoError(j.Close())
+
+	events, err := Read(buf)
+	require.NoError(err)
+
+	event.Time = now
+	require.Equal(
+		[]Event{
+			event,
+			{
+				Time:      now,
+				Type:      PollFinished,
+				RequestID: 5,
+				Votes: map[ids.ID]int{
+					blkID: 2,
+				},
+			},
+		},
+		events,
+	)
+}
+
+func TestReadFilesSortsRotatedFiles(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		dir       = t.TempDir()
+		blkID     = ids.GenerateTestID()
+		startTime = time.Unix(1_700_000_000, 0)
+	)
+	writeEvents := func(events ...Event) []byte {
+		buf := &bytes.Buffer{}
+		j := newJournal(logging.NoLog{}, nopCloser{buf})
+		for _, event := range events {
+			j.clock.Set(event.Time)
+			j.Record(event)
+		}
+		return buf.Bytes()
+	}
+
+	oldEvent := Event{
+		Time:    startTime,
+		Type:    BlockAdded,
+		BlockID: &blkID,
+		Height:  1,
+	}
+	newEvent := Event{
+		Time:    startTime.Add(time.Second),
+		Type:    BlockAccepted,
+		BlockID: &blkID,
+		Height:  1,
+	}
+
+	compressed := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(compressed)
+	_, err := gzipWriter.Write(writeEvents(oldEvent))
+	require.NoError(err)
+	require.NoError(gzipWriter.Close())
+
+	rotatedPath := filepath.Join(dir, "chain-rotated.jsonl.gz")
+	require.NoError(perms.WriteFile(rotatedPath, compressed.Bytes(), perms.ReadWrite))
+	currentPath := filepath.Join(dir, "chain.jsonl")
+	require.NoError(perms.WriteFile(currentPath, writeEvents(newEvent), perms.ReadWrite))
+
+	events, err := ReadFiles(currentPath, rotatedPath)
+	require.NoError(err)
+	require.Len(events, 2)
+	require.Equal(BlockAdded, events[0].Type)
+	require.Equal(BlockAccepted, events[1].Type)
+
+	_, err = ReadFiles(filepath.Join(dir, "missing.jsonl"))
+	require.ErrorIs(err, os.ErrNotExist)
+}
+
+func TestTimeline(t *testing.T) {
+	var (
+		genesisID  = ids.GenerateTestID()
+		blkID      = ids.GenerateTestID()
+		conflictID = ids.GenerateTestID()
+		otherID    = ids.GenerateTestID()
+		nodeID0    = ids.GenerateTestNodeID()
+		nodeID1    = ids.GenerateTestNodeID()
+	)
+	events := []Event{
+		{Type: BlockAdded, BlockID: &blkID, ParentID: &genesisID, Height: 1},
+		{Type: BlockAdded, BlockID: &conflictID, ParentID: &genesisID, Height: 1},
+		{Type: BlockAdded, BlockID: &otherID, ParentID: &blkID, Height: 2},
+		{Type: PollStarted, BlockID: &blkID, RequestID: 1},
+		{Type: VoteReceived, BlockID: &conflictID, RequestID: 1, NodeID: &nodeID0},
+		{Type: VoteDropped, RequestID: 1, NodeID: &nodeID1},
+		{Type: PollStarted, BlockID: &otherID, RequestID: 2},
+		{Type: VoteDropped, RequestID: 2, NodeID: &nodeID0},
+		{Type: PollFinished, BlockID: &otherID, RequestID: 2, Votes: map[ids.ID]int{blkID: 1}},
+		{Type: BlockRejected, BlockID: &conflictID, Height: 1},
+		{Type: PollFinished, BlockID: &genesisID, RequestID: 1, Votes: map[ids.ID]int{conflictID: 1}},
+	}
+
+	tests := []struct {
+		name     string
+		timeline []Event
+		expected []int
+	}{
+		{
+			name:     "block",
+			timeline: Timeline(events, blkID),
+			// The child of the block is included as it references the block
+			// as its parent. Votes in the poll started for the block are
+			// included, even if they are for a different block.
+			expected: []int{0, 2, 3, 4, 5, 8, 10},
+		},
+		{
+			name:     "conflicting block",
+			timeline: Timeline(events, conflictID),
+			expected: []int{1, 4, 9, 10},
+		},
+		{
+			name:     "height",
+			timeline: HeightTimeline(events, 1),
+			expected: []int{0, 1, 2, 3, 4, 5, 8, 9, 10},
+		},
+		{
+			name:     "unknown height",
+			timeline: HeightTimeline(events, 3),
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var expected []Event
+			for _, i := range test.expected {
+				expected = append(expected, events[i])
+			}
+			require.Equal(t, expected, test.timeline)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package journal
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+const maxEventSize = units.MiB
+
+// Read parses the events written to a journal file.
+func Read(r io.Reader) ([]Event, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxEventSize)
+
+	var events []Event
+	for line := 1; scanner.Scan(); line++ {
+		eventBytes := scanner.Bytes()
+		if len(eventBytes) == 0 {
+			continue
+		}
+
+		var event Event
+		if err := json.Unmarshal(eventBytes, &event); err != nil {
+			return nil, fmt.Errorf("failed to parse line %d: %w", line, err)
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
+
+// ReadFiles parses the events written to the journal files at [paths], which
+// may have been compressed when they were rotated. The events are returned in
+// the order that they were recorded.
+func ReadFiles(paths ...string) ([]Event, error) {
+	var events []Event
+	for _, path := range paths {
+		fileEvents, err := readFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		events = append(events, fileEvents...)
+	}
+
+	slices.SortStableFunc(events, func(a, b Event) int {
+		return a.Time.Compare(b.Time)
+	})
+	return events, nil
+}
+
+func readFile(path string) ([]Event, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if !strings.HasSuffix(path, ".gz") {
+		return Read(file)
+	}
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return Read(reader)
+}
+
+// Timeline returns the [events] that are about [blkID].
+func Timeline(events []Event, blkID ids.ID) []Event {
+	return filter(events, set.Of(blkID))
+}
+
+// HeightTimeline returns the [events] that are about any block added to
+// consensus at [height].
+func HeightTimeline(events []Event, height uint64) []Event {
+	var blkIDs set.Set[ids.ID]
+	for _, event := range events {
+		if event.Type == BlockAdded && event.Height == height && event.BlockID != nil {
+			blkIDs.Add(*event.BlockID)
+		}
+	}
+	return filter(events, blkIDs)
+}
+
+// filter returns the [events] that are about any of [blkIDs]. Votes and results
+// for other blocks are included if they were cast in a poll that was started
+// for one of [blkIDs], as they explain why the poll finished the way it did.
+func filter(events []Event, blkIDs set.Set[ids.ID]) []Event {
+	var (
+		filtered []Event
+		// Request IDs are reset when the node restarts, so a poll is only
+		// relevant until the next poll with the same request ID is started.
+		relevantPolls = make(map[uint32]bool)
+	)
+	for _, event := range events {
+		relevant := event.References(blkIDs)
+		switch event.Type {
+		case PollStarted:
+			relevantPolls[event.RequestID] = relevant
+		case VoteReceived, VoteDropped, PollFinished:
+			relevant = relevant || relevantPolls[event.RequestID]
+		}
+		if relevant {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/journal"
+)
+
+// This renders the consensus timeline of a block, or of all the blocks at a
+// height, from the journal files of a chain.
+//
+// The journal files, including the compressed rotated files, are provided as
+// arguments:
+//
+//	timeline --height 1024 journal/<chain ID>*
+func main() {
+	var (
+		block  = flag.String("block", "", "ID of the block to render the timeline of")
+		height = flag.Uint64("height", 0, "height of the blocks to render the timeline of. Ignored if --block is provided")
+	)
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		log.Fatal("at least one journal file must be provided")
+	}
+	if *block == "" && *height == 0 {
+		log.Fatal("--block or --height must be provided")
+	}
+
+	events, err := journal.ReadFiles(paths...)
+	if err != nil {
+		log.Fatalf("failed to read journal: %v", err)
+	}
+
+	var timeline []journal.Event
+	if *block != "" {
+		blkID, err := ids.FromString(*block)
+		if err != nil {
+			log.Fatalf("failed to parse block ID: %v", err)
+		}
+		timeline = journal.Timeline(events, blkID)
+	} else {
+		timeline = journal.HeightTimeline(events, *height)
+	}
+
+	for _, event := range timeline {
+		fmt.Println(event.String())
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/ancestor"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/journal"
 )
 
 var _ snowman.Block = (*memoryBlock)(nil)
@@ -18,18 +19,36 @@ type memoryBlock struct {
 
 	tree    ancestor.Tree
 	metrics *metrics
+	journal journal.Journal
 }
 
 // Accept accepts the underlying block & removes sibling subtrees
 func (mb *memoryBlock) Accept(ctx context.Context) error {
 	mb.tree.RemoveDescendants(mb.Parent())
 	mb.metrics.numNonVerifieds.Set(float64(mb.tree.Len()))
-	return mb.Block.Accept(ctx)
+	if err := mb.Block.Accept(ctx); err != nil {
+		return err
+	}
+	mb.record(journal.BlockAccepted)
+	return nil
 }
 
 // Reject rejects the underlying block & removes child subtrees
 func (mb *memoryBlock) Reject(ctx context.Context) error {
 	mb.tree.RemoveDescendants(mb.ID())
 	mb.metrics.numNonVerifieds.Set(float64(mb.tree.Len()))
-	return mb.Block.Reject(ctx)
+	if err := mb.Block.Reject(ctx); err != nil {
+		return err
+	}
+	mb.record(journal.BlockRejected)
+	return nil
+}
+
+func (mb *memoryBlock) record(eventType journal.EventType) {
+	blkID := mb.ID()
+	mb.journal.Record(journal.Event{
+		Type:    eventType,
+		BlockID: &blkID,
+		Height:  mb.Height(),
+	})
 }
@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman/snowmantest"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/ancestor"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/journal"
+)
+
+func TestMemoryBlockJournal(t *testing.T) {
+	tests := []struct {
+		name          string
+		decide        func(*memoryBlock) error
+		acceptErr     error
+		rejectErr     error
+		expectedErr   error
+		expectedTypes []journal.EventType
+	}{
+		{
+			name:          "accept",
+			decide:        func(mb *memoryBlock) error { return mb.Accept(context.Background()) },
+			expectedTypes: []journal.EventType{journal.BlockAccepted},
+		},
+		{
+			name:        "accept fails",
+			decide:      func(mb *memoryBlock) error { return mb.Accept(context.Background()) },
+			acceptErr:   errTest,
+			expectedErr: errTest,
+		},
+		{
+			name:          "reject",
+			decide:        func(mb *memoryBlock) error { return mb.Reject(context.Background()) },
+			expectedTypes: []journal.EventType{journal.BlockRejected},
+		},
+		{
+			name:        "reject fails",
+			decide:      func(mb *memoryBlock) error { return mb.Reject(context.Background()) },
+			rejectErr:   errTest,
+			expectedErr: errTest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			m, err := newMetrics(prometheus.NewRegistry())
+			require.NoError(err)
+
+			blk := snowmantest.BuildChild(snowmantest.Genesis)
+			blk.AcceptV = test.acceptErr
+			blk.RejectV = test.rejectErr
+
+			consensusJournal := &testJournal{}
+			mb := &memoryBlock{
+				Block:   blk,
+				tree:    ancestor.NewTree(),
+				metrics: m,
+				journal: consensusJournal,
+			}
+
+			err = test.decide(mb)
+			require.ErrorIs(err, test.expectedErr)
+
+			var types []journal.EventType
+			for _, event := range consensusJournal.events {
+				types = append(types, event.Type)
+			}
+			require.Equal(test.expectedTypes, types)
+		})
+	}
+}
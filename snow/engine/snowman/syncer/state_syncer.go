@@ -109,6 +109,15 @@ func New(
 	}
 }
 
+func (ss *stateSyncer) LastRequestID() uint32 {
+	return ss.requestID
+}
+
+// Abandon is a no-op, as Start resets any work in progress.
+func (*stateSyncer) Abandon(context.Context) error {
+	return nil
+}
+
 func (ss *stateSyncer) Start(ctx context.Context, startReqID uint32) error {
 	ss.Ctx.Log.Info("starting state sync")
 
@@ -120,6 +129,8 @@ func (ss *stateSyncer) Start(ctx context.Context, startReqID uint32) error {
 		return fmt.Errorf("failed to notify VM that state syncing has started: %w", err)
 	}
 
+	// Start is called again if the chain is forced back into state sync.
+	ss.started = false
 	ss.requestID = startReqID
 
 	return ss.tryStartSyncing(ctx)
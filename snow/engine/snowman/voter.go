@@ -9,8 +9,9 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman/poll"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/job"
-	"github.com/MetalBlockchain/metalgo/utils/bag"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/journal"
 )
 
 var _ job.Job[ids.ID] = (*voter)(nil)
@@ -43,11 +44,22 @@ func (v *voter) Execute(ctx context.Context, _ []ids.ID, _ []ids.ID) error {
 		}
 	}
 
-	var results []bag.Bag[ids.ID]
+	var results []poll.Result
 	if shouldVote {
 		v.e.selectedVoteIndex.Observe(float64(voteIndex))
+		v.e.Journal.Record(journal.Event{
+			Type:      journal.VoteReceived,
+			BlockID:   &vote,
+			RequestID: v.requestID,
+			NodeID:    &v.nodeID,
+		})
 		results = v.e.polls.Vote(v.requestID, v.nodeID, vote)
 	} else {
+		v.e.Journal.Record(journal.Event{
+			Type:      journal.VoteDropped,
+			RequestID: v.requestID,
+			NodeID:    &v.nodeID,
+		})
 		results = v.e.polls.Drop(v.requestID, v.nodeID)
 	}
 
@@ -56,13 +68,24 @@ func (v *voter) Execute(ctx context.Context, _ []ids.ID, _ []ids.ID) error {
 	}
 
 	for _, result := range results {
-		result := result
+		votes := result.Votes
 		v.e.Ctx.Log.Debug("finishing poll",
-			zap.Stringer("result", &result),
+			zap.Uint32("requestID", result.RequestID),
+			zap.Stringer("result", &votes),
 		)
-		if err := v.e.Consensus.RecordPoll(ctx, result); err != nil {
+		prevPreference := v.e.Consensus.Preference()
+		if err := v.e.Consensus.RecordPoll(ctx, votes); err != nil {
 			return err
 		}
+
+		preference := v.e.Consensus.Preference()
+		v.e.Journal.Record(journal.Event{
+			Type:      journal.PollFinished,
+			BlockID:   &preference,
+			RequestID: result.RequestID,
+			Votes:     journal.Votes(votes),
+		})
+		v.e.recordPreferenceChange(prevPreference)
 	}
 
 	if err := v.e.VM.SetPreference(ctx, v.e.Consensus.Preference()); err != nil {
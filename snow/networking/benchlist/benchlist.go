@@ -4,15 +4,18 @@
 package benchlist
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
@@ -23,6 +26,17 @@ import (
 	safemath "github.com/MetalBlockchain/metalgo/utils/math"
 )
 
+// benchedNodeLength is the length of a persisted [BenchedNode] entry.
+//
+// BenchedAt + BenchedUntil + FailureStreak + Manual
+const benchedNodeLength = 3*database.Uint64Size + database.BoolSize
+
+var (
+	errInvalidBenchDuration     = errors.New("bench duration must be positive")
+	errNotBenched               = errors.New("node is not benched")
+	errUnexpectedBenchedNodeLen = fmt.Errorf("expected benched node entry length %d", benchedNodeLength)
+)
+
 // If a peer consistently does not respond to queries, it will
 // increase latencies on the network whenever that peer is polled.
 // If we cannot terminate the poll early, then the poll will wait
@@ -40,6 +54,50 @@ type Benchlist interface {
 	// IsBenched returns true if messages to [validatorID]
 	// should not be sent over the network and should immediately fail.
 	IsBenched(nodeID ids.NodeID) bool
+	// Benched returns the currently benched nodes, ordered by when they were
+	// benched.
+	Benched() []BenchedNode
+	// Bench benches [nodeID] for [duration], regardless of its failure streak
+	// or the amount of stake that is already benched. If [nodeID] is already
+	// benched, its bench is replaced.
+	Bench(nodeID ids.NodeID, duration time.Duration) error
+	// Unbench immediately unbenches [nodeID].
+	Unbench(nodeID ids.NodeID) error
+}
+
+// BenchedNode describes why and for how long a node is benched.
+type BenchedNode struct {
+	NodeID ids.NodeID
+	// Number of consecutive failed queries that caused the node to be benched.
+	// Zero if the node was benched manually.
+	FailureStreak int
+	BenchedAt     time.Time
+	BenchedUntil  time.Time
+	// True if the node was benched with Bench rather than due to failures.
+	Manual bool
+}
+
+func (n *BenchedNode) Marshal() []byte {
+	data := make([]byte, benchedNodeLength)
+	binary.BigEndian.PutUint64(data, uint64(n.BenchedAt.UnixNano()))
+	binary.BigEndian.PutUint64(data[database.Uint64Size:], uint64(n.BenchedUntil.UnixNano()))
+	binary.BigEndian.PutUint64(data[2*database.Uint64Size:], uint64(n.FailureStreak))
+	if n.Manual {
+		data[3*database.Uint64Size] = 1
+	}
+	return data
+}
+
+func (n *BenchedNode) Unmarshal(data []byte) error {
+	if len(data) != benchedNodeLength {
+		return errUnexpectedBenchedNodeLen
+	}
+
+	n.BenchedAt = time.Unix(0, int64(binary.BigEndian.Uint64(data)))
+	n.BenchedUntil = time.Unix(0, int64(binary.BigEndian.Uint64(data[database.Uint64Size:])))
+	n.FailureStreak = int(binary.BigEndian.Uint64(data[2*database.Uint64Size:]))
+	n.Manual = data[3*database.Uint64Size] == 1
+	return nil
 }
 
 type failureStreak struct {
@@ -79,6 +137,13 @@ type benchlist struct {
 	// Min heap of benched validators ordered by when they can be unbenched
 	benchedHeap heap.Map[ids.NodeID, time.Time]
 
+	// Validator ID --> Details of why and for how long it is benched
+	benchedNodes map[ids.NodeID]BenchedNode
+
+	// If non-nil, benched nodes are persisted to [db] so that they remain
+	// benched across restarts
+	db database.Database
+
 	// A validator will be benched if [threshold] messages in a row
 	// to them time out and the first of those messages was more than
 	// [minimumFailingDuration] ago
@@ -102,6 +167,7 @@ func NewBenchlist(
 	minimumFailingDuration,
 	duration time.Duration,
 	maxPortion float64,
+	db database.Database,
 	reg prometheus.Registerer,
 ) (Benchlist, error) {
 	if maxPortion < 0 || maxPortion >= 1 {
@@ -123,6 +189,8 @@ func NewBenchlist(
 		benchlistSet:           set.Set[ids.NodeID]{},
 		benchable:              benchable,
 		benchedHeap:            heap.NewMap[ids.NodeID, time.Time](time.Time.Before),
+		benchedNodes:           make(map[ids.NodeID]BenchedNode),
+		db:                     db,
 		vdrs:                   validators,
 		threshold:              threshold,
 		minimumFailingDuration: minimumFailingDuration,
@@ -138,10 +206,61 @@ func NewBenchlist(
 		return nil, err
 	}
 
+	if err := benchlist.load(); err != nil {
+		return nil, fmt.Errorf("failed to load benched nodes: %w", err)
+	}
+
 	go benchlist.run()
 	return benchlist, nil
 }
 
+// load re-benches the nodes that were persisted as benched and whose bench
+// hasn't expired yet. The maximum portion of benched stake isn't enforced,
+// because the validator set may not be known yet.
+func (b *benchlist) load() error {
+	if b.db == nil {
+		return nil
+	}
+
+	it := b.db.NewIterator()
+	defer it.Release()
+
+	now := b.clock.Time()
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			return err
+		}
+
+		benchedNode := BenchedNode{NodeID: nodeID}
+		if err := benchedNode.Unmarshal(it.Value()); err != nil {
+			return err
+		}
+
+		if !now.Before(benchedNode.BenchedUntil) {
+			if err := b.db.Delete(it.Key()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		b.ctx.Log.Debug("restoring benched node",
+			zap.Stringer("nodeID", nodeID),
+			zap.Time("benchedUntil", benchedNode.BenchedUntil),
+		)
+		b.benchlistSet.Add(nodeID)
+		b.benchable.Benched(b.ctx.ChainID, nodeID)
+		b.benchedHeap.Push(nodeID, benchedNode.BenchedUntil)
+		b.benchedNodes[nodeID] = benchedNode
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	b.numBenched.Set(float64(b.benchedHeap.Len()))
+	return nil
+}
+
 // TODO: Close this goroutine during node shutdown
 func (b *benchlist) run() {
 	timer := time.NewTimer(0)
@@ -201,10 +320,32 @@ func (b *benchlist) removedExpiredNodes() {
 		b.ctx.Log.Debug("removing node from benchlist",
 			zap.Stringer("nodeID", nodeID),
 		)
-		b.benchlistSet.Remove(nodeID)
-		b.benchable.Unbenched(b.ctx.ChainID, nodeID)
+		b.remove(nodeID)
+	}
+
+	b.updateMetrics()
+}
+
+// Assumes [b.lock] is held
+// Assumes [nodeID] has already been removed from [b.benchedHeap]
+func (b *benchlist) remove(nodeID ids.NodeID) {
+	b.benchlistSet.Remove(nodeID)
+	delete(b.benchedNodes, nodeID)
+	b.benchable.Unbenched(b.ctx.ChainID, nodeID)
+
+	if b.db == nil {
+		return
+	}
+	if err := b.db.Delete(nodeID.Bytes()); err != nil {
+		b.ctx.Log.Error("failed to delete benched node",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
 	}
+}
 
+// Assumes [b.lock] is held
+func (b *benchlist) updateMetrics() {
 	b.numBenched.Set(float64(b.benchedHeap.Len()))
 	benchedStake, err := b.vdrs.SubsetWeight(b.ctx.SubnetID, b.benchlistSet)
 	if err != nil {
@@ -239,6 +380,59 @@ func (b *benchlist) IsBenched(nodeID ids.NodeID) bool {
 	return b.benchlistSet.Contains(nodeID)
 }
 
+func (b *benchlist) Benched() []BenchedNode {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	benched := make([]BenchedNode, 0, len(b.benchedNodes))
+	for _, benchedNode := range b.benchedNodes {
+		benched = append(benched, benchedNode)
+	}
+	slices.SortFunc(benched, func(i, j BenchedNode) int {
+		return i.BenchedAt.Compare(j.BenchedAt)
+	})
+	return benched
+}
+
+func (b *benchlist) Bench(nodeID ids.NodeID, duration time.Duration) error {
+	if duration <= 0 {
+		return errInvalidBenchDuration
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.clock.Time()
+	b.ctx.Log.Info("manually benching node",
+		zap.Stringer("nodeID", nodeID),
+		zap.Duration("benchDuration", duration),
+	)
+	b.add(BenchedNode{
+		NodeID:       nodeID,
+		BenchedAt:    now,
+		BenchedUntil: now.Add(duration),
+		Manual:       true,
+	})
+	b.updateMetrics()
+	return nil
+}
+
+func (b *benchlist) Unbench(nodeID ids.NodeID) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.benchedHeap.Remove(nodeID); !ok {
+		return errNotBenched
+	}
+
+	b.ctx.Log.Info("manually unbenching node",
+		zap.Stringer("nodeID", nodeID),
+	)
+	b.remove(nodeID)
+	b.updateMetrics()
+	return nil
+}
+
 // RegisterResponse notes that we received a response from [nodeID]
 func (b *benchlist) RegisterResponse(nodeID ids.NodeID) {
 	b.streaklock.Lock()
@@ -271,13 +465,13 @@ func (b *benchlist) RegisterFailure(nodeID ids.NodeID) {
 	b.streaklock.Unlock()
 
 	if failureStreak.consecutive >= b.threshold && now.After(failureStreak.firstFailure.Add(b.minimumFailingDuration)) {
-		b.bench(nodeID)
+		b.bench(nodeID, failureStreak.consecutive)
 	}
 }
 
 // Assumes [b.lock] is held
 // Assumes [nodeID] is not already benched
-func (b *benchlist) bench(nodeID ids.NodeID) {
+func (b *benchlist) bench(nodeID ids.NodeID, failureStreak int) {
 	validatorStake := b.vdrs.GetWeight(b.ctx.SubnetID, nodeID)
 	if validatorStake == 0 {
 		// We might want to bench a non-validator because they don't respond to
@@ -339,14 +533,32 @@ func (b *benchlist) bench(nodeID ids.NodeID) {
 	)
 
 	// Add to benchlist times with randomized delay
-	b.benchlistSet.Add(nodeID)
-	b.benchable.Benched(b.ctx.ChainID, nodeID)
+	b.add(BenchedNode{
+		NodeID:        nodeID,
+		FailureStreak: failureStreak,
+		BenchedAt:     now,
+		BenchedUntil:  benchedUntil,
+	})
+
+	// Update metrics
+	b.numBenched.Set(float64(b.benchedHeap.Len()))
+	b.weightBenched.Set(float64(newBenchedStake))
+}
+
+// Assumes [b.lock] is held
+func (b *benchlist) add(benchedNode BenchedNode) {
+	nodeID := benchedNode.NodeID
+	if !b.benchlistSet.Contains(nodeID) {
+		b.benchlistSet.Add(nodeID)
+		b.benchable.Benched(b.ctx.ChainID, nodeID)
+	}
 
 	b.streaklock.Lock()
 	delete(b.failureStreaks, nodeID)
 	b.streaklock.Unlock()
 
-	b.benchedHeap.Push(nodeID, benchedUntil)
+	b.benchedHeap.Push(nodeID, benchedNode.BenchedUntil)
+	b.benchedNodes[nodeID] = benchedNode
 
 	// Update the timer to account for the newly benched node.
 	select {
@@ -354,7 +566,13 @@ func (b *benchlist) bench(nodeID ids.NodeID) {
 	default:
 	}
 
-	// Update metrics
-	b.numBenched.Set(float64(b.benchedHeap.Len()))
-	b.weightBenched.Set(float64(newBenchedStake))
+	if b.db == nil {
+		return
+	}
+	if err := b.db.Put(nodeID.Bytes(), benchedNode.Marshal()); err != nil {
+		b.ctx.Log.Error("failed to persist benched node",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
+	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow/snowtest"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
@@ -50,6 +51,7 @@ func TestBenchlistAdd(t *testing.T) {
 		minimumFailingDuration,
 		duration,
 		maxPortion,
+		nil,
 		prometheus.NewRegistry(),
 	)
 	require.NoError(err)
@@ -175,6 +177,7 @@ func TestBenchlistMaxStake(t *testing.T) {
 		minimumFailingDuration,
 		duration,
 		maxPortion,
+		nil,
 		prometheus.NewRegistry(),
 	)
 	require.NoError(err)
@@ -298,6 +301,7 @@ func TestBenchlistRemove(t *testing.T) {
 		minimumFailingDuration,
 		duration,
 		maxPortion,
+		nil,
 		prometheus.NewRegistry(),
 	)
 	require.NoError(err)
@@ -365,3 +369,158 @@ func TestBenchlistRemove(t *testing.T) {
 
 	require.Equal(3, count)
 }
+
+// Test that validators can be benched and unbenched manually
+func TestBenchlistManualBench(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	vdrs := validators.NewManager()
+	vdrID0 := ids.GenerateTestNodeID()
+	vdrID1 := ids.GenerateTestNodeID()
+
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID0, nil, ids.Empty, 50))
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID1, nil, ids.Empty, 50))
+
+	var benched, unbenched []ids.NodeID
+	benchable := &TestBenchable{
+		T: t,
+		BenchedF: func(_ ids.ID, nodeID ids.NodeID) {
+			benched = append(benched, nodeID)
+		},
+		UnbenchedF: func(_ ids.ID, nodeID ids.NodeID) {
+			unbenched = append(unbenched, nodeID)
+		},
+	}
+
+	threshold := 3
+	duration := time.Minute
+	maxPortion := 0.5
+	benchIntf, err := NewBenchlist(
+		ctx,
+		benchable,
+		vdrs,
+		threshold,
+		minimumFailingDuration,
+		duration,
+		maxPortion,
+		nil,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	b := benchIntf.(*benchlist)
+	now := time.Now()
+	b.lock.Lock()
+	b.clock.Set(now)
+	b.lock.Unlock()
+
+	// Bench vdr0 due to failures
+	for i := 0; i < threshold; i++ {
+		b.RegisterFailure(vdrID0)
+	}
+	failingTime := now.Add(minimumFailingDuration).Add(time.Second)
+	b.lock.Lock()
+	b.clock.Set(failingTime)
+	b.lock.Unlock()
+	b.RegisterFailure(vdrID0)
+	require.True(b.IsBenched(vdrID0))
+
+	// Manually benching vdr1 ignores the maximum portion of benched stake
+	manualTime := failingTime.Add(time.Second)
+	b.lock.Lock()
+	b.clock.Set(manualTime)
+	b.lock.Unlock()
+	require.ErrorIs(b.Bench(vdrID1, 0), errInvalidBenchDuration)
+	require.NoError(b.Bench(vdrID1, time.Hour))
+	require.True(b.IsBenched(vdrID1))
+	require.Equal([]ids.NodeID{vdrID0, vdrID1}, benched)
+
+	benchedNodes := b.Benched()
+	require.Len(benchedNodes, 2)
+
+	require.Equal(vdrID0, benchedNodes[0].NodeID)
+	require.Equal(threshold+1, benchedNodes[0].FailureStreak)
+	require.Equal(failingTime, benchedNodes[0].BenchedAt)
+	require.False(benchedNodes[0].BenchedUntil.After(failingTime.Add(duration)))
+	require.False(benchedNodes[0].Manual)
+
+	require.Equal(BenchedNode{
+		NodeID:       vdrID1,
+		BenchedAt:    manualTime,
+		BenchedUntil: manualTime.Add(time.Hour),
+		Manual:       true,
+	}, benchedNodes[1])
+
+	// Manually unbench vdr0
+	require.NoError(b.Unbench(vdrID0))
+	require.False(b.IsBenched(vdrID0))
+	require.Equal([]ids.NodeID{vdrID0}, unbenched)
+	require.ErrorIs(b.Unbench(vdrID0), errNotBenched)
+
+	benchedNodes = b.Benched()
+	require.Len(benchedNodes, 1)
+	require.Equal(vdrID1, benchedNodes[0].NodeID)
+}
+
+// Test that benched validators are restored from the database
+func TestBenchlistPersist(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	vdrs := validators.NewManager()
+	vdrID0 := ids.GenerateTestNodeID()
+	vdrID1 := ids.GenerateTestNodeID()
+
+	db := memdb.New()
+	newBenchlist := func() *benchlist {
+		benchIntf, err := NewBenchlist(
+			ctx,
+			&TestBenchable{},
+			vdrs,
+			3,
+			minimumFailingDuration,
+			time.Minute,
+			0.5,
+			db,
+			prometheus.NewRegistry(),
+		)
+		require.NoError(err)
+		return benchIntf.(*benchlist)
+	}
+
+	b := newBenchlist()
+	now := time.Now()
+
+	// vdr0's bench will have expired by the time the benchlist is restored
+	b.lock.Lock()
+	b.clock.Set(now.Add(-2 * time.Hour))
+	b.lock.Unlock()
+	require.NoError(b.Bench(vdrID0, time.Hour))
+
+	b.lock.Lock()
+	b.clock.Set(now)
+	b.lock.Unlock()
+	require.NoError(b.Bench(vdrID1, time.Hour))
+
+	b = newBenchlist()
+	require.False(b.IsBenched(vdrID0))
+	require.True(b.IsBenched(vdrID1))
+
+	benchedNodes := b.Benched()
+	require.Len(benchedNodes, 1)
+	require.Equal(vdrID1, benchedNodes[0].NodeID)
+	require.True(now.Equal(benchedNodes[0].BenchedAt))
+	require.True(now.Add(time.Hour).Equal(benchedNodes[0].BenchedUntil))
+	require.True(benchedNodes[0].Manual)
+
+	has, err := db.Has(vdrID0.Bytes())
+	require.NoError(err)
+	require.False(has)
+
+	// Unbenching removes the node from the database
+	require.NoError(b.Unbench(vdrID1))
+	b = newBenchlist()
+	require.False(b.IsBenched(vdrID1))
+}
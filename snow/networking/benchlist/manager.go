@@ -4,16 +4,24 @@
 package benchlist
 
 import (
+	"errors"
 	"sync"
 	"time"
 
 	"github.com/MetalBlockchain/metalgo/api/metrics"
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/prefixdb"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 )
 
-var _ Manager = (*manager)(nil)
+var (
+	errUnknownChain      = errors.New("unknown chain")
+	errBenchlistDisabled = errors.New("benchlist is disabled")
+
+	_ Manager = (*manager)(nil)
+)
 
 // Manager provides an interface for a benchlist to register whether
 // queries have been successful or unsuccessful and place validators with
@@ -36,17 +44,29 @@ type Manager interface {
 	// [nodeID] is benched. If called on an id.ShortID that does
 	// not map to a validator, it will return an empty array.
 	GetBenched(nodeID ids.NodeID) []ids.ID
+	// Benched returns the currently benched nodes of every registered chain.
+	Benched() map[ids.ID][]BenchedNode
+	// Bench benches [nodeID] on chain [chainID] for [duration], regardless of
+	// its failure streak or the amount of stake that is already benched. If
+	// [duration] is 0, the configured bench duration is used.
+	Bench(chainID ids.ID, nodeID ids.NodeID, duration time.Duration) error
+	// Unbench immediately unbenches [nodeID] on chain [chainID].
+	Unbench(chainID ids.ID, nodeID ids.NodeID) error
 }
 
-// Config defines the configuration for a benchlist
+// Config defines the configuration for a benchlist.
+// If [DB] is non-nil, benched nodes are persisted to it so that they remain
+// benched across restarts.
 type Config struct {
 	Benchable              Benchable             `json:"-"`
 	Validators             validators.Manager    `json:"-"`
 	BenchlistRegisterer    metrics.MultiGatherer `json:"-"`
+	DB                     database.Database     `json:"-"`
 	Threshold              int                   `json:"threshold"`
 	MinimumFailingDuration time.Duration         `json:"minimumFailingDuration"`
 	Duration               time.Duration         `json:"duration"`
 	MaxPortion             float64               `json:"maxPortion"`
+	Persist                bool                  `json:"persist"`
 }
 
 type manager struct {
@@ -118,6 +138,11 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 		return err
 	}
 
+	var db database.Database
+	if m.config.DB != nil {
+		db = prefixdb.New(ctx.ChainID[:], m.config.DB)
+	}
+
 	benchlist, err := NewBenchlist(
 		ctx,
 		m.config.Benchable,
@@ -126,6 +151,7 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 		m.config.MinimumFailingDuration,
 		m.config.Duration,
 		m.config.MaxPortion,
+		db,
 		reg,
 	)
 	if err != nil {
@@ -136,6 +162,42 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 	return nil
 }
 
+func (m *manager) Benched() map[ids.ID][]BenchedNode {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	benched := make(map[ids.ID][]BenchedNode, len(m.chainBenchlists))
+	for chainID, benchlist := range m.chainBenchlists {
+		benched[chainID] = benchlist.Benched()
+	}
+	return benched
+}
+
+func (m *manager) Bench(chainID ids.ID, nodeID ids.NodeID, duration time.Duration) error {
+	m.lock.RLock()
+	benchlist, exists := m.chainBenchlists[chainID]
+	m.lock.RUnlock()
+
+	if !exists {
+		return errUnknownChain
+	}
+	if duration == 0 {
+		duration = m.config.Duration
+	}
+	return benchlist.Bench(nodeID, duration)
+}
+
+func (m *manager) Unbench(chainID ids.ID, nodeID ids.NodeID) error {
+	m.lock.RLock()
+	benchlist, exists := m.chainBenchlists[chainID]
+	m.lock.RUnlock()
+
+	if !exists {
+		return errUnknownChain
+	}
+	return benchlist.Unbench(nodeID)
+}
+
 func (m *manager) RegisterResponse(chainID ids.ID, nodeID ids.NodeID) {
 	m.lock.RLock()
 	benchlist, exists := m.chainBenchlists[chainID]
@@ -180,3 +242,15 @@ func (noBenchlist) IsBenched(ids.NodeID, ids.ID) bool {
 func (noBenchlist) GetBenched(ids.NodeID) []ids.ID {
 	return []ids.ID{}
 }
+
+func (noBenchlist) Benched() map[ids.ID][]BenchedNode {
+	return map[ids.ID][]BenchedNode{}
+}
+
+func (noBenchlist) Bench(ids.ID, ids.NodeID, time.Duration) error {
+	return errBenchlistDisabled
+}
+
+func (noBenchlist) Unbench(ids.ID, ids.NodeID) error {
+	return errBenchlistDisabled
+}
@@ -39,13 +39,21 @@ const (
 	// If a consensus message takes longer than this to process, the handler
 	// will log a warning.
 	syncProcessingTimeWarnLimit = 30 * time.Second
+	// Maximum number of responses held while the chain is paused. Held
+	// responses keep their throttler bytes reserved, so once this limit is
+	// reached, further responses are replaced by their failure messages.
+	maxHeldResponses = 1024
 )
 
 var (
 	_ Handler = (*handler)(nil)
 
-	errMissingEngine  = errors.New("missing engine")
-	errNoStartingGear = errors.New("failed to select starting gear")
+	errMissingEngine          = errors.New("missing engine")
+	errNoStartingGear         = errors.New("failed to select starting gear")
+	errAlreadyPaused          = errors.New("chain is already paused")
+	errNotPaused              = errors.New("chain isn't paused")
+	errRebootstrapUnsupported = errors.New("chain can't be re-bootstrapped")
+	errStateSyncDisabled      = errors.New("state sync isn't enabled")
 )
 
 type Handler interface {
@@ -66,6 +74,16 @@ type Handler interface {
 	Push(ctx context.Context, msg Message)
 	Len() int
 
+	// Pause stops the chain from handling messages until Resume is called.
+	// Unrequested messages received while the chain is paused are dropped.
+	// Responses are held and handled once the chain is resumed.
+	Pause(ctx context.Context) error
+	Resume(ctx context.Context) error
+	Paused() bool
+	// Rebootstrap moves the chain back into state sync, if [stateSync] is
+	// true, or into bootstrapping.
+	Rebootstrap(ctx context.Context, stateSync bool) error
+
 	Stop(ctx context.Context)
 	StopWithError(ctx context.Context, err error)
 	// AwaitStopped returns an error if the call would block and [ctx] is done.
@@ -112,6 +130,27 @@ type handler struct {
 	asyncMessagePool errgroup.Group
 	timeouts         chan struct{}
 
+	// paused is halted while the chain is paused.
+	paused common.Halter
+	// pauseLock must be held while pausing or resuming the chain and while
+	// accessing [resumed], [heldSyncMsgs], [heldAsyncMsgs],
+	// [numHeldResponses] and [heldConnectivity].
+	pauseLock sync.Mutex
+	// resumed is closed when the chain is resumed. It is nil if the chain
+	// isn't paused.
+	resumed chan struct{}
+	// Responses and failure messages that were received while the chain was
+	// paused.
+	heldSyncMsgs, heldAsyncMsgs []heldMessage
+	// Number of held messages that are responses from peers.
+	numHeldResponses int
+	// Connected and Disconnected messages that were received while the chain
+	// was paused, coalesced per node.
+	heldConnectivity map[ids.NodeID]*heldConnectivity
+	// rebootstrapped is set once the chain has been moved back into state sync
+	// or bootstrapping.
+	rebootstrapped atomic.Bool
+
 	closeOnce            sync.Once
 	startClosingTime     time.Time
 	totalClosingTime     time.Duration
@@ -283,6 +322,307 @@ func (h *handler) Start(ctx context.Context, recoverPanic bool) {
 	}
 }
 
+// heldMessage is a message that was received while the chain was paused.
+type heldMessage struct {
+	ctx context.Context
+	msg Message
+}
+
+// heldConnectivity is the net change in connectivity of a node while the chain
+// was paused. If both are set, [disconnected] is handled before [connected].
+type heldConnectivity struct {
+	disconnected *heldMessage
+	connected    *heldMessage
+}
+
+func (h *handler) Pause(context.Context) error {
+	h.pauseLock.Lock()
+	defer h.pauseLock.Unlock()
+
+	if h.paused.Halted() {
+		return errAlreadyPaused
+	}
+
+	h.ctx.Log.Info("pausing chain")
+	h.paused.Halt()
+	h.resumed = make(chan struct{})
+	h.heldConnectivity = make(map[ids.NodeID]*heldConnectivity)
+	return nil
+}
+
+func (h *handler) Resume(ctx context.Context) error {
+	h.pauseLock.Lock()
+	if !h.paused.Halted() {
+		h.pauseLock.Unlock()
+		return errNotPaused
+	}
+
+	h.ctx.Log.Info("resuming chain",
+		zap.Int("numHeldMessages", len(h.heldSyncMsgs)+len(h.heldAsyncMsgs)),
+		zap.Int("numHeldConnectivityChanges", len(h.heldConnectivity)),
+	)
+	h.paused.Resume()
+	close(h.resumed)
+	h.resumed = nil
+	heldSyncMsgs := h.heldSyncMsgs
+	heldAsyncMsgs := h.heldAsyncMsgs
+	heldConnectivity := h.heldConnectivity
+	h.heldSyncMsgs = nil
+	h.heldAsyncMsgs = nil
+	h.numHeldResponses = 0
+	h.heldConnectivity = nil
+	h.pauseLock.Unlock()
+
+	// Connectivity changes are handled first so that the engine's view of its
+	// peers is up to date before it handles the held responses.
+	for _, held := range heldConnectivity {
+		if held.disconnected != nil {
+			h.syncMessageQueue.Push(held.disconnected.ctx, held.disconnected.msg)
+		}
+		if held.connected != nil {
+			h.syncMessageQueue.Push(held.connected.ctx, held.connected.msg)
+		}
+	}
+	for _, held := range heldSyncMsgs {
+		h.syncMessageQueue.Push(held.ctx, held.msg)
+	}
+	for _, held := range heldAsyncMsgs {
+		h.asyncMessageQueue.Push(held.ctx, held.msg)
+	}
+	return nil
+}
+
+func (h *handler) Paused() bool {
+	return h.paused.Halted()
+}
+
+func (h *handler) Rebootstrap(ctx context.Context, stateSync bool) error {
+	// The context lock is held for the whole transition so that the running
+	// engine can't race with the bootstrapper's database being cleared.
+	h.ctx.Lock.Lock()
+	defer h.ctx.Lock.Unlock()
+
+	state := h.ctx.State.Get()
+	if state.Type != p2ppb.EngineType_ENGINE_TYPE_SNOWMAN {
+		return fmt.Errorf("%w: running %s", errRebootstrapUnsupported, state.Type)
+	}
+
+	engines := h.engineManager.Get(state.Type)
+	current, ok := engines.Get(state.State)
+	if !ok {
+		return fmt.Errorf(
+			"%w %s running %s",
+			errMissingEngine,
+			state.State,
+			state.Type,
+		)
+	}
+
+	var gear common.Engine = engines.Bootstrapper
+	if stateSync {
+		if engines.StateSyncer == nil {
+			return errStateSyncDisabled
+		}
+
+		stateSyncEnabled, err := engines.StateSyncer.IsEnabled(ctx)
+		if err != nil {
+			return err
+		}
+		if !stateSyncEnabled {
+			return errStateSyncDisabled
+		}
+
+		// drop bootstrap state from previous runs before starting state sync
+		if err := engines.Bootstrapper.Clear(ctx); err != nil {
+			return err
+		}
+		gear = engines.StateSyncer
+	}
+
+	h.ctx.Log.Info("re-bootstrapping chain",
+		zap.Stringer("state", state.State),
+		zap.Bool("stateSync", stateSync),
+	)
+
+	h.rebootstrapped.Store(true)
+
+	// Any error here leaves the chain in an unknown state, so the chain is
+	// shutdown.
+	if err := current.Abandon(ctx); err != nil {
+		h.StopWithError(ctx, fmt.Errorf("failed to abandon processing work: %w", err))
+		return err
+	}
+
+	// Continue from the current engine's request ID so that responses to its
+	// outstanding requests aren't mistaken for responses to the new ones.
+	//
+	// Any error here leaves the chain in an unknown state, so the chain is
+	// shutdown.
+	if err := gear.Start(ctx, current.LastRequestID()); err != nil {
+		h.StopWithError(ctx, fmt.Errorf("failed to re-bootstrap chain: %w", err))
+		return err
+	}
+	return nil
+}
+
+// holdIfPaused returns true if [msg] shouldn't be handled because the chain is
+// paused. Unrequested messages are dropped. All other messages, such as
+// responses, failures and connectivity updates, are held so that they can be
+// handled once the chain is resumed:
+//   - Connected and Disconnected messages are coalesced per node.
+//   - Once [maxHeldResponses] responses are held, further responses are
+//     replaced by their failure messages so that every outstanding request is
+//     still answered without holding more throttler bytes.
+func (h *handler) holdIfPaused(ctx context.Context, msg Message, held *[]heldMessage) bool {
+	if !h.paused.Halted() {
+		return false
+	}
+
+	h.pauseLock.Lock()
+	defer h.pauseLock.Unlock()
+
+	// The chain may have been resumed after the first check.
+	if !h.paused.Halted() {
+		return false
+	}
+
+	op := msg.Op()
+	switch {
+	case message.UnrequestedOps.Contains(op):
+		h.ctx.Log.Debug("dropping message",
+			zap.String("reason", "chain paused"),
+			zap.Stringer("nodeID", msg.NodeID()),
+			zap.Stringer("messageOp", op),
+		)
+		msg.OnFinishedHandling()
+		return true
+	case op == message.ConnectedOp || op == message.DisconnectedOp:
+		h.holdConnectivity(ctx, msg)
+		return true
+	}
+
+	if failedMsg, ok := failedMessage(h.ctx.ChainID, msg); ok {
+		if h.numHeldResponses >= maxHeldResponses {
+			h.ctx.Log.Debug("replacing held response",
+				zap.String("reason", "too many held responses"),
+				zap.Stringer("nodeID", msg.NodeID()),
+				zap.Stringer("messageOp", op),
+				zap.Stringer("failedOp", failedMsg.Op()),
+			)
+			msg.OnFinishedHandling()
+			msg = failedMsg
+		} else {
+			h.numHeldResponses++
+		}
+	}
+
+	*held = append(*held, heldMessage{
+		ctx: ctx,
+		msg: msg,
+	})
+	return true
+}
+
+// holdConnectivity records a Connected or Disconnected message while the chain
+// is paused. A node that connects and then disconnects while the chain is
+// paused has no net change, so neither message is handled.
+//
+// Assumes [h.pauseLock] is held.
+func (h *handler) holdConnectivity(ctx context.Context, msg Message) {
+	nodeID := msg.NodeID()
+	connectivity, ok := h.heldConnectivity[nodeID]
+	if !ok {
+		connectivity = &heldConnectivity{}
+		h.heldConnectivity[nodeID] = connectivity
+	}
+
+	held := &heldMessage{
+		ctx: ctx,
+		msg: msg,
+	}
+	switch {
+	case msg.Op() == message.ConnectedOp:
+		connectivity.connected = held
+	case connectivity.connected != nil:
+		connectivity.connected = nil
+	default:
+		connectivity.disconnected = held
+	}
+
+	if connectivity.connected == nil && connectivity.disconnected == nil {
+		delete(h.heldConnectivity, nodeID)
+	}
+}
+
+// failedMessage returns the failure message that the engine expects in place
+// of the response [msg]. Returns false if [msg] isn't a response.
+func failedMessage(chainID ids.ID, msg Message) (Message, bool) {
+	nodeID := msg.NodeID()
+	requestID, _ := message.GetRequestID(msg.Message())
+
+	var failedMsg message.InboundMessage
+	switch op := msg.Op(); op {
+	case message.StateSummaryFrontierOp:
+		failedMsg = message.InternalGetStateSummaryFrontierFailed(nodeID, chainID, requestID)
+	case message.AcceptedStateSummaryOp:
+		failedMsg = message.InternalGetAcceptedStateSummaryFailed(nodeID, chainID, requestID)
+	case message.AcceptedFrontierOp:
+		failedMsg = message.InternalGetAcceptedFrontierFailed(nodeID, chainID, requestID)
+	case message.AcceptedOp:
+		failedMsg = message.InternalGetAcceptedFailed(nodeID, chainID, requestID)
+	case message.AncestorsOp:
+		failedMsg = message.InternalGetAncestorsFailed(nodeID, chainID, requestID, msg.EngineType)
+	case message.PutOp:
+		failedMsg = message.InternalGetFailed(nodeID, chainID, requestID)
+	case message.ChitsOp:
+		failedMsg = message.InternalQueryFailed(nodeID, chainID, requestID)
+	case message.AppResponseOp:
+		failedMsg = message.InboundAppError(
+			nodeID,
+			chainID,
+			requestID,
+			common.ErrTimeout.Code,
+			common.ErrTimeout.Message,
+		)
+	case message.AppErrorOp:
+		appErr, ok := msg.Message().(*p2ppb.AppError)
+		if !ok {
+			return Message{}, false
+		}
+		failedMsg = message.InboundAppError(
+			nodeID,
+			chainID,
+			requestID,
+			appErr.ErrorCode,
+			appErr.ErrorMessage,
+		)
+	default:
+		return Message{}, false
+	}
+	return Message{
+		InboundMessage: failedMsg,
+		EngineType:     msg.EngineType,
+	}, true
+}
+
+// awaitResume blocks until the chain isn't paused. Returns false if the handler
+// was stopped first.
+func (h *handler) awaitResume() bool {
+	h.pauseLock.Lock()
+	resumed := h.resumed
+	h.pauseLock.Unlock()
+	if resumed == nil {
+		return true
+	}
+
+	select {
+	case <-resumed:
+		return true
+	case <-h.closingChan:
+		return false
+	}
+}
+
 // Push the message onto the handler's queue
 func (h *handler) Push(ctx context.Context, msg Message) {
 	switch msg.Op() {
@@ -330,6 +670,20 @@ func (h *handler) Stop(_ context.Context) {
 		h.asyncMessageQueue.Shutdown()
 		close(h.closingChan)
 		h.haltBootstrapping()
+
+		// Messages held while the chain was paused will never be handled.
+		h.pauseLock.Lock()
+		for _, held := range h.heldSyncMsgs {
+			held.msg.OnFinishedHandling()
+		}
+		for _, held := range h.heldAsyncMsgs {
+			held.msg.OnFinishedHandling()
+		}
+		h.heldSyncMsgs = nil
+		h.heldAsyncMsgs = nil
+		h.numHeldResponses = 0
+		h.heldConnectivity = nil
+		h.pauseLock.Unlock()
 	})
 }
 
@@ -367,6 +721,9 @@ func (h *handler) dispatchSync(ctx context.Context) {
 		if !ok {
 			return
 		}
+		if h.holdIfPaused(ctx, msg, &h.heldSyncMsgs) {
+			continue
+		}
 
 		// If there is an error handling the message, shut down the chain
 		if err := h.handleSyncMsg(ctx, msg); err != nil {
@@ -397,6 +754,9 @@ func (h *handler) dispatchAsync(ctx context.Context) {
 		if !ok {
 			return
 		}
+		if h.holdIfPaused(ctx, msg, &h.heldAsyncMsgs) {
+			continue
+		}
 
 		h.handleAsyncMsg(ctx, msg)
 	}
@@ -426,6 +786,12 @@ func (h *handler) dispatchChans(ctx context.Context) {
 			msg = message.InternalTimeout(h.ctx.NodeID)
 		}
 
+		// Messages generated while the chain is paused are handled once the
+		// chain is resumed.
+		if !h.awaitResume() {
+			return
+		}
+
 		if err := h.handleChanMsg(msg); err != nil {
 			h.StopWithError(ctx, fmt.Errorf(
 				"%w while processing chan message: %s",
@@ -653,3 +653,318 @@ func TestHandlerStartError(t *testing.T) {
 	_, err = handler.AwaitStopped(context.Background())
 	require.NoError(err)
 }
+
+func TestHandlerPause(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	msgFromVMChan := make(chan common.Message)
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, ids.GenerateTestNodeID(), nil, ids.Empty, 1))
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+
+	peerTracker, err := p2p.NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		version.CurrentApp,
+	)
+	require.NoError(err)
+
+	handler, err := New(
+		ctx,
+		vdrs,
+		msgFromVMChan,
+		time.Hour,
+		testThreadPoolSize,
+		resourceTracker,
+		subnets.New(ctx.NodeID, subnets.Config{}),
+		commontracker.NewPeers(),
+		peerTracker,
+		prometheus.NewRegistry(),
+		func() {},
+	)
+	require.NoError(err)
+
+	bootstrapper := &enginetest.Bootstrapper{
+		Engine: enginetest.Engine{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+
+	var (
+		pulled    = make(chan struct{}, 1)
+		getFailed = make(chan struct{}, 1)
+		notified  = make(chan struct{}, 1)
+	)
+	engine := &enginetest.Engine{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+	engine.PullQueryF = func(context.Context, ids.NodeID, uint32, ids.ID, uint64) error {
+		pulled <- struct{}{}
+		return nil
+	}
+	engine.GetFailedF = func(context.Context, ids.NodeID, uint32) error {
+		getFailed <- struct{}{}
+		return nil
+	}
+	engine.NotifyF = func(context.Context, common.Message) error {
+		notified <- struct{}{}
+		return nil
+	}
+
+	handler.SetEngineManager(&EngineManager{
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    engine,
+		},
+	})
+
+	ctx.State.Set(snow.EngineState{
+		Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.NormalOp, // assumed bootstrap is done
+	})
+
+	bootstrapper.StartF = func(context.Context, uint32) error {
+		return nil
+	}
+
+	handler.Start(context.Background(), false)
+
+	require.ErrorIs(handler.Resume(context.Background()), errNotPaused)
+	require.NoError(handler.Pause(context.Background()))
+	require.ErrorIs(handler.Pause(context.Background()), errAlreadyPaused)
+	require.True(handler.Paused())
+
+	_, err = handler.HealthCheck(context.Background())
+	require.ErrorIs(err, ErrPaused)
+
+	// The pull query should be dropped, while the response should be held
+	// until the chain is resumed.
+	nodeID := ids.GenerateTestNodeID()
+	handler.Push(context.Background(), Message{
+		InboundMessage: message.InboundPullQuery(ctx.ChainID, 1, time.Hour, ids.GenerateTestID(), 0, nodeID),
+		EngineType:     p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+	})
+	handler.Push(context.Background(), Message{
+		InboundMessage: message.InternalGetFailed(nodeID, ctx.ChainID, 2),
+		EngineType:     p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+	})
+	msgFromVMChan <- common.PendingTxs
+	require.Eventually(func() bool {
+		return handler.Len() == 0
+	}, time.Second, time.Millisecond)
+	require.Empty(getFailed)
+	require.Empty(notified)
+
+	require.NoError(handler.Resume(context.Background()))
+	require.False(handler.Paused())
+	<-getFailed
+	<-notified
+	require.Empty(pulled)
+
+	handler.Stop(context.Background())
+	_, err = handler.AwaitStopped(context.Background())
+	require.NoError(err)
+}
+
+func TestHandlerRebootstrap(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+
+	peerTracker, err := p2p.NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		version.CurrentApp,
+	)
+	require.NoError(err)
+
+	handler, err := New(
+		ctx,
+		validators.NewManager(),
+		nil,
+		time.Second,
+		testThreadPoolSize,
+		resourceTracker,
+		subnets.New(ctx.NodeID, subnets.Config{}),
+		commontracker.NewPeers(),
+		peerTracker,
+		prometheus.NewRegistry(),
+		func() {},
+	)
+	require.NoError(err)
+
+	bootstrapper := &enginetest.Bootstrapper{
+		Engine: enginetest.Engine{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+
+	const lastRequestID = 5
+	engine := &enginetest.Engine{T: t}
+	engine.Default(false)
+	engine.LastRequestIDF = func() uint32 {
+		return lastRequestID
+	}
+	var abandoned bool
+	engine.AbandonF = func(context.Context) error {
+		abandoned = true
+		return nil
+	}
+
+	var numStarts int
+	bootstrapper.StartF = func(_ context.Context, startReqID uint32) error {
+		require.Equal(uint32(lastRequestID), startReqID)
+		numStarts++
+		ctx.State.Set(snow.EngineState{
+			Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+			State: snow.Bootstrapping,
+		})
+		return nil
+	}
+
+	handler.SetEngineManager(&EngineManager{
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    engine,
+		},
+	})
+
+	ctx.State.Set(snow.EngineState{
+		Type:  p2ppb.EngineType_ENGINE_TYPE_AVALANCHE,
+		State: snow.NormalOp,
+	})
+	require.ErrorIs(handler.Rebootstrap(context.Background(), false), errRebootstrapUnsupported)
+
+	ctx.State.Set(snow.EngineState{
+		Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.NormalOp,
+	})
+	require.ErrorIs(handler.Rebootstrap(context.Background(), true), errStateSyncDisabled)
+	require.Zero(numStarts)
+
+	require.NoError(handler.Rebootstrap(context.Background(), false))
+	require.True(abandoned)
+	require.Equal(1, numStarts)
+	require.Equal(snow.Bootstrapping, ctx.State.Get().State)
+
+	_, err = handler.HealthCheck(context.Background())
+	require.ErrorIs(err, ErrRebootstrapping)
+}
+
+func TestHandlerPauseHeldMessages(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+
+	peerTracker, err := p2p.NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		version.CurrentApp,
+	)
+	require.NoError(err)
+
+	handlerIntf, err := New(
+		ctx,
+		validators.NewManager(),
+		nil,
+		time.Second,
+		testThreadPoolSize,
+		resourceTracker,
+		subnets.New(ctx.NodeID, subnets.Config{}),
+		commontracker.NewPeers(),
+		peerTracker,
+		prometheus.NewRegistry(),
+		func() {},
+	)
+	require.NoError(err)
+	h := handlerIntf.(*handler)
+
+	require.NoError(h.Pause(context.Background()))
+
+	// Responses beyond the limit are replaced by their failure messages, so
+	// that every outstanding request is still answered.
+	nodeID := ids.GenerateTestNodeID()
+	for i := 0; i < maxHeldResponses+1; i++ {
+		msg := Message{
+			InboundMessage: message.InboundChits(ctx.ChainID, uint32(i), ids.Empty, ids.Empty, ids.Empty, nodeID),
+			EngineType:     p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+		}
+		require.True(h.holdIfPaused(context.Background(), msg, &h.heldSyncMsgs))
+	}
+	require.Len(h.heldSyncMsgs, maxHeldResponses+1)
+	require.Equal(message.ChitsOp, h.heldSyncMsgs[maxHeldResponses-1].msg.Op())
+
+	lastHeld := h.heldSyncMsgs[maxHeldResponses].msg
+	require.Equal(message.QueryFailedOp, lastHeld.Op())
+	require.Equal(nodeID, lastHeld.NodeID())
+	requestID, ok := message.GetRequestID(lastHeld.Message())
+	require.True(ok)
+	require.Equal(uint32(maxHeldResponses), requestID)
+
+	// Failure messages are always held.
+	failedMsg := Message{
+		InboundMessage: message.InternalGetFailed(nodeID, ctx.ChainID, maxHeldResponses+1),
+		EngineType:     p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+	}
+	require.True(h.holdIfPaused(context.Background(), failedMsg, &h.heldSyncMsgs))
+	require.Len(h.heldSyncMsgs, maxHeldResponses+2)
+
+	// Connectivity changes are coalesced per node.
+	var (
+		reconnectedNodeID = ids.GenerateTestNodeID()
+		transientNodeID   = ids.GenerateTestNodeID()
+	)
+	for _, msg := range []message.InboundMessage{
+		message.InternalDisconnected(reconnectedNodeID),
+		message.InternalConnected(reconnectedNodeID, version.CurrentApp),
+		message.InternalDisconnected(reconnectedNodeID),
+		message.InternalConnected(reconnectedNodeID, version.CurrentApp),
+		message.InternalConnected(transientNodeID, version.CurrentApp),
+		message.InternalDisconnected(transientNodeID),
+	} {
+		require.True(h.holdIfPaused(context.Background(), Message{InboundMessage: msg}, &h.heldSyncMsgs))
+	}
+	require.Len(h.heldSyncMsgs, maxHeldResponses+2)
+	require.Len(h.heldConnectivity, 1)
+	connectivity := h.heldConnectivity[reconnectedNodeID]
+	require.Equal(message.DisconnectedOp, connectivity.disconnected.msg.Op())
+	require.Equal(message.ConnectedOp, connectivity.connected.msg.Op())
+
+	require.NoError(h.Resume(context.Background()))
+	require.Equal(maxHeldResponses+4, h.Len())
+}
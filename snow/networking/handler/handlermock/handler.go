@@ -115,6 +115,34 @@ func (mr *HandlerMockRecorder) Len() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Len", reflect.TypeOf((*Handler)(nil).Len))
 }
 
+// Pause mocks base method.
+func (m *Handler) Pause(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *HandlerMockRecorder) Pause(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*Handler)(nil).Pause), arg0)
+}
+
+// Paused mocks base method.
+func (m *Handler) Paused() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Paused")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Paused indicates an expected call of Paused.
+func (mr *HandlerMockRecorder) Paused() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Paused", reflect.TypeOf((*Handler)(nil).Paused))
+}
+
 // Push mocks base method.
 func (m *Handler) Push(arg0 context.Context, arg1 handler.Message) {
 	m.ctrl.T.Helper()
@@ -127,6 +155,20 @@ func (mr *HandlerMockRecorder) Push(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*Handler)(nil).Push), arg0, arg1)
 }
 
+// Rebootstrap mocks base method.
+func (m *Handler) Rebootstrap(arg0 context.Context, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebootstrap", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rebootstrap indicates an expected call of Rebootstrap.
+func (mr *HandlerMockRecorder) Rebootstrap(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebootstrap", reflect.TypeOf((*Handler)(nil).Rebootstrap), arg0, arg1)
+}
+
 // RegisterTimeout mocks base method.
 func (m *Handler) RegisterTimeout(arg0 time.Duration) {
 	m.ctrl.T.Helper()
@@ -139,6 +181,20 @@ func (mr *HandlerMockRecorder) RegisterTimeout(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTimeout", reflect.TypeOf((*Handler)(nil).RegisterTimeout), arg0)
 }
 
+// Resume mocks base method.
+func (m *Handler) Resume(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *HandlerMockRecorder) Resume(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*Handler)(nil).Resume), arg0)
+}
+
 // SetEngineManager mocks base method.
 func (m *Handler) SetEngineManager(arg0 *handler.EngineManager) {
 	m.ctrl.T.Helper()
@@ -9,10 +9,15 @@ import (
 	"fmt"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 )
 
-var ErrNotConnectedEnoughStake = errors.New("not connected to enough stake")
+var (
+	ErrNotConnectedEnoughStake = errors.New("not connected to enough stake")
+	ErrPaused                  = errors.New("chain is paused")
+	ErrRebootstrapping         = errors.New("chain is re-bootstrapping")
+)
 
 func (h *handler) HealthCheck(ctx context.Context) (interface{}, error) {
 	state := h.ctx.State.Get()
@@ -30,6 +35,16 @@ func (h *handler) HealthCheck(ctx context.Context) (interface{}, error) {
 	intf := map[string]interface{}{
 		"engine":     engineIntf,
 		"networking": networkingIntf,
+		"state":      state.State.String(),
+	}
+	if h.Paused() {
+		intf["paused"] = true
+		return intf, ErrPaused
+	}
+	// The initial bootstrapping of a chain is reported by the bootstrapped
+	// health check, so only re-bootstrapping is reported here.
+	if h.rebootstrapped.Load() && state.State != snow.NormalOp {
+		return intf, ErrRebootstrapping
 	}
 	if engineErr == nil {
 		return intf, networkingErr
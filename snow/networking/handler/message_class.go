@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handler
+
+import "github.com/MetalBlockchain/metalgo/message"
+
+const (
+	consensusClass messageClass = iota
+	bootstrapClass
+	appRequestClass
+	appGossipClass
+
+	numMessageClasses
+)
+
+// classWeights is the number of messages of each class that are popped in a
+// round of the message queue, if the class has messages.
+var classWeights = [numMessageClasses]int{
+	consensusClass:  4,
+	bootstrapClass:  2,
+	appRequestClass: 2,
+	appGossipClass:  1,
+}
+
+// messageClass groups messages that are scheduled together in the message
+// queue.
+type messageClass int
+
+func messageClassOf(op message.Op) messageClass {
+	switch op {
+	case message.GetStateSummaryFrontierOp, message.GetStateSummaryFrontierFailedOp, message.StateSummaryFrontierOp,
+		message.GetAcceptedStateSummaryOp, message.GetAcceptedStateSummaryFailedOp, message.AcceptedStateSummaryOp,
+		message.GetAcceptedFrontierOp, message.GetAcceptedFrontierFailedOp, message.AcceptedFrontierOp,
+		message.GetAcceptedOp, message.GetAcceptedFailedOp, message.AcceptedOp,
+		message.GetAncestorsOp, message.GetAncestorsFailedOp, message.AncestorsOp:
+		return bootstrapClass
+	case message.AppRequestOp, message.AppErrorOp, message.AppResponseOp:
+		return appRequestClass
+	case message.AppGossipOp:
+		return appGossipClass
+	default:
+		return consensusClass
+	}
+}
+
+func (c messageClass) String() string {
+	switch c {
+	case consensusClass:
+		return "consensus"
+	case bootstrapClass:
+		return "bootstrap"
+	case appRequestClass:
+		return "app_request"
+	case appGossipClass:
+		return "app_gossip"
+	default:
+		return "unknown"
+	}
+}
@@ -6,6 +6,7 @@ package handler
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -52,9 +53,19 @@ type MessageQueue interface {
 	Shutdown()
 }
 
-// TODO: Use a better data structure for this.
-// We can do something better than pushing to the back of a queue. A multi-level
-// queue?
+// minQuantum is the smallest share of a round that a peer is allotted, so that
+// peers that have used excessive CPU are still served eventually.
+const minQuantum = .01
+
+// messageQueue is a multi-level queue. Messages are grouped into classes, which
+// are served in weighted round-robin order. Within a class, messages are
+// queued per peer and the peers are served with deficit round-robin, where
+// the quantum of a peer grows with its stake and shrinks if it has recently
+// caused excessive CPU usage.
+//
+// The messages of a peer are only popped in the order that they were pushed
+// if they are of the same class. Messages of different classes may be
+// reordered, as each class is served in turn.
 type messageQueue struct {
 	// Useful for faking time in tests
 	clock   mockable.Clock
@@ -69,10 +80,17 @@ type messageQueue struct {
 
 	cond   *sync.Cond
 	closed bool
-	// Node ID --> Messages this node has in [msgs]
+	// Node ID --> Messages this node has in [classes]
 	nodeToUnprocessedMsgs map[ids.NodeID]int
-	// Unprocessed messages
-	msgAndCtxs buffer.Deque[*msgAndContext]
+	// Unprocessed messages of each class
+	classes [numMessageClasses]*classQueue
+	// Number of messages in [classes]
+	numMsgs int
+	// Class that is currently being served
+	currentClass messageClass
+	// Number of messages that [currentClass] can pop before the next class is
+	// served
+	classCredits int
 }
 
 func NewMessageQueue(
@@ -90,7 +108,11 @@ func NewMessageQueue(
 		cpuTracker:            cpuTracker,
 		cond:                  sync.NewCond(&sync.Mutex{}),
 		nodeToUnprocessedMsgs: make(map[ids.NodeID]int),
-		msgAndCtxs:            buffer.NewUnboundedDeque[*msgAndContext](1 /*=initSize*/),
+		currentClass:          consensusClass,
+		classCredits:          classWeights[consensusClass],
+	}
+	for i := range m.classes {
+		m.classes[i] = newClassQueue()
 	}
 	return m, m.metrics.initialize(metricsNamespace, reg)
 }
@@ -105,24 +127,28 @@ func (m *messageQueue) Push(ctx context.Context, msg Message) {
 	}
 
 	// Add the message to the queue
-	m.msgAndCtxs.PushRight(&msgAndContext{
-		msg: msg,
-		ctx: ctx,
+	class := messageClassOf(msg.Op())
+	m.classes[class].push(&msgAndContext{
+		msg:      msg,
+		ctx:      ctx,
+		pushTime: m.clock.Time(),
 	})
+	m.numMsgs++
 	m.nodeToUnprocessedMsgs[msg.NodeID()]++
 
 	// Update metrics
 	m.metrics.count.With(prometheus.Labels{
 		opLabel: msg.Op().String(),
 	}).Inc()
+	m.metrics.classCount.With(prometheus.Labels{
+		classLabel: class.String(),
+	}).Inc()
 	m.metrics.nodesWithMessages.Set(float64(len(m.nodeToUnprocessedMsgs)))
 
 	// Signal a waiting thread
 	m.cond.Signal()
 }
 
-// FIFO, but skip over messages whose senders whose messages have caused us to
-// use excessive CPU recently.
 func (m *messageQueue) Pop() (context.Context, Message, bool) {
 	m.cond.L.Lock()
 	defer m.cond.L.Unlock()
@@ -131,53 +157,42 @@ func (m *messageQueue) Pop() (context.Context, Message, bool) {
 		if m.closed {
 			return nil, Message{}, false
 		}
-		if m.msgAndCtxs.Len() != 0 {
+		if m.numMsgs != 0 {
 			break
 		}
 		m.cond.Wait()
 	}
 
-	n := m.msgAndCtxs.Len() // note that n > 0
-	i := 0
-	for {
-		if i == n {
-			m.log.Debug("canPop is false for all unprocessed messages",
-				zap.Int("numMessages", n),
-			)
-		}
-
-		var (
-			msgAndCtx, _ = m.msgAndCtxs.PopLeft()
-			msg          = msgAndCtx.msg
-			ctx          = msgAndCtx.ctx
-			nodeID       = msg.NodeID()
-		)
+	var (
+		class     = m.nextClass()
+		msgAndCtx = m.classes[class].pop(m.quantum)
+		msg       = msgAndCtx.msg
+		nodeID    = msg.NodeID()
+	)
+	m.numMsgs--
+	m.nodeToUnprocessedMsgs[nodeID]--
+	if m.nodeToUnprocessedMsgs[nodeID] == 0 {
+		delete(m.nodeToUnprocessedMsgs, nodeID)
+	}
 
-		// See if it's OK to process [msg] next
-		if m.canPop(msg) || i == n { // i should never == n but handle anyway as a fail-safe
-			m.nodeToUnprocessedMsgs[nodeID]--
-			if m.nodeToUnprocessedMsgs[nodeID] == 0 {
-				delete(m.nodeToUnprocessedMsgs, nodeID)
-			}
-			m.metrics.count.With(prometheus.Labels{
-				opLabel: msg.Op().String(),
-			}).Dec()
-			m.metrics.nodesWithMessages.Set(float64(len(m.nodeToUnprocessedMsgs)))
-			return ctx, msg, true
-		}
-		// [msg.nodeID] is causing excessive CPU usage.
-		// Push [msg] to back of [m.msgs] and handle it later.
-		m.msgAndCtxs.PushRight(msgAndCtx)
-		i++
-		m.metrics.numExcessiveCPU.Inc()
+	classLabels := prometheus.Labels{
+		classLabel: class.String(),
 	}
+	m.metrics.count.With(prometheus.Labels{
+		opLabel: msg.Op().String(),
+	}).Dec()
+	m.metrics.classCount.With(classLabels).Dec()
+	m.metrics.popped.With(classLabels).Inc()
+	m.metrics.waitTime.With(classLabels).Add(float64(m.clock.Time().Sub(msgAndCtx.pushTime)))
+	m.metrics.nodesWithMessages.Set(float64(len(m.nodeToUnprocessedMsgs)))
+	return msgAndCtx.ctx, msg, true
 }
 
 func (m *messageQueue) Len() int {
 	m.cond.L.Lock()
 	defer m.cond.L.Unlock()
 
-	return m.msgAndCtxs.Len()
+	return m.numMsgs
 }
 
 func (m *messageQueue) Shutdown() {
@@ -185,14 +200,17 @@ func (m *messageQueue) Shutdown() {
 	defer m.cond.L.Unlock()
 
 	// Remove all the current messages from the queue
-	for m.msgAndCtxs.Len() > 0 {
-		msgAndCtx, _ := m.msgAndCtxs.PopLeft()
-		msgAndCtx.msg.OnFinishedHandling()
+	for _, classQueue := range m.classes {
+		for _, msgAndCtx := range classQueue.clear() {
+			msgAndCtx.msg.OnFinishedHandling()
+		}
 	}
+	m.numMsgs = 0
 	m.nodeToUnprocessedMsgs = nil
 
 	// Update metrics
 	m.metrics.count.Reset()
+	m.metrics.classCount.Reset()
 	m.metrics.nodesWithMessages.Set(0)
 
 	// Mark the queue as closed
@@ -200,22 +218,28 @@ func (m *messageQueue) Shutdown() {
 	m.cond.Broadcast()
 }
 
-// canPop will return true for at least one message in [m.msgs]
-func (m *messageQueue) canPop(msg message.InboundMessage) bool {
-	// Always pop connected and disconnected messages.
-	if op := msg.Op(); op == message.ConnectedOp || op == message.DisconnectedOp {
-		return true
+// nextClass returns the class to pop the next message from.
+//
+// Assumes that there is at least one message in the queue.
+func (m *messageQueue) nextClass() messageClass {
+	for {
+		if m.classCredits > 0 && m.classes[m.currentClass].len() > 0 {
+			m.classCredits--
+			return m.currentClass
+		}
+		m.currentClass = (m.currentClass + 1) % numMessageClasses
+		m.classCredits = classWeights[m.currentClass]
 	}
+}
 
-	// If the deadline to handle [msg] has passed, always pop it.
-	// It will be dropped immediately.
-	if expiration := msg.Expiration(); m.clock.Time().After(expiration) {
-		return true
-	}
-	// Every node has some allowed CPU allocation depending on
-	// the number of nodes with unprocessed messages.
-	baseMaxCPU := 1 / float64(len(m.nodeToUnprocessedMsgs))
-	nodeID := msg.NodeID()
+// quantum returns the number of messages that [nodeID] is allotted in a round
+// of a class that has [numPeers] peers with messages.
+//
+// Every peer is allotted an equal share of the round, which is increased by
+// its portion of the stake. If the peer's recent CPU usage exceeds its share,
+// the allotment is reduced proportionally.
+func (m *messageQueue) quantum(nodeID ids.NodeID, numPeers int) float64 {
+	baseShare := 1 / float64(numPeers)
 	weight := m.vdrs.GetWeight(m.subnetID, nodeID)
 
 	var portionWeight float64
@@ -236,13 +260,109 @@ func (m *messageQueue) canPop(msg message.InboundMessage) bool {
 		portionWeight = float64(weight) / float64(totalVdrsWeight)
 	}
 
-	// Validators are allowed to use more CPU. More weight --> more CPU use allowed.
+	// Validators are allowed a larger share. More weight --> larger share.
+	share := baseShare + (1.0-baseShare)*portionWeight
+	quantum := share * float64(numPeers)
+
 	recentCPUUsage := m.cpuTracker.Usage(nodeID, m.clock.Time())
-	maxCPU := baseMaxCPU + (1.0-baseMaxCPU)*portionWeight
-	return recentCPUUsage <= maxCPU
+	if recentCPUUsage > share {
+		m.metrics.numExcessiveCPU.Inc()
+		quantum *= share / recentCPUUsage
+	}
+	return max(quantum, minQuantum)
 }
 
 type msgAndContext struct {
-	msg Message
-	ctx context.Context
+	msg      Message
+	ctx      context.Context
+	pushTime time.Time
+}
+
+// classQueue queues the messages of a class per peer and serves the peers with
+// deficit round-robin.
+type classQueue struct {
+	// Peers with messages, in the order that they will be served
+	peers buffer.Deque[*peerQueue]
+	// Node ID --> Messages of the node
+	nodeToPeer map[ids.NodeID]*peerQueue
+	numMsgs    int
+}
+
+type peerQueue struct {
+	nodeID ids.NodeID
+	msgs   buffer.Deque[*msgAndContext]
+	// Number of messages the peer can pop before the next peer is served
+	deficit float64
+}
+
+func newClassQueue() *classQueue {
+	return &classQueue{
+		peers:      buffer.NewUnboundedDeque[*peerQueue](1 /*=initSize*/),
+		nodeToPeer: make(map[ids.NodeID]*peerQueue),
+	}
+}
+
+func (c *classQueue) len() int {
+	return c.numMsgs
+}
+
+func (c *classQueue) push(msgAndCtx *msgAndContext) {
+	nodeID := msgAndCtx.msg.NodeID()
+	peer, ok := c.nodeToPeer[nodeID]
+	if !ok {
+		peer = &peerQueue{
+			nodeID: nodeID,
+			msgs:   buffer.NewUnboundedDeque[*msgAndContext](1 /*=initSize*/),
+		}
+		c.nodeToPeer[nodeID] = peer
+		c.peers.PushRight(peer)
+	}
+	peer.msgs.PushRight(msgAndCtx)
+	c.numMsgs++
+}
+
+// pop removes the next message to be handled. Each time a peer reaches the
+// front of the round, its deficit is increased by the result of [quantum].
+//
+// Assumes that there is at least one message in the class.
+func (c *classQueue) pop(quantum func(nodeID ids.NodeID, numPeers int) float64) *msgAndContext {
+	for {
+		peer, _ := c.peers.PeekLeft()
+		if peer.deficit < 1 {
+			peer.deficit += quantum(peer.nodeID, c.peers.Len())
+		}
+		if peer.deficit < 1 {
+			// The peer hasn't accumulated enough of a deficit to be served
+			// this round.
+			c.peers.PopLeft()
+			c.peers.PushRight(peer)
+			continue
+		}
+
+		msgAndCtx, _ := peer.msgs.PopLeft()
+		peer.deficit--
+		c.numMsgs--
+		switch {
+		case peer.msgs.Len() == 0:
+			// Peers without messages don't keep their deficit.
+			c.peers.PopLeft()
+			delete(c.nodeToPeer, peer.nodeID)
+		case peer.deficit < 1:
+			c.peers.PopLeft()
+			c.peers.PushRight(peer)
+		}
+		return msgAndCtx
+	}
+}
+
+// clear removes and returns all the messages in the class.
+func (c *classQueue) clear() []*msgAndContext {
+	var msgs []*msgAndContext
+	for _, peer := range c.peers.List() {
+		msgs = append(msgs, peer.msgs.List()...)
+	}
+	c.peers = buffer.NewUnboundedDeque[*peerQueue](1 /*=initSize*/)
+	c.nodeToPeer = make(map[ids.NodeID]*peerQueue)
+	c.numMsgs = 0
+	return msgs
 }
@@ -11,12 +11,21 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/metric"
 )
 
-const opLabel = "op"
+const (
+	opLabel    = "op"
+	classLabel = "class"
+)
 
-var opLabels = []string{opLabel}
+var (
+	opLabels    = []string{opLabel}
+	classLabels = []string{classLabel}
+)
 
 type messageQueueMetrics struct {
 	count             *prometheus.GaugeVec
+	classCount        *prometheus.GaugeVec
+	popped            *prometheus.CounterVec
+	waitTime          *prometheus.GaugeVec
 	nodesWithMessages prometheus.Gauge
 	numExcessiveCPU   prometheus.Counter
 }
@@ -34,6 +43,30 @@ func (m *messageQueueMetrics) initialize(
 		},
 		opLabels,
 	)
+	m.classCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "class_count",
+			Help:      "messages in the queue of each class",
+		},
+		classLabels,
+	)
+	m.popped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "popped",
+			Help:      "messages of each class removed from the queue to be handled",
+		},
+		classLabels,
+	)
+	m.waitTime = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "wait_time",
+			Help:      "time (in ns) messages of each class spent in the queue before being handled",
+		},
+		classLabels,
+	)
 	m.nodesWithMessages = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "nodes",
@@ -42,11 +75,14 @@ func (m *messageQueueMetrics) initialize(
 	m.numExcessiveCPU = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: namespace,
 		Name:      "excessive_cpu",
-		Help:      "times a message has been deferred due to excessive CPU usage",
+		Help:      "times a node's share of a round has been reduced due to excessive CPU usage",
 	})
 
 	return errors.Join(
 		metricsRegisterer.Register(m.count),
+		metricsRegisterer.Register(m.classCount),
+		metricsRegisterer.Register(m.popped),
+		metricsRegisterer.Register(m.waitTime),
 		metricsRegisterer.Register(m.nodesWithMessages),
 		metricsRegisterer.Register(m.numExcessiveCPU),
 	)
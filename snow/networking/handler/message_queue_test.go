@@ -21,14 +21,15 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 )
 
-func TestQueue(t *testing.T) {
+func newTestMessageQueue(t *testing.T, vdrs validators.Manager, usage map[ids.NodeID]float64) *messageQueue {
 	ctrl := gomock.NewController(t)
-	require := require.New(t)
 	cpuTracker := trackermock.NewTracker(ctrl)
-	vdrs := validators.NewManager()
-	vdr1ID, vdr2ID := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
-	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, vdr1ID, nil, ids.Empty, 1))
-	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, vdr2ID, nil, ids.Empty, 1))
+	cpuTracker.EXPECT().Usage(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(nodeID ids.NodeID, _ time.Time) float64 {
+			return usage[nodeID]
+		},
+	).AnyTimes()
+
 	mIntf, err := NewMessageQueue(
 		logging.NoLog{},
 		constants.PrimaryNetworkID,
@@ -37,26 +38,48 @@ func TestQueue(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 	)
-	require.NoError(err)
-	u := mIntf.(*messageQueue)
-	currentTime := time.Now()
-	u.clock.Set(currentTime)
+	require.NoError(t, err)
+	return mIntf.(*messageQueue)
+}
 
-	msg1 := Message{
+func pullQuery(nodeID ids.NodeID, requestID uint32) Message {
+	return Message{
 		InboundMessage: message.InboundPullQuery(
 			ids.Empty,
-			0,
+			requestID,
 			time.Second,
-			ids.GenerateTestID(),
+			ids.Empty,
 			0,
-			vdr1ID,
+			nodeID,
 		),
 		EngineType: p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
 	}
+}
+
+// popNodeIDs pops [n] messages from [u] and returns their senders.
+func popNodeIDs(t *testing.T, u *messageQueue, n int) []ids.NodeID {
+	nodeIDs := make([]ids.NodeID, n)
+	for i := range nodeIDs {
+		_, msg, ok := u.Pop()
+		require.True(t, ok)
+		nodeIDs[i] = msg.NodeID()
+	}
+	return nodeIDs
+}
 
-	// Push then pop should work regardless of usage when there are no other
-	// messages on [u.msgs]
-	cpuTracker.EXPECT().Usage(vdr1ID, gomock.Any()).Return(0.1).Times(1)
+func TestQueue(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewManager()
+	vdr1ID := ids.GenerateTestNodeID()
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, vdr1ID, nil, ids.Empty, 1))
+	u := newTestMessageQueue(t, vdrs, map[ids.NodeID]float64{
+		// Push then pop should work regardless of usage when there are no
+		// other messages in the queue.
+		vdr1ID: 1,
+	})
+
+	msg1 := pullQuery(vdr1ID, 1)
 	u.Push(context.Background(), msg1)
 	require.Equal(1, u.nodeToUnprocessedMsgs[vdr1ID])
 	require.Equal(1, u.Len())
@@ -66,105 +89,146 @@ func TestQueue(t *testing.T) {
 	require.Zero(u.Len())
 	require.Equal(msg1, gotMsg1)
 
-	cpuTracker.EXPECT().Usage(vdr1ID, gomock.Any()).Return(0.0).Times(1)
+	// Messages of a peer are popped in the order they were pushed.
+	msg2 := pullQuery(vdr1ID, 2)
 	u.Push(context.Background(), msg1)
-	require.Equal(1, u.nodeToUnprocessedMsgs[vdr1ID])
-	require.Equal(1, u.Len())
+	u.Push(context.Background(), msg2)
+	require.Equal(2, u.nodeToUnprocessedMsgs[vdr1ID])
+	require.Equal(2, u.Len())
 	_, gotMsg1, ok = u.Pop()
 	require.True(ok)
-	require.Empty(u.nodeToUnprocessedMsgs)
-	require.Zero(u.Len())
 	require.Equal(msg1, gotMsg1)
-
-	cpuTracker.EXPECT().Usage(vdr1ID, gomock.Any()).Return(1.0).Times(1)
-	u.Push(context.Background(), msg1)
-	require.Equal(1, u.nodeToUnprocessedMsgs[vdr1ID])
-	require.Equal(1, u.Len())
-	_, gotMsg1, ok = u.Pop()
+	_, gotMsg2, ok := u.Pop()
 	require.True(ok)
+	require.Equal(msg2, gotMsg2)
 	require.Empty(u.nodeToUnprocessedMsgs)
 	require.Zero(u.Len())
-	require.Equal(msg1, gotMsg1)
+}
 
-	cpuTracker.EXPECT().Usage(vdr1ID, gomock.Any()).Return(0.0).Times(1)
-	u.Push(context.Background(), msg1)
-	require.Equal(1, u.nodeToUnprocessedMsgs[vdr1ID])
-	require.Equal(1, u.Len())
-	_, gotMsg1, ok = u.Pop()
-	require.True(ok)
-	require.Empty(u.nodeToUnprocessedMsgs)
-	require.Zero(u.Len())
-	require.Equal(msg1, gotMsg1)
+func TestQueueExcessiveCPU(t *testing.T) {
+	nodeID1, nodeID2 := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	u := newTestMessageQueue(t, validators.NewManager(), map[ids.NodeID]float64{
+		nodeID1: 1,
+		nodeID2: 0,
+	})
 
-	// Push msg1 from vdr1ID
-	u.Push(context.Background(), msg1)
-	require.Equal(1, u.nodeToUnprocessedMsgs[vdr1ID])
-	require.Equal(1, u.Len())
+	for i := uint32(0); i < 4; i++ {
+		u.Push(context.Background(), pullQuery(nodeID1, i))
+	}
+	for i := uint32(0); i < 4; i++ {
+		u.Push(context.Background(), pullQuery(nodeID2, i))
+	}
 
-	msg2 := Message{
-		InboundMessage: message.InboundPullQuery(
-			ids.Empty,
-			0,
-			time.Second,
-			ids.GenerateTestID(),
-			0,
-			vdr2ID,
-		),
-		EngineType: p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+	// Both peers are allotted half of the CPU. [nodeID1] has used twice its
+	// allotment, so it is only served every other round until [nodeID2] has no
+	// more messages.
+	require.Equal(
+		t,
+		[]ids.NodeID{
+			nodeID2, nodeID1, nodeID2, nodeID2, nodeID1, nodeID2, nodeID1, nodeID1,
+		},
+		popNodeIDs(t, u, 8),
+	)
+	require.Zero(t, u.Len())
+}
+
+func TestQueueStakeWeighted(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewManager()
+	vdrID, otherVdrID, nonVdrID := ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, vdrID, nil, ids.Empty, 1))
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, otherVdrID, nil, ids.Empty, 1))
+	u := newTestMessageQueue(t, vdrs, nil)
+
+	for i := uint32(0); i < 10; i++ {
+		u.Push(context.Background(), pullQuery(vdrID, i))
+		u.Push(context.Background(), pullQuery(nonVdrID, i))
 	}
 
-	// Push msg2 from vdr2ID
-	u.Push(context.Background(), msg2)
-	require.Equal(2, u.Len())
-	require.Equal(1, u.nodeToUnprocessedMsgs[vdr2ID])
-	// Set vdr1's usage to 99% and vdr2's to .01
-	cpuTracker.EXPECT().Usage(vdr1ID, gomock.Any()).Return(.99).Times(2)
-	cpuTracker.EXPECT().Usage(vdr2ID, gomock.Any()).Return(.01).Times(1)
-	// Pop should return msg2 first because vdr1 has exceeded it's portion of CPU time
-	_, gotMsg2, ok := u.Pop()
-	require.True(ok)
-	require.Equal(1, u.Len())
-	require.Equal(msg2, gotMsg2)
-	_, gotMsg1, ok = u.Pop()
-	require.True(ok)
-	require.Equal(msg1, gotMsg1)
-	require.Empty(u.nodeToUnprocessedMsgs)
-	require.Zero(u.Len())
+	// [vdrID] has half of the stake, so its share is 3/4 and its quantum is
+	// 1.5 messages, while [nonVdrID]'s share is 1/2 and its quantum is 1
+	// message. So [vdrID] is served 3 of every 5 messages.
+	var numVdrMsgs int
+	for _, nodeID := range popNodeIDs(t, u, 10) {
+		if nodeID == vdrID {
+			numVdrMsgs++
+		}
+	}
+	require.Equal(6, numVdrMsgs)
+}
 
-	// u is now empty
-	// Non-validators should be able to put messages onto [u]
-	nonVdrNodeID1, nonVdrNodeID2 := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
-	msg3 := Message{
-		InboundMessage: message.InboundPullQuery(ids.Empty, 0, 0, ids.Empty, 0, nonVdrNodeID1),
-		EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+func TestQueueClasses(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	u := newTestMessageQueue(t, validators.NewManager(), nil)
+
+	for i := uint32(0); i < 5; i++ {
+		u.Push(context.Background(), Message{
+			InboundMessage: message.InboundGetAcceptedFrontier(ids.Empty, i, time.Second, nodeID),
+			EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+		})
+		u.Push(context.Background(), pullQuery(nodeID, i))
 	}
-	msg4 := Message{
-		InboundMessage: message.InboundPushQuery(ids.Empty, 0, 0, nil, 0, nonVdrNodeID2),
-		EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+	require.Equal(5, u.classes[consensusClass].len())
+	require.Equal(5, u.classes[bootstrapClass].len())
+
+	var (
+		ops        []message.Op
+		requestIDs = make(map[message.Op][]uint32)
+	)
+	for range 10 {
+		_, msg, ok := u.Pop()
+		require.True(ok)
+		ops = append(ops, msg.Op())
+		requestID, ok := message.GetRequestID(msg.Message())
+		require.True(ok)
+		requestIDs[msg.Op()] = append(requestIDs[msg.Op()], requestID)
 	}
-	u.Push(context.Background(), msg3)
-	u.Push(context.Background(), msg4)
-	u.Push(context.Background(), msg1)
-	require.Equal(3, u.Len())
 
-	// msg1 should get popped first because nonVdrNodeID1 and nonVdrNodeID2
-	// exceeded their limit
-	cpuTracker.EXPECT().Usage(nonVdrNodeID1, gomock.Any()).Return(.34).Times(1)
-	cpuTracker.EXPECT().Usage(nonVdrNodeID2, gomock.Any()).Return(.34).Times(2)
-	cpuTracker.EXPECT().Usage(vdr1ID, gomock.Any()).Return(0.0).Times(1)
+	// Consensus messages are popped 4 at a time and bootstrap messages are
+	// popped 2 at a time. So even though each GetAcceptedFrontier was pushed
+	// before the PullQuery with the same request ID, the PullQueries are
+	// popped first.
+	require.Equal(
+		[]message.Op{
+			message.PullQueryOp, message.PullQueryOp, message.PullQueryOp, message.PullQueryOp,
+			message.GetAcceptedFrontierOp, message.GetAcceptedFrontierOp,
+			message.PullQueryOp,
+			message.GetAcceptedFrontierOp, message.GetAcceptedFrontierOp, message.GetAcceptedFrontierOp,
+		},
+		ops,
+	)
 
-	// u.msgs is [msg3, msg4, msg1]
-	_, gotMsg1, ok = u.Pop()
-	require.True(ok)
-	require.Equal(msg1, gotMsg1)
-	// u.msgs is [msg3, msg4]
-	cpuTracker.EXPECT().Usage(nonVdrNodeID1, gomock.Any()).Return(.51).Times(2)
-	_, gotMsg4, ok := u.Pop()
-	require.True(ok)
-	require.Equal(msg4, gotMsg4)
-	// u.msgs is [msg3]
-	_, gotMsg3, ok := u.Pop()
-	require.True(ok)
-	require.Equal(msg3, gotMsg3)
+	// The messages of a peer are only popped in the order they were pushed
+	// within each class.
+	require.Equal(
+		map[message.Op][]uint32{
+			message.PullQueryOp:           {0, 1, 2, 3, 4},
+			message.GetAcceptedFrontierOp: {0, 1, 2, 3, 4},
+		},
+		requestIDs,
+	)
+}
+
+func TestQueueShutdown(t *testing.T) {
+	require := require.New(t)
+
+	u := newTestMessageQueue(t, validators.NewManager(), nil)
+	u.Push(context.Background(), pullQuery(ids.GenerateTestNodeID(), 0))
+	u.Push(context.Background(), Message{
+		InboundMessage: message.InboundAppRequest(ids.Empty, 0, time.Second, nil, ids.GenerateTestNodeID()),
+		EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+	})
+	require.Equal(2, u.Len())
+
+	u.Shutdown()
 	require.Zero(u.Len())
+	for _, classQueue := range u.classes {
+		require.Zero(classQueue.len())
+	}
+
+	_, _, ok := u.Pop()
+	require.False(ok)
 }
@@ -13,9 +13,11 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
 	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
@@ -47,6 +49,26 @@ type requestEntry struct {
 	engineType p2p.EngineType
 }
 
+// throttledMessage releases the bytes it acquired from the subnet throttler
+// once it has been handled.
+type throttledMessage struct {
+	message.InboundMessage
+	release throttling.ReleaseFunc
+}
+
+func (m *throttledMessage) OnFinishedHandling() {
+	m.release()
+	m.InboundMessage.OnFinishedHandling()
+}
+
+// messageSize returns the number of bytes of the uncompressed message.
+func messageSize(m fmt.Stringer) uint64 {
+	if m, ok := m.(proto.Message); ok {
+		return uint64(proto.Size(m))
+	}
+	return 0
+}
+
 type peer struct {
 	version *version.Application
 	// The subnets that this peer is currently tracking
@@ -82,6 +104,8 @@ type ChainRouter struct {
 	metrics                *routerMetrics
 	// Parameters for doing health checks
 	healthConfig HealthConfig
+	// Limits the bytes of unrequested messages processing for each subnet
+	subnetThrottler throttling.SubnetInboundMsgThrottler
 	// aggregator of requests based on their time
 	timedRequests *linked.Hashmap[ids.RequestID, requestEntry]
 }
@@ -101,6 +125,7 @@ func (cr *ChainRouter) Initialize(
 	trackedSubnets set.Set[ids.ID],
 	onFatal func(exitCode int),
 	healthConfig HealthConfig,
+	subnetThrottler throttling.SubnetInboundMsgThrottler,
 	reg prometheus.Registerer,
 ) error {
 	cr.log = log
@@ -114,6 +139,7 @@ func (cr *ChainRouter) Initialize(
 	cr.timedRequests = linked.NewHashmap[ids.RequestID, requestEntry]()
 	cr.peers = make(map[ids.NodeID]*peer)
 	cr.healthConfig = healthConfig
+	cr.subnetThrottler = subnetThrottler
 
 	// Mark myself as connected
 	cr.myNodeID = nodeID
@@ -283,14 +309,33 @@ func (cr *ChainRouter) HandleInbound(ctx context.Context, msg message.InboundMes
 			return
 		}
 
+		// Unrequested messages are limited per subnet so that a noisy subnet
+		// can't consume the inbound buffer of the other subnets.
+		release, ok := cr.subnetThrottler.Acquire(messageSize(m), chainCtx.SubnetID)
+		if !ok {
+			cr.log.Debug("dropping message",
+				zap.String("reason", "the subnet exceeded its inbound allocation"),
+				zap.Stringer("messageOp", op),
+				zap.Stringer("nodeID", nodeID),
+				zap.Stringer("chainID", chainID),
+				zap.Stringer("subnetID", chainCtx.SubnetID),
+			)
+			cr.metrics.droppedRequests.Inc()
+			msg.OnFinishedHandling()
+			return
+		}
+
 		// Note: engineType is not guaranteed to be one of the explicitly named
 		// enum values. If it was not specified it defaults to UNSPECIFIED.
 		engineType, _ := message.GetEngineType(m)
 		chain.Push(
 			ctx,
 			handler.Message{
-				InboundMessage: msg,
-				EngineType:     engineType,
+				InboundMessage: &throttledMessage{
+					InboundMessage: msg,
+					release:        release,
+				},
+				EngineType: engineType,
 			},
 		)
 		return
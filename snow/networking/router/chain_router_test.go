@@ -16,6 +16,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/engine/enginetest"
@@ -81,6 +82,7 @@ func TestShutdown(t *testing.T) {
 		set.Set[ids.ID]{},
 		nil,
 		HealthConfig{},
+		throttling.NewNoSubnetInboundMsgThrottler(),
 		prometheus.NewRegistry(),
 	))
 
@@ -209,6 +211,7 @@ func TestConnectedAfterShutdownErrorLogRegression(t *testing.T) {
 		set.Set[ids.ID]{},
 		nil,
 		HealthConfig{},
+		throttling.NewNoSubnetInboundMsgThrottler(),
 		prometheus.NewRegistry(),
 	))
 
@@ -342,6 +345,7 @@ func TestShutdownTimesOut(t *testing.T) {
 		set.Set[ids.ID]{},
 		nil,
 		HealthConfig{},
+		throttling.NewNoSubnetInboundMsgThrottler(),
 		prometheus.NewRegistry(),
 	))
 
@@ -491,6 +495,7 @@ func TestRouterTimeout(t *testing.T) {
 		set.Set[ids.ID]{},
 		nil,
 		HealthConfig{},
+		throttling.NewNoSubnetInboundMsgThrottler(),
 		prometheus.NewRegistry(),
 	))
 	defer chainRouter.Shutdown(context.Background())
@@ -823,6 +828,7 @@ func TestRouterHonorsRequestedEngine(t *testing.T) {
 		set.Set[ids.ID]{},
 		nil,
 		HealthConfig{},
+		throttling.NewNoSubnetInboundMsgThrottler(),
 		prometheus.NewRegistry(),
 	))
 	defer chainRouter.Shutdown(context.Background())
@@ -920,6 +926,114 @@ func TestRouterHonorsRequestedEngine(t *testing.T) {
 	chainRouter.lock.Unlock()
 }
 
+func TestRouterSubnetThrottling(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+
+	tm, err := timeout.NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     3 * time.Second,
+			MinimumTimeout:     3 * time.Second,
+			MaximumTimeout:     5 * time.Minute,
+			TimeoutCoefficient: 1,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		benchlist.NewNoBenchlist(),
+		prometheus.NewRegistry(),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	go tm.Dispatch()
+	defer tm.Stop()
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	snowCtx.SubnetID = ids.GenerateTestID()
+	ctx := snowtest.ConsensusContext(snowCtx)
+
+	nodeID := ids.GenerateTestNodeID()
+	newMsg := func(requestID uint32) message.InboundMessage {
+		return message.InboundPushQuery(
+			ctx.ChainID,
+			requestID,
+			0,
+			[]byte{1, 2, 3},
+			0,
+			nodeID,
+		)
+	}
+
+	// Only allow a single message to be processing at a time
+	msgSize := messageSize(newMsg(1).Message())
+	subnetThrottler, err := throttling.NewSubnetInboundMsgThrottler(
+		prometheus.NewRegistry(),
+		throttling.SubnetInboundMsgThrottlerConfig{
+			AllocSize: 1024,
+		},
+		map[ids.ID]uint64{
+			ctx.SubnetID: msgSize,
+		},
+	)
+	require.NoError(err)
+
+	chainRouter := ChainRouter{}
+	require.NoError(chainRouter.Initialize(
+		ids.EmptyNodeID,
+		logging.NoLog{},
+		tm,
+		time.Millisecond,
+		set.Set[ids.ID]{},
+		true,
+		set.Of(ctx.SubnetID),
+		nil,
+		HealthConfig{},
+		subnetThrottler,
+		prometheus.NewRegistry(),
+	))
+	defer chainRouter.Shutdown(context.Background())
+
+	h := handlermock.NewHandler(ctrl)
+	h.EXPECT().Context().Return(ctx).AnyTimes()
+	h.EXPECT().SetOnStopped(gomock.Any()).AnyTimes()
+	h.EXPECT().Stop(gomock.Any()).AnyTimes()
+	h.EXPECT().AwaitStopped(gomock.Any()).AnyTimes()
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).Times(1)
+	h.EXPECT().ShouldHandle(gomock.Any()).Return(true).AnyTimes()
+	chainRouter.AddChain(context.Background(), h)
+
+	var pushed handler.Message
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(_ context.Context, msg handler.Message) {
+		pushed = msg
+	})
+	chainRouter.HandleInbound(context.Background(), newMsg(1))
+
+	// The subnet's allocation is in use, so the message is dropped without
+	// being pushed to the handler.
+	finished := false
+	dropped := &onFinishedMessage{
+		InboundMessage: newMsg(2),
+		onFinished: func() {
+			finished = true
+		},
+	}
+	chainRouter.HandleInbound(context.Background(), dropped)
+	require.True(finished)
+
+	// Once the first message is handled, its bytes are released.
+	pushed.OnFinishedHandling()
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).Times(1)
+	chainRouter.HandleInbound(context.Background(), newMsg(3))
+}
+
+type onFinishedMessage struct {
+	message.InboundMessage
+	onFinished func()
+}
+
+func (m *onFinishedMessage) OnFinishedHandling() {
+	m.onFinished()
+}
+
 func TestRouterClearTimeouts(t *testing.T) {
 	requestID := uint32(123)
 
@@ -1032,6 +1146,7 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 		set.Set[ids.ID]{},
 		nil,
 		HealthConfig{},
+		throttling.NewNoSubnetInboundMsgThrottler(),
 		prometheus.NewRegistry(),
 	))
 	defer chainRouter.Shutdown(context.Background())
@@ -1194,6 +1309,7 @@ func TestValidatorOnlyAllowedNodeMessageDrops(t *testing.T) {
 		set.Set[ids.ID]{},
 		nil,
 		HealthConfig{},
+		throttling.NewNoSubnetInboundMsgThrottler(),
 		prometheus.NewRegistry(),
 	))
 	defer chainRouter.Shutdown(context.Background())
@@ -1455,6 +1571,7 @@ func newChainRouterTest(t *testing.T) (*ChainRouter, *enginetest.Engine) {
 		set.Set[ids.ID]{},
 		nil,
 		HealthConfig{},
+		throttling.NewNoSubnetInboundMsgThrottler(),
 		prometheus.NewRegistry(),
 	))
 
@@ -18,6 +18,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/message/messagemock"
 	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/engine/enginetest"
@@ -79,6 +80,8 @@ func TestTimeout(t *testing.T) {
 		logging.NoLog{},
 		metrics,
 		constants.DefaultNetworkCompressionType,
+		nil,
+		"",
 		10*time.Second,
 	)
 	require.NoError(err)
@@ -93,6 +96,7 @@ func TestTimeout(t *testing.T) {
 		set.Set[ids.ID]{},
 		nil,
 		router.HealthConfig{},
+		throttling.NewNoSubnetInboundMsgThrottler(),
 		prometheus.NewRegistry(),
 	))
 
@@ -338,6 +342,8 @@ func TestReliableMessages(t *testing.T) {
 		logging.NoLog{},
 		metrics,
 		constants.DefaultNetworkCompressionType,
+		nil,
+		"",
 		10*time.Second,
 	)
 	require.NoError(err)
@@ -352,6 +358,7 @@ func TestReliableMessages(t *testing.T) {
 		set.Set[ids.ID]{},
 		nil,
 		router.HealthConfig{},
+		throttling.NewNoSubnetInboundMsgThrottler(),
 		prometheus.NewRegistry(),
 	))
 
@@ -495,6 +502,8 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		logging.NoLog{},
 		metrics,
 		constants.DefaultNetworkCompressionType,
+		nil,
+		"",
 		10*time.Second,
 	)
 	require.NoError(err)
@@ -509,6 +518,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		set.Set[ids.ID]{},
 		nil,
 		router.HealthConfig{},
+		throttling.NewNoSubnetInboundMsgThrottler(),
 		prometheus.NewRegistry(),
 	))
 
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
+)
+
+var _ handler.Handler = (*trackedHandler)(nil)
+
+// TrackHandler wraps [h] so that the simulator waits for every message pushed
+// to it to be handled before processing the next event. The real handler
+// processes messages on its own goroutines, so its node's chain must be
+// registered with the node's router through the returned handler to be run on
+// the virtual clock.
+//
+// A tracked handler must not be paused, as the simulator would wait for the
+// held messages forever. Messages that a node sends to itself are handed to its
+// router on a new goroutine and aren't waited for, which is why the Sampler
+// never samples the sampling node.
+func (s *Simulator) TrackHandler(h handler.Handler) handler.Handler {
+	return &trackedHandler{
+		Handler:  h,
+		handling: &s.handling,
+	}
+}
+
+type trackedHandler struct {
+	handler.Handler
+	handling *sync.WaitGroup
+}
+
+func (h *trackedHandler) Push(ctx context.Context, msg handler.Message) {
+	h.handling.Add(1)
+	msg.InboundMessage = &trackedMessage{
+		InboundMessage: msg.InboundMessage,
+		handling:       h.handling,
+	}
+	h.Handler.Push(ctx, msg)
+}
+
+// trackedMessage marks itself as handled once the handler is done with it.
+type trackedMessage struct {
+	message.InboundMessage
+	handling *sync.WaitGroup
+}
+
+func (m *trackedMessage) OnFinishedHandling() {
+	m.InboundMessage.OnFinishedHandling()
+	m.handling.Done()
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/validators"
+)
+
+var (
+	errTooFewNodes = errors.New("too few nodes to sample")
+
+	_ validators.Sampler = (*sampler)(nil)
+)
+
+// Sampler returns a sampler that [nodeID] can use to query validators
+// reproducibly. Every other node in the simulator is treated as a validator of
+// equal weight.
+//
+// [nodeID] is never sampled, as a node's sender delivers the messages it sends
+// to itself on a new goroutine, which the simulator can't wait for.
+func (s *Simulator) Sampler(nodeID ids.NodeID) validators.Sampler {
+	return &sampler{
+		simulator: s,
+		nodeID:    nodeID,
+	}
+}
+
+type sampler struct {
+	simulator *Simulator
+	nodeID    ids.NodeID
+}
+
+func (s *sampler) Sample(_ ids.ID, size int) ([]ids.NodeID, error) {
+	sim := s.simulator
+	sim.lock.Lock()
+	defer sim.lock.Unlock()
+
+	nodeIDs := make([]ids.NodeID, 0, size)
+	for _, i := range sim.rng.Perm(len(sim.nodes)) {
+		if len(nodeIDs) == size {
+			break
+		}
+		if nodeID := sim.nodes[i].id; nodeID != s.nodeID {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	if len(nodeIDs) < size {
+		return nil, fmt.Errorf("%w: %d < %d", errTooFewNodes, len(nodeIDs), size)
+	}
+	return nodeIDs, nil
+}
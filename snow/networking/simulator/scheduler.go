@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/utils/timer"
+)
+
+var _ timer.Scheduler = (*scheduler)(nil)
+
+// NewScheduler returns a scheduler that calls [handler] on the virtual clock.
+// Together with Clock, it allows a timeout manager to be run on the simulator.
+func (s *Simulator) NewScheduler(handler func()) timer.Scheduler {
+	return &scheduler{
+		simulator: s,
+		handler:   handler,
+	}
+}
+
+type scheduler struct {
+	simulator *Simulator
+	handler   func()
+
+	// The following fields are protected by the simulator's lock.
+
+	// timeout is incremented whenever the timeout is set or cancelled, so that
+	// events scheduled for replaced timeouts are ignored.
+	timeout uint64
+	stopped bool
+}
+
+// Dispatch returns immediately, as the handler is called by the simulator.
+func (*scheduler) Dispatch() {}
+
+func (s *scheduler) Stop() {
+	s.simulator.lock.Lock()
+	defer s.simulator.lock.Unlock()
+
+	s.stopped = true
+	s.timeout++
+}
+
+func (s *scheduler) SetTimeoutIn(duration time.Duration) {
+	sim := s.simulator
+	sim.lock.Lock()
+	defer sim.lock.Unlock()
+
+	if s.stopped {
+		return
+	}
+
+	s.timeout++
+	timeout := s.timeout
+	sim.schedule(sim.clock.Time().Add(duration), func() {
+		sim.lock.Lock()
+		current := s.timeout == timeout
+		sim.lock.Unlock()
+
+		if current {
+			s.handler()
+		}
+	})
+}
+
+func (s *scheduler) Cancel() {
+	s.simulator.lock.Lock()
+	defer s.simulator.lock.Unlock()
+
+	s.timeout++
+}
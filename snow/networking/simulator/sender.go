@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/networking/sender"
+	"github.com/MetalBlockchain/metalgo/subnets"
+	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+var _ sender.ExternalSender = (*Sender)(nil)
+
+// Sender sends messages from a node through the simulator.
+//
+// Every node in the simulator is treated as a connected validator, so sampled
+// validators, non-validators, and peers are all chosen uniformly from the other
+// nodes.
+type Sender struct {
+	simulator *Simulator
+	nodeID    ids.NodeID
+}
+
+func (s *Sender) Send(
+	msg message.OutboundMessage,
+	config common.SendConfig,
+	_ ids.ID,
+	allower subnets.Allower,
+) set.Set[ids.NodeID] {
+	sim := s.simulator
+	sim.lock.Lock()
+	defer sim.lock.Unlock()
+
+	from := sim.nodeMap[s.nodeID]
+
+	// Sort the explicitly requested nodes so that the order of the messages
+	// doesn't depend on map iteration order.
+	nodeIDs := config.NodeIDs.List()
+	utils.Sort(nodeIDs)
+
+	sentTo := set.NewSet[ids.NodeID](len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		to, ok := sim.nodeMap[nodeID]
+		if !ok || !allower.IsAllowed(nodeID, true) {
+			continue
+		}
+		sim.send(from, to, msg)
+		sentTo.Add(nodeID)
+	}
+
+	numToSample := config.Validators + config.NonValidators + config.Peers
+	for _, i := range sim.rng.Perm(len(sim.nodes)) {
+		if numToSample <= 0 {
+			break
+		}
+
+		to := sim.nodes[i]
+		if to.id == s.nodeID || sentTo.Contains(to.id) || !allower.IsAllowed(to.id, true) {
+			continue
+		}
+		sim.send(from, to, msg)
+		sentTo.Add(to.id)
+		numToSample--
+	}
+	return sentTo
+}
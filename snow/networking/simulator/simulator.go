@@ -0,0 +1,394 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulator runs many nodes in a single process on a virtual clock.
+// Messages between nodes are subject to configurable latency, loss, reordering,
+// partitions, and Byzantine mutation.
+//
+// Nodes can be wired from the real networking stack: a router as the node's
+// inbound handler, chain handlers wrapped by TrackHandler, a timeout manager
+// built with the simulator's Clock and NewScheduler, and engines that sample
+// validators with the simulator's Sampler. The virtual clock only advances once
+// every tracked handler has finished handling its messages, so timeouts fire at
+// the same virtual time regardless of how long the handlers take to run.
+//
+// All of the simulator's randomness is derived from a single seed, so a
+// simulation is reproducible as long as the nodes process messages
+// deterministically.
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/snow/networking/router"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
+	"github.com/MetalBlockchain/metalgo/utils/heap"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+)
+
+const maxMessageTimeout = 10 * time.Second
+
+var (
+	errNegativeLatency = errors.New("latency must be non-negative")
+	errInvalidDropRate = errors.New("drop rate must be in [0, 1]")
+	errInvalidReorder  = errors.New("reorder rate must be in [0, 1]")
+	errDuplicateNode   = errors.New("duplicate node")
+	errUnknownNode     = errors.New("unknown node")
+	errConditionNotMet = errors.New("condition not met before the time limit")
+)
+
+// Mutator is called for every message sent by a Byzantine node. The returned
+// message is delivered to [to] instead of [msg]. If nil is returned, the
+// message is dropped.
+type Mutator func(from, to ids.NodeID, msg message.OutboundMessage) message.OutboundMessage
+
+type Config struct {
+	// StartTime is the initial time of the virtual clock.
+	StartTime time.Time
+	// Latency is the minimum amount of time it takes for a message to be
+	// delivered.
+	Latency time.Duration
+	// Jitter is the maximum amount of time, chosen uniformly at random, that is
+	// added to the latency of each message. Jitter causes messages to be
+	// delivered out of order.
+	Jitter time.Duration
+	// DropRate is the probability that a message is lost.
+	DropRate float64
+	// ReorderRate is the probability that a message is held back for an
+	// additional ReorderDelay, so that it is delivered after messages that
+	// were sent after it.
+	ReorderRate  float64
+	ReorderDelay time.Duration
+}
+
+func (c *Config) Verify() error {
+	switch {
+	case c.Latency < 0 || c.Jitter < 0 || c.ReorderDelay < 0:
+		return errNegativeLatency
+	case c.DropRate < 0 || c.DropRate > 1:
+		return fmt.Errorf("%w: %f", errInvalidDropRate, c.DropRate)
+	case c.ReorderRate < 0 || c.ReorderRate > 1:
+		return fmt.Errorf("%w: %f", errInvalidReorder, c.ReorderRate)
+	default:
+		return nil
+	}
+}
+
+// Stats counts the messages that have passed through the simulator.
+type Stats struct {
+	Sent        uint64
+	Delivered   uint64
+	Dropped     uint64
+	Partitioned uint64
+	Mutated     uint64
+}
+
+type event struct {
+	time time.Time
+	// seq breaks ties between events scheduled at the same time, so that
+	// events are always processed in the order they were scheduled.
+	seq uint64
+	f   func()
+}
+
+func lessEvent(a, b *event) bool {
+	if !a.time.Equal(b.time) {
+		return a.time.Before(b.time)
+	}
+	return a.seq < b.seq
+}
+
+type node struct {
+	id      ids.NodeID
+	handler router.InboundHandler
+	mutator Mutator
+}
+
+type Simulator struct {
+	config  Config
+	creator message.Creator
+
+	lock  sync.Mutex
+	rng   *rand.Rand
+	clock mockable.Clock
+	seq   uint64
+	queue heap.Queue[*event]
+	stats Stats
+	// nodes are kept in the order they were added so that sampling peers
+	// doesn't depend on map iteration order.
+	nodes   []*node
+	nodeMap map[ids.NodeID]*node
+	// partition maps each node to the group it belongs to. Nodes that aren't
+	// in the map belong to group 0.
+	partition map[ids.NodeID]int
+
+	// handling counts the messages pushed to tracked handlers that haven't
+	// finished being handled.
+	handling sync.WaitGroup
+}
+
+// New returns a simulator whose random decisions are all derived from [seed].
+func New(config Config, seed int64) (*Simulator, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	creator, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		compression.TypeNone,
+		nil,
+		"",
+		maxMessageTimeout,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Simulator{
+		config:  config,
+		creator: creator,
+		rng:     rand.New(rand.NewSource(seed)), //#nosec G404
+		queue:   heap.NewQueue(lessEvent),
+		nodeMap: make(map[ids.NodeID]*node),
+	}
+	s.clock.Set(config.StartTime)
+	return s, nil
+}
+
+// Creator returns the message creator used to parse the messages delivered by
+// the simulator. It can also be used by nodes to build outbound messages.
+func (s *Simulator) Creator() message.Creator {
+	return s.creator
+}
+
+// AddNode registers [handler] to receive the messages sent to [nodeID] and
+// returns the sender that [nodeID] should use to send messages.
+func (s *Simulator) AddNode(nodeID ids.NodeID, handler router.InboundHandler) (*Sender, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.nodeMap[nodeID]; ok {
+		return nil, fmt.Errorf("%w: %s", errDuplicateNode, nodeID)
+	}
+
+	n := &node{
+		id:      nodeID,
+		handler: handler,
+	}
+	s.nodes = append(s.nodes, n)
+	s.nodeMap[nodeID] = n
+	return &Sender{
+		simulator: s,
+		nodeID:    nodeID,
+	}, nil
+}
+
+// SetMutator makes [nodeID] Byzantine by passing all of its outbound messages
+// through [mutator]. A nil mutator makes the node honest again.
+func (s *Simulator) SetMutator(nodeID ids.NodeID, mutator Mutator) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	n, ok := s.nodeMap[nodeID]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownNode, nodeID)
+	}
+	n.mutator = mutator
+	return nil
+}
+
+// Partition splits the network so that messages are only delivered between
+// nodes in the same group. Nodes that aren't in any of [groups] form an
+// additional group. Messages that are in flight when the partition is created
+// are dropped if they would cross it.
+func (s *Simulator) Partition(groups ...[]ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.partition = make(map[ids.NodeID]int)
+	for i, group := range groups {
+		for _, nodeID := range group {
+			s.partition[nodeID] = i + 1
+		}
+	}
+}
+
+// Heal removes any partition of the network.
+func (s *Simulator) Heal() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.partition = nil
+}
+
+// Now returns the current time of the virtual clock.
+func (s *Simulator) Now() time.Time {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.clock.Time()
+}
+
+// Clock returns the virtual clock. It is only advanced while every tracked
+// handler is idle, so it can be read by the nodes while they handle messages.
+func (s *Simulator) Clock() *mockable.Clock {
+	return &s.clock
+}
+
+// Stats returns the message counts so far.
+func (s *Simulator) Stats() Stats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.stats
+}
+
+// AfterFunc schedules [f] to be called once the virtual clock has advanced by
+// [d]. It can be used to simulate timeouts.
+func (s *Simulator) AfterFunc(d time.Duration, f func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.schedule(s.clock.Time().Add(d), f)
+}
+
+// Step processes the next scheduled event, advancing the virtual clock to the
+// time of the event. Step returns once every message pushed to a tracked
+// handler has been handled. Returns false if there were no events to process.
+func (s *Simulator) Step() bool {
+	s.handling.Wait()
+
+	s.lock.Lock()
+	e, ok := s.queue.Pop()
+	if ok {
+		s.clock.Set(e.time)
+	}
+	s.lock.Unlock()
+
+	if ok {
+		e.f()
+		s.handling.Wait()
+	}
+	return ok
+}
+
+// RunFor processes all the events scheduled in the next [d] and then advances
+// the virtual clock by [d].
+func (s *Simulator) RunFor(d time.Duration) {
+	end := s.Now().Add(d)
+	for s.nextEventBefore(end) {
+		s.Step()
+	}
+	s.handling.Wait()
+
+	s.lock.Lock()
+	s.clock.Set(end)
+	s.lock.Unlock()
+}
+
+// RunUntil processes events until [done] returns true. An error is returned if
+// [done] didn't return true before the virtual clock advanced by [limit] or
+// before there were no more events to process.
+func (s *Simulator) RunUntil(done func() bool, limit time.Duration) error {
+	end := s.Now().Add(limit)
+	for !done() {
+		if !s.nextEventBefore(end) {
+			return fmt.Errorf("%w: %s", errConditionNotMet, limit)
+		}
+		s.Step()
+	}
+	return nil
+}
+
+func (s *Simulator) nextEventBefore(end time.Time) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e, ok := s.queue.Peek()
+	return ok && !e.time.After(end)
+}
+
+// schedule assumes the lock is held.
+func (s *Simulator) schedule(t time.Time, f func()) {
+	s.queue.Push(&event{
+		time: t,
+		seq:  s.seq,
+		f:    f,
+	})
+	s.seq++
+}
+
+// send assumes the lock is held.
+func (s *Simulator) send(from *node, to *node, msg message.OutboundMessage) {
+	s.stats.Sent++
+
+	if from.mutator != nil {
+		mutated := from.mutator(from.id, to.id, msg)
+		if mutated == nil {
+			s.stats.Dropped++
+			return
+		}
+		if mutated != msg {
+			s.stats.Mutated++
+		}
+		msg = mutated
+	}
+
+	if s.rng.Float64() < s.config.DropRate {
+		s.stats.Dropped++
+		return
+	}
+
+	bytes, err := msg.BytesFor(nil)
+	if err != nil {
+		s.stats.Dropped++
+		return
+	}
+
+	delay := s.config.Latency
+	if s.config.Jitter > 0 {
+		delay += time.Duration(s.rng.Int63n(int64(s.config.Jitter) + 1))
+	}
+	if s.rng.Float64() < s.config.ReorderRate {
+		delay += s.config.ReorderDelay
+	}
+
+	s.schedule(s.clock.Time().Add(delay), func() {
+		s.deliver(from.id, to, bytes)
+	})
+}
+
+func (s *Simulator) deliver(from ids.NodeID, to *node, bytes []byte) {
+	s.lock.Lock()
+	if s.partition[from] != s.partition[to.id] {
+		s.stats.Partitioned++
+		s.lock.Unlock()
+		return
+	}
+	s.lock.Unlock()
+
+	msg, err := s.creator.Parse(bytes, from, func() {})
+	if err != nil {
+		s.lock.Lock()
+		s.stats.Dropped++
+		s.lock.Unlock()
+		return
+	}
+
+	s.lock.Lock()
+	s.stats.Delivered++
+	s.lock.Unlock()
+
+	to.handler.HandleInbound(context.Background(), msg)
+}
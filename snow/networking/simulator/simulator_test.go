@@ -0,0 +1,704 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
+	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman/snowmantest"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/engine/enginetest"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block/blocktest"
+	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
+	"github.com/MetalBlockchain/metalgo/snow/networking/router"
+	"github.com/MetalBlockchain/metalgo/snow/networking/timeout"
+	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
+	"github.com/MetalBlockchain/metalgo/snow/snowtest"
+	"github.com/MetalBlockchain/metalgo/snow/validators"
+	"github.com/MetalBlockchain/metalgo/subnets"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/math/meter"
+	"github.com/MetalBlockchain/metalgo/utils/resource"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/timer"
+	"github.com/MetalBlockchain/metalgo/version"
+
+	p2ppb "github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	commontracker "github.com/MetalBlockchain/metalgo/snow/engine/common/tracker"
+	smeng "github.com/MetalBlockchain/metalgo/snow/engine/snowman"
+	smgetter "github.com/MetalBlockchain/metalgo/snow/engine/snowman/getter"
+	snowsender "github.com/MetalBlockchain/metalgo/snow/networking/sender"
+)
+
+var (
+	chainID = ids.GenerateTestID()
+
+	errUnknownBlock = errors.New("unknown block")
+)
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: Config{
+				Latency:      time.Millisecond,
+				Jitter:       time.Millisecond,
+				DropRate:     .5,
+				ReorderRate:  1,
+				ReorderDelay: time.Millisecond,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "negative latency",
+			config: Config{
+				Latency: -1,
+			},
+			expectedErr: errNegativeLatency,
+		},
+		{
+			name: "negative jitter",
+			config: Config{
+				Jitter: -1,
+			},
+			expectedErr: errNegativeLatency,
+		},
+		{
+			name: "drop rate too high",
+			config: Config{
+				DropRate: 1.5,
+			},
+			expectedErr: errInvalidDropRate,
+		},
+		{
+			name: "negative reorder rate",
+			config: Config{
+				ReorderRate: -.5,
+			},
+			expectedErr: errInvalidReorder,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestAddNode(t *testing.T) {
+	require := require.New(t)
+
+	sim, err := New(Config{}, 0)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	_, err = sim.AddNode(nodeID, nil)
+	require.NoError(err)
+
+	_, err = sim.AddNode(nodeID, nil)
+	require.ErrorIs(err, errDuplicateNode)
+
+	err = sim.SetMutator(ids.GenerateTestNodeID(), nil)
+	require.ErrorIs(err, errUnknownNode)
+}
+
+func TestLatency(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(1_000, 0)
+	sim, err := New(Config{
+		StartTime: start,
+		Latency:   100 * time.Millisecond,
+	}, 0)
+	require.NoError(err)
+
+	var (
+		sender0, _ = sim.AddNode(ids.GenerateTestNodeID(), nil)
+		nodeID1    = ids.GenerateTestNodeID()
+		received   []time.Time
+	)
+	_, err = sim.AddNode(nodeID1, router.InboundHandlerFunc(func(context.Context, message.InboundMessage) {
+		received = append(received, sim.Now())
+	}))
+	require.NoError(err)
+
+	msg, err := sim.Creator().AppGossip(chainID, []byte{1})
+	require.NoError(err)
+
+	sentTo := sender0.Send(msg, common.SendConfig{NodeIDs: set.Of(nodeID1)}, ids.Empty, subnets.NoOpAllower)
+	require.Equal(set.Of(nodeID1), sentTo)
+
+	sim.RunFor(50 * time.Millisecond)
+	require.Empty(received)
+
+	sim.RunFor(50 * time.Millisecond)
+	require.Equal([]time.Time{start.Add(100 * time.Millisecond)}, received)
+	require.Equal(Stats{Sent: 1, Delivered: 1}, sim.Stats())
+}
+
+func TestAfterFunc(t *testing.T) {
+	require := require.New(t)
+
+	sim, err := New(Config{}, 0)
+	require.NoError(err)
+
+	var calls []int
+	sim.AfterFunc(2*time.Second, func() {
+		calls = append(calls, 2)
+	})
+	sim.AfterFunc(time.Second, func() {
+		calls = append(calls, 1)
+	})
+	sim.AfterFunc(time.Second, func() {
+		calls = append(calls, 3)
+	})
+
+	require.NoError(sim.RunUntil(func() bool {
+		return len(calls) == 3
+	}, time.Minute))
+	require.Equal([]int{1, 3, 2}, calls)
+	require.Equal(time.Time{}.Add(2*time.Second), sim.Now())
+
+	require.ErrorIs(sim.RunUntil(func() bool { return false }, time.Minute), errConditionNotMet)
+}
+
+// floodNetwork is a network of nodes that re-gossip the first message they
+// receive to all of their peers.
+type floodNetwork struct {
+	sim      *Simulator
+	nodeIDs  []ids.NodeID
+	senders  []*Sender
+	received []map[string]bool
+	trace    []string
+}
+
+func newFloodNetwork(t *testing.T, config Config, seed int64, numNodes int) *floodNetwork {
+	require := require.New(t)
+
+	sim, err := New(config, seed)
+	require.NoError(err)
+
+	n := &floodNetwork{
+		sim:      sim,
+		nodeIDs:  make([]ids.NodeID, numNodes),
+		senders:  make([]*Sender, numNodes),
+		received: make([]map[string]bool, numNodes),
+	}
+	for i := range n.nodeIDs {
+		i := i
+		n.nodeIDs[i] = ids.BuildTestNodeID([]byte{byte(i + 1)})
+		n.received[i] = make(map[string]bool)
+		n.senders[i], err = sim.AddNode(n.nodeIDs[i], router.InboundHandlerFunc(func(_ context.Context, msg message.InboundMessage) {
+			gossip := string(msg.Message().(*p2ppb.AppGossip).AppBytes)
+			n.trace = append(n.trace, fmt.Sprintf("%s %s->%d %s", sim.Now(), msg.NodeID(), i, gossip))
+			if n.received[i][gossip] {
+				return
+			}
+			n.gossip(t, i, gossip)
+		}))
+		require.NoError(err)
+	}
+	return n
+}
+
+func (n *floodNetwork) gossip(t *testing.T, from int, gossip string) {
+	n.received[from][gossip] = true
+
+	msg, err := n.sim.Creator().AppGossip(chainID, []byte(gossip))
+	require.NoError(t, err)
+	n.senders[from].Send(msg, common.SendConfig{Peers: len(n.nodeIDs)}, ids.Empty, subnets.NoOpAllower)
+}
+
+func (n *floodNetwork) numReceived(gossip string) int {
+	count := 0
+	for _, received := range n.received {
+		if received[gossip] {
+			count++
+		}
+	}
+	return count
+}
+
+func TestDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	config := Config{
+		Latency:      10 * time.Millisecond,
+		Jitter:       50 * time.Millisecond,
+		DropRate:     .1,
+		ReorderRate:  .1,
+		ReorderDelay: 100 * time.Millisecond,
+	}
+	run := func(seed int64) []string {
+		n := newFloodNetwork(t, config, seed, 20)
+		n.gossip(t, 0, "hello")
+		n.sim.RunFor(time.Minute)
+		return n.trace
+	}
+
+	trace := run(1)
+	require.NotEmpty(trace)
+	require.Equal(trace, run(1))
+	require.NotEqual(trace, run(2))
+}
+
+func TestDropAll(t *testing.T) {
+	require := require.New(t)
+
+	n := newFloodNetwork(t, Config{DropRate: 1}, 0, 10)
+	n.gossip(t, 0, "hello")
+	n.sim.RunFor(time.Minute)
+
+	require.Equal(1, n.numReceived("hello"))
+	require.Equal(Stats{Sent: 9, Dropped: 9}, n.sim.Stats())
+}
+
+func TestPartition(t *testing.T) {
+	require := require.New(t)
+
+	n := newFloodNetwork(t, Config{Latency: time.Millisecond}, 0, 50)
+	n.sim.Partition(n.nodeIDs[:20], n.nodeIDs[20:30])
+
+	n.gossip(t, 0, "first")
+	n.gossip(t, 25, "second")
+	n.gossip(t, 40, "third")
+	n.sim.RunFor(time.Minute)
+
+	require.Equal(20, n.numReceived("first"))
+	require.Equal(10, n.numReceived("second"))
+	require.Equal(20, n.numReceived("third"))
+
+	n.sim.Heal()
+	n.gossip(t, 1, "fourth")
+	n.sim.RunFor(time.Minute)
+
+	require.Equal(50, n.numReceived("fourth"))
+}
+
+func TestMutator(t *testing.T) {
+	require := require.New(t)
+
+	n := newFloodNetwork(t, Config{}, 0, 2)
+	require.NoError(n.sim.SetMutator(n.nodeIDs[0], func(ids.NodeID, ids.NodeID, message.OutboundMessage) message.OutboundMessage {
+		mutated, err := n.sim.Creator().AppGossip(chainID, []byte("mutated"))
+		require.NoError(err)
+		return mutated
+	}))
+
+	n.gossip(t, 0, "hello")
+	n.sim.RunFor(time.Minute)
+
+	require.False(n.received[1]["hello"])
+	require.True(n.received[1]["mutated"])
+	// The node that received the mutated message gossips it back, which
+	// causes the Byzantine node to send the mutated message again.
+	require.True(n.received[0]["mutated"])
+	require.Equal(uint64(2), n.sim.Stats().Mutated)
+}
+
+func TestScheduler(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(1_000, 0)
+	sim, err := New(Config{StartTime: start}, 0)
+	require.NoError(err)
+
+	tm, err := timeout.NewManagerWithScheduler(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Second,
+			MinimumTimeout:     time.Second,
+			MaximumTimeout:     time.Second,
+			TimeoutCoefficient: 1,
+			TimeoutHalflife:    time.Minute,
+		},
+		benchlist.NewNoBenchlist(),
+		prometheus.NewRegistry(),
+		prometheus.NewRegistry(),
+		sim.Clock(),
+		sim.NewScheduler,
+	)
+	require.NoError(err)
+	go tm.Dispatch()
+	defer tm.Stop()
+
+	var (
+		nodeID    = ids.GenerateTestNodeID()
+		timedOut  []ids.RequestID
+		requestID = func(id uint32) ids.RequestID {
+			return ids.RequestID{
+				NodeID:    nodeID,
+				ChainID:   chainID,
+				RequestID: id,
+				Op:        byte(message.ChitsOp),
+			}
+		}
+		register = func(id uint32) {
+			tm.RegisterRequest(nodeID, chainID, false, requestID(id), func() {
+				require.Equal(start.Add(time.Second+time.Duration(id)*time.Millisecond), sim.Now())
+				timedOut = append(timedOut, requestID(id))
+			})
+		}
+	)
+	register(0)
+	sim.RunFor(time.Millisecond)
+	register(1)
+	sim.RunFor(time.Millisecond)
+	register(2)
+
+	tm.RemoveRequest(requestID(1))
+
+	sim.RunFor(time.Second - time.Millisecond)
+	require.Equal([]ids.RequestID{requestID(0)}, timedOut)
+
+	sim.RunFor(time.Second)
+	require.Equal([]ids.RequestID{requestID(0), requestID(2)}, timedOut)
+}
+
+// snowmanNode runs the real router, timeout manager, sender, handler, and
+// snowman engine on the simulator.
+type snowmanNode struct {
+	ctx    *snow.ConsensusContext
+	engine *countingEngine
+	blocks []*snowmantest.Block
+}
+
+// countingEngine counts the queries that failed, which are caused by the
+// timeout manager firing on the virtual clock.
+type countingEngine struct {
+	*smeng.Engine
+	queriesFailed int
+}
+
+func (e *countingEngine) QueryFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	e.queriesFailed++
+	return e.Engine.QueryFailed(ctx, nodeID, requestID)
+}
+
+func newSnowmanNode(
+	t *testing.T,
+	sim *Simulator,
+	nodeID ids.NodeID,
+	vdrs validators.Manager,
+	params snowball.Parameters,
+	blocks []*snowmantest.Block,
+	toBuild *snowmantest.Block,
+) *snowmanNode {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, chainID)
+	snowCtx.NodeID = nodeID
+	ctx := snowtest.ConsensusContext(snowCtx)
+	ctx.State.Set(snow.EngineState{
+		Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.Bootstrapping,
+	})
+
+	n := &snowmanNode{
+		ctx:    ctx,
+		blocks: make([]*snowmantest.Block, len(blocks)),
+	}
+	// Every node decides its own copies of the blocks.
+	for i, blk := range blocks {
+		blkCopy := *blk
+		n.blocks[i] = &blkCopy
+	}
+
+	tm, err := timeout.NewManagerWithScheduler(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Second,
+			MinimumTimeout:     500 * time.Millisecond,
+			MaximumTimeout:     5 * time.Second,
+			TimeoutCoefficient: 2,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		benchlist.NewNoBenchlist(),
+		prometheus.NewRegistry(),
+		prometheus.NewRegistry(),
+		sim.Clock(),
+		sim.NewScheduler,
+	)
+	require.NoError(err)
+	go tm.Dispatch()
+	t.Cleanup(tm.Stop)
+
+	chainRouter := &router.ChainRouter{}
+	require.NoError(chainRouter.Initialize(
+		nodeID,
+		logging.NoLog{},
+		tm,
+		time.Second,
+		set.Set[ids.ID]{},
+		true,
+		set.Set[ids.ID]{},
+		nil,
+		router.HealthConfig{},
+		throttling.NewNoSubnetInboundMsgThrottler(),
+		prometheus.NewRegistry(),
+	))
+	t.Cleanup(func() {
+		chainRouter.Shutdown(context.Background())
+	})
+
+	externalSender, err := sim.AddNode(nodeID, chainRouter)
+	require.NoError(err)
+
+	subnet := subnets.New(nodeID, subnets.Config{})
+	sender, err := snowsender.New(
+		ctx,
+		sim.Creator(),
+		externalSender,
+		chainRouter,
+		tm,
+		p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+		subnet,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	known := set.Of(n.blocks[0].ID())
+	find := func(matches func(*snowmantest.Block) bool) (*snowmantest.Block, bool) {
+		for _, blk := range n.blocks {
+			if matches(blk) {
+				return blk, true
+			}
+		}
+		return nil, false
+	}
+	vm := &blocktest.VM{
+		ParseBlockF: func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+			blk, ok := find(func(blk *snowmantest.Block) bool {
+				return bytes.Equal(blk.Bytes(), blkBytes)
+			})
+			if !ok {
+				return nil, errUnknownBlock
+			}
+			known.Add(blk.ID())
+			return blk, nil
+		},
+		GetBlockF: func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+			if !known.Contains(blkID) {
+				return nil, errUnknownBlock
+			}
+			blk, _ := find(func(blk *snowmantest.Block) bool {
+				return blk.ID() == blkID
+			})
+			return blk, nil
+		},
+		BuildBlockF: func(context.Context) (snowman.Block, error) {
+			if toBuild == nil {
+				return nil, errUnknownBlock
+			}
+			blk, _ := find(func(blk *snowmantest.Block) bool {
+				return blk.ID() == toBuild.ID()
+			})
+			known.Add(blk.ID())
+			return blk, nil
+		},
+		LastAcceptedF: func(context.Context) (ids.ID, error) {
+			return n.blocks[0].ID(), nil
+		},
+		GetBlockIDAtHeightF: func(_ context.Context, height uint64) (ids.ID, error) {
+			blk, ok := find(func(blk *snowmantest.Block) bool {
+				return blk.Height() == height && blk.Status == snowtest.Accepted
+			})
+			if !ok {
+				return ids.Empty, errUnknownBlock
+			}
+			return blk.ID(), nil
+		},
+	}
+
+	getter, err := smgetter.New(vm, sender, ctx.Log, time.Second, 2000, ctx.Registerer)
+	require.NoError(err)
+
+	connectedValidators := commontracker.NewPeers()
+	vdrs.RegisterSetCallbackListener(ctx.SubnetID, connectedValidators)
+	for _, vdr := range vdrs.GetValidatorIDs(ctx.SubnetID) {
+		require.NoError(connectedValidators.Connected(context.Background(), vdr, version.CurrentApp))
+	}
+
+	engine, err := smeng.New(smeng.Config{
+		AllGetsServer:       getter,
+		Ctx:                 ctx,
+		VM:                  vm,
+		Sender:              sender,
+		Validators:          vdrs,
+		ConnectedValidators: connectedValidators,
+		Params:              params,
+		Consensus:           &snowman.Topological{},
+		Sampler:             sim.Sampler(nodeID),
+	})
+	require.NoError(err)
+	n.engine = &countingEngine{Engine: engine}
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+	p2pTracker, err := p2p.NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		version.CurrentApp,
+	)
+	require.NoError(err)
+	h, err := handler.New(
+		ctx,
+		vdrs,
+		nil,
+		time.Hour,
+		1,
+		resourceTracker,
+		subnet,
+		commontracker.NewPeers(),
+		p2pTracker,
+		prometheus.NewRegistry(),
+		func() {},
+	)
+	require.NoError(err)
+
+	// The chain starts in normal operation once its bootstrapper is started.
+	bootstrapper := &enginetest.Bootstrapper{}
+	bootstrapper.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+	bootstrapper.StartF = n.engine.Start
+	h.SetEngineManager(&handler.EngineManager{
+		Snowman: &handler.Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    n.engine,
+		},
+	})
+
+	trackedHandler := sim.TrackHandler(h)
+	chainRouter.AddChain(context.Background(), trackedHandler)
+	trackedHandler.Start(context.Background(), false)
+	return n
+}
+
+// buildBlock notifies the engine that its VM is ready to build a block.
+func (n *snowmanNode) buildBlock(t *testing.T) {
+	n.ctx.Lock.Lock()
+	defer n.ctx.Lock.Unlock()
+
+	require.NoError(t, n.engine.Notify(context.Background(), common.PendingTxs))
+}
+
+func (n *snowmanNode) accepted(height uint64) (ids.ID, bool) {
+	for _, blk := range n.blocks {
+		if blk.Height() == height && blk.Status == snowtest.Accepted {
+			return blk.ID(), true
+		}
+	}
+	return ids.Empty, false
+}
+
+func TestSnowmanUnderFaults(t *testing.T) {
+	require := require.New(t)
+
+	sim, err := New(Config{
+		Latency:      10 * time.Millisecond,
+		Jitter:       90 * time.Millisecond,
+		DropRate:     .05,
+		ReorderRate:  .05,
+		ReorderDelay: 500 * time.Millisecond,
+	}, 0)
+	require.NoError(err)
+
+	params := snowball.Parameters{
+		K:                     5,
+		AlphaPreference:       3,
+		AlphaConfidence:       4,
+		Beta:                  5,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     10,
+		MaxOutstandingItems:   256,
+		MaxItemProcessingTime: time.Minute,
+	}
+
+	// Two nodes build conflicting blocks on top of genesis.
+	var (
+		genesis = snowmantest.BuildChain(1)[0]
+		red     = snowmantest.BuildChild(genesis)
+		blue    = snowmantest.BuildChild(genesis)
+		blocks  = []*snowmantest.Block{genesis, red, blue}
+	)
+
+	nodeIDs := make([]ids.NodeID, 20)
+	vdrs := validators.NewManager()
+	for i := range nodeIDs {
+		nodeIDs[i] = ids.BuildTestNodeID([]byte{byte(i + 1)})
+		require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, nodeIDs[i], nil, ids.Empty, 1))
+	}
+
+	nodes := make([]*snowmanNode, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		var toBuild *snowmantest.Block
+		switch i {
+		case 0:
+			toBuild = red
+		case 10:
+			toBuild = blue
+		}
+		nodes[i] = newSnowmanNode(t, sim, nodeID, vdrs, params, blocks, toBuild)
+	}
+
+	// Keep each proposer isolated with half of the network for a while to
+	// make sure consensus makes progress once the partition is healed.
+	sim.Partition(nodeIDs[:10])
+	sim.AfterFunc(10*time.Second, sim.Heal)
+	sim.AfterFunc(0, func() {
+		nodes[0].buildBlock(t)
+		nodes[10].buildBlock(t)
+	})
+
+	require.NoError(sim.RunUntil(func() bool {
+		for _, node := range nodes {
+			if _, ok := node.accepted(1); !ok {
+				return false
+			}
+		}
+		return true
+	}, time.Hour))
+
+	acceptedID, _ := nodes[0].accepted(1)
+	queriesFailed := 0
+	for _, node := range nodes {
+		nodeAcceptedID, _ := node.accepted(1)
+		require.Equal(acceptedID, nodeAcceptedID)
+		queriesFailed += node.engine.queriesFailed
+	}
+	// Polls across the partition can only finish once their requests time out.
+	require.Positive(queriesFailed)
+
+	stats := sim.Stats()
+	require.Positive(stats.Partitioned)
+	require.Positive(stats.Dropped)
+}
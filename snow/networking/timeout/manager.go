@@ -10,13 +10,20 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+	"github.com/MetalBlockchain/metalgo/utils/math"
 	"github.com/MetalBlockchain/metalgo/utils/timer"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 )
 
+// maxTrackedLatencies is the maximum number of nodes whose response latency is
+// tracked at once.
+const maxTrackedLatencies = 4096
+
 var _ Manager = (*manager)(nil)
 
 // Manages timeouts for requests sent to peers.
@@ -63,6 +70,10 @@ type Manager interface {
 	// Mark that we no longer expect a response to this request we sent.
 	// Does not modify the timeout.
 	RemoveRequest(requestID ids.RequestID)
+	// Latency returns the average latency of the responses from [nodeID],
+	// where requests that timed out count as taking the timeout duration. If
+	// no latency has been observed from [nodeID], false is returned.
+	Latency(nodeID ids.NodeID) (time.Duration, bool)
 
 	// Stops the manager.
 	Stop()
@@ -74,9 +85,33 @@ func NewManager(
 	requestReg prometheus.Registerer,
 	responseReg prometheus.Registerer,
 ) (Manager, error) {
-	tm, err := timer.NewAdaptiveTimeoutManager(
+	return NewManagerWithScheduler(
+		timeoutConfig,
+		benchlistMgr,
+		requestReg,
+		responseReg,
+		&mockable.Clock{},
+		func(handler func()) timer.Scheduler {
+			return timer.NewTimer(handler)
+		},
+	)
+}
+
+// NewManagerWithScheduler returns a Manager that reads the time from [clock]
+// and fires timeouts through the Scheduler returned by [newScheduler].
+func NewManagerWithScheduler(
+	timeoutConfig *timer.AdaptiveTimeoutConfig,
+	benchlistMgr benchlist.Manager,
+	requestReg prometheus.Registerer,
+	responseReg prometheus.Registerer,
+	clock *mockable.Clock,
+	newScheduler func(handler func()) timer.Scheduler,
+) (Manager, error) {
+	tm, err := timer.NewAdaptiveTimeoutManagerWithScheduler(
 		timeoutConfig,
 		requestReg,
+		clock,
+		newScheduler,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't create timeout manager: %w", err)
@@ -88,9 +123,12 @@ func NewManager(
 	}
 
 	return &manager{
-		tm:           tm,
-		benchlistMgr: benchlistMgr,
-		metrics:      m,
+		tm:              tm,
+		benchlistMgr:    benchlistMgr,
+		metrics:         m,
+		clock:           clock,
+		latencyHalflife: timeoutConfig.TimeoutHalflife,
+		latencies:       &cache.LRU[ids.NodeID, math.Averager]{Size: maxTrackedLatencies},
 	}, nil
 }
 
@@ -99,6 +137,12 @@ type manager struct {
 	benchlistMgr benchlist.Manager
 	metrics      *timeoutMetrics
 	stopOnce     sync.Once
+
+	clock           *mockable.Clock
+	latencyHalflife time.Duration
+	latencyLock     sync.Mutex
+	// Node ID --> Average response latency
+	latencies *cache.LRU[ids.NodeID, math.Averager]
 }
 
 func (m *manager) Dispatch() {
@@ -140,6 +184,7 @@ func (m *manager) RegisterRequest(
 			// If the request timed out and wasn't an AppRequest, tell the
 			// benchlist manager.
 			m.benchlistMgr.RegisterFailure(chainID, nodeID)
+			m.observeLatency(nodeID, m.TimeoutDuration())
 		}
 		timeoutHandler()
 	}
@@ -158,12 +203,40 @@ func (m *manager) RegisterResponse(
 	m.metrics.Observe(chainID, op, latency)
 	m.benchlistMgr.RegisterResponse(chainID, nodeID)
 	m.tm.Remove(requestID)
+	if op != message.AppResponseOp {
+		m.observeLatency(nodeID, latency)
+	}
 }
 
 func (m *manager) RemoveRequest(requestID ids.RequestID) {
 	m.tm.Remove(requestID)
 }
 
+func (m *manager) Latency(nodeID ids.NodeID) (time.Duration, bool) {
+	m.latencyLock.Lock()
+	defer m.latencyLock.Unlock()
+
+	averager, ok := m.latencies.Get(nodeID)
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(averager.Read()), true
+}
+
+func (m *manager) observeLatency(nodeID ids.NodeID, latency time.Duration) {
+	m.latencyLock.Lock()
+	defer m.latencyLock.Unlock()
+
+	now := m.clock.Time()
+	averager, ok := m.latencies.Get(nodeID)
+	if !ok {
+		averager = math.NewAverager(float64(latency), m.latencyHalflife, now)
+		m.latencies.Put(nodeID, averager)
+		return
+	}
+	averager.Observe(float64(latency), now)
+}
+
 func (m *manager) RegisterRequestToUnreachableValidator() {
 	m.tm.ObserveLatency(m.TimeoutDuration())
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
 	"github.com/MetalBlockchain/metalgo/utils/timer"
 )
@@ -47,3 +48,37 @@ func TestManagerFire(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestManagerLatency(t *testing.T) {
+	require := require.New(t)
+
+	benchlist := benchlist.NewNoBenchlist()
+	manager, err := NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Millisecond,
+			MinimumTimeout:     time.Millisecond,
+			MaximumTimeout:     10 * time.Second,
+			TimeoutCoefficient: 1.25,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		benchlist,
+		prometheus.NewRegistry(),
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	_, ok := manager.Latency(nodeID)
+	require.False(ok)
+
+	manager.RegisterResponse(nodeID, ids.Empty, ids.RequestID{}, message.ChitsOp, time.Second)
+	latency, ok := manager.Latency(nodeID)
+	require.True(ok)
+	require.Equal(time.Second, latency)
+
+	// Application responses don't reflect the responsiveness of the node.
+	otherNodeID := ids.GenerateTestNodeID()
+	manager.RegisterResponse(otherNodeID, ids.Empty, ids.RequestID{}, message.AppResponseOp, time.Second)
+	_, ok = manager.Latency(otherNodeID)
+	require.False(ok)
+}
@@ -68,6 +68,21 @@ func (mr *ManagerMockRecorder) IsBenched(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBenched", reflect.TypeOf((*Manager)(nil).IsBenched), arg0, arg1)
 }
 
+// Latency mocks base method.
+func (m *Manager) Latency(arg0 ids.NodeID) (time.Duration, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Latency", arg0)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Latency indicates an expected call of Latency.
+func (mr *ManagerMockRecorder) Latency(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latency", reflect.TypeOf((*Manager)(nil).Latency), arg0)
+}
+
 // RegisterChain mocks base method.
 func (m *Manager) RegisterChain(arg0 *snow.ConsensusContext) error {
 	m.ctrl.T.Helper()
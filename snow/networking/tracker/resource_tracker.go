@@ -6,6 +6,7 @@ package tracker
 import (
 	"errors"
 	"fmt"
+	"math"
 	"sync"
 	"time"
 
@@ -19,7 +20,11 @@ import (
 
 const epsilon = 1e-9
 
-var _ ResourceTracker = (*resourceTracker)(nil)
+var (
+	lnHalf = math.Log(.5)
+
+	_ ResourceTracker = (*resourceTracker)(nil)
+)
 
 type Tracker interface {
 	// Returns the current usage for the given node.
@@ -38,10 +43,25 @@ type DiskTracker interface {
 	AvailableDiskBytes() uint64
 }
 
+// MemoryTracker estimates the memory usage caused by each node. The usage of a
+// node is the number of bytes of its messages that were recently held in memory
+// plus its share, by processing time, of the bytes recently allocated by this
+// process.
+type MemoryTracker interface {
+	Tracker
+	// Registers that [bytes] of messages from the given node started being
+	// held in memory at the given time.
+	StartBuffering(nodeID ids.NodeID, now time.Time, bytes uint64)
+	// Registers that [bytes] of messages from the given node stopped being
+	// held in memory at the given time.
+	StopBuffering(nodeID ids.NodeID, now time.Time, bytes uint64)
+}
+
 // ResourceTracker is an interface for tracking peers' usage of resources
 type ResourceTracker interface {
 	CPUTracker() Tracker
 	DiskTracker() DiskTracker
+	MemoryTracker() MemoryTracker
 	// Registers that the given node started processing at the given time.
 	StartProcessing(ids.NodeID, time.Time)
 	// Registers that the given node stopped processing at the given time.
@@ -187,6 +207,97 @@ func (t *diskResourceTracker) TimeUntilUsage(nodeID ids.NodeID, now time.Time, v
 	return m.TimeUntil(now, value/scale)
 }
 
+type memoryResourceTracker struct {
+	t *resourceTracker
+}
+
+func (t *memoryResourceTracker) Usage(nodeID ids.NodeID, now time.Time) float64 {
+	rt := t.t
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	rt.updateMemoryMetrics()
+	rt.attributeAllocations(now)
+
+	m, exists := rt.memory.Get(nodeID)
+	if !exists {
+		return 0
+	}
+	return m.read(now, rt.halflife)
+}
+
+// TotalUsage returns the number of bytes of memory resident for all tracked
+// processes. If that isn't known, the size of the Go heap is returned instead.
+func (t *memoryResourceTracker) TotalUsage() float64 {
+	rt := t.t
+	return float64(max(rt.resources.MemoryUsage(), rt.resources.HeapUsage()))
+}
+
+func (t *memoryResourceTracker) TimeUntilUsage(nodeID ids.NodeID, now time.Time, value float64) time.Duration {
+	rt := t.t
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	rt.attributeAllocations(now)
+	rt.pruneMemory(now)
+
+	m, exists := rt.memory.Get(nodeID)
+	if !exists {
+		return 0
+	}
+
+	// Both the buffered and the allocated bytes decay with [rt.halflife] while
+	// the node isn't using any memory.
+	currentValue := m.read(now, rt.halflife)
+	if currentValue <= value {
+		return 0
+	}
+	numHalflives := math.Log2(currentValue / value)
+	duration := numHalflives * float64(rt.halflife)
+	// Overflow protection
+	if duration > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(duration)
+}
+
+func (t *memoryResourceTracker) StartBuffering(nodeID ids.NodeID, now time.Time, bytes uint64) {
+	rt := t.t
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	m := rt.getMemoryUsage(nodeID)
+	m.buffered.Inc(now, float64(bytes))
+}
+
+func (t *memoryResourceTracker) StopBuffering(nodeID ids.NodeID, now time.Time, bytes uint64) {
+	rt := t.t
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	m := rt.getMemoryUsage(nodeID)
+	m.buffered.Dec(now, float64(bytes))
+}
+
+// memoryUsage is the memory usage attributed to a node.
+type memoryUsage struct {
+	// buffered tracks the number of bytes of messages from the node that are
+	// held in memory.
+	buffered meter.Meter
+	// allocated is the number of bytes allocated by this process that are
+	// attributed to the node, decayed to [lastUpdated].
+	allocated   float64
+	lastUpdated time.Time
+}
+
+func (m *memoryUsage) read(now time.Time, halflife time.Duration) float64 {
+	if timeSincePreviousUpdate := now.Sub(m.lastUpdated); timeSincePreviousUpdate > 0 {
+		m.allocated *= math.Exp(lnHalf * float64(timeSincePreviousUpdate) / float64(halflife))
+		m.lastUpdated = now
+	}
+	return m.buffered.Read(now) + m.allocated
+}
+
 type resourceTracker struct {
 	lock sync.RWMutex
 
@@ -200,8 +311,14 @@ type resourceTracker struct {
 	// utilized. This doesn't necessarily result in the meters being sorted
 	// based on their usage. However, in practice the nodes that are not being
 	// utilized will move towards the oldest elements where they can be deleted.
-	meters  *linked.Hashmap[ids.NodeID, meter.Meter]
+	meters *linked.Hashmap[ids.NodeID, meter.Meter]
+	// Each element is the memory usage attributed to a node. Like [meters],
+	// [memory] is ordered by the first time that a node used memory.
+	memory  *linked.Hashmap[ids.NodeID, *memoryUsage]
 	metrics *trackerMetrics
+	// allocatedBytes is the number of bytes allocated on the heap by this
+	// process that have been attributed to nodes.
+	allocatedBytes uint64
 }
 
 func NewResourceTracker(
@@ -216,6 +333,8 @@ func NewResourceTracker(
 		processingMeter: factory.New(halflife),
 		halflife:        halflife,
 		meters:          linked.NewHashmap[ids.NodeID, meter.Meter](),
+		memory:          linked.NewHashmap[ids.NodeID, *memoryUsage](),
+		allocatedBytes:  resources.AllocatedBytes(),
 	}
 	var err error
 	t.metrics, err = newCPUTrackerMetrics(reg)
@@ -233,6 +352,10 @@ func (rt *resourceTracker) DiskTracker() DiskTracker {
 	return &diskResourceTracker{t: rt}
 }
 
+func (rt *resourceTracker) MemoryTracker() MemoryTracker {
+	return &memoryResourceTracker{t: rt}
+}
+
 func (rt *resourceTracker) StartProcessing(nodeID ids.NodeID, now time.Time) {
 	rt.lock.Lock()
 	defer rt.lock.Unlock()
@@ -265,6 +388,77 @@ func (rt *resourceTracker) getMeter(nodeID ids.NodeID) meter.Meter {
 	return newMeter
 }
 
+// getMemoryUsage returns the memory usage attributed to [nodeID].
+// assumes [rt.lock] is held.
+func (rt *resourceTracker) getMemoryUsage(nodeID ids.NodeID) *memoryUsage {
+	m, exists := rt.memory.Get(nodeID)
+	if exists {
+		return m
+	}
+
+	newMemoryUsage := &memoryUsage{
+		buffered: rt.factory.New(rt.halflife),
+	}
+	rt.memory.Put(nodeID, newMemoryUsage)
+	return newMemoryUsage
+}
+
+// assumes [rt.lock] is held.
+func (rt *resourceTracker) updateMemoryMetrics() {
+	rt.metrics.memoryMetric.Set(float64(rt.resources.MemoryUsage()))
+	rt.metrics.heapMetric.Set(float64(rt.resources.HeapUsage()))
+}
+
+// attributeAllocations attributes the bytes allocated since the last call to
+// the nodes in proportion to their recent processing time. The allocated bytes
+// are sampled periodically by [rt.resources], so new allocations are only
+// attributed once per sample.
+// assumes [rt.lock] is held.
+func (rt *resourceTracker) attributeAllocations(now time.Time) {
+	allocatedBytes := rt.resources.AllocatedBytes()
+	if allocatedBytes <= rt.allocatedBytes {
+		return
+	}
+	newAllocatedBytes := float64(allocatedBytes - rt.allocatedBytes)
+	rt.allocatedBytes = allocatedBytes
+
+	totalProcessingTime := rt.processingMeter.Read(now)
+	if totalProcessingTime <= epsilon {
+		return
+	}
+
+	it := rt.meters.NewIterator()
+	for it.Next() {
+		processingTime := it.Value().Read(now)
+		if processingTime <= epsilon {
+			continue
+		}
+
+		m := rt.getMemoryUsage(it.Key())
+		m.read(now, rt.halflife)
+		m.allocated += newAllocatedBytes * processingTime / totalProcessingTime
+	}
+}
+
+// pruneMemory attempts to remove the memory usage of nodes that currently use
+// less than [epsilon] bytes.
+//
+// Like [prune], this doesn't guarantee that all such nodes are removed.
+func (rt *resourceTracker) pruneMemory(now time.Time) {
+	for {
+		oldest, m, exists := rt.memory.Oldest()
+		if !exists {
+			return
+		}
+
+		if m.read(now, rt.halflife) > epsilon {
+			return
+		}
+
+		rt.memory.Delete(oldest)
+	}
+}
+
 // prune attempts to remove meters that currently show a value less than
 // [epsilon].
 //
@@ -291,6 +485,8 @@ type trackerMetrics struct {
 	diskReadsMetric      prometheus.Gauge
 	diskWritesMetric     prometheus.Gauge
 	diskSpaceAvailable   prometheus.Gauge
+	memoryMetric         prometheus.Gauge
+	heapMetric           prometheus.Gauge
 }
 
 func newCPUTrackerMetrics(reg prometheus.Registerer) (*trackerMetrics, error) {
@@ -315,6 +511,14 @@ func newCPUTrackerMetrics(reg prometheus.Registerer) (*trackerMetrics, error) {
 			Name: "disk_available_space",
 			Help: "Available space remaining (bytes) on the database volume",
 		}),
+		memoryMetric: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "memory_usage",
+			Help: "Resident memory (bytes) tracked by the resource manager",
+		}),
+		heapMetric: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "heap_usage",
+			Help: "Go heap usage (bytes) tracked by the resource manager",
+		}),
 	}
 	err := errors.Join(
 		reg.Register(m.processingTimeMetric),
@@ -322,6 +526,8 @@ func newCPUTrackerMetrics(reg prometheus.Registerer) (*trackerMetrics, error) {
 		reg.Register(m.diskReadsMetric),
 		reg.Register(m.diskWritesMetric),
 		reg.Register(m.diskSpaceAvailable),
+		reg.Register(m.memoryMetric),
+		reg.Register(m.heapMetric),
 	)
 	return m, err
 }
@@ -43,6 +43,7 @@ func TestCPUTracker(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockUser := resourcemock.NewUser(ctrl)
 	mockUser.EXPECT().CPUUsage().Return(1.0).Times(3)
+	mockUser.EXPECT().AllocatedBytes().Return(uint64(0))
 
 	tracker, err := NewResourceTracker(prometheus.NewRegistry(), mockUser, meter.ContinuousFactory{}, time.Second)
 	require.NoError(err)
@@ -126,3 +127,99 @@ func TestCPUTrackerTimeUntilCPUUtilization(t *testing.T) {
 	// Make sure it returns the zero duration if the node isn't known
 	require.Zero(cpuTracker.TimeUntilUsage(ids.GenerateTestNodeID(), now, 0.0001))
 }
+
+func TestMemoryTracker(t *testing.T) {
+	require := require.New(t)
+
+	halflife := 5 * time.Second
+
+	ctrl := gomock.NewController(t)
+	mockUser := resourcemock.NewUser(ctrl)
+	mockUser.EXPECT().MemoryUsage().Return(uint64(1024)).AnyTimes()
+	mockUser.EXPECT().HeapUsage().Return(uint64(512)).AnyTimes()
+	var allocatedBytes uint64
+	mockUser.EXPECT().AllocatedBytes().DoAndReturn(func() uint64 {
+		return allocatedBytes
+	}).AnyTimes()
+
+	tracker, err := NewResourceTracker(prometheus.NewRegistry(), mockUser, meter.ContinuousFactory{}, halflife)
+	require.NoError(err)
+
+	node1 := ids.BuildTestNodeID([]byte{1})
+	node2 := ids.BuildTestNodeID([]byte{2})
+	node3 := ids.BuildTestNodeID([]byte{3})
+	memoryTracker := tracker.MemoryTracker()
+
+	// Allocations are attributed to the nodes by their processing time.
+	now := time.Now()
+	tracker.StartProcessing(node1, now)
+	tracker.StartProcessing(node2, now)
+	tracker.StartProcessing(node1, now)
+	now = now.Add(halflife)
+	tracker.StopProcessing(node1, now)
+	tracker.StopProcessing(node2, now)
+	tracker.StopProcessing(node1, now)
+	allocatedBytes += 300
+	require.InDelta(200, memoryTracker.Usage(node1, now), .00001)
+	require.InDelta(100, memoryTracker.Usage(node2, now), .00001)
+	require.Zero(memoryTracker.Usage(node3, now))
+
+	// Allocations are only attributed once.
+	require.InDelta(200, memoryTracker.Usage(node1, now), .00001)
+
+	// Allocations decay with [halflife].
+	now = now.Add(halflife)
+	require.InDelta(100, memoryTracker.Usage(node1, now), .00001)
+
+	// Buffered bytes are attributed to the sender.
+	memoryTracker.StartBuffering(node3, now, 1000)
+	now = now.Add(halflife)
+	memoryTracker.StopBuffering(node3, now, 1000)
+	node3Usage := memoryTracker.Usage(node3, now)
+	require.Positive(node3Usage)
+	require.Less(node3Usage, 1000.)
+
+	require.InDelta(1024, memoryTracker.TotalUsage(), .00001)
+}
+
+func TestMemoryTrackerTimeUntilUsage(t *testing.T) {
+	require := require.New(t)
+
+	halflife := 5 * time.Second
+
+	ctrl := gomock.NewController(t)
+	mockUser := resourcemock.NewUser(ctrl)
+	mockUser.EXPECT().MemoryUsage().Return(uint64(0)).AnyTimes()
+	mockUser.EXPECT().HeapUsage().Return(uint64(0)).AnyTimes()
+	var allocatedBytes uint64
+	mockUser.EXPECT().AllocatedBytes().DoAndReturn(func() uint64 {
+		return allocatedBytes
+	}).AnyTimes()
+
+	trackerIntf, err := NewResourceTracker(prometheus.NewRegistry(), mockUser, meter.ContinuousFactory{}, halflife)
+	require.NoError(err)
+	tracker := trackerIntf.(*resourceTracker)
+
+	now := time.Now()
+	nodeID := ids.GenerateTestNodeID()
+	tracker.StartProcessing(nodeID, now)
+	now = now.Add(halflife)
+	tracker.StopProcessing(nodeID, now)
+	allocatedBytes += 1000
+
+	memoryTracker := tracker.MemoryTracker()
+	currentVal := memoryTracker.Usage(nodeID, now)
+	desiredVal := currentVal / 3
+	timeUntilDesiredVal := memoryTracker.TimeUntilUsage(nodeID, now, desiredVal)
+	now = now.Add(timeUntilDesiredVal)
+	actualVal := memoryTracker.Usage(nodeID, now)
+	require.InDelta(desiredVal, actualVal, .00001)
+
+	require.Zero(memoryTracker.TimeUntilUsage(nodeID, now, actualVal+.1))
+	require.Zero(memoryTracker.TimeUntilUsage(ids.GenerateTestNodeID(), now, 1))
+
+	// Nodes that no longer use memory are pruned.
+	now = now.Add(1000 * halflife)
+	require.Zero(memoryTracker.TimeUntilUsage(nodeID, now, 1))
+	require.Zero(tracker.memory.Len())
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptime
+
+import "time"
+
+const (
+	// BucketDuration is the width of the time buckets that uptime history is
+	// recorded in.
+	BucketDuration = time.Hour
+
+	// MaxHistoryBuckets is the maximum number of buckets that can be returned
+	// from a single uptime history query.
+	MaxHistoryBuckets = 31 * 24
+
+	// HistoryRetention is how long uptime history is kept for. Buckets that
+	// start more than HistoryRetention before the chain time are pruned, even
+	// if their node is no longer a validator.
+	HistoryRetention = 90 * 24 * time.Hour
+)
+
+// splitIntoBuckets calls [f] once for every bucket that overlaps
+// [start, end) with the start of the bucket and the length of the overlap.
+func splitIntoBuckets(start, end time.Time, f func(bucketStart time.Time, overlap time.Duration) error) error {
+	for start.Before(end) {
+		bucketStart := start.Truncate(BucketDuration)
+		bucketEnd := bucketStart.Add(BucketDuration)
+		if end.Before(bucketEnd) {
+			bucketEnd = end
+		}
+		if err := f(bucketStart, bucketEnd.Sub(start)); err != nil {
+			return err
+		}
+		start = bucketEnd
+	}
+	return nil
+}
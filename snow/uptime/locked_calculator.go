@@ -77,6 +77,20 @@ func (c *lockedCalculator) CalculateUptimePercentFrom(nodeID ids.NodeID, startTi
 	return c.c.CalculateUptimePercentFrom(nodeID, startTime)
 }
 
+func (c *lockedCalculator) CalculateUptimeHistory(nodeID ids.NodeID, startTime, endTime time.Time) ([]time.Duration, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.isBootstrapped == nil || !c.isBootstrapped.Get() {
+		return nil, errStillBootstrapping
+	}
+
+	c.calculatorLock.Lock()
+	defer c.calculatorLock.Unlock()
+
+	return c.c.CalculateUptimeHistory(nodeID, startTime, endTime)
+}
+
 func (c *lockedCalculator) SetCalculator(isBootstrapped *utils.Atomic[bool], lock sync.Locker, newC Calculator) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -34,6 +34,9 @@ func TestLockedCalculator(t *testing.T) {
 	_, err = lc.CalculateUptimePercentFrom(nodeID, time.Now())
 	require.ErrorIs(err, errStillBootstrapping)
 
+	_, err = lc.CalculateUptimeHistory(nodeID, time.Now(), time.Now())
+	require.ErrorIs(err, errStillBootstrapping)
+
 	var isBootstrapped utils.Atomic[bool]
 	mockCalc := uptimemock.NewCalculator(ctrl)
 
@@ -48,6 +51,9 @@ func TestLockedCalculator(t *testing.T) {
 	_, err = lc.CalculateUptimePercentFrom(nodeID, time.Now())
 	require.ErrorIs(err, errStillBootstrapping)
 
+	_, err = lc.CalculateUptimeHistory(nodeID, time.Now(), time.Now())
+	require.ErrorIs(err, errStillBootstrapping)
+
 	isBootstrapped.Set(true)
 
 	// Should return the value from the mocked inner calculator
@@ -62,4 +68,8 @@ func TestLockedCalculator(t *testing.T) {
 	mockCalc.EXPECT().CalculateUptimePercentFrom(gomock.Any(), gomock.Any()).AnyTimes().Return(float64(0), errTest)
 	_, err = lc.CalculateUptimePercentFrom(nodeID, time.Now())
 	require.ErrorIs(err, errTest)
+
+	mockCalc.EXPECT().CalculateUptimeHistory(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil, errTest)
+	_, err = lc.CalculateUptimeHistory(nodeID, time.Now(), time.Now())
+	require.ErrorIs(err, errTest)
 }
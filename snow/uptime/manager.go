@@ -5,6 +5,7 @@ package uptime
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/MetalBlockchain/metalgo/database"
@@ -17,6 +18,8 @@ var _ Manager = (*manager)(nil)
 var (
 	errAlreadyStartedTracking = errors.New("already started tracking")
 	errNotStartedTracking     = errors.New("not started tracking")
+	errInvalidHistoryRange    = errors.New("history end time is before start time")
+	errHistoryRangeTooLarge   = errors.New("history range too large")
 )
 
 type Manager interface {
@@ -39,6 +42,10 @@ type Calculator interface {
 	CalculateUptimePercent(nodeID ids.NodeID) (float64, error)
 	// CalculateUptimePercentFrom expects [startTime] to be truncated (floored) to the nearest second
 	CalculateUptimePercentFrom(nodeID ids.NodeID, startTime time.Time) (float64, error)
+	// CalculateUptimeHistory returns how long [nodeID] was online in every
+	// bucket overlapping [startTime, endTime). The i'th entry is the uptime of
+	// the bucket starting at startTime.Truncate(BucketDuration) + i*BucketDuration.
+	CalculateUptimeHistory(nodeID ids.NodeID, startTime, endTime time.Time) ([]time.Duration, error)
 }
 
 type manager struct {
@@ -115,31 +122,40 @@ func (m *manager) Disconnect(nodeID ids.NodeID) error {
 }
 
 func (m *manager) CalculateUptime(nodeID ids.NodeID) (time.Duration, time.Time, error) {
-	upDuration, lastUpdated, err := m.state.GetUptime(nodeID)
+	upDuration, upStart, now, err := m.calculateUptime(nodeID)
 	if err != nil {
 		return 0, time.Time{}, err
 	}
+	return upDuration + now.Sub(upStart), now, nil
+}
+
+// calculateUptime returns the uptime of [nodeID] that has been written to the
+// state along with the period [upStart, now) that the node should additionally
+// be considered online for.
+func (m *manager) calculateUptime(nodeID ids.NodeID) (time.Duration, time.Time, time.Time, error) {
+	upDuration, lastUpdated, err := m.state.GetUptime(nodeID)
+	if err != nil {
+		return 0, time.Time{}, time.Time{}, err
+	}
 
 	now := m.clock.UnixTime()
 	// If we are in a weird reality where time has gone backwards, make sure
 	// that we don't double count or delete any uptime.
 	if now.Before(lastUpdated) {
-		return upDuration, lastUpdated, nil
+		return upDuration, lastUpdated, lastUpdated, nil
 	}
 
 	// If we haven't started tracking, then we assume that the node has been
 	// online since their last update.
 	if !m.startedTracking {
-		durationOffline := now.Sub(lastUpdated)
-		newUpDuration := upDuration + durationOffline
-		return newUpDuration, now, nil
+		return upDuration, lastUpdated, now, nil
 	}
 
 	// If we are tracking and they aren't connected, they have been offline
 	// since their last update.
 	timeConnected, isConnected := m.connections[nodeID]
 	if !isConnected {
-		return upDuration, now, nil
+		return upDuration, now, now, nil
 	}
 
 	// The time the peer connected needs to be adjusted to ensure no time period
@@ -151,14 +167,12 @@ func (m *manager) CalculateUptime(nodeID ids.NodeID) (time.Duration, time.Time,
 	// If we are in a weird reality where time has gone backwards, make sure
 	// that we don't double count or delete any uptime.
 	if now.Before(timeConnected) {
-		return upDuration, now, nil
+		return upDuration, now, now, nil
 	}
 
-	// Increase the uptimes by the amount of time this node has been running
-	// since the last time it's uptime was written to disk.
-	durationConnected := now.Sub(timeConnected)
-	newUpDuration := upDuration + durationConnected
-	return newUpDuration, now, nil
+	// The node has been running since the later of the time it connected and
+	// the last time it's uptime was written to disk.
+	return upDuration, timeConnected, now, nil
 }
 
 func (m *manager) CalculateUptimePercent(nodeID ids.NodeID) (float64, error) {
@@ -182,10 +196,57 @@ func (m *manager) CalculateUptimePercentFrom(nodeID ids.NodeID, startTime time.T
 	return uptime, nil
 }
 
+func (m *manager) CalculateUptimeHistory(nodeID ids.NodeID, startTime, endTime time.Time) ([]time.Duration, error) {
+	if endTime.Before(startTime) {
+		return nil, errInvalidHistoryRange
+	}
+
+	startTime = startTime.Truncate(BucketDuration)
+	if numBuckets := (endTime.Sub(startTime) + BucketDuration - 1) / BucketDuration; numBuckets > MaxHistoryBuckets {
+		return nil, fmt.Errorf("%w: %d buckets > %d", errHistoryRangeTooLarge, numBuckets, MaxHistoryBuckets)
+	}
+
+	var buckets []time.Duration
+	for bucketStart := startTime; bucketStart.Before(endTime); bucketStart = bucketStart.Add(BucketDuration) {
+		upDuration, err := m.state.GetUptimeBucket(nodeID, bucketStart)
+		if err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, upDuration)
+	}
+
+	// Periods during which uptime isn't tracked weren't observed, so they are
+	// never included in the history.
+	if !m.startedTracking {
+		return buckets, nil
+	}
+
+	// Include the uptime that hasn't been written to the state yet.
+	_, upStart, now, err := m.calculateUptime(nodeID)
+	if err == database.ErrNotFound {
+		// The node isn't currently a validator, so all of its history has
+		// already been written.
+		return buckets, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = splitIntoBuckets(upStart, now, func(bucketStart time.Time, overlap time.Duration) error {
+		index := int(bucketStart.Sub(startTime) / BucketDuration)
+		if bucketStart.Before(startTime) || index >= len(buckets) {
+			return nil
+		}
+		buckets[index] += overlap
+		return nil
+	})
+	return buckets, err
+}
+
 // updateUptime updates the uptime of the node on the state by the amount of
 // time that the node has been connected.
 func (m *manager) updateUptime(nodeID ids.NodeID) error {
-	newDuration, newLastUpdated, err := m.CalculateUptime(nodeID)
+	upDuration, upStart, now, err := m.calculateUptime(nodeID)
 	if err == database.ErrNotFound {
 		// We don't track the uptimes of non-validators.
 		return nil
@@ -194,5 +255,21 @@ func (m *manager) updateUptime(nodeID ids.NodeID) error {
 		return err
 	}
 
-	return m.state.SetUptime(nodeID, newDuration, newLastUpdated)
+	// If we haven't started tracking, the node is assumed to have been online
+	// since its last update. That period wasn't observed, so it counts towards
+	// the node's uptime but isn't recorded in its history.
+	if m.startedTracking {
+		err := splitIntoBuckets(upStart, now, func(bucketStart time.Time, overlap time.Duration) error {
+			bucketUpDuration, err := m.state.GetUptimeBucket(nodeID, bucketStart)
+			if err != nil {
+				return err
+			}
+			return m.state.SetUptimeBucket(nodeID, bucketStart, bucketUpDuration+overlap)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.state.SetUptime(nodeID, upDuration+now.Sub(upStart), now)
 }
@@ -557,3 +557,126 @@ func TestStopTrackingUnixTimeRegression(t *testing.T) {
 	require.NoError(err)
 	require.GreaterOrEqual(float64(1), perc)
 }
+
+func TestCalculateUptimeHistory(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	startTime := time.Unix(1_700_000_000, 0).Truncate(BucketDuration)
+
+	s := NewTestState()
+	s.AddNode(nodeID0, startTime)
+
+	clk := mockable.Clock{}
+	up := NewManager(s, &clk)
+	clk.Set(startTime)
+
+	require.NoError(up.StartTracking([]ids.NodeID{nodeID0}))
+
+	// Online for the second half of the first bucket through the first half of
+	// the third bucket.
+	clk.Set(startTime.Add(30 * time.Minute))
+	require.NoError(up.Connect(nodeID0))
+	clk.Set(startTime.Add(2*BucketDuration + 30*time.Minute))
+	require.NoError(up.Disconnect(nodeID0))
+
+	// Online, but not yet written to the state, for the first quarter of the
+	// fourth bucket.
+	clk.Set(startTime.Add(3 * BucketDuration))
+	require.NoError(up.Connect(nodeID0))
+	clk.Set(startTime.Add(3*BucketDuration + 15*time.Minute))
+
+	history, err := up.CalculateUptimeHistory(nodeID0, startTime, clk.UnixTime())
+	require.NoError(err)
+	require.Equal(
+		[]time.Duration{
+			30 * time.Minute,
+			BucketDuration,
+			30 * time.Minute,
+			15 * time.Minute,
+		},
+		history,
+	)
+
+	// Unaligned start times are rounded down to the start of their bucket.
+	history, err = up.CalculateUptimeHistory(nodeID0, startTime.Add(BucketDuration+time.Minute), startTime.Add(2*BucketDuration+time.Minute))
+	require.NoError(err)
+	require.Equal(
+		[]time.Duration{
+			BucketDuration,
+			30 * time.Minute,
+		},
+		history,
+	)
+
+	// The total uptime matches the sum of the buckets.
+	duration, _, err := up.CalculateUptime(nodeID0)
+	require.NoError(err)
+	require.Equal(2*BucketDuration+15*time.Minute, duration)
+
+	_, err = up.CalculateUptimeHistory(nodeID0, clk.UnixTime(), startTime)
+	require.ErrorIs(err, errInvalidHistoryRange)
+
+	_, err = up.CalculateUptimeHistory(nodeID0, startTime, startTime.Add((MaxHistoryBuckets+1)*BucketDuration))
+	require.ErrorIs(err, errHistoryRangeTooLarge)
+}
+
+func TestCalculateUptimeHistoryUntracked(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	startTime := time.Unix(1_700_000_000, 0).Truncate(BucketDuration)
+
+	s := NewTestState()
+	s.AddNode(nodeID0, startTime)
+
+	clk := mockable.Clock{}
+	up := NewManager(s, &clk)
+
+	// Uptime isn't tracked, for example because this node is offline, during
+	// the first bucket.
+	clk.Set(startTime.Add(BucketDuration))
+	require.NoError(up.StartTracking([]ids.NodeID{nodeID0}))
+	require.NoError(up.Connect(nodeID0))
+
+	clk.Set(startTime.Add(2 * BucketDuration))
+	require.NoError(up.StopTracking([]ids.NodeID{nodeID0}))
+
+	clk.Set(startTime.Add(3 * BucketDuration))
+
+	// The untracked periods count towards the uptime...
+	duration, _, err := up.CalculateUptime(nodeID0)
+	require.NoError(err)
+	require.Equal(3*BucketDuration, duration)
+
+	// ...but aren't recorded in the history.
+	history, err := up.CalculateUptimeHistory(nodeID0, startTime, clk.UnixTime())
+	require.NoError(err)
+	require.Equal([]time.Duration{0, BucketDuration, 0}, history)
+
+	require.NoError(up.StartTracking([]ids.NodeID{nodeID0}))
+	history, err = up.CalculateUptimeHistory(nodeID0, startTime, clk.UnixTime())
+	require.NoError(err)
+	require.Equal([]time.Duration{0, BucketDuration, 0}, history)
+}
+
+func TestCalculateUptimeHistoryNonValidator(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	startTime := time.Unix(1_700_000_000, 0).Truncate(BucketDuration)
+
+	s := NewTestState()
+	require.NoError(s.SetUptimeBucket(nodeID0, startTime, time.Minute))
+
+	clk := mockable.Clock{}
+	up := NewManager(s, &clk)
+	clk.Set(startTime.Add(BucketDuration))
+
+	require.NoError(up.StartTracking(nil))
+	require.NoError(up.Connect(nodeID0))
+
+	history, err := up.CalculateUptimeHistory(nodeID0, startTime, startTime.Add(BucketDuration))
+	require.NoError(err)
+	require.Equal([]time.Duration{time.Minute}, history)
+}
@@ -24,3 +24,7 @@ func (noOpCalculator) CalculateUptimePercent(ids.NodeID) (float64, error) {
 func (noOpCalculator) CalculateUptimePercentFrom(ids.NodeID, time.Time) (float64, error) {
 	return 0, nil
 }
+
+func (noOpCalculator) CalculateUptimeHistory(ids.NodeID, time.Time, time.Time) ([]time.Duration, error) {
+	return nil, nil
+}
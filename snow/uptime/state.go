@@ -31,4 +31,21 @@ type State interface {
 	GetStartTime(
 		nodeID ids.NodeID,
 	) (startTime time.Time, err error)
+
+	// GetUptimeBucket returns the amount of time [nodeID] was recorded as
+	// online during the bucket starting at [bucketStart]. Buckets that were
+	// never written report a zero duration.
+	GetUptimeBucket(
+		nodeID ids.NodeID,
+		bucketStart time.Time,
+	) (upDuration time.Duration, err error)
+
+	// SetUptimeBucket updates the amount of time [nodeID] was recorded as
+	// online during the bucket starting at [bucketStart].
+	// Invariant: expects [bucketStart] to be aligned to [BucketDuration].
+	SetUptimeBucket(
+		nodeID ids.NodeID,
+		bucketStart time.Time,
+		upDuration time.Duration,
+	) error
 }
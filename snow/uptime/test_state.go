@@ -22,11 +22,13 @@ type TestState struct {
 	dbReadError  error
 	dbWriteError error
 	nodes        map[ids.NodeID]*uptime
+	buckets      map[ids.NodeID]map[int64]time.Duration // nodeID -> bucket start -> up duration
 }
 
 func NewTestState() *TestState {
 	return &TestState{
-		nodes: make(map[ids.NodeID]*uptime),
+		nodes:   make(map[ids.NodeID]*uptime),
+		buckets: make(map[ids.NodeID]map[int64]time.Duration),
 	}
 }
 
@@ -63,3 +65,17 @@ func (s *TestState) GetStartTime(nodeID ids.NodeID) (time.Time, error) {
 	}
 	return up.startTime, s.dbReadError
 }
+
+func (s *TestState) GetUptimeBucket(nodeID ids.NodeID, bucketStart time.Time) (time.Duration, error) {
+	return s.buckets[nodeID][bucketStart.Unix()], s.dbReadError
+}
+
+func (s *TestState) SetUptimeBucket(nodeID ids.NodeID, bucketStart time.Time, upDuration time.Duration) error {
+	nodeBuckets, ok := s.buckets[nodeID]
+	if !ok {
+		nodeBuckets = make(map[int64]time.Duration)
+		s.buckets[nodeID] = nodeBuckets
+	}
+	nodeBuckets[bucketStart.Unix()] = upDuration
+	return s.dbWriteError
+}
@@ -56,6 +56,21 @@ func (mr *CalculatorMockRecorder) CalculateUptime(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateUptime", reflect.TypeOf((*Calculator)(nil).CalculateUptime), arg0)
 }
 
+// CalculateUptimeHistory mocks base method.
+func (m *Calculator) CalculateUptimeHistory(arg0 ids.NodeID, arg1, arg2 time.Time) ([]time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalculateUptimeHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalculateUptimeHistory indicates an expected call of CalculateUptimeHistory.
+func (mr *CalculatorMockRecorder) CalculateUptimeHistory(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateUptimeHistory", reflect.TypeOf((*Calculator)(nil).CalculateUptimeHistory), arg0, arg1, arg2)
+}
+
 // CalculateUptimePercent mocks base method.
 func (m *Calculator) CalculateUptimePercent(arg0 ids.NodeID) (float64, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
+	"github.com/MetalBlockchain/metalgo/utils/sampler"
+)
+
+var (
+	_ Sampler             = Manager(nil)
+	_ Sampler             = (*latencySampler)(nil)
+	_ SetCallbackListener = (*latencySubnetSampler)(nil)
+)
+
+// Sampler samples the validators of a subnet.
+type Sampler interface {
+	// Sample returns a collection of validatorIDs in the subnet, potentially
+	// with duplicates. If sampling the requested size isn't possible, an error
+	// will be returned.
+	Sample(subnetID ids.ID, size int) ([]ids.NodeID, error)
+}
+
+// LatencyTracker reports the observed response latency of nodes.
+type LatencyTracker interface {
+	// Latency returns the average response latency of [nodeID]. If no
+	// latency has been observed from [nodeID], false is returned.
+	Latency(nodeID ids.NodeID) (time.Duration, bool)
+}
+
+// NewLatencyAwareSampler returns a sampler that samples validators by stake
+// but, with probability [bias], prefers the validator with the lower observed
+// latency among validators of equal stake.
+//
+// The stake sampled from every set of equally weighted validators has the same
+// distribution as [Manager.Sample]. The probability that any validator is
+// sampled stays within [1-bias, 1+bias] times its probability when sampling by
+// stake alone.
+//
+// [bias] must be in [0, 1].
+func NewLatencyAwareSampler(vdrs Manager, latencies LatencyTracker, bias float64) Sampler {
+	return &latencySampler{
+		vdrs:      vdrs,
+		latencies: latencies,
+		bias:      bias,
+		subnets:   make(map[ids.ID]*latencySubnetSampler),
+	}
+}
+
+type latencySampler struct {
+	vdrs      Manager
+	latencies LatencyTracker
+	bias      float64
+
+	lock    sync.Mutex
+	subnets map[ids.ID]*latencySubnetSampler
+}
+
+func (s *latencySampler) Sample(subnetID ids.ID, size int) ([]ids.NodeID, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	subnet, ok := s.subnets[subnetID]
+	if !ok {
+		subnet = &latencySubnetSampler{
+			subnetID:  subnetID,
+			vdrs:      s.vdrs,
+			latencies: s.latencies,
+		}
+		subnet.sampler = sampler.NewCostAwareWeightedWithoutReplacement(s.bias, subnet.latency)
+		subnet.stale.Store(true)
+		s.subnets[subnetID] = subnet
+		s.vdrs.RegisterSetCallbackListener(subnetID, subnet)
+	}
+	return subnet.sample(size)
+}
+
+// latencySubnetSampler samples the validators of a single subnet.
+//
+// Validator set changes are reported while the validator set is locked, so
+// they only mark the sampler as stale rather than grabbing the sampler's lock.
+type latencySubnetSampler struct {
+	subnetID  ids.ID
+	vdrs      Manager
+	latencies LatencyTracker
+
+	stale   atomic.Bool
+	nodeIDs []ids.NodeID
+	sampler sampler.WeightedWithoutReplacement
+}
+
+func (s *latencySubnetSampler) OnValidatorAdded(ids.NodeID, *bls.PublicKey, ids.ID, uint64) {
+	s.stale.Store(true)
+}
+
+func (s *latencySubnetSampler) OnValidatorRemoved(ids.NodeID, uint64) {
+	s.stale.Store(true)
+}
+
+func (s *latencySubnetSampler) OnValidatorWeightChanged(ids.NodeID, uint64, uint64) {
+	s.stale.Store(true)
+}
+
+func (s *latencySubnetSampler) sample(size int) ([]ids.NodeID, error) {
+	if s.stale.Swap(false) {
+		vdrs := s.vdrs.GetMap(s.subnetID)
+		nodeIDs := make([]ids.NodeID, 0, len(vdrs))
+		for nodeID := range vdrs {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+		slices.SortFunc(nodeIDs, ids.NodeID.Compare)
+
+		weights := make([]uint64, len(nodeIDs))
+		for i, nodeID := range nodeIDs {
+			weights[i] = vdrs[nodeID].Weight
+		}
+		if err := s.sampler.Initialize(weights); err != nil {
+			// Make sure the failed initialization is retried.
+			s.stale.Store(true)
+			return nil, err
+		}
+		s.nodeIDs = nodeIDs
+	}
+
+	indices, ok := s.sampler.Sample(size)
+	if !ok {
+		return nil, errInsufficientWeight
+	}
+
+	list := make([]ids.NodeID, size)
+	for i, index := range indices {
+		list[i] = s.nodeIDs[index]
+	}
+	return list, nil
+}
+
+func (s *latencySubnetSampler) latency(index int) (uint64, bool) {
+	latency, ok := s.latencies.Latency(s.nodeIDs[index])
+	return uint64(latency), ok
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package validators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+var _ LatencyTracker = testLatencies(nil)
+
+type testLatencies map[ids.NodeID]time.Duration
+
+func (l testLatencies) Latency(nodeID ids.NodeID) (time.Duration, bool) {
+	latency, ok := l[nodeID]
+	return latency, ok
+}
+
+func TestLatencyAwareSampler(t *testing.T) {
+	require := require.New(t)
+
+	m := NewManager()
+	subnetID := ids.GenerateTestID()
+	latencies := testLatencies{}
+	s := NewLatencyAwareSampler(m, latencies, 1)
+
+	sampled, err := s.Sample(subnetID, 0)
+	require.NoError(err)
+	require.Empty(sampled)
+
+	_, err = s.Sample(subnetID, 1)
+	require.ErrorIs(err, errInsufficientWeight)
+
+	// Validators added after the first sample must be sampled.
+	nodeID0 := ids.GenerateTestNodeID()
+	require.NoError(m.AddStaker(subnetID, nodeID0, nil, ids.Empty, 1))
+
+	sampled, err = s.Sample(subnetID, 1)
+	require.NoError(err)
+	require.Equal([]ids.NodeID{nodeID0}, sampled)
+
+	_, err = s.Sample(subnetID, 2)
+	require.ErrorIs(err, errInsufficientWeight)
+
+	nodeID1 := ids.GenerateTestNodeID()
+	require.NoError(m.AddStaker(subnetID, nodeID1, nil, ids.Empty, 1))
+
+	// Validators are sampled without replacement of their stake.
+	sampled, err = s.Sample(subnetID, 2)
+	require.NoError(err)
+	require.ElementsMatch([]ids.NodeID{nodeID0, nodeID1}, sampled)
+
+	// With full bias, the slow validator is only sampled if both draws
+	// selected it.
+	latencies[nodeID0] = time.Second
+	latencies[nodeID1] = time.Millisecond
+
+	counts := make(map[ids.NodeID]int)
+	for i := 0; i < 10_000; i++ {
+		sampled, err := s.Sample(subnetID, 1)
+		require.NoError(err)
+		counts[sampled[0]]++
+	}
+	require.InDelta(2_500, counts[nodeID0], 300)
+	require.InDelta(7_500, counts[nodeID1], 300)
+
+	// Removed validators must no longer be sampled.
+	require.NoError(m.RemoveWeight(subnetID, nodeID1, 1))
+
+	for i := 0; i < 100; i++ {
+		sampled, err := s.Sample(subnetID, 1)
+		require.NoError(err)
+		require.Equal([]ids.NodeID{nodeID0}, sampled)
+	}
+}
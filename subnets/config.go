@@ -49,6 +49,13 @@ type Config struct {
 	// TODO: Move this flag once the proposervm is configurable on a per-chain
 	// basis.
 	ProposerNumHistoricalBlocks uint64 `json:"proposerNumHistoricalBlocks" yaml:"proposerNumHistoricalBlocks"`
+
+	// InboundMsgAllocSize is the max number of bytes of unrequested inbound
+	// messages for this Subnet's chains that may be processing at once.
+	// Messages that would exceed the allocation are dropped. If 0, the Subnet
+	// is only limited by the allocation shared by all Subnets. Ignored for the
+	// primary network.
+	InboundMsgAllocSize uint64 `json:"inboundMsgAllocSize" yaml:"inboundMsgAllocSize"`
 }
 
 func (c *Config) Valid() error {
@@ -25,13 +25,22 @@ var (
 			return NewNoCompressor(), nil
 		},
 		TypeZstd: NewZstdCompressor,
+		TypeZstdDict: func(maxSize int64) (Compressor, error) {
+			return NewZstdDictCompressor(maxSize, testZstdDict)
+		},
+	}
+
+	testZstdDict = &ZstdDict{
+		ID:      1,
+		Content: []byte("a dictionary used for testing compression with a dictionary"),
 	}
 
 	//go:embed zstd_zip_bomb.bin
 	zstdZipBomb []byte
 
 	zipBombs = map[Type][]byte{
-		TypeZstd: zstdZipBomb,
+		TypeZstd:     zstdZipBomb,
+		TypeZstdDict: zstdZipBomb,
 	}
 )
 
@@ -153,6 +162,10 @@ func FuzzZstdCompressor(f *testing.F) {
 	fuzzHelper(f, TypeZstd)
 }
 
+func FuzzZstdDictCompressor(f *testing.F) {
+	fuzzHelper(f, TypeZstdDict)
+}
+
 func fuzzHelper(f *testing.F, compressionType Type) {
 	var (
 		compressor Compressor
@@ -162,6 +175,9 @@ func fuzzHelper(f *testing.F, compressionType Type) {
 	case TypeZstd:
 		compressor, err = NewZstdCompressor(maxMessageSize)
 		require.NoError(f, err)
+	case TypeZstdDict:
+		compressor, err = NewZstdDictCompressor(maxMessageSize, testZstdDict)
+		require.NoError(f, err)
 	default:
 		require.FailNow(f, "Unknown compression type")
 	}
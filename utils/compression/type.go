@@ -15,6 +15,10 @@ type Type byte
 const (
 	TypeNone Type = iota + 1
 	TypeZstd
+	// TypeZstdDict compresses messages with zstd and a dictionary that is
+	// shared with the peer. Messages sent to peers that don't support the
+	// dictionary are compressed with [TypeZstd].
+	TypeZstdDict
 )
 
 func (t Type) String() string {
@@ -23,6 +27,8 @@ func (t Type) String() string {
 		return "none"
 	case TypeZstd:
 		return "zstd"
+	case TypeZstdDict:
+		return "zstd-dict"
 	default:
 		return "unknown"
 	}
@@ -34,6 +40,8 @@ func TypeFromString(s string) (Type, error) {
 		return TypeNone, nil
 	case TypeZstd.String():
 		return TypeZstd, nil
+	case TypeZstdDict.String():
+		return TypeZstdDict, nil
 	default:
 		return TypeNone, errUnknownCompressionType
 	}
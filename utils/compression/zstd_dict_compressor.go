@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/DataDog/zstd"
+)
+
+// zstdDictIDLen is the number of bytes used to encode the ID of a dictionary.
+const zstdDictIDLen = 4
+
+var (
+	_ Compressor = (*zstdDictCompressor)(nil)
+
+	ErrEmptyZstdDict = errors.New("empty zstd dictionary")
+	errShortZstdDict = errors.New("zstd dictionary is too short")
+	errNilZstdDict   = errors.New("nil zstd dictionary")
+)
+
+// ZstdDict is a dictionary that is shared between peers to improve the
+// compression of small messages. Peers refer to dictionaries by their ID, so
+// the content of a dictionary must never change once its ID has been used.
+type ZstdDict struct {
+	ID      uint32
+	Content []byte
+}
+
+// ParseZstdDict parses a dictionary serialized with [ZstdDict.Bytes].
+func ParseZstdDict(b []byte) (*ZstdDict, error) {
+	if len(b) < zstdDictIDLen {
+		return nil, fmt.Errorf("%w: (%d) < (%d)", errShortZstdDict, len(b), zstdDictIDLen)
+	}
+	if len(b) == zstdDictIDLen {
+		return nil, ErrEmptyZstdDict
+	}
+	return &ZstdDict{
+		ID:      binary.BigEndian.Uint32(b),
+		Content: b[zstdDictIDLen:],
+	}, nil
+}
+
+// Bytes returns the serialized dictionary: its ID followed by its content.
+func (d *ZstdDict) Bytes() []byte {
+	b := make([]byte, zstdDictIDLen, zstdDictIDLen+len(d.Content))
+	binary.BigEndian.PutUint32(b, d.ID)
+	return append(b, d.Content...)
+}
+
+// NewZstdDictCompressor returns a zstd compressor that uses [dict] as a
+// dictionary. Messages compressed with a dictionary can only be decompressed
+// with the same dictionary.
+func NewZstdDictCompressor(maxSize int64, dict *ZstdDict) (Compressor, error) {
+	if maxSize == math.MaxInt64 {
+		// See NewZstdCompressor.
+		return nil, ErrInvalidMaxSizeCompressor
+	}
+	if dict == nil {
+		return nil, errNilZstdDict
+	}
+	if len(dict.Content) == 0 {
+		return nil, ErrEmptyZstdDict
+	}
+
+	processor, err := zstd.NewBulkProcessor(dict.Content, zstd.DefaultCompression)
+	if err != nil {
+		return nil, err
+	}
+	return &zstdDictCompressor{
+		maxSize:   maxSize,
+		dict:      dict.Content,
+		processor: processor,
+	}, nil
+}
+
+type zstdDictCompressor struct {
+	maxSize int64
+	dict    []byte
+	// processor holds the digested dictionary, so that it isn't digested
+	// again for every compressed message.
+	processor *zstd.BulkProcessor
+}
+
+func (z *zstdDictCompressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > z.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrMsgTooLarge, len(msg), z.maxSize)
+	}
+	return z.processor.Compress(nil, msg)
+}
+
+func (z *zstdDictCompressor) Decompress(msg []byte) ([]byte, error) {
+	// The bulk processor trusts the content size in the frame header, so a
+	// stream is used to bound the amount of memory allocated.
+	reader := zstd.NewReaderDict(bytes.NewReader(msg), z.dict)
+	defer reader.Close()
+
+	limitReader := io.LimitReader(reader, z.maxSize+1)
+	decompressed, err := io.ReadAll(limitReader)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > z.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrDecompressedMsgTooLarge, len(decompressed), z.maxSize)
+	}
+	return decompressed, nil
+}
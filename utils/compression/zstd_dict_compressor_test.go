@@ -0,0 +1,149 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseZstdDict(t *testing.T) {
+	tests := []struct {
+		name         string
+		bytes        []byte
+		expectedDict *ZstdDict
+		expectedErr  error
+	}{
+		{
+			name:        "too short",
+			bytes:       []byte{0, 0, 1},
+			expectedErr: errShortZstdDict,
+		},
+		{
+			name:        "empty",
+			bytes:       []byte{0, 0, 0, 1},
+			expectedErr: ErrEmptyZstdDict,
+		},
+		{
+			name:  "valid",
+			bytes: []byte{0, 0, 1, 2, 'd', 'i', 'c', 't'},
+			expectedDict: &ZstdDict{
+				ID:      0x0102,
+				Content: []byte("dict"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			dict, err := ParseZstdDict(tt.bytes)
+			require.ErrorIs(err, tt.expectedErr)
+			require.Equal(tt.expectedDict, dict)
+			if tt.expectedErr == nil {
+				require.Equal(tt.bytes, dict.Bytes())
+			}
+		})
+	}
+}
+
+func TestZstdDictCompressorRequiresDict(t *testing.T) {
+	require := require.New(t)
+
+	data := []byte("some data that is compressed with a dictionary")
+
+	compressor, err := NewZstdDictCompressor(maxMessageSize, testZstdDict)
+	require.NoError(err)
+	compressed, err := compressor.Compress(data)
+	require.NoError(err)
+
+	otherCompressor, err := NewZstdDictCompressor(maxMessageSize, &ZstdDict{
+		ID:      2,
+		Content: []byte("a different dictionary that doesn't match the other one"),
+	})
+	require.NoError(err)
+	decompressed, err := otherCompressor.Decompress(compressed)
+	if err == nil {
+		require.NotEqual(data, decompressed)
+	}
+
+	_, err = NewZstdDictCompressor(maxMessageSize, &ZstdDict{ID: 3})
+	require.ErrorIs(err, ErrEmptyZstdDict)
+}
+
+func TestTrainZstdDict(t *testing.T) {
+	require := require.New(t)
+
+	// Small messages with mostly shared content, like consensus messages.
+	samples := make([][]byte, 1000)
+	for i := range samples {
+		samples[i] = []byte(fmt.Sprintf(
+			"chain=2q9e4r6Mu3U68nU1fYjgbR6JvwrRx36CohpAX5UQxse55x1Q5 request=%d container=SkB92YpWm4UpburLz9tEKZw2i67H3FF6YkjaU4BkFUDTG9Xm height=%d",
+			i,
+			1_000_000+i,
+		))
+	}
+
+	dict, err := TrainZstdDict(samples, 4096)
+	require.NoError(err)
+	require.NotEmpty(dict)
+	require.LessOrEqual(len(dict), 4096)
+
+	zstdCompressor, err := NewZstdCompressor(maxMessageSize)
+	require.NoError(err)
+	dictCompressor, err := NewZstdDictCompressor(maxMessageSize, &ZstdDict{
+		ID:      1,
+		Content: dict,
+	})
+	require.NoError(err)
+
+	msg := []byte("chain=2q9e4r6Mu3U68nU1fYjgbR6JvwrRx36CohpAX5UQxse55x1Q5 request=123456 container=SkB92YpWm4UpburLz9tEKZw2i67H3FF6YkjaU4BkFUDTG9Xm height=2000000")
+	withoutDict, err := zstdCompressor.Compress(msg)
+	require.NoError(err)
+	withDict, err := dictCompressor.Compress(msg)
+	require.NoError(err)
+	require.Less(len(withDict), len(withoutDict))
+
+	decompressed, err := dictCompressor.Decompress(withDict)
+	require.NoError(err)
+	require.Equal(msg, decompressed)
+}
+
+func TestTrainZstdDictErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		samples     [][]byte
+		maxSize     int
+		expectedErr error
+	}{
+		{
+			name:        "size too small",
+			samples:     [][]byte{make([]byte, 1024)},
+			maxSize:     zstdDictMinSize - 1,
+			expectedErr: errDictSizeTooSmall,
+		},
+		{
+			name:        "no samples",
+			maxSize:     zstdDictMinSize,
+			expectedErr: errNoSamples,
+		},
+		{
+			name: "nothing in common",
+			samples: [][]byte{
+				[]byte("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ+/"),
+				[]byte("the quick brown fox jumps over the lazy dog, again and again....."),
+			},
+			maxSize:     zstdDictMinSize,
+			expectedErr: errNoCommonContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := TrainZstdDict(tt.samples, tt.maxSize)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
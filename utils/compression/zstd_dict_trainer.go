@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	// zstdDictDmerLen is the length of the substrings whose frequency is used to
+	// score segments of the samples.
+	zstdDictDmerLen = 8
+	// zstdDictSegmentLen is the length of the segments of the samples that are
+	// added to the dictionary.
+	zstdDictSegmentLen = 64
+	// zstdDictMinSize is the smallest dictionary that can be trained.
+	zstdDictMinSize = 256
+)
+
+var (
+	errNoSamples        = errors.New("no samples")
+	errDictSizeTooSmall = errors.New("dictionary size is too small")
+	errNoCommonContent  = errors.New("samples don't have any content in common")
+)
+
+// TrainZstdDict builds a dictionary of at most [maxSize] bytes from [samples],
+// which should be representative of the messages that will be compressed with
+// the dictionary.
+//
+// This is a simplified version of the COVER algorithm used by the zstd CLI:
+// the samples are split into epochs and, from each epoch, the segment whose
+// substrings appear in the most samples is added to the dictionary. The
+// substrings of added segments aren't counted again, so that the dictionary
+// doesn't contain the same content multiple times. The returned dictionary is
+// a raw content dictionary.
+func TrainZstdDict(samples [][]byte, maxSize int) ([]byte, error) {
+	if maxSize < zstdDictMinSize {
+		return nil, fmt.Errorf("%w: (%d) < (%d)", errDictSizeTooSmall, maxSize, zstdDictMinSize)
+	}
+
+	var (
+		data []byte
+		// freqs is the number of samples that contain each dmer.
+		freqs = make(map[uint64]int)
+		seen  = make(map[uint64]struct{})
+	)
+	for _, sample := range samples {
+		if len(sample) < zstdDictDmerLen {
+			continue
+		}
+
+		clear(seen)
+		for i := 0; i+zstdDictDmerLen <= len(sample); i++ {
+			dmer := binary.BigEndian.Uint64(sample[i:])
+			if _, ok := seen[dmer]; ok {
+				continue
+			}
+			seen[dmer] = struct{}{}
+			freqs[dmer]++
+		}
+		data = append(data, sample...)
+	}
+	if len(data) < zstdDictSegmentLen {
+		return nil, errNoSamples
+	}
+
+	// Content that only appears in a single sample isn't useful.
+	for dmer, freq := range freqs {
+		if freq < 2 {
+			delete(freqs, dmer)
+		}
+	}
+
+	var (
+		numEpochs = max(1, min(maxSize/zstdDictSegmentLen, len(data)/zstdDictSegmentLen))
+		epochLen  = len(data) / numEpochs
+		segments  [][]byte
+		size      int
+	)
+	for size < maxSize {
+		added := false
+		for epoch := 0; epoch < numEpochs && size < maxSize; epoch++ {
+			start := epoch * epochLen
+			segment, score := bestZstdDictSegment(data[start:start+epochLen], freqs)
+			if score == 0 {
+				continue
+			}
+
+			segment = segment[:min(len(segment), maxSize-size)]
+			for i := 0; i+zstdDictDmerLen <= len(segment); i++ {
+				delete(freqs, binary.BigEndian.Uint64(segment[i:]))
+			}
+			segments = append(segments, segment)
+			size += len(segment)
+			added = true
+		}
+		if !added {
+			break
+		}
+	}
+	if len(segments) == 0 {
+		return nil, errNoCommonContent
+	}
+
+	// zstd can reference content at the end of the dictionary with smaller
+	// offsets, so the best segments are placed last.
+	slices.Reverse(segments)
+	dict := make([]byte, 0, size)
+	for _, segment := range segments {
+		dict = append(dict, segment...)
+	}
+	return dict, nil
+}
+
+// bestZstdDictSegment returns the segment of [epoch] with the highest score,
+// where the score of a segment is the sum of the frequencies of its distinct
+// dmers.
+func bestZstdDictSegment(epoch []byte, freqs map[uint64]int) ([]byte, int) {
+	const dmersPerSegment = zstdDictSegmentLen - zstdDictDmerLen + 1
+	if len(epoch) < zstdDictSegmentLen {
+		return nil, 0
+	}
+
+	var (
+		active    = make(map[uint64]int)
+		score     int
+		bestScore int
+		bestStart int
+	)
+	for i := 0; i+zstdDictDmerLen <= len(epoch); i++ {
+		dmer := binary.BigEndian.Uint64(epoch[i:])
+		if active[dmer] == 0 {
+			score += freqs[dmer]
+		}
+		active[dmer]++
+
+		start := i - dmersPerSegment + 1
+		if start > 0 {
+			removed := binary.BigEndian.Uint64(epoch[start-1:])
+			active[removed]--
+			if active[removed] == 0 {
+				score -= freqs[removed]
+				delete(active, removed)
+			}
+		}
+		if start >= 0 && score > bestScore {
+			bestScore = score
+			bestStart = start
+		}
+	}
+	return epoch[bestStart : bestStart+zstdDictSegmentLen], bestScore
+}
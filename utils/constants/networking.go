@@ -29,6 +29,11 @@ const (
 	DefaultNetworkPeerListPullGossipFreq         = 2 * time.Second
 	DefaultNetworkPeerListBloomResetFreq         = time.Minute
 
+	// Peer Rotation
+	DefaultNetworkPeerRotationFrequency = 10 * time.Minute
+	DefaultNetworkPeerRotationPortion   = .05
+	DefaultNetworkPeerScoreHalflife     = 5 * time.Minute
+
 	// Inbound Connection Throttling
 	DefaultInboundConnUpgradeThrottlerCooldown = 10 * time.Second
 	DefaultInboundThrottlerMaxConnsPerSec      = 256
@@ -72,21 +77,33 @@ const (
 	DefaultFrontierPollFrequency    = 100 * time.Millisecond
 
 	// Inbound Throttling
-	DefaultInboundThrottlerAtLargeAllocSize         = 6 * units.MiB
-	DefaultInboundThrottlerVdrAllocSize             = 32 * units.MiB
-	DefaultInboundThrottlerNodeMaxAtLargeBytes      = DefaultMaxMessageSize
-	DefaultInboundThrottlerMaxProcessingMsgsPerNode = 1024
-	DefaultInboundThrottlerBandwidthRefillRate      = 512 * units.KiB
-	DefaultInboundThrottlerBandwidthMaxBurstSize    = DefaultMaxMessageSize
-	DefaultInboundThrottlerCPUMaxRecheckDelay       = 5 * time.Second
-	DefaultInboundThrottlerDiskMaxRecheckDelay      = 5 * time.Second
-	MinInboundThrottlerMaxRecheckDelay              = time.Millisecond
+	DefaultInboundThrottlerAtLargeAllocSize           = 6 * units.MiB
+	DefaultInboundThrottlerVdrAllocSize               = 32 * units.MiB
+	DefaultInboundThrottlerNodeMaxAtLargeBytes        = DefaultMaxMessageSize
+	DefaultInboundThrottlerMaxProcessingMsgsPerNode   = 1024
+	DefaultInboundThrottlerSubnetAllocSize            = DefaultInboundThrottlerAtLargeAllocSize + DefaultInboundThrottlerVdrAllocSize
+	DefaultInboundThrottlerPrimaryNetworkReservedSize = DefaultInboundThrottlerVdrAllocSize / 2
+	DefaultInboundThrottlerBandwidthRefillRate        = 512 * units.KiB
+	DefaultInboundThrottlerBandwidthMaxBurstSize      = DefaultMaxMessageSize
+	DefaultInboundThrottlerCPUMaxRecheckDelay         = 5 * time.Second
+	DefaultInboundThrottlerDiskMaxRecheckDelay        = 5 * time.Second
+	DefaultInboundThrottlerMemoryMaxRecheckDelay      = 5 * time.Second
+	MinInboundThrottlerMaxRecheckDelay                = time.Millisecond
 
 	// Outbound Throttling
 	DefaultOutboundThrottlerAtLargeAllocSize    = 32 * units.MiB
 	DefaultOutboundThrottlerVdrAllocSize        = 32 * units.MiB
 	DefaultOutboundThrottlerNodeMaxAtLargeBytes = DefaultMaxMessageSize
 
+	// Outbound Lanes
+	DefaultNetworkOutboundLanesEnabled          = false
+	DefaultNetworkOutboundConsensusLaneWeight   = 4
+	DefaultNetworkOutboundBootstrapLaneWeight   = 2
+	DefaultNetworkOutboundAppLaneWeight         = 1
+	DefaultNetworkOutboundConsensusLaneMaxBytes = 4 * units.MiB
+	DefaultNetworkOutboundBootstrapLaneMaxBytes = 16 * units.MiB
+	DefaultNetworkOutboundAppLaneMaxBytes       = 8 * units.MiB
+
 	// Network Health
 	DefaultHealthCheckAveragerHalflife = 10 * time.Second
 
@@ -3,7 +3,11 @@
 
 package dynamicip
 
-import "github.com/MetalBlockchain/metalgo/utils/logging"
+import (
+	"context"
+
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
 
 var _ Updater = noUpdater{}
 
@@ -16,3 +20,7 @@ type noUpdater struct{}
 func (noUpdater) Dispatch(logging.Logger) {}
 
 func (noUpdater) Stop() {}
+
+func (noUpdater) HealthCheck(context.Context) (interface{}, error) {
+	return nil, nil
+}
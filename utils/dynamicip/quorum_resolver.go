@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dynamicip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+var (
+	_ Resolver = (*quorumResolver)(nil)
+
+	errNoQuorum = errors.New("resolvers didn't agree on a public IP")
+)
+
+// quorumResolver resolves our public IP using several resolvers and only
+// accepts an IP that a majority of them agree on.
+type quorumResolver struct {
+	names     []string
+	resolvers []Resolver
+}
+
+type resolution struct {
+	addr netip.Addr
+	err  error
+}
+
+func (r *quorumResolver) Resolve(ctx context.Context) (netip.Addr, error) {
+	results := make([]resolution, len(r.resolvers))
+	done := make(chan struct{})
+	for i, resolver := range r.resolvers {
+		go func() {
+			addr, err := resolver.Resolve(ctx)
+			results[i] = resolution{
+				addr: addr,
+				err:  err,
+			}
+			done <- struct{}{}
+		}()
+	}
+	for range r.resolvers {
+		<-done
+	}
+
+	var (
+		votes = make(map[netip.Addr]int)
+		errs  []error
+	)
+	for i, result := range results {
+		if result.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.names[i], result.err))
+			continue
+		}
+
+		votes[result.addr]++
+		if votes[result.addr] > len(r.resolvers)/2 {
+			return result.addr, nil
+		}
+	}
+
+	for i, result := range results {
+		if result.err == nil {
+			errs = append(errs, fmt.Errorf("%s: resolved %s", r.names[i], result.addr))
+		}
+	}
+	return netip.Addr{}, fmt.Errorf("%w: %w", errNoQuorum, errors.Join(errs...))
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dynamicip
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("non-nil error")
+
+func TestQuorumResolver(t *testing.T) {
+	var (
+		addr1 = netip.AddrFrom4([4]byte{1, 2, 3, 4})
+		addr2 = netip.AddrFrom4([4]byte{5, 6, 7, 8})
+	)
+
+	tests := []struct {
+		name         string
+		results      []resolution
+		expectedAddr netip.Addr
+		expectedErr  error
+	}{
+		{
+			name: "all agree",
+			results: []resolution{
+				{addr: addr1},
+				{addr: addr1},
+				{addr: addr1},
+			},
+			expectedAddr: addr1,
+		},
+		{
+			name: "majority agree",
+			results: []resolution{
+				{addr: addr2},
+				{addr: addr1},
+				{addr: addr1},
+			},
+			expectedAddr: addr1,
+		},
+		{
+			name: "majority agree with failure",
+			results: []resolution{
+				{err: errTest},
+				{addr: addr1},
+				{addr: addr1},
+			},
+			expectedAddr: addr1,
+		},
+		{
+			name: "half agree",
+			results: []resolution{
+				{addr: addr1},
+				{addr: addr1},
+				{addr: addr2},
+				{err: errTest},
+			},
+			expectedErr: errNoQuorum,
+		},
+		{
+			name: "disagree",
+			results: []resolution{
+				{addr: addr1},
+				{addr: addr2},
+			},
+			expectedErr: errNoQuorum,
+		},
+		{
+			name: "failures",
+			results: []resolution{
+				{err: errTest},
+				{addr: addr1},
+				{err: errTest},
+			},
+			expectedErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			r := &quorumResolver{}
+			for _, result := range tt.results {
+				r.names = append(r.names, "mock")
+				r.resolvers = append(r.resolvers, &mockResolver{
+					onResolve: func(context.Context) (netip.Addr, error) {
+						return result.addr, result.err
+					},
+				})
+			}
+
+			addr, err := r.Resolve(context.Background())
+			require.ErrorIs(err, tt.expectedErr)
+			require.Equal(tt.expectedAddr, addr)
+		})
+	}
+}
+
+func TestNewQuorumResolver(t *testing.T) {
+	tests := []struct {
+		name         string
+		services     []string
+		expectedType Resolver
+		expectedErr  error
+	}{
+		{
+			name:        "no services",
+			expectedErr: errNoResolvers,
+		},
+		{
+			name:         "single service",
+			services:     []string{STUNName},
+			expectedType: &stunResolver{},
+		},
+		{
+			name:         "multiple services",
+			services:     []string{STUNName, OpenDNSName, IFConfigCoName},
+			expectedType: &quorumResolver{},
+		},
+		{
+			name:        "duplicate service",
+			services:    []string{STUNName, OpenDNSName, "STUN"},
+			expectedErr: errDuplicateResolver,
+		},
+		{
+			name:        "unknown service",
+			services:    []string{STUNName, "not a valid resolution service name"},
+			expectedErr: errUnknownResolver,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			resolver, err := NewQuorumResolver(tt.services)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				require.IsType(tt.expectedType, resolver)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/netip"
+	"slices"
 	"strings"
 )
 
@@ -22,9 +23,18 @@ const (
 	IFConfigName   = "ifconfig"
 	IFConfigCoName = "ifconfigco"
 	IFConfigMeName = "ifconfigme"
+	STUNName       = "stun"
+
+	// STUNPrefix may be followed by the host:port of a STUN server to use
+	// instead of the default one.
+	STUNPrefix = STUNName + ":"
 )
 
-var errUnknownResolver = errors.New("unknown resolver")
+var (
+	errUnknownResolver   = errors.New("unknown resolver")
+	errNoResolvers       = errors.New("no resolvers given")
+	errDuplicateResolver = errors.New("duplicate resolver")
+)
 
 // Resolver resolves our public IP
 type Resolver interface {
@@ -35,10 +45,18 @@ type Resolver interface {
 // Returns a new Resolver that uses the given service
 // to resolve our public IP.
 // [resolverName] must be one of:
-// [OpenDNSName], [IFConfigName], [IFConfigCoName], [IFConfigMeName].
+// [OpenDNSName], [IFConfigName], [IFConfigCoName], [IFConfigMeName],
+// [STUNName] or [STUNPrefix] followed by the host:port of a STUN server.
 // If [resolverService] isn't one of the above, returns an error
 func NewResolver(resolverName string) (Resolver, error) {
-	switch strings.ToLower(resolverName) {
+	resolverName = strings.ToLower(resolverName)
+	if server, ok := strings.CutPrefix(resolverName, STUNPrefix); ok {
+		return &stunResolver{server: server}, nil
+	}
+
+	switch resolverName {
+	case STUNName:
+		return &stunResolver{server: stunServer}, nil
 	case OpenDNSName:
 		return newOpenDNSResolver(), nil
 	case IFConfigName, IFConfigCoName:
@@ -49,3 +67,35 @@ func NewResolver(resolverName string) (Resolver, error) {
 		return nil, fmt.Errorf("%w: %s", errUnknownResolver, resolverName)
 	}
 }
+
+// NewQuorumResolver returns a new Resolver that uses all of the given services
+// to resolve our public IP. An IP is only returned if a majority of the
+// services resolved it. If a single service is given, this is equivalent to
+// NewResolver.
+func NewQuorumResolver(resolverNames []string) (Resolver, error) {
+	switch len(resolverNames) {
+	case 0:
+		return nil, errNoResolvers
+	case 1:
+		return NewResolver(resolverNames[0])
+	}
+
+	r := &quorumResolver{
+		names:     make([]string, len(resolverNames)),
+		resolvers: make([]Resolver, len(resolverNames)),
+	}
+	for i, name := range resolverNames {
+		name = strings.ToLower(name)
+		if slices.Contains(r.names[:i], name) {
+			return nil, fmt.Errorf("%w: %s", errDuplicateResolver, name)
+		}
+
+		resolver, err := NewResolver(name)
+		if err != nil {
+			return nil, err
+		}
+		r.names[i] = name
+		r.resolvers[i] = resolver
+	}
+	return r, nil
+}
@@ -36,6 +36,14 @@ func TestNewResolver(t *testing.T) {
 			service: strings.ToUpper(IFConfigMeName),
 			err:     nil,
 		},
+		{
+			service: STUNName,
+			err:     nil,
+		},
+		{
+			service: STUNPrefix + "127.0.0.1:3478",
+			err:     nil,
+		},
 		{
 			service: "not a valid resolution service name",
 			err:     errUnknownResolver,
@@ -0,0 +1,201 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dynamicip
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"time"
+)
+
+// See RFC 5389 for the specification of STUN.
+const (
+	stunServer = "stun.l.google.com:19302"
+
+	stunBindingRequest       = 0x0001
+	stunBindingSuccess       = 0x0101
+	stunBindingError         = 0x0111
+	stunMagicCookie          = 0x2112A442
+	stunHeaderLen            = 20
+	stunTransactionIDLen     = 12
+	stunMaxMessageLen        = 1280
+	stunAttrMappedAddress    = 0x0001
+	stunAttrXORMappedAddress = 0x0020
+	stunFamilyIPv4           = 0x01
+	stunFamilyIPv6           = 0x02
+
+	// stunInitialTimeout is the initial retransmission timeout. It doubles
+	// after each retransmission, until the context is done.
+	stunInitialTimeout = 500 * time.Millisecond
+)
+
+var (
+	_ Resolver = (*stunResolver)(nil)
+
+	errSTUNErrorResponse    = errors.New("STUN server returned an error")
+	errSTUNNoMappedAddress  = errors.New("STUN response has no mapped address")
+	errSTUNMalformedAddress = errors.New("malformed STUN address attribute")
+)
+
+// stunResolver resolves our public IP using a STUN Binding request.
+type stunResolver struct {
+	server string
+}
+
+func (r *stunResolver) Resolve(ctx context.Context) (netip.Addr, error) {
+	d := net.Dialer{}
+	conn, err := d.DialContext(ctx, "udp", r.server)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	defer conn.Close()
+
+	var transactionID [stunTransactionIDLen]byte
+	if _, err := rand.Read(transactionID[:]); err != nil {
+		return netip.Addr{}, err
+	}
+	request := make([]byte, stunHeaderLen)
+	binary.BigEndian.PutUint16(request, stunBindingRequest)
+	binary.BigEndian.PutUint32(request[4:], stunMagicCookie)
+	copy(request[8:], transactionID[:])
+
+	response := make([]byte, stunMaxMessageLen)
+	timeout := stunInitialTimeout
+	for {
+		if _, err := conn.Write(request); err != nil {
+			return netip.Addr{}, err
+		}
+
+		deadline := time.Now().Add(timeout)
+		if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return netip.Addr{}, err
+		}
+		timeout *= 2
+
+		for {
+			n, err := conn.Read(response)
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				break
+			}
+			if err != nil {
+				return netip.Addr{}, err
+			}
+
+			addr, ok, err := parseSTUNResponse(response[:n], transactionID)
+			if ok {
+				return addr, err
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return netip.Addr{}, fmt.Errorf("no response from STUN server %q: %w", r.server, err)
+		}
+	}
+}
+
+// parseSTUNResponse returns the address in [response]. ok is false if
+// [response] isn't a response to the request with [transactionID].
+func parseSTUNResponse(
+	response []byte,
+	transactionID [stunTransactionIDLen]byte,
+) (netip.Addr, bool, error) {
+	if len(response) < stunHeaderLen ||
+		binary.BigEndian.Uint32(response[4:]) != stunMagicCookie ||
+		[stunTransactionIDLen]byte(response[8:stunHeaderLen]) != transactionID {
+		return netip.Addr{}, false, nil
+	}
+
+	switch binary.BigEndian.Uint16(response) {
+	case stunBindingSuccess:
+	case stunBindingError:
+		return netip.Addr{}, true, errSTUNErrorResponse
+	default:
+		return netip.Addr{}, false, nil
+	}
+
+	length := int(binary.BigEndian.Uint16(response[2:]))
+	if stunHeaderLen+length > len(response) {
+		return netip.Addr{}, true, errSTUNNoMappedAddress
+	}
+
+	var (
+		attrs        = response[stunHeaderLen : stunHeaderLen+length]
+		mappedAddr   netip.Addr
+		mappedAddrOK bool
+	)
+	for len(attrs) >= 4 {
+		attrType := binary.BigEndian.Uint16(attrs)
+		attrLen := int(binary.BigEndian.Uint16(attrs[2:]))
+		if 4+attrLen > len(attrs) {
+			break
+		}
+		value := attrs[4 : 4+attrLen]
+
+		switch attrType {
+		case stunAttrXORMappedAddress:
+			// The address is XOR'd with the magic cookie and the transaction
+			// ID so that NATs can't rewrite it.
+			var key [16]byte
+			binary.BigEndian.PutUint32(key[:], stunMagicCookie)
+			copy(key[4:], transactionID[:])
+			addr, err := parseSTUNAddress(value, key)
+			return addr, true, err
+		case stunAttrMappedAddress:
+			addr, err := parseSTUNAddress(value, [16]byte{})
+			if err != nil {
+				return netip.Addr{}, true, err
+			}
+			mappedAddr, mappedAddrOK = addr, true
+		}
+
+		// Attributes are padded to a multiple of 4 bytes.
+		next := 4 + (attrLen+3)&^3
+		if next > len(attrs) {
+			break
+		}
+		attrs = attrs[next:]
+	}
+
+	// Older servers only include MAPPED-ADDRESS.
+	if mappedAddrOK {
+		return mappedAddr, true, nil
+	}
+	return netip.Addr{}, true, errSTUNNoMappedAddress
+}
+
+// parseSTUNAddress parses the value of an address attribute, XOR'd with [key].
+func parseSTUNAddress(value []byte, key [16]byte) (netip.Addr, error) {
+	if len(value) < 4 {
+		return netip.Addr{}, errSTUNMalformedAddress
+	}
+
+	var addrLen int
+	switch value[1] {
+	case stunFamilyIPv4:
+		addrLen = net.IPv4len
+	case stunFamilyIPv6:
+		addrLen = net.IPv6len
+	default:
+		return netip.Addr{}, errSTUNMalformedAddress
+	}
+	if len(value) != 4+addrLen {
+		return netip.Addr{}, errSTUNMalformedAddress
+	}
+
+	addrBytes := make([]byte, addrLen)
+	for i := range addrBytes {
+		addrBytes[i] = value[4+i] ^ key[i]
+	}
+	addr, _ := netip.AddrFromSlice(addrBytes)
+	return addr, nil
+}
@@ -0,0 +1,208 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dynamicip
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeSTUNServer starts a STUN server that responds to Binding requests
+// with the responses returned by [respond].
+func newFakeSTUNServer(
+	t *testing.T,
+	addr netip.Addr,
+	respond func(transactionID [stunTransactionIDLen]byte) [][]byte,
+) string {
+	conn, err := net.ListenUDP("udp", net.UDPAddrFromAddrPort(netip.AddrPortFrom(addr, 0)))
+	if err != nil {
+		t.Skipf("couldn't listen on %s: %s", addr, err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	go func() {
+		request := make([]byte, stunMaxMessageLen)
+		for {
+			n, from, err := conn.ReadFromUDPAddrPort(request)
+			if err != nil {
+				return
+			}
+			if n != stunHeaderLen || binary.BigEndian.Uint16(request) != stunBindingRequest {
+				continue
+			}
+
+			for _, response := range respond([stunTransactionIDLen]byte(request[8:stunHeaderLen])) {
+				_, _ = conn.WriteToUDPAddrPort(response, from)
+			}
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func marshalSTUNResponse(
+	messageType uint16,
+	transactionID [stunTransactionIDLen]byte,
+	attrs ...[]byte,
+) []byte {
+	response := make([]byte, stunHeaderLen)
+	binary.BigEndian.PutUint16(response, messageType)
+	binary.BigEndian.PutUint32(response[4:], stunMagicCookie)
+	copy(response[8:], transactionID[:])
+	for _, attr := range attrs {
+		response = append(response, attr...)
+	}
+	binary.BigEndian.PutUint16(response[2:], uint16(len(response)-stunHeaderLen))
+	return response
+}
+
+func marshalSTUNAddress(
+	attrType uint16,
+	addr netip.Addr,
+	transactionID [stunTransactionIDLen]byte,
+) []byte {
+	family := byte(stunFamilyIPv4)
+	if addr.Is6() {
+		family = stunFamilyIPv6
+	}
+	addrBytes := addr.AsSlice()
+	if attrType == stunAttrXORMappedAddress {
+		var key [16]byte
+		binary.BigEndian.PutUint32(key[:], stunMagicCookie)
+		copy(key[4:], transactionID[:])
+		for i := range addrBytes {
+			addrBytes[i] ^= key[i]
+		}
+	}
+
+	attr := make([]byte, 8, 8+len(addrBytes))
+	binary.BigEndian.PutUint16(attr, attrType)
+	binary.BigEndian.PutUint16(attr[2:], uint16(4+len(addrBytes)))
+	attr[5] = family
+	return append(attr, addrBytes...)
+}
+
+func TestSTUNResolver(t *testing.T) {
+	var (
+		ipv4 = netip.MustParseAddr("203.0.113.1")
+		ipv6 = netip.MustParseAddr("2001:db8::1")
+		// software is an attribute that must be skipped. Its length isn't a
+		// multiple of 4, so it is padded.
+		software = []byte{0x80, 0x22, 0, 3, 'f', 'o', 'o', 0}
+	)
+
+	tests := []struct {
+		name         string
+		serverAddr   netip.Addr
+		respond      func([stunTransactionIDLen]byte) [][]byte
+		expectedAddr netip.Addr
+		expectedErr  error
+	}{
+		{
+			name:       "xor mapped ipv4",
+			serverAddr: netip.MustParseAddr("127.0.0.1"),
+			respond: func(id [stunTransactionIDLen]byte) [][]byte {
+				return [][]byte{marshalSTUNResponse(
+					stunBindingSuccess,
+					id,
+					software,
+					marshalSTUNAddress(stunAttrXORMappedAddress, ipv4, id),
+				)}
+			},
+			expectedAddr: ipv4,
+		},
+		{
+			name:       "xor mapped ipv6",
+			serverAddr: netip.IPv6Loopback(),
+			respond: func(id [stunTransactionIDLen]byte) [][]byte {
+				return [][]byte{marshalSTUNResponse(
+					stunBindingSuccess,
+					id,
+					marshalSTUNAddress(stunAttrXORMappedAddress, ipv6, id),
+				)}
+			},
+			expectedAddr: ipv6,
+		},
+		{
+			name:       "mapped",
+			serverAddr: netip.MustParseAddr("127.0.0.1"),
+			respond: func(id [stunTransactionIDLen]byte) [][]byte {
+				return [][]byte{marshalSTUNResponse(
+					stunBindingSuccess,
+					id,
+					marshalSTUNAddress(stunAttrMappedAddress, ipv4, id),
+				)}
+			},
+			expectedAddr: ipv4,
+		},
+		{
+			name:       "other transaction ignored",
+			serverAddr: netip.MustParseAddr("127.0.0.1"),
+			respond: func(id [stunTransactionIDLen]byte) [][]byte {
+				otherID := id
+				otherID[0]++
+				return [][]byte{
+					marshalSTUNResponse(
+						stunBindingSuccess,
+						otherID,
+						marshalSTUNAddress(stunAttrXORMappedAddress, ipv6, otherID),
+					),
+					marshalSTUNResponse(
+						stunBindingSuccess,
+						id,
+						marshalSTUNAddress(stunAttrXORMappedAddress, ipv4, id),
+					),
+				}
+			},
+			expectedAddr: ipv4,
+		},
+		{
+			name:       "error response",
+			serverAddr: netip.MustParseAddr("127.0.0.1"),
+			respond: func(id [stunTransactionIDLen]byte) [][]byte {
+				return [][]byte{marshalSTUNResponse(stunBindingError, id)}
+			},
+			expectedErr: errSTUNErrorResponse,
+		},
+		{
+			name:       "no mapped address",
+			serverAddr: netip.MustParseAddr("127.0.0.1"),
+			respond: func(id [stunTransactionIDLen]byte) [][]byte {
+				return [][]byte{marshalSTUNResponse(stunBindingSuccess, id, software)}
+			},
+			expectedErr: errSTUNNoMappedAddress,
+		},
+		{
+			name:       "no response",
+			serverAddr: netip.MustParseAddr("127.0.0.1"),
+			respond: func([stunTransactionIDLen]byte) [][]byte {
+				return nil
+			},
+			expectedErr: context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			server := newFakeSTUNServer(t, tt.serverAddr, tt.respond)
+			resolver, err := NewResolver(STUNPrefix + server)
+			require.NoError(err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			addr, err := resolver.Resolve(ctx)
+			require.ErrorIs(err, tt.expectedErr)
+			require.Equal(tt.expectedAddr, addr)
+		})
+	}
+}
@@ -5,7 +5,9 @@ package dynamicip
 
 import (
 	"context"
+	"fmt"
 	"net/netip"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -27,6 +29,9 @@ type Updater interface {
 	Dispatch(log logging.Logger)
 	// Stop resolving and updating our public IP.
 	Stop()
+	// HealthCheck reports an error if our public IP couldn't be resolved the
+	// last time it was attempted.
+	HealthCheck(context.Context) (interface{}, error)
 }
 
 type updater struct {
@@ -44,6 +49,12 @@ type updater struct {
 	doneChan chan struct{}
 	// How often we update the public IP.
 	updateFreq time.Duration
+
+	lock sync.Mutex
+	// The error returned by the last call to resolver.Resolve(), if any.
+	lastErr error
+	// The last time resolver.Resolve() succeeded.
+	lastResolved time.Time
 }
 
 // Returns a new Updater that updates [dynamicIP]
@@ -62,6 +73,7 @@ func NewUpdater(
 		rootCtxCancel: cancel,
 		doneChan:      make(chan struct{}),
 		updateFreq:    updateFreq,
+		lastResolved:  time.Now(),
 	}
 }
 
@@ -85,6 +97,7 @@ func (u *updater) Dispatch(log logging.Logger) {
 			ctx, cancel := context.WithTimeout(u.rootCtx, ipResolutionTimeout)
 			newAddr, err := u.resolver.Resolve(ctx)
 			cancel()
+			u.setResult(err)
 			if err != nil {
 				log.Warn("couldn't resolve public IP. If this machine's IP recently changed, it may be sharing the wrong public IP with peers",
 					zap.Error(err),
@@ -106,6 +119,30 @@ func (u *updater) Dispatch(log logging.Logger) {
 	}
 }
 
+func (u *updater) setResult(err error) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.lastErr = err
+	if err == nil {
+		u.lastResolved = time.Now()
+	}
+}
+
+func (u *updater) HealthCheck(context.Context) (interface{}, error) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	details := map[string]interface{}{
+		"ip":           u.dynamicIP.Get().Addr().String(),
+		"lastResolved": u.lastResolved,
+	}
+	if u.lastErr != nil {
+		return details, fmt.Errorf("couldn't resolve public IP: %w", u.lastErr)
+	}
+	return details, nil
+}
+
 func (u *updater) Stop() {
 	// Cause Dispatch() to return and cancel all
 	// in-flight calls to resolver.Resolve().
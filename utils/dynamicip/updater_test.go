@@ -93,3 +93,52 @@ func TestNewUpdater(t *testing.T) {
 		require.FailNow("timeout waiting for doneChan to close")
 	}
 }
+
+func TestUpdaterHealthCheck(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		addr       = netip.AddrFrom4([4]byte{1, 2, 3, 4})
+		dynamicIP  = utils.NewAtomic(netip.AddrPortFrom(addr, 9651))
+		resolveErr = utils.NewAtomic[error](errTest)
+		resolved   = make(chan struct{})
+	)
+	u := NewUpdater(
+		dynamicIP,
+		&mockResolver{
+			onResolve: func(context.Context) (netip.Addr, error) {
+				defer func() {
+					resolved <- struct{}{}
+				}()
+				return addr, resolveErr.Get()
+			},
+		},
+		time.Millisecond,
+	)
+
+	_, err := u.HealthCheck(context.Background())
+	require.NoError(err)
+
+	go u.Dispatch(logging.NoLog{})
+
+	// A resolution's result is recorded before the next resolution starts.
+	<-resolved
+	<-resolved
+	_, err = u.HealthCheck(context.Background())
+	require.ErrorIs(err, errTest)
+
+	// The resolution in progress may have already read the previous error.
+	resolveErr.Set(nil)
+	<-resolved
+	<-resolved
+	<-resolved
+	_, err = u.HealthCheck(context.Background())
+	require.NoError(err)
+
+	go func() {
+		for range resolved {
+		}
+	}()
+	u.Stop()
+	close(resolved)
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ips
+
+import (
+	"errors"
+	"net/netip"
+	"slices"
+)
+
+var errUnknownPreference = errors.New("unknown IP preference")
+
+// Preference determines the order in which the IPs of a peer are attempted.
+type Preference byte
+
+const (
+	// NoPreference attempts the IPs in the order they were claimed by the
+	// peer.
+	NoPreference Preference = iota
+	PreferIPv4
+	PreferIPv6
+)
+
+func (p Preference) String() string {
+	switch p {
+	case NoPreference:
+		return "none"
+	case PreferIPv4:
+		return "ipv4"
+	case PreferIPv6:
+		return "ipv6"
+	default:
+		return "unknown"
+	}
+}
+
+func PreferenceFromString(s string) (Preference, error) {
+	switch s {
+	case NoPreference.String():
+		return NoPreference, nil
+	case PreferIPv4.String():
+		return PreferIPv4, nil
+	case PreferIPv6.String():
+		return PreferIPv6, nil
+	default:
+		return NoPreference, errUnknownPreference
+	}
+}
+
+func (p Preference) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + p.String() + `"`), nil
+}
+
+// Sort orders [addrPorts] so that the preferred IPs are first. The relative
+// order of equally preferred IPs is maintained.
+func (p Preference) Sort(addrPorts []netip.AddrPort) {
+	if p == NoPreference {
+		return
+	}
+
+	preferIPv6 := p == PreferIPv6
+	slices.SortStableFunc(addrPorts, func(a, b netip.AddrPort) int {
+		aIs6 := a.Addr().Is6() && !a.Addr().Is4In6()
+		bIs6 := b.Addr().Is6() && !b.Addr().Is4In6()
+		switch {
+		case aIs6 == bIs6:
+			return 0
+		case aIs6 == preferIPv6:
+			return -1
+		default:
+			return 1
+		}
+	})
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ips
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferenceSort(t *testing.T) {
+	var (
+		ipv4A = netip.MustParseAddrPort("1.2.3.4:9651")
+		ipv4B = netip.MustParseAddrPort("5.6.7.8:9651")
+		ipv6A = netip.MustParseAddrPort("[2001:db8::1]:9651")
+		ipv6B = netip.MustParseAddrPort("[2001:db8::2]:9651")
+	)
+
+	tests := []struct {
+		preference Preference
+		expected   []netip.AddrPort
+	}{
+		{
+			preference: NoPreference,
+			expected:   []netip.AddrPort{ipv6A, ipv4A, ipv6B, ipv4B},
+		},
+		{
+			preference: PreferIPv4,
+			expected:   []netip.AddrPort{ipv4A, ipv4B, ipv6A, ipv6B},
+		},
+		{
+			preference: PreferIPv6,
+			expected:   []netip.AddrPort{ipv6A, ipv6B, ipv4A, ipv4B},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.preference.String(), func(t *testing.T) {
+			addrPorts := []netip.AddrPort{ipv6A, ipv4A, ipv6B, ipv4B}
+			test.preference.Sort(addrPorts)
+			require.Equal(t, test.expected, addrPorts)
+		})
+	}
+}
+
+func TestPreferenceFromString(t *testing.T) {
+	for _, preference := range []Preference{NoPreference, PreferIPv4, PreferIPv6} {
+		parsed, err := PreferenceFromString(preference.String())
+		require.NoError(t, err)
+		require.Equal(t, preference, parsed)
+	}
+
+	_, err := PreferenceFromString("ipv5")
+	require.ErrorIs(t, err, errUnknownPreference)
+}
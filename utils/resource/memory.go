@@ -0,0 +1,25 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package resource
+
+import (
+	"math"
+	"runtime/debug"
+
+	"github.com/shirou/gopsutil/mem"
+)
+
+// MemoryLimit returns the number of bytes of memory available to this process.
+// This is the smaller of the total memory of the system and the Go runtime's
+// soft memory limit, which can be set with GOMEMLIMIT.
+func MemoryLimit() uint64 {
+	limit := uint64(math.MaxUint64)
+	if softLimit := debug.SetMemoryLimit(-1); softLimit > 0 {
+		limit = uint64(softLimit)
+	}
+	if stat, err := mem.VirtualMemory(); err == nil && stat.Total > 0 {
+		limit = min(limit, stat.Total)
+	}
+	return limit
+}
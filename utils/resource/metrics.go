@@ -15,6 +15,8 @@ type metrics struct {
 	numDiskReadBytes   *prometheus.GaugeVec
 	numDiskWrites      *prometheus.GaugeVec
 	numDiskWritesBytes *prometheus.GaugeVec
+	residentBytes      *prometheus.GaugeVec
+	heapBytes          prometheus.Gauge
 }
 
 func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
@@ -54,6 +56,19 @@ func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
 			},
 			[]string{"processID"},
 		),
+		residentBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "resident_bytes",
+				Help: "Number of bytes of memory resident",
+			},
+			[]string{"processID"},
+		),
+		heapBytes: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "heap_bytes",
+				Help: "Number of bytes of the Go heap occupied by objects",
+			},
+		),
 	}
 	err := errors.Join(
 		registerer.Register(m.numCPUCycles),
@@ -61,6 +76,8 @@ func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
 		registerer.Register(m.numDiskReadBytes),
 		registerer.Register(m.numDiskWrites),
 		registerer.Register(m.numDiskWritesBytes),
+		registerer.Register(m.residentBytes),
+		registerer.Register(m.heapBytes),
 	)
 	return m, err
 }
@@ -21,3 +21,15 @@ func (noUsage) DiskUsage() (float64, float64) {
 func (noUsage) AvailableDiskBytes() uint64 {
 	return math.MaxUint64
 }
+
+func (noUsage) MemoryUsage() uint64 {
+	return 0
+}
+
+func (noUsage) HeapUsage() uint64 {
+	return 0
+}
+
+func (noUsage) AllocatedBytes() uint64 {
+	return 0
+}
@@ -38,6 +38,20 @@ func (m *User) EXPECT() *UserMockRecorder {
 	return m.recorder
 }
 
+// AllocatedBytes mocks base method.
+func (m *User) AllocatedBytes() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocatedBytes")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// AllocatedBytes indicates an expected call of AllocatedBytes.
+func (mr *UserMockRecorder) AllocatedBytes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocatedBytes", reflect.TypeOf((*User)(nil).AllocatedBytes))
+}
+
 // AvailableDiskBytes mocks base method.
 func (m *User) AvailableDiskBytes() uint64 {
 	m.ctrl.T.Helper()
@@ -80,3 +94,31 @@ func (mr *UserMockRecorder) DiskUsage() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiskUsage", reflect.TypeOf((*User)(nil).DiskUsage))
 }
+
+// HeapUsage mocks base method.
+func (m *User) HeapUsage() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeapUsage")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// HeapUsage indicates an expected call of HeapUsage.
+func (mr *UserMockRecorder) HeapUsage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeapUsage", reflect.TypeOf((*User)(nil).HeapUsage))
+}
+
+// MemoryUsage mocks base method.
+func (m *User) MemoryUsage() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MemoryUsage")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// MemoryUsage indicates an expected call of MemoryUsage.
+func (mr *UserMockRecorder) MemoryUsage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MemoryUsage", reflect.TypeOf((*User)(nil).MemoryUsage))
+}
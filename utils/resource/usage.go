@@ -16,6 +16,17 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/storage"
+
+	runtimemetrics "runtime/metrics"
+)
+
+const (
+	// heapObjectsMetric is the runtime metric of the bytes occupied by live
+	// and not yet freed objects on the heap.
+	heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+	// heapAllocsMetric is the runtime metric of the cumulative bytes allocated
+	// on the heap.
+	heapAllocsMetric = "/gc/heap/allocs:bytes"
 )
 
 var (
@@ -43,9 +54,24 @@ type DiskUser interface {
 	AvailableDiskBytes() uint64
 }
 
+type MemoryUser interface {
+	// MemoryUsage returns the number of bytes of memory that are resident for
+	// all tracked processes.
+	MemoryUsage() uint64
+
+	// HeapUsage returns the number of bytes of this process's Go heap that are
+	// occupied by objects.
+	HeapUsage() uint64
+
+	// AllocatedBytes returns the cumulative number of bytes allocated on this
+	// process's Go heap.
+	AllocatedBytes() uint64
+}
+
 type User interface {
 	CPUUser
 	DiskUser
+	MemoryUser
 }
 
 type ProcessTracker interface {
@@ -82,6 +108,14 @@ type manager struct {
 
 	availableDiskBytes uint64
 
+	// [memoryUsage] is the number of bytes resident for all processes.
+	memoryUsage uint64
+	// [heapUsage] is the number of bytes of this process's Go heap in use.
+	heapUsage uint64
+	// [allocatedBytes] is the number of bytes allocated on this process's Go
+	// heap.
+	allocatedBytes uint64
+
 	closeOnce sync.Once
 	onClose   chan struct{}
 }
@@ -132,6 +166,27 @@ func (m *manager) AvailableDiskBytes() uint64 {
 	return m.availableDiskBytes
 }
 
+func (m *manager) MemoryUsage() uint64 {
+	m.usageLock.RLock()
+	defer m.usageLock.RUnlock()
+
+	return m.memoryUsage
+}
+
+func (m *manager) HeapUsage() uint64 {
+	m.usageLock.RLock()
+	defer m.usageLock.RUnlock()
+
+	return m.heapUsage
+}
+
+func (m *manager) AllocatedBytes() uint64 {
+	m.usageLock.RLock()
+	defer m.usageLock.RUnlock()
+
+	return m.allocatedBytes
+}
+
 func (m *manager) TrackProcess(pid int) {
 	p, err := process.NewProcess(int32(pid))
 	if err != nil {
@@ -168,8 +223,12 @@ func (m *manager) update(diskPath string, frequency, cpuHalflife, diskHalflife t
 	newDiskWeight, oldDiskWeight := getSampleWeights(frequency, diskHalflife)
 
 	frequencyInSeconds := frequency.Seconds()
+	heapSamples := []runtimemetrics.Sample{
+		{Name: heapObjectsMetric},
+		{Name: heapAllocsMetric},
+	}
 	for {
-		currentCPUUsage, currentReadUsage, currentWriteUsage := m.getActiveUsage(frequencyInSeconds)
+		currentCPUUsage, currentReadUsage, currentWriteUsage, currentMemoryUsage := m.getActiveUsage(frequencyInSeconds)
 		currentScaledCPUUsage := newCPUWeight * currentCPUUsage
 		currentScaledReadUsage := newDiskWeight * currentReadUsage
 		currentScaledWriteUsage := newDiskWeight * currentWriteUsage
@@ -183,6 +242,18 @@ func (m *manager) update(diskPath string, frequency, cpuHalflife, diskHalflife t
 			)
 		}
 
+		// Memory usage is a level rather than a rate, so the latest sample is
+		// used as is.
+		runtimemetrics.Read(heapSamples)
+		var currentHeapUsage, currentAllocatedBytes uint64
+		if heapSamples[0].Value.Kind() == runtimemetrics.KindUint64 {
+			currentHeapUsage = heapSamples[0].Value.Uint64()
+		}
+		if heapSamples[1].Value.Kind() == runtimemetrics.KindUint64 {
+			currentAllocatedBytes = heapSamples[1].Value.Uint64()
+		}
+		m.processMetrics.heapBytes.Set(float64(currentHeapUsage))
+
 		m.usageLock.Lock()
 		m.cpuUsage = oldCPUWeight*m.cpuUsage + currentScaledCPUUsage
 		m.readUsage = oldDiskWeight*m.readUsage + currentScaledReadUsage
@@ -192,6 +263,10 @@ func (m *manager) update(diskPath string, frequency, cpuHalflife, diskHalflife t
 			m.availableDiskBytes = availableBytes
 		}
 
+		m.memoryUsage = currentMemoryUsage
+		m.heapUsage = currentHeapUsage
+		m.allocatedBytes = currentAllocatedBytes
+
 		m.usageLock.Unlock()
 
 		select {
@@ -206,20 +281,23 @@ func (m *manager) update(diskPath string, frequency, cpuHalflife, diskHalflife t
 // 1. Current CPU usage by all processes.
 // 2. Current bytes/sec read from disk by all processes.
 // 3. Current bytes/sec written to disk by all processes.
-func (m *manager) getActiveUsage(secondsSinceLastUpdate float64) (float64, float64, float64) {
+// 4. Current bytes of memory resident for all processes.
+func (m *manager) getActiveUsage(secondsSinceLastUpdate float64) (float64, float64, float64, uint64) {
 	m.processesLock.Lock()
 	defer m.processesLock.Unlock()
 
 	var (
-		totalCPU   float64
-		totalRead  float64
-		totalWrite float64
+		totalCPU    float64
+		totalRead   float64
+		totalWrite  float64
+		totalMemory uint64
 	)
 	for _, p := range m.processes {
 		cpu, read, write := p.getActiveUsage(secondsSinceLastUpdate)
 		totalCPU += cpu
 		totalRead += read
 		totalWrite += write
+		totalMemory += p.lastResidentBytes
 
 		processIDStr := strconv.Itoa(int(p.p.Pid))
 		m.processMetrics.numCPUCycles.WithLabelValues(processIDStr).Set(p.lastTotalCPU)
@@ -227,9 +305,10 @@ func (m *manager) getActiveUsage(secondsSinceLastUpdate float64) (float64, float
 		m.processMetrics.numDiskReadBytes.WithLabelValues(processIDStr).Set(float64(p.lastReadBytes))
 		m.processMetrics.numDiskWrites.WithLabelValues(processIDStr).Set(float64(p.numWrites))
 		m.processMetrics.numDiskWritesBytes.WithLabelValues(processIDStr).Set(float64(p.lastWriteBytes))
+		m.processMetrics.residentBytes.WithLabelValues(processIDStr).Set(float64(p.lastResidentBytes))
 	}
 
-	return totalCPU, totalRead, totalWrite
+	return totalCPU, totalRead, totalWrite, totalMemory
 }
 
 type proc struct {
@@ -251,6 +330,10 @@ type proc struct {
 	// [lastWriteBytes] is the most recent measurement of total disk bytes
 	// written.
 	lastWriteBytes uint64
+
+	// [lastResidentBytes] is the most recent measurement of the resident set
+	// size.
+	lastResidentBytes uint64
 }
 
 func (p *proc) getActiveUsage(secondsSinceLastUpdate float64) (float64, float64, float64) {
@@ -278,6 +361,16 @@ func (p *proc) getActiveUsage(secondsSinceLastUpdate float64) (float64, float64,
 		io = &process.IOCountersStat{}
 	}
 
+	memory, err := p.p.MemoryInfo()
+	if err != nil {
+		p.log.Verbo("failed to lookup resource",
+			zap.String("resource", "process memory"),
+			zap.Int32("pid", p.p.Pid),
+			zap.Error(err),
+		)
+		memory = &process.MemoryInfoStat{}
+	}
+
 	var (
 		cpu   float64
 		read  float64
@@ -305,6 +398,7 @@ func (p *proc) getActiveUsage(secondsSinceLastUpdate float64) (float64, float64,
 	p.lastReadBytes = io.ReadBytes
 	p.numWrites = io.WriteCount
 	p.lastWriteBytes = io.WriteBytes
+	p.lastResidentBytes = memory.RSS
 
 	return cpu, read, write
 }
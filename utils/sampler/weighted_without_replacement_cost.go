@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sampler
+
+// biasPrecision is the number of distinct values the bias is quantized to.
+const biasPrecision = 1 << 53
+
+// Cost reports the cost of sampling [index]. If the cost of [index] is
+// unknown, false is returned.
+type Cost func(index int) (uint64, bool)
+
+// NewCostAwareWeightedWithoutReplacement returns a sampler that samples weight
+// without replacement, but that prefers cheaper indices among indices of equal
+// weight.
+//
+// Each sampled index is drawn in two steps. First, the weight of the index is
+// sampled exactly as [NewWeightedWithoutReplacement] would. This means that
+// the total weight sampled from every set of equally weighted indices has the
+// same distribution as it would have without this sampler. Second, the index
+// is drawn from its set of equally weighted indices. With probability [bias],
+// a second index is drawn from the set and the index with the lower known cost
+// is returned.
+//
+// As a result, the probability of any draw returning an index is bounded by
+// [1-bias, 1+bias] times the probability of drawing it by weight alone.
+//
+// [bias] must be in [0, 1].
+func NewCostAwareWeightedWithoutReplacement(bias float64, cost Cost) WeightedWithoutReplacement {
+	return newWeightedWithoutReplacementCost(globalRNG, NewWeightedWithoutReplacement(), bias, cost)
+}
+
+// NewDeterministicCostAwareWeightedWithoutReplacement returns a new sampler
+func NewDeterministicCostAwareWeightedWithoutReplacement(
+	source Source,
+	bias float64,
+	cost Cost,
+) WeightedWithoutReplacement {
+	return newWeightedWithoutReplacementCost(
+		&rng{
+			rng: source,
+		},
+		NewDeterministicWeightedWithoutReplacement(source),
+		bias,
+		cost,
+	)
+}
+
+func newWeightedWithoutReplacementCost(
+	rng *rng,
+	s WeightedWithoutReplacement,
+	bias float64,
+	cost Cost,
+) *weightedWithoutReplacementCost {
+	return &weightedWithoutReplacementCost{
+		rng:  rng,
+		s:    s,
+		bias: uint64(min(max(bias, 0), 1) * biasPrecision),
+		cost: cost,
+	}
+}
+
+// weightedWithoutReplacementCost re-draws the indices sampled by [s] from the
+// indices of equal weight.
+//
+// Within a set of equally weighted indices, an index is drawn with probability
+// proportional to its remaining weight, which is exactly the distribution [s]
+// would have sampled it with. This is done by rejection sampling, which
+// terminates quickly as long as the number of samples is small relative to the
+// total weight of the set.
+type weightedWithoutReplacementCost struct {
+	rng  *rng
+	s    WeightedWithoutReplacement
+	bias uint64
+	cost Cost
+
+	weights []uint64
+	// classes maps each index to the indices that have the same weight,
+	// including itself. Indices with a unique weight are not included.
+	classes map[int][]int
+}
+
+func (s *weightedWithoutReplacementCost) Initialize(weights []uint64) error {
+	if err := s.s.Initialize(weights); err != nil {
+		return err
+	}
+
+	byWeight := make(map[uint64][]int)
+	for index, weight := range weights {
+		if weight == 0 {
+			continue
+		}
+		byWeight[weight] = append(byWeight[weight], index)
+	}
+
+	s.weights = weights
+	s.classes = make(map[int][]int)
+	for _, class := range byWeight {
+		if len(class) < 2 {
+			continue
+		}
+		for _, index := range class {
+			s.classes[index] = class
+		}
+	}
+	return nil
+}
+
+func (s *weightedWithoutReplacementCost) Sample(count int) ([]int, bool) {
+	indices, ok := s.s.Sample(count)
+	if !ok || s.bias == 0 || len(s.classes) == 0 {
+		return indices, ok
+	}
+
+	// drawn tracks how much weight has been drawn from each index that has
+	// been re-drawn.
+	drawn := make(map[int]uint64)
+	for i, index := range indices {
+		class, ok := s.classes[index]
+		if !ok {
+			continue
+		}
+
+		candidate := s.draw(class, drawn)
+		if s.rng.Uint64Inclusive(biasPrecision-1) < s.bias {
+			challenger := s.draw(class, drawn)
+			if s.cheaper(challenger, candidate) {
+				candidate = challenger
+			}
+		}
+
+		drawn[candidate]++
+		indices[i] = candidate
+	}
+	return indices, true
+}
+
+// draw returns an index in [class] with probability proportional to its
+// remaining weight.
+//
+// Invariant: [class] must have remaining weight.
+func (s *weightedWithoutReplacementCost) draw(class []int, drawn map[int]uint64) int {
+	weight := s.weights[class[0]]
+	for {
+		index := class[s.rng.Uint64Inclusive(uint64(len(class)-1))]
+		if s.rng.Uint64Inclusive(weight-1) >= drawn[index] {
+			return index
+		}
+	}
+}
+
+// cheaper returns true if the cost of both indices is known and the cost of
+// [a] is less than the cost of [b].
+func (s *weightedWithoutReplacementCost) cheaper(a, b int) bool {
+	aCost, ok := s.cost(a)
+	if !ok {
+		return false
+	}
+	bCost, ok := s.cost(b)
+	return ok && aCost < bCost
+}
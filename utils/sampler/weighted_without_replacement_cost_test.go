@@ -0,0 +1,190 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sampler
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mathext/prng"
+)
+
+// costByIndex makes higher indices more expensive, with [unknown] indices
+// having no known cost.
+func costByIndex(unknown ...int) Cost {
+	return func(index int) (uint64, bool) {
+		return uint64(index), !slices.Contains(unknown, index)
+	}
+}
+
+func newTestCostSampler(bias float64, cost Cost) WeightedWithoutReplacement {
+	source := prng.NewMT19937_64()
+	source.Seed(0)
+	return NewDeterministicCostAwareWeightedWithoutReplacement(source, bias, cost)
+}
+
+func TestCostAwareWeightedWithoutReplacementOutOfRange(t *testing.T) {
+	require := require.New(t)
+	s := newTestCostSampler(1, costByIndex())
+
+	require.NoError(s.Initialize([]uint64{1, 1}))
+
+	_, ok := s.Sample(3)
+	require.False(ok)
+}
+
+func TestCostAwareWeightedWithoutReplacementExhaustsWeight(t *testing.T) {
+	require := require.New(t)
+	s := newTestCostSampler(1, costByIndex())
+
+	require.NoError(s.Initialize([]uint64{1, 1, 1, 2, 2}))
+
+	for i := 0; i < 100; i++ {
+		indices, ok := s.Sample(7)
+		require.True(ok)
+
+		slices.Sort(indices)
+		require.Equal([]int{0, 1, 2, 3, 3, 4, 4}, indices)
+	}
+}
+
+func TestCostAwareWeightedWithoutReplacementZeroBias(t *testing.T) {
+	require := require.New(t)
+
+	weights := []uint64{1, 1, 1, 1, 2, 3}
+	costAware := newTestCostSampler(0, costByIndex())
+	require.NoError(costAware.Initialize(weights))
+
+	source := prng.NewMT19937_64()
+	source.Seed(0)
+	byWeight := NewDeterministicWeightedWithoutReplacement(source)
+	require.NoError(byWeight.Initialize(weights))
+
+	for i := 0; i < 100; i++ {
+		expected, ok := byWeight.Sample(3)
+		require.True(ok)
+
+		indices, ok := costAware.Sample(3)
+		require.True(ok)
+		require.Equal(expected, indices)
+	}
+}
+
+func TestCostAwareWeightedWithoutReplacementPrefersCheaper(t *testing.T) {
+	require := require.New(t)
+
+	// With a single sample and full bias, index 0 is returned unless both
+	// draws were index 1, which happens with probability 1/4.
+	s := newTestCostSampler(1, costByIndex())
+	require.NoError(s.Initialize([]uint64{1, 1}))
+
+	counts := make([]int, 2)
+	for i := 0; i < 10_000; i++ {
+		indices, ok := s.Sample(1)
+		require.True(ok)
+		counts[indices[0]]++
+	}
+	require.InDelta(7_500, counts[0], 300)
+	require.InDelta(2_500, counts[1], 300)
+}
+
+func TestCostAwareWeightedWithoutReplacementUnknownCost(t *testing.T) {
+	require := require.New(t)
+
+	// Indices without a known cost are neither preferred nor avoided.
+	s := newTestCostSampler(1, costByIndex(0))
+	require.NoError(s.Initialize([]uint64{1, 1}))
+
+	counts := make([]int, 2)
+	for i := 0; i < 10_000; i++ {
+		indices, ok := s.Sample(1)
+		require.True(ok)
+		counts[indices[0]]++
+	}
+	require.InDelta(5_000, counts[0], 300)
+	require.InDelta(5_000, counts[1], 300)
+}
+
+// TestCostAwareWeightedWithoutReplacementStakeBounds verifies the safety
+// properties of the sampler across biases:
+//
+//  1. The weight sampled from each set of equally weighted indices matches
+//     sampling by weight alone.
+//  2. The share of samples of every index stays within [1-bias, 1+bias] times
+//     its share when sampling by weight alone, regardless of how favorable or
+//     unfavorable its cost is.
+func TestCostAwareWeightedWithoutReplacementStakeBounds(t *testing.T) {
+	const (
+		numSamples = 20
+		numTrials  = 5_000
+		// tolerance is the allowed statistical error of a share.
+		tolerance = 0.01
+	)
+
+	// Most weight is split evenly across many indices, as is common for
+	// validator sets, with a few larger indices of unique weight.
+	weights := []uint64{
+		100, 100, 100, 100, 100, 100, 100, 100, 100, 100,
+		100, 100, 100, 100, 100, 100, 100, 100, 100, 100,
+		250, 250, 250, 250,
+		500, 1000,
+	}
+
+	byWeight := func() []float64 {
+		source := prng.NewMT19937_64()
+		source.Seed(1)
+		s := NewDeterministicWeightedWithoutReplacement(source)
+		require.NoError(t, s.Initialize(weights))
+		return sampleShares(t, s, len(weights), numSamples, numTrials)
+	}()
+
+	for _, bias := range []float64{0, .1, .25, .5, 1} {
+		t.Run(fmt.Sprintf("bias=%v", bias), func(t *testing.T) {
+			require := require.New(t)
+
+			s := newTestCostSampler(bias, costByIndex())
+			require.NoError(s.Initialize(weights))
+			shares := sampleShares(t, s, len(weights), numSamples, numTrials)
+
+			classShares := make(map[uint64]float64)
+			expectedClassShares := make(map[uint64]float64)
+			for index, weight := range weights {
+				classShares[weight] += shares[index]
+				expectedClassShares[weight] += byWeight[index]
+			}
+			for weight, expected := range expectedClassShares {
+				require.InDelta(expected, classShares[weight], tolerance, "weight %d", weight)
+			}
+
+			for index, expected := range byWeight {
+				require.GreaterOrEqual(shares[index], (1-bias)*expected-tolerance, "index %d", index)
+				require.LessOrEqual(shares[index], (1+bias)*expected+tolerance, "index %d", index)
+			}
+		})
+	}
+}
+
+// sampleShares returns the share of samples of each index.
+func sampleShares(
+	t *testing.T,
+	s WeightedWithoutReplacement,
+	numIndices int,
+	numSamples int,
+	numTrials int,
+) []float64 {
+	shares := make([]float64, numIndices)
+	for i := 0; i < numTrials; i++ {
+		indices, ok := s.Sample(numSamples)
+		require.True(t, ok)
+		for _, index := range indices {
+			shares[index]++
+		}
+	}
+	for index := range shares {
+		shares[index] /= float64(numSamples * numTrials)
+	}
+	return shares
+}
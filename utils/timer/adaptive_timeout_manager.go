@@ -24,6 +24,7 @@ var (
 	errTooSmallTimeoutCoefficient = errors.New("timeout coefficient must be >= 1")
 
 	_ AdaptiveTimeoutManager = (*adaptiveTimeoutManager)(nil)
+	_ Scheduler              = (*Timer)(nil)
 )
 
 type adaptiveTimeout struct {
@@ -70,10 +71,24 @@ type AdaptiveTimeoutManager interface {
 	ObserveLatency(latency time.Duration)
 }
 
+// Scheduler calls a handler once a timeout elapses. *Timer is a Scheduler that
+// uses the wall clock.
+type Scheduler interface {
+	// Dispatch runs the scheduler until Stop is called.
+	Dispatch()
+	// Stop the scheduler from calling the handler.
+	Stop()
+	// SetTimeoutIn sets the handler to be called in [duration], replacing any
+	// previously set timeout.
+	SetTimeoutIn(duration time.Duration)
+	// Cancel the currently set timeout.
+	Cancel()
+}
+
 type adaptiveTimeoutManager struct {
 	lock sync.Mutex
 	// Tells the time. Can be faked for testing.
-	clock                            mockable.Clock
+	clock                            *mockable.Clock
 	networkTimeoutMetric, avgLatency prometheus.Gauge
 	numTimeouts                      prometheus.Counter
 	numPendingTimeouts               prometheus.Gauge
@@ -86,12 +101,31 @@ type adaptiveTimeoutManager struct {
 	maximumTimeout     time.Duration
 	currentTimeout     time.Duration // Amount of time before a timeout
 	timeoutHeap        heap.Map[ids.RequestID, *adaptiveTimeout]
-	timer              *Timer // Timer that will fire to clear the timeouts
+	timer              Scheduler // Timer that will fire to clear the timeouts
 }
 
 func NewAdaptiveTimeoutManager(
 	config *AdaptiveTimeoutConfig,
 	reg prometheus.Registerer,
+) (AdaptiveTimeoutManager, error) {
+	return NewAdaptiveTimeoutManagerWithScheduler(
+		config,
+		reg,
+		&mockable.Clock{},
+		func(handler func()) Scheduler {
+			return NewTimer(handler)
+		},
+	)
+}
+
+// NewAdaptiveTimeoutManagerWithScheduler returns an AdaptiveTimeoutManager that
+// reads the time from [clock] and fires timeouts through the Scheduler returned
+// by [newScheduler]. This allows the manager to be run on a virtual clock.
+func NewAdaptiveTimeoutManagerWithScheduler(
+	config *AdaptiveTimeoutConfig,
+	reg prometheus.Registerer,
+	clock *mockable.Clock,
+	newScheduler func(handler func()) Scheduler,
 ) (AdaptiveTimeoutManager, error) {
 	switch {
 	case config.InitialTimeout > config.MaximumTimeout:
@@ -121,6 +155,7 @@ func NewAdaptiveTimeoutManager(
 			Name: "pending_timeouts",
 			Help: "Number of pending timeouts",
 		}),
+		clock:              clock,
 		minimumTimeout:     config.MinimumTimeout,
 		maximumTimeout:     config.MaximumTimeout,
 		currentTimeout:     config.InitialTimeout,
@@ -129,7 +164,7 @@ func NewAdaptiveTimeoutManager(
 			return a.deadline.Before(b.deadline)
 		}),
 	}
-	tm.timer = NewTimer(tm.timeout)
+	tm.timer = newScheduler(tm.timeout)
 	tm.averager = math.NewAverager(float64(config.InitialTimeout), config.TimeoutHalflife, tm.clock.Time())
 
 	err := errors.Join(
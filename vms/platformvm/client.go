@@ -120,6 +120,17 @@ type Client interface {
 		height uint64,
 		options ...rpc.Option,
 	) (map[ids.NodeID]*validators.GetValidatorOutput, error)
+	// GetValidatorUptimeHistory returns how long the primary network
+	// validator [nodeID] was online in each uptime bucket over
+	// [startTime, endTime). If [endTime] is the zero time, the current time is
+	// used.
+	GetValidatorUptimeHistory(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		startTime time.Time,
+		endTime time.Time,
+		options ...rpc.Option,
+	) ([]api.UptimeBucket, error)
 	// GetBlock returns the block with the given id.
 	GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetBlockByHeight returns the block at the given [height].
@@ -504,6 +515,25 @@ func (c *client) GetValidatorsAt(
 	return res.Validators, err
 }
 
+func (c *client) GetValidatorUptimeHistory(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	startTime time.Time,
+	endTime time.Time,
+	options ...rpc.Option,
+) ([]api.UptimeBucket, error) {
+	args := &api.GetUptimeHistoryArgs{
+		NodeID:    nodeID,
+		StartTime: json.Uint64(startTime.Unix()),
+	}
+	if !endTime.IsZero() {
+		args.EndTime = json.Uint64(endTime.Unix())
+	}
+	res := &api.GetUptimeHistoryReply{}
+	err := c.requester.SendRequest(ctx, "platform.getValidatorUptimeHistory", args, res, options...)
+	return res.Buckets, err
+}
+
 func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error) {
 	res := &api.FormattedBlock{}
 	if err := c.requester.SendRequest(ctx, "platform.getBlock", &api.GetBlockArgs{
@@ -19,6 +19,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/uptime"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
@@ -1777,6 +1778,34 @@ func (s *Service) GetValidatorsAt(r *http.Request, args *GetValidatorsAtArgs, re
 	return nil
 }
 
+// GetValidatorUptimeHistory returns how long a primary network validator was
+// online in each uptime bucket over the requested time range.
+func (s *Service) GetValidatorUptimeHistory(_ *http.Request, args *api.GetUptimeHistoryArgs, reply *api.GetUptimeHistoryReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getValidatorUptimeHistory"),
+		zap.Stringer("nodeID", args.NodeID),
+		zap.Uint64("startTime", uint64(args.StartTime)),
+		zap.Uint64("endTime", uint64(args.EndTime)),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	startTime := time.Unix(int64(args.StartTime), 0).Truncate(uptime.BucketDuration)
+	endTime := s.vm.clock.UnixTime()
+	if args.EndTime != 0 {
+		endTime = time.Unix(int64(args.EndTime), 0)
+	}
+
+	upDurations, err := s.vm.uptimeManager.CalculateUptimeHistory(args.NodeID, startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("couldn't calculate uptime history: %w", err)
+	}
+	reply.Buckets = api.NewUptimeBuckets(startTime, uptime.BucketDuration, upDurations)
+	return nil
+}
+
 func (s *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *api.GetBlockResponse) error {
 	s.vm.ctx.Log.Debug("API called",
 		zap.String("service", "platform"),
@@ -26,6 +26,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+	"github.com/MetalBlockchain/metalgo/snow/uptime"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/upgrade/upgradetest"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
@@ -774,6 +775,40 @@ func TestGetTimestamp(t *testing.T) {
 	require.Equal(newTimestamp, reply.Timestamp)
 }
 
+func TestGetValidatorUptimeHistory(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	nodeID := genesistest.DefaultNodeIDs[0]
+	require.NoError(service.vm.Connected(context.Background(), nodeID, version.CurrentApp))
+
+	service.vm.ctx.Lock.Lock()
+	startTime := service.vm.clock.UnixTime().Truncate(uptime.BucketDuration)
+	service.vm.clock.Set(startTime.Add(uptime.BucketDuration + time.Minute))
+	service.vm.ctx.Lock.Unlock()
+
+	args := api.GetUptimeHistoryArgs{
+		NodeID:    nodeID,
+		StartTime: avajson.Uint64(startTime.Unix()),
+	}
+	reply := api.GetUptimeHistoryReply{}
+	require.NoError(service.GetValidatorUptimeHistory(nil, &args, &reply))
+	require.Len(reply.Buckets, 2)
+
+	secondBucket := reply.Buckets[1]
+	require.Equal(avajson.Uint64(startTime.Add(uptime.BucketDuration).Unix()), secondBucket.StartTime)
+	require.Equal(avajson.Uint64(startTime.Add(2*uptime.BucketDuration).Unix()), secondBucket.EndTime)
+	require.Equal(avajson.Uint64(time.Minute/time.Second), secondBucket.UpDuration)
+
+	// A node that was never online has an empty history.
+	args.NodeID = ids.GenerateTestNodeID()
+	require.NoError(service.GetValidatorUptimeHistory(nil, &args, &reply))
+	require.Len(reply.Buckets, 2)
+	for _, bucket := range reply.Buckets {
+		require.Zero(bucket.UpDuration)
+	}
+}
+
 func TestGetBlock(t *testing.T) {
 	tests := []struct {
 		name     string
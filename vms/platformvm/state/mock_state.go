@@ -679,6 +679,21 @@ func (mr *MockStateMockRecorder) GetUptime(nodeID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUptime", reflect.TypeOf((*MockState)(nil).GetUptime), nodeID)
 }
 
+// GetUptimeBucket mocks base method.
+func (m *MockState) GetUptimeBucket(nodeID ids.NodeID, bucketStart time.Time) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUptimeBucket", nodeID, bucketStart)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUptimeBucket indicates an expected call of GetUptimeBucket.
+func (mr *MockStateMockRecorder) GetUptimeBucket(nodeID, bucketStart any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUptimeBucket", reflect.TypeOf((*MockState)(nil).GetUptimeBucket), nodeID, bucketStart)
+}
+
 // HasExpiry mocks base method.
 func (m *MockState) HasExpiry(arg0 ExpiryEntry) (bool, error) {
 	m.ctrl.T.Helper()
@@ -896,6 +911,20 @@ func (mr *MockStateMockRecorder) SetUptime(nodeID, upDuration, lastUpdated any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUptime", reflect.TypeOf((*MockState)(nil).SetUptime), nodeID, upDuration, lastUpdated)
 }
 
+// SetUptimeBucket mocks base method.
+func (m *MockState) SetUptimeBucket(nodeID ids.NodeID, bucketStart time.Time, upDuration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUptimeBucket", nodeID, bucketStart, upDuration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUptimeBucket indicates an expected call of SetUptimeBucket.
+func (mr *MockStateMockRecorder) SetUptimeBucket(nodeID, bucketStart, upDuration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUptimeBucket", reflect.TypeOf((*MockState)(nil).SetUptimeBucket), nodeID, bucketStart, upDuration)
+}
+
 // UTXOIDs mocks base method.
 func (m *MockState) UTXOIDs(addr []byte, previous ids.ID, limit int) ([]ids.ID, error) {
 	m.ctrl.T.Helper()
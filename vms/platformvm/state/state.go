@@ -73,6 +73,8 @@ var (
 	SubnetDelegatorPrefix         = []byte("subnetDelegator")
 	ValidatorWeightDiffsPrefix    = []byte("flatValidatorDiffs")
 	ValidatorPublicKeyDiffsPrefix = []byte("flatPublicKeyDiffs")
+	UptimeHistoryPrefix           = []byte("uptimeHistory")
+	UptimeHistoryByTimePrefix     = []byte("uptimeHistoryByTime")
 	TxPrefix                      = []byte("tx")
 	RewardUTXOsPrefix             = []byte("rewardUTXOs")
 	UTXOPrefix                    = []byte("utxo")
@@ -257,8 +259,10 @@ type stateBlk struct {
  * | |     '-- txID -> nil
  * | |-. weight diffs
  * | | '-- subnet+height+nodeID -> weightChange
- * | '-. pub key diffs
- * |   '-- subnet+height+nodeID -> uncompressed public key or nil
+ * | |-. pub key diffs
+ * | | '-- subnet+height+nodeID -> uncompressed public key or nil
+ * | '-. uptime history
+ * |   '-- nodeID+bucketStart -> upDuration
  * |-. blockIDs
  * | '-- height -> blockID
  * |-. blocks
@@ -343,6 +347,10 @@ type state struct {
 	validatorWeightDiffsDB    database.Database
 	validatorPublicKeyDiffsDB database.Database
 
+	modifiedUptimeBuckets map[uptimeBucketKey]time.Duration // map of nodeID+bucketStart -> up duration
+	uptimeHistoryDB       database.Database
+	uptimeHistoryByTimeDB database.Database // bucketStart+nodeID -> nil
+
 	addedTxs map[ids.ID]*txAndStatus            // map of txID -> {*txs.Tx, Status}
 	txCache  cache.Cacher[ids.ID, *txAndStatus] // txID -> {*txs.Tx, Status}; if the entry is nil, it is not in the database
 	txDB     database.Database
@@ -512,6 +520,8 @@ func New(
 
 	validatorWeightDiffsDB := prefixdb.New(ValidatorWeightDiffsPrefix, validatorsDB)
 	validatorPublicKeyDiffsDB := prefixdb.New(ValidatorPublicKeyDiffsPrefix, validatorsDB)
+	uptimeHistoryDB := prefixdb.New(UptimeHistoryPrefix, validatorsDB)
+	uptimeHistoryByTimeDB := prefixdb.New(UptimeHistoryByTimePrefix, validatorsDB)
 
 	txCache, err := metercacher.New(
 		"tx_cache",
@@ -647,6 +657,10 @@ func New(
 		validatorWeightDiffsDB:       validatorWeightDiffsDB,
 		validatorPublicKeyDiffsDB:    validatorPublicKeyDiffsDB,
 
+		modifiedUptimeBuckets: make(map[uptimeBucketKey]time.Duration),
+		uptimeHistoryDB:       uptimeHistoryDB,
+		uptimeHistoryByTimeDB: uptimeHistoryByTimeDB,
+
 		addedTxs: make(map[ids.ID]*txAndStatus),
 		txDB:     prefixdb.New(TxPrefix, baseDB),
 		txCache:  txCache,
@@ -1760,6 +1774,7 @@ func (s *state) write(updateValidators bool, height uint64) error {
 		s.writeCurrentStakers(updateValidators, height, codecVersion),
 		s.writePendingStakers(),
 		s.WriteValidatorMetadata(s.currentValidatorList, s.currentSubnetValidatorList, codecVersion), // Must be called after writeCurrentStakers
+		s.writeUptimeHistory(),
 		s.writeTXs(),
 		s.writeRewardUTXOs(),
 		s.writeUTXOs(),
@@ -1786,6 +1801,8 @@ func (s *state) Close() error {
 		s.currentDelegatorBaseDB.Close(),
 		s.currentValidatorBaseDB.Close(),
 		s.currentValidatorsDB.Close(),
+		s.uptimeHistoryDB.Close(),
+		s.uptimeHistoryByTimeDB.Close(),
 		s.validatorsDB.Close(),
 		s.txDB.Close(),
 		s.rewardUTXODB.Close(),
@@ -2634,6 +2651,79 @@ func (s *state) SetUptime(vdrID ids.NodeID, upDuration time.Duration, lastUpdate
 	return s.validatorState.SetUptime(vdrID, constants.PrimaryNetworkID, upDuration, lastUpdated)
 }
 
+func (s *state) GetUptimeBucket(nodeID ids.NodeID, bucketStart time.Time) (time.Duration, error) {
+	key := uptimeBucketKey{
+		nodeID:      nodeID,
+		bucketStart: uint64(bucketStart.Unix()),
+	}
+	if upDuration, ok := s.modifiedUptimeBuckets[key]; ok {
+		return upDuration, nil
+	}
+
+	upDuration, err := database.GetUInt64(s.uptimeHistoryDB, key.Marshal())
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return time.Duration(upDuration), err
+}
+
+func (s *state) SetUptimeBucket(nodeID ids.NodeID, bucketStart time.Time, upDuration time.Duration) error {
+	key := uptimeBucketKey{
+		nodeID:      nodeID,
+		bucketStart: uint64(bucketStart.Unix()),
+	}
+	s.modifiedUptimeBuckets[key] = upDuration
+	return nil
+}
+
+func (s *state) writeUptimeHistory() error {
+	for key, upDuration := range s.modifiedUptimeBuckets {
+		delete(s.modifiedUptimeBuckets, key)
+		if err := database.PutUInt64(s.uptimeHistoryDB, key.Marshal(), uint64(upDuration)); err != nil {
+			return fmt.Errorf("failed to write uptime history: %w", err)
+		}
+		if err := s.uptimeHistoryByTimeDB.Put(key.MarshalByTime(), nil); err != nil {
+			return fmt.Errorf("failed to write uptime history: %w", err)
+		}
+	}
+	if err := s.pruneUptimeHistory(); err != nil {
+		return fmt.Errorf("failed to prune uptime history: %w", err)
+	}
+	return nil
+}
+
+// pruneUptimeHistory deletes the buckets of every node, including nodes that
+// are no longer validators, that start more than [uptime.HistoryRetention]
+// before the chain time.
+func (s *state) pruneUptimeHistory() error {
+	cutoff := s.GetTimestamp().Add(-uptime.HistoryRetention).Unix()
+	if cutoff <= 0 {
+		return nil
+	}
+
+	it := s.uptimeHistoryByTimeDB.NewIterator()
+	defer it.Release()
+
+	// Keys are ordered by bucket start, so pruning stops at the first bucket
+	// inside of the retention window.
+	for it.Next() {
+		var key uptimeBucketKey
+		if err := key.UnmarshalByTime(it.Key()); err != nil {
+			return err
+		}
+		if key.bucketStart >= uint64(cutoff) {
+			break
+		}
+		if err := s.uptimeHistoryDB.Delete(key.Marshal()); err != nil {
+			return err
+		}
+		if err := s.uptimeHistoryByTimeDB.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
 func markInitialized(db database.KeyValueWriter) error {
 	return db.Put(InitializedKey, nil)
 }
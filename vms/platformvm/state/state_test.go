@@ -22,6 +22,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/choices"
+	"github.com/MetalBlockchain/metalgo/snow/uptime"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/upgrade/upgradetest"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
@@ -1496,6 +1497,97 @@ func TestStateAccruedFeesCommitAndLoad(t *testing.T) {
 	require.Equal(expectedAccruedFees, s.GetAccruedFees())
 }
 
+// Verify that committing the state writes the uptime history to the database
+// and that loading the state fetches the uptime history from the database.
+func TestStateUptimeHistoryCommitAndLoad(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	s := newTestState(t, db)
+
+	var (
+		nodeID      = ids.GenerateTestNodeID()
+		bucketStart = time.Unix(1_700_000_000, 0).Truncate(time.Hour)
+	)
+	upDuration, err := s.GetUptimeBucket(nodeID, bucketStart)
+	require.NoError(err)
+	require.Zero(upDuration)
+
+	require.NoError(s.SetUptimeBucket(nodeID, bucketStart, time.Minute))
+	upDuration, err = s.GetUptimeBucket(nodeID, bucketStart)
+	require.NoError(err)
+	require.Equal(time.Minute, upDuration)
+	require.NoError(s.Commit())
+
+	s = newTestState(t, db)
+	upDuration, err = s.GetUptimeBucket(nodeID, bucketStart)
+	require.NoError(err)
+	require.Equal(time.Minute, upDuration)
+
+	upDuration, err = s.GetUptimeBucket(nodeID, bucketStart.Add(time.Hour))
+	require.NoError(err)
+	require.Zero(upDuration)
+}
+
+// Verify that committing the state prunes the buckets that started more than
+// the retention window before the chain time, including the buckets of removed
+// validators.
+func TestStateUptimeHistoryPruning(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	s := newTestState(t, db)
+
+	var (
+		startTime      = time.Unix(1_700_000_000, 0).Truncate(time.Hour)
+		validatorsData = txs.Validator{
+			NodeID: ids.GenerateTestNodeID(),
+			End:    uint64(startTime.Add(14 * 24 * time.Hour).Unix()),
+			Wght:   1234,
+		}
+		nodeID   = validatorsData.NodeID
+		utx      = createPermissionlessValidatorTx(require, constants.PrimaryNetworkID, validatorsData)
+		addValTx = &txs.Tx{Unsigned: utx}
+	)
+	require.NoError(addValTx.Initialize(txs.Codec))
+
+	staker, err := NewCurrentStaker(addValTx.ID(), utx, startTime, 0)
+	require.NoError(err)
+	require.NoError(s.PutCurrentValidator(staker))
+	s.AddTx(addValTx, status.Committed)
+
+	var (
+		oldBucketStart  = startTime
+		keptBucketStart = startTime.Add(time.Hour)
+	)
+	s.SetTimestamp(keptBucketStart)
+	require.NoError(s.SetUptimeBucket(nodeID, oldBucketStart, time.Minute))
+	require.NoError(s.SetUptimeBucket(nodeID, keptBucketStart, time.Minute))
+	require.NoError(s.Commit())
+
+	// Removing the validator doesn't delete its history.
+	s.DeleteCurrentValidator(staker)
+	s.SetTimestamp(keptBucketStart.Add(uptime.HistoryRetention))
+	require.NoError(s.Commit())
+
+	upDuration, err := s.GetUptimeBucket(nodeID, oldBucketStart)
+	require.NoError(err)
+	require.Zero(upDuration)
+
+	upDuration, err = s.GetUptimeBucket(nodeID, keptBucketStart)
+	require.NoError(err)
+	require.Equal(time.Minute, upDuration)
+
+	s.SetTimestamp(keptBucketStart.Add(uptime.HistoryRetention + time.Second))
+	require.NoError(s.Commit())
+
+	for _, db := range []database.Database{s.uptimeHistoryDB, s.uptimeHistoryByTimeDB} {
+		numBuckets, err := database.Count(db)
+		require.NoError(err)
+		require.Zero(numBuckets)
+	}
+}
+
 func TestMarkAndIsInitialized(t *testing.T) {
 	require := require.New(t)
 
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+// uptimeBucketKey = [nodeID] + [bucketStart]
+const uptimeBucketKeyLength = ids.NodeIDLen + database.Uint64Size
+
+var errUnexpectedUptimeBucketKeyLength = fmt.Errorf("expected uptime bucket key length %d", uptimeBucketKeyLength)
+
+type uptimeBucketKey struct {
+	nodeID      ids.NodeID
+	bucketStart uint64 // Unix time in seconds
+}
+
+// Marshal orders the keys of a node by the start of their bucket, so that a
+// node's history can be iterated in time order.
+func (k *uptimeBucketKey) Marshal() []byte {
+	data := make([]byte, uptimeBucketKeyLength)
+	copy(data, k.nodeID[:])
+	binary.BigEndian.PutUint64(data[ids.NodeIDLen:], k.bucketStart)
+	return data
+}
+
+// MarshalByTime orders the keys of all nodes by the start of their bucket, so
+// that the oldest buckets can be found without iterating over every node.
+func (k *uptimeBucketKey) MarshalByTime() []byte {
+	data := make([]byte, uptimeBucketKeyLength)
+	binary.BigEndian.PutUint64(data, k.bucketStart)
+	copy(data[database.Uint64Size:], k.nodeID[:])
+	return data
+}
+
+func (k *uptimeBucketKey) UnmarshalByTime(data []byte) error {
+	if len(data) != uptimeBucketKeyLength {
+		return errUnexpectedUptimeBucketKeyLength
+	}
+
+	k.bucketStart = binary.BigEndian.Uint64(data)
+	copy(k.nodeID[:], data[database.Uint64Size:])
+	return nil
+}